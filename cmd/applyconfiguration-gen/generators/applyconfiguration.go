@@ -224,9 +224,13 @@ func (g *applyConfigurationGenerator) generateWithFuncs(t *types.Type, typeParam
 			// For slices where the items are generated apply configuration types, accept varargs of
 			// pointers of the type as "with" function arguments so the "with" function can be used like so:
 			// WithFoos(Foo().WithName("x"), Foo().WithName("y"))
+			isOwnerReferences := objectMeta.Name == t.Name && member.Name == "OwnerReferences"
 			if t := deref(member.Type); t.Kind == types.Slice && g.refGraph.isApplyConfig(t.Elem) {
 				memberParams.ArgType = &types.Type{Kind: types.Pointer, Elem: memberType.Elem}
 				g.generateMemberWithForSlice(sw, member, memberParams)
+				if isOwnerReferences {
+					g.generateWithControllerReference(sw, memberParams)
+				}
 				continue
 			}
 			// Note: There are no maps where the values are generated apply configurations (because
@@ -410,6 +414,17 @@ func (g *applyConfigurationGenerator) generateMemberWithForMap(sw *generator.Sni
 	sw.Do("}\n", memberParams)
 }
 
+// generateWithControllerReference generates a convenience wrapper around
+// WithOwnerReferences which builds a controller owner reference from an object.
+func (g *applyConfigurationGenerator) generateWithControllerReference(sw *generator.SnippetWriter, memberParams memberParams) {
+	sw.Do(withControllerReference, generator.Args{
+		"ApplyConfig":      memberParams.ApplyConfig,
+		"metaObject":       metaObject,
+		"groupVersionKind": groupVersionKind,
+		"ownerReference":   types.Ref(memberParams.MemberType.Elem.Name.Package, "OwnerReference"),
+	})
+}
+
 func (g *applyConfigurationGenerator) ensureEmbedExistsIfApplicable(sw *generator.SnippetWriter, memberParams memberParams) {
 	// Embedded types that are not inlined must be nillable so they are not included in the apply configuration
 	// when all their fields are omitted.
@@ -418,6 +433,23 @@ func (g *applyConfigurationGenerator) ensureEmbedExistsIfApplicable(sw *generato
 	}
 }
 
+var withControllerReference = `
+// WithControllerReference adds an owner reference to the given owner, whose group, version and kind
+// are given by gvk, to the OwnerReferences field in the declarative configuration and returns the
+// receiver, so that objects can be build by chaining "With" function invocations. Like
+// metav1.NewControllerRef, the reference marks the owner as the managing controller and blocks
+// the owner's foreground deletion until this object is deleted.
+func (b *$.ApplyConfig.ApplyConfiguration|public$) WithControllerReference(owner $.metaObject|raw$, gvk $.groupVersionKind|raw$) *$.ApplyConfig.ApplyConfiguration|public$ {
+	return b.WithOwnerReferences($.ownerReference|raw$().
+		WithAPIVersion(gvk.GroupVersion().String()).
+		WithKind(gvk.Kind).
+		WithName(owner.GetName()).
+		WithUID(owner.GetUID()).
+		WithController(true).
+		WithBlockOwnerDeletion(true))
+}
+`
+
 var ensureEmbedExists = `
 func (b *$.ApplyConfig.ApplyConfiguration|public$) ensure$.MemberType.Elem|public$Exists() {
   if b.$.MemberType.Elem|public$ == nil {
@@ -25,6 +25,7 @@ var (
 	groupVersionKind       = types.Ref("k8s.io/apimachinery/pkg/runtime/schema", "GroupVersionKind")
 	typeMeta               = types.Ref("k8s.io/apimachinery/pkg/apis/meta/v1", "TypeMeta")
 	objectMeta             = types.Ref("k8s.io/apimachinery/pkg/apis/meta/v1", "ObjectMeta")
+	metaObject             = types.Ref("k8s.io/apimachinery/pkg/apis/meta/v1", "Object")
 	rawExtension           = types.Ref("k8s.io/apimachinery/pkg/runtime", "RawExtension")
 	unknown                = types.Ref("k8s.io/apimachinery/pkg/runtime", "Unknown")
 	extractInto            = types.Ref("k8s.io/apimachinery/pkg/util/managedfields", "ExtractInto")
@@ -64,6 +64,18 @@ type Args struct {
 
 	// PrefersProtobuf determines if the generated clientset uses protobuf for API requests.
 	PrefersProtobuf bool
+
+	// CreateOrUpdate determines if the generated clients have a
+	// CreateOrUpdate<Type> helper for types with the get, create and update verbs.
+	CreateOrUpdate bool
+
+	// UpdateWithRetry determines if the generated clients have UpdateWithRetry
+	// and UpdateStatusWithRetry helpers which retry a mutation on conflicts.
+	UpdateWithRetry bool
+
+	// ServerSideApplier determines if the generated group clients have an
+	// Applier with preset apply options. It requires ApplyConfigurationPackage.
+	ServerSideApplier bool
 }
 
 func New() *Args {
@@ -104,6 +116,12 @@ func (args *Args) AddFlags(fs *pflag.FlagSet, inputBase string) {
 		"optional package of apply configurations, generated by applyconfiguration-gen, that are required to generate Apply functions for each type in the clientset. By default Apply functions are not generated.")
 	fs.BoolVar(&args.PrefersProtobuf, "prefers-protobuf", args.PrefersProtobuf,
 		"when set, client-gen will generate a clientset that uses protobuf for API requests")
+	fs.BoolVar(&args.CreateOrUpdate, "create-or-update", args.CreateOrUpdate,
+		"when set, client-gen will generate a CreateOrUpdate<Type> helper for each type with the get, create and update verbs")
+	fs.BoolVar(&args.UpdateWithRetry, "update-with-retry", args.UpdateWithRetry,
+		"when set, client-gen will generate UpdateWithRetry and UpdateStatusWithRetry helpers which re-apply a mutation to a freshly fetched object on conflicts")
+	fs.BoolVar(&args.ServerSideApplier, "server-side-applier", args.ServerSideApplier,
+		"when set, client-gen will generate an Applier with preset field manager and force for each group client; requires --apply-configuration-package")
 
 	// support old flags
 	fs.SetNormalizeFunc(mapFlagName("clientset-path", "output-pkg", fs.GetNormalizeFunc()))
@@ -122,6 +140,9 @@ func (args *Args) Validate() error {
 	if len(args.ClientsetAPIPath) == 0 {
 		return fmt.Errorf("--clientset-api-path cannot be empty")
 	}
+	if args.ServerSideApplier && len(args.ApplyConfigurationPackage) == 0 {
+		return fmt.Errorf("--server-side-applier requires --apply-configuration-package")
+	}
 
 	return nil
 }
@@ -129,7 +129,7 @@ func DefaultNameSystem() string {
 	return "public"
 }
 
-func targetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clientsetDir, clientsetPkg string, groupPkgName string, groupGoName string, apiPath string, inputPkg string, applyBuilderPkg string, boilerplate []byte, prefersProtobuf bool) generator.Target {
+func targetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clientsetDir, clientsetPkg string, groupPkgName string, groupGoName string, apiPath string, inputPkg string, applyBuilderPkg string, boilerplate []byte, prefersProtobuf, createOrUpdate, updateWithRetry, serverSideApplier bool) generator.Target {
 	subdir := []string{"typed", strings.ToLower(groupPkgName), strings.ToLower(gv.Version.NonEmpty())}
 	gvDir := filepath.Join(clientsetDir, filepath.Join(subdir...))
 	gvPkg := path.Join(clientsetPkg, path.Join(subdir...))
@@ -162,6 +162,8 @@ func targetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clie
 					version:                   gv.Version.String(),
 					groupGoName:               groupGoName,
 					prefersProtobuf:           prefersProtobuf,
+					createOrUpdate:            createOrUpdate,
+					updateWithRetry:           updateWithRetry,
 					typeToMatch:               t,
 					imports:                   generator.NewImportTrackerForPackage(gvPkg),
 				})
@@ -171,15 +173,17 @@ func targetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clie
 				GoGenerator: generator.GoGenerator{
 					OutputFilename: groupPkgName + "_client.go",
 				},
-				outputPackage:    gvPkg,
-				inputPackage:     inputPkg,
-				clientsetPackage: clientsetPkg,
-				group:            gv.Group.NonEmpty(),
-				version:          gv.Version.String(),
-				groupGoName:      groupGoName,
-				apiPath:          apiPath,
-				types:            typeList,
-				imports:          generator.NewImportTrackerForPackage(gvPkg),
+				outputPackage:             gvPkg,
+				inputPackage:              inputPkg,
+				clientsetPackage:          clientsetPkg,
+				applyConfigurationPackage: applyBuilderPkg,
+				serverSideApplier:         serverSideApplier,
+				group:                     gv.Group.NonEmpty(),
+				version:                   gv.Version.String(),
+				groupGoName:               groupGoName,
+				apiPath:                   apiPath,
+				types:                     typeList,
+				imports:                   generator.NewImportTrackerForPackage(gvPkg),
 			})
 
 			expansionFileName := "generated_expansion.go"
@@ -437,10 +441,10 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 				targetForGroup(
 					gv, orderer.OrderTypes(types), clientsetDir, clientsetPkg,
 					group.PackageName, groupGoNames[gv], args.ClientsetAPIPath,
-					inputPath, args.ApplyConfigurationPackage, boilerplate, args.PrefersProtobuf))
+					inputPath, args.ApplyConfigurationPackage, boilerplate, args.PrefersProtobuf, args.CreateOrUpdate, args.UpdateWithRetry, args.ServerSideApplier))
 			if args.FakeClient {
 				targetList = append(targetList,
-					fake.TargetForGroup(gv, orderer.OrderTypes(types), clientsetDir, clientsetPkg, group.PackageName, groupGoNames[gv], inputPath, args.ApplyConfigurationPackage, boilerplate))
+					fake.TargetForGroup(gv, orderer.OrderTypes(types), clientsetDir, clientsetPkg, group.PackageName, groupGoNames[gv], inputPath, args.ApplyConfigurationPackage, boilerplate, args.CreateOrUpdate, args.UpdateWithRetry, args.ServerSideApplier))
 			}
 		}
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/parser"
+	"k8s.io/gengo/v2/types"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+)
+
+// TestPrefersProtobuf checks that only clients generated with
+// --prefers-protobuf negotiate protobuf, which makes their requests accept
+// application/vnd.kubernetes.protobuf with a fallback to application/json.
+func TestPrefersProtobuf(t *testing.T) {
+	const pkg = "k8s.io/code-generator/cmd/client-gen/generators/testdata/protobuf/v1"
+	p := parser.New()
+	if err := p.LoadPackages(pkg); err != nil {
+		t.Fatal(err)
+	}
+	c, err := generator.NewContext(p, NameSystems(nil), DefaultNameSystem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeList := []*types.Type{c.Universe.Type(types.Name{Package: pkg, Name: "Example"})}
+	gv := clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}
+
+	for _, prefersProtobuf := range []bool{false, true} {
+		dir := t.TempDir()
+		target := targetForGroup(gv, typeList, dir, "example.com/clientset", "example", "Example", "/apis", pkg, "", []byte("// boilerplate\n"), prefersProtobuf, false, false, false)
+		if err := c.ExecuteTarget(target); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "typed", "example", "v1", "example.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := string(data)
+
+		// The verbs of gentype negotiate protobuf with this option.
+		if got := strings.Contains(client, "gentype.PrefersProtobuf[*protobufv1.Example]()"); got != prefersProtobuf {
+			t.Errorf("prefers protobuf %v: the generated client passes gentype.PrefersProtobuf: %v", prefersProtobuf, got)
+		}
+		// The extended methods make the requests themselves.
+		if got := strings.Contains(client, "UseProtobufAsDefault()."); got != prefersProtobuf {
+			t.Errorf("prefers protobuf %v: the generated GetExtended uses protobuf as default: %v", prefersProtobuf, got)
+		}
+	}
+}
@@ -30,7 +30,7 @@ import (
 	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
 )
 
-func TargetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clientsetDir, clientsetPkg string, groupPkgName string, groupGoName string, inputPkg string, applyBuilderPackage string, boilerplate []byte) generator.Target {
+func TargetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clientsetDir, clientsetPkg string, groupPkgName string, groupGoName string, inputPkg string, applyBuilderPackage string, boilerplate []byte, createOrUpdate, updateWithRetry, serverSideApplier bool) generator.Target {
 	// TODO: should make this a function, called by here and in client-generator.go
 	subdir := []string{"typed", strings.ToLower(groupPkgName), strings.ToLower(gv.Version.NonEmpty())}
 	outputDir := filepath.Join(clientsetDir, filepath.Join(subdir...), "fake")
@@ -65,6 +65,8 @@ func TargetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clie
 					typeToMatch:               t,
 					imports:                   generator.NewImportTrackerForPackage(outputPkg),
 					applyConfigurationPackage: applyBuilderPackage,
+					createOrUpdate:            createOrUpdate,
+					updateWithRetry:           updateWithRetry,
 				})
 			}
 
@@ -78,6 +80,7 @@ func TargetForGroup(gv clientgentypes.GroupVersion, typeList []*types.Type, clie
 				groupGoName:       groupGoName,
 				types:             typeList,
 				imports:           generator.NewImportTrackerForPackage(outputPkg),
+				serverSideApplier: serverSideApplier && len(applyBuilderPackage) > 0,
 			})
 			return generators
 		},
@@ -39,6 +39,9 @@ type genFakeForGroup struct {
 	// types in this group
 	types   []*types.Type
 	imports namer.ImportTracker
+	// serverSideApplier determines if the group client has an Applier with
+	// preset apply options.
+	serverSideApplier bool
 	// If the genGroup has been called. This generator should only execute once.
 	called bool
 }
@@ -82,11 +85,13 @@ func (g *genFakeForGroup) GenerateType(c *generator.Context, t *types.Type, w io
 	}
 
 	sw.Do(groupClientTemplate, m)
+	hasApplier := false
 	for _, t := range g.types {
 		tags, err := util.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
 		if err != nil {
 			return err
 		}
+		hasApplier = hasApplier || tags.HasVerb("apply")
 		wrapper := map[string]interface{}{
 			"type":              t,
 			"GroupGoName":       g.groupGoName,
@@ -100,6 +105,10 @@ func (g *genFakeForGroup) GenerateType(c *generator.Context, t *types.Type, w io
 		sw.Do(getterImplNamespaced, wrapper)
 	}
 	sw.Do(getRESTClient, m)
+	if g.serverSideApplier && hasApplier {
+		m["realClientPackage"] = strings.ToLower(path.Base(g.realClientPackage))
+		sw.Do(applierTemplate, m)
+	}
 	return sw.Error()
 }
 
@@ -129,3 +138,11 @@ func (c *Fake$.GroupGoName$$.Version$) RESTClient() $.RESTClientInterface|raw$ {
 	return ret
 }
 `
+
+var applierTemplate = `
+// Applier returns the real applier of the group on top of the fake client, so that
+// the applied configurations are recorded with the preset apply options.
+func (c *Fake$.GroupGoName$$.Version$) Applier(fieldManager string, force bool) $.realClientPackage$.$.GroupGoName$$.Version$Applier {
+	return $.realClientPackage$.New$.GroupGoName$$.Version$Applier(c, fieldManager, force)
+}
+`
@@ -42,6 +42,8 @@ type genFakeForType struct {
 	typeToMatch               *types.Type
 	imports                   namer.ImportTracker
 	applyConfigurationPackage string
+	createOrUpdate            bool
+	updateWithRetry           bool
 }
 
 var _ generator.Generator = &genFakeForType{}
@@ -71,28 +73,36 @@ func (g *genFakeForType) GenerateType(c *generator.Context, t *types.Type, w io.
 
 	const pkgClientGoTesting = "k8s.io/client-go/testing"
 	m := map[string]interface{}{
-		"type":                t,
-		"inputType":           t,
-		"resultType":          t,
-		"subresourcePath":     "",
-		"namespaced":          !tags.NonNamespaced,
-		"GroupGoName":         g.groupGoName,
-		"Version":             namer.IC(g.version),
-		"realClientInterface": c.Universe.Type(types.Name{Package: g.realClientPackage, Name: t.Name.Name + "Interface"}),
-		"SchemeGroupVersion":  c.Universe.Type(types.Name{Package: t.Name.Package, Name: "SchemeGroupVersion"}),
-		"CreateOptions":       c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "CreateOptions"}),
-		"DeleteOptions":       c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "DeleteOptions"}),
-		"GetOptions":          c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "GetOptions"}),
-		"ListOptions":         c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ListOptions"}),
-		"PatchOptions":        c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "PatchOptions"}),
-		"ApplyOptions":        c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ApplyOptions"}),
-		"UpdateOptions":       c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "UpdateOptions"}),
-		"PatchType":           c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "PatchType"}),
-		"ApplyPatchType":      c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "ApplyPatchType"}),
-		"watchInterface":      c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Interface"}),
-		"jsonMarshal":         c.Universe.Type(types.Name{Package: "encoding/json", Name: "Marshal"}),
-		"fmtErrorf":           c.Universe.Type(types.Name{Package: "fmt", Name: "Errorf"}),
-		"contextContext":      c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
+		"type":                    t,
+		"inputType":               t,
+		"resultType":              t,
+		"subresourcePath":         "",
+		"namespaced":              !tags.NonNamespaced,
+		"GroupGoName":             g.groupGoName,
+		"Version":                 namer.IC(g.version),
+		"realClientInterface":     c.Universe.Type(types.Name{Package: g.realClientPackage, Name: t.Name.Name + "Interface"}),
+		"SchemeGroupVersion":      c.Universe.Type(types.Name{Package: t.Name.Package, Name: "SchemeGroupVersion"}),
+		"CreateOptions":           c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "CreateOptions"}),
+		"DeleteOptions":           c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "DeleteOptions"}),
+		"GetOptions":              c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "GetOptions"}),
+		"ListOptions":             c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ListOptions"}),
+		"PatchOptions":            c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "PatchOptions"}),
+		"ApplyOptions":            c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ApplyOptions"}),
+		"UpdateOptions":           c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "UpdateOptions"}),
+		"PatchType":               c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "PatchType"}),
+		"ApplyPatchType":          c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "ApplyPatchType"}),
+		"MergePatchType":          c.Universe.Variable(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "MergePatchType"}),
+		"StrategicMergePatchType": c.Universe.Variable(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "StrategicMergePatchType"}),
+		"watchInterface":          c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Interface"}),
+		"jsonMarshal":             c.Universe.Type(types.Name{Package: "encoding/json", Name: "Marshal"}),
+		"errorsIsAlreadyExists":   c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsAlreadyExists"}),
+		"errorsIsConflict":        c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsConflict"}),
+		"errorsIsNotFound":        c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsNotFound"}),
+		"retryDefaultRetry":       c.Universe.Variable(types.Name{Package: "k8s.io/client-go/util/retry", Name: "DefaultRetry"}),
+		"retryOnError":            c.Universe.Function(types.Name{Package: "k8s.io/client-go/util/retry", Name: "OnError"}),
+		"retryRetryOnConflict":    c.Universe.Function(types.Name{Package: "k8s.io/client-go/util/retry", Name: "RetryOnConflict"}),
+		"fmtErrorf":               c.Universe.Type(types.Name{Package: "fmt", Name: "Errorf"}),
+		"contextContext":          c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
 
 		"NewRootListActionWithOptions":              c.Universe.Function(types.Name{Package: pkgClientGoTesting, Name: "NewRootListActionWithOptions"}),
 		"NewListActionWithOptions":                  c.Universe.Function(types.Name{Package: pkgClientGoTesting, Name: "NewListActionWithOptions"}),
@@ -148,6 +158,23 @@ func (g *genFakeForType) GenerateType(c *generator.Context, t *types.Type, w io.
 		return sw.Error()
 	}
 
+	if tags.HasVerb("patch") {
+		sw.Do(typedPatchTemplate, m)
+	}
+
+	if g.createOrUpdate && tags.HasVerb("get") && tags.HasVerb("create") && tags.HasVerb("update") {
+		sw.Do(createOrUpdateTemplate, m)
+	}
+
+	if g.updateWithRetry && tags.HasVerb("get") {
+		if tags.HasVerb("update") {
+			sw.Do(updateWithRetryTemplate, m)
+		}
+		if tags.HasVerb("updateStatus") && hasStatus(t) {
+			sw.Do(updateStatusWithRetryTemplate, m)
+		}
+	}
+
 	_, typeGVString := util.ParsePathGroupVersion(g.inputPackage)
 
 	// generate extended client methods
@@ -533,11 +560,113 @@ func (c *fake$.type|publicPlural$) Apply(ctx $.contextContext|raw$, $.type|priva
 	}
 	emptyResult := &$.resultType|raw${}
 	obj, err := c.Fake.
-		$if .namespaced$Invokes($.NewPatchSubresourceActionWithOptions|raw$(c.Resource(), c.Namespace(), $.type|private$Name, $.ApplyPatchType|raw$, data, opts.ToPatchOptions(), "$.inputType|private$"), emptyResult)
-		$else$Invokes($.NewRootPatchSubresourceActionWithOptions|raw$(c.Resource(), $.type|private$Name, $.ApplyPatchType|raw$, data, opts.ToPatchOptions(), "$.inputType|private$"), emptyResult)$end$
+		$if .namespaced$Invokes($.NewPatchSubresourceActionWithOptions|raw$(c.Resource(), c.Namespace(), $.type|private$Name, $.ApplyPatchType|raw$, data, opts.ToPatchOptions(), "$.subresourcePath$"), emptyResult)
+		$else$Invokes($.NewRootPatchSubresourceActionWithOptions|raw$(c.Resource(), $.type|private$Name, $.ApplyPatchType|raw$, data, opts.ToPatchOptions(), "$.subresourcePath$"), emptyResult)$end$
 	if obj == nil {
 		return emptyResult, err
 	}
 	return obj.(*$.resultType|raw$), err
 }
 `
+
+var typedPatchTemplate = `
+// MergePatch$.type|public$ marshals patch to JSON and records it as a JSON merge patch of the named $.type|private$.
+func (c *fake$.type|publicPlural$) MergePatch$.type|public$(ctx $.contextContext|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error) {
+	data, err := $.jsonMarshal|raw$(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, $.MergePatchType|raw$, data, opts)
+}
+
+// StrategicMergePatch$.type|public$ marshals patch to JSON and records it as a strategic merge patch of the named $.type|private$.
+func (c *fake$.type|publicPlural$) StrategicMergePatch$.type|public$(ctx $.contextContext|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error) {
+	data, err := $.jsonMarshal|raw$(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, $.StrategicMergePatchType|raw$, data, opts)
+}
+`
+
+var createOrUpdateTemplate = `
+// CreateOrUpdate$.type|public$ gets the $.type|private$ named like obj, applies mutate to it and records an update.
+// If the $.type|private$ does not exist, mutate is applied to a copy of obj which is then recorded as created.
+func (c *fake$.type|publicPlural$) CreateOrUpdate$.type|public$(ctx $.contextContext|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryOnError|raw$($.retryDefaultRetry|raw$, func(err error) bool {
+		return $.errorsIsConflict|raw$(err) || $.errorsIsAlreadyExists|raw$(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if $.errorsIsNotFound|raw$(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, $.CreateOptions|raw${DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+// hasStatus mirrors the check the typed client uses to decide whether a type
+// has an UpdateStatus method.
+func hasStatus(t *types.Type) bool {
+	for _, m := range t.Members {
+		if m.Name == "Status" {
+			return true
+		}
+	}
+	return false
+}
+
+var updateWithRetryTemplate = `
+// UpdateWithRetry gets the $.type|private$ named like obj, applies mutate to it and records an update.
+// On conflicts the $.type|private$ is fetched again and mutate is applied to the fresh copy.
+func (c *fake$.type|publicPlural$) UpdateWithRetry(ctx $.contextContext|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryRetryOnConflict|raw$($.retryDefaultRetry|raw$, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+var updateStatusWithRetryTemplate = `
+// UpdateStatusWithRetry gets the $.type|private$ named like obj, applies mutate to it and records a status update.
+// On conflicts the $.type|private$ is fetched again and mutate is applied to the fresh copy.
+func (c *fake$.type|publicPlural$) UpdateStatusWithRetry(ctx $.contextContext|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryRetryOnConflict|raw$($.retryDefaultRetry|raw$, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
@@ -41,6 +41,12 @@ type genGroup struct {
 	imports          namer.ImportTracker
 	inputPackage     string
 	clientsetPackage string // must be a Go import-path
+	// applyConfigurationPackage is the package of the apply configurations,
+	// or empty if no Apply functions are generated.
+	applyConfigurationPackage string
+	// serverSideApplier determines if the group client has an Applier with
+	// preset apply options.
+	serverSideApplier bool
 	// If the genGroup has been called. This generator should only execute once.
 	called bool
 }
@@ -107,7 +113,15 @@ func (g *genGroup) GenerateType(c *generator.Context, t *types.Type, w io.Writer
 		"SchemePrioritizedVersionsForGroup":  c.Universe.Variable(types.Name{Package: schemePackage, Name: "Scheme.PrioritizedVersionsForGroup"}),
 		"Codecs":                             c.Universe.Variable(types.Name{Package: schemePackage, Name: "Codecs"}),
 		"Scheme":                             c.Universe.Variable(types.Name{Package: schemePackage, Name: "Scheme"}),
+		"context":                            c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
+		"ApplyOptions":                       c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ApplyOptions"}),
+		"groupGoName":                        namer.IL(g.groupGoName),
 	}
+	appliers, err := g.appliers(c)
+	if err != nil {
+		return err
+	}
+	m["appliers"] = len(appliers) > 0
 	sw.Do(groupInterfaceTemplate, m)
 	sw.Do(groupClientTemplate, m)
 	for _, t := range g.types {
@@ -136,15 +150,64 @@ func (g *genGroup) GenerateType(c *generator.Context, t *types.Type, w io.Writer
 		sw.Do(setClientDefaultsTemplate, m)
 	}
 	sw.Do(getRESTClient, m)
+	if len(appliers) > 0 {
+		sw.Do(applierInterfaceTemplate, m)
+		for _, applier := range appliers {
+			sw.Do(applierInterfaceMethodTemplate, applier)
+		}
+		sw.Do("}\n", nil)
+		sw.Do(applierTemplate, m)
+		for _, applier := range appliers {
+			if applier["namespaced"].(bool) {
+				sw.Do(applierImplNamespaced, applier)
+			} else {
+				sw.Do(applierImplNonNamespaced, applier)
+			}
+		}
+	}
 
 	return sw.Error()
 }
 
+// appliers returns the template arguments for the Apply<Type> methods of the
+// group's Applier, one for each type with the apply verb. It returns nothing
+// unless client-gen runs with --server-side-applier and generates Apply
+// functions.
+func (g *genGroup) appliers(c *generator.Context) ([]map[string]interface{}, error) {
+	if !g.serverSideApplier || len(g.applyConfigurationPackage) == 0 {
+		return nil, nil
+	}
+	_, gvString := util.ParsePathGroupVersion(g.inputPackage)
+	var appliers []map[string]interface{}
+	for _, t := range g.types {
+		tags, err := util.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if err != nil {
+			return nil, err
+		}
+		if !tags.HasVerb("apply") {
+			continue
+		}
+		appliers = append(appliers, map[string]interface{}{
+			"type":             t,
+			"groupGoName":      namer.IL(g.groupGoName),
+			"Version":          namer.IC(g.version),
+			"namespaced":       !tags.NonNamespaced,
+			"context":          c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
+			"inputApplyConfig": types.Ref(path.Join(g.applyConfigurationPackage, gvString), t.Name.Name+"ApplyConfiguration"),
+		})
+	}
+	return appliers, nil
+}
+
 var groupInterfaceTemplate = `
 type $.GroupGoName$$.Version$Interface interface {
     RESTClient() $.restRESTClientInterface|raw$
     $range .types$ $.|publicPlural$Getter
     $end$
+    $if .appliers$// Applier returns a $.GroupGoName$$.Version$Applier which applies with fieldManager and, if force is set,
+    // takes ownership of conflicting fields.
+    Applier(fieldManager string, force bool) $.GroupGoName$$.Version$Applier
+    $end$
 }
 `
 
@@ -259,3 +322,51 @@ func setConfigDefaults(config *$.restConfig|raw$) {
 	}
 }
 `
+
+var applierInterfaceTemplate = `
+// $.GroupGoName$$.Version$Applier applies apply configurations of the $.groupName$ group with preset
+// apply options.
+type $.GroupGoName$$.Version$Applier interface {
+`
+
+var applierInterfaceMethodTemplate = `Apply$.type|public$(ctx $.context|raw$, $.type|private$ *$.inputApplyConfig|raw$) (*$.type|raw$, error)
+`
+
+var applierTemplate = `
+// $.groupGoName$$.Version$Applier implements $.GroupGoName$$.Version$Applier
+type $.groupGoName$$.Version$Applier struct {
+	client $.GroupGoName$$.Version$Interface
+	opts   $.ApplyOptions|raw$
+}
+
+// New$.GroupGoName$$.Version$Applier returns a $.GroupGoName$$.Version$Applier which applies through client with
+// fieldManager and, if force is set, takes ownership of conflicting fields.
+func New$.GroupGoName$$.Version$Applier(client $.GroupGoName$$.Version$Interface, fieldManager string, force bool) $.GroupGoName$$.Version$Applier {
+	return &$.groupGoName$$.Version$Applier{
+		client: client,
+		opts:   $.ApplyOptions|raw${FieldManager: fieldManager, Force: force},
+	}
+}
+
+func (c *$.GroupGoName$$.Version$Client) Applier(fieldManager string, force bool) $.GroupGoName$$.Version$Applier {
+	return New$.GroupGoName$$.Version$Applier(c, fieldManager, force)
+}
+`
+
+var applierImplNamespaced = `
+// Apply$.type|public$ applies $.type|private$ in its namespace with the preset apply options.
+func (a *$.groupGoName$$.Version$Applier) Apply$.type|public$(ctx $.context|raw$, $.type|private$ *$.inputApplyConfig|raw$) (*$.type|raw$, error) {
+	var namespace string
+	if $.type|private$ != nil && $.type|private$.GetNamespace() != nil {
+		namespace = *$.type|private$.GetNamespace()
+	}
+	return a.client.$.type|publicPlural$(namespace).Apply(ctx, $.type|private$, a.opts)
+}
+`
+
+var applierImplNonNamespaced = `
+// Apply$.type|public$ applies $.type|private$ with the preset apply options.
+func (a *$.groupGoName$$.Version$Applier) Apply$.type|public$(ctx $.context|raw$, $.type|private$ *$.inputApplyConfig|raw$) (*$.type|raw$, error) {
+	return a.client.$.type|publicPlural$().Apply(ctx, $.type|private$, a.opts)
+}
+`
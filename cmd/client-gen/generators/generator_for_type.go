@@ -42,6 +42,8 @@ type genClientForType struct {
 	version                   string
 	groupGoName               string
 	prefersProtobuf           bool
+	createOrUpdate            bool
+	updateWithRetry           bool
 	typeToMatch               *types.Type
 	imports                   namer.ImportTracker
 }
@@ -171,6 +173,15 @@ func (g *genClientForType) GenerateType(c *generator.Context, t *types.Type, w i
 		"ApplyOptions":              c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ApplyOptions"}),
 		"UpdateOptions":             c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "UpdateOptions"}),
 		"PatchType":                 c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "PatchType"}),
+		"MergePatchType":            c.Universe.Variable(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "MergePatchType"}),
+		"StrategicMergePatchType":   c.Universe.Variable(types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "StrategicMergePatchType"}),
+		"jsonMarshal":               c.Universe.Function(types.Name{Package: "encoding/json", Name: "Marshal"}),
+		"errorsIsAlreadyExists":     c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsAlreadyExists"}),
+		"errorsIsConflict":          c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsConflict"}),
+		"errorsIsNotFound":          c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsNotFound"}),
+		"retryDefaultRetry":         c.Universe.Variable(types.Name{Package: "k8s.io/client-go/util/retry", Name: "DefaultRetry"}),
+		"retryOnError":              c.Universe.Function(types.Name{Package: "k8s.io/client-go/util/retry", Name: "OnError"}),
+		"retryRetryOnConflict":      c.Universe.Function(types.Name{Package: "k8s.io/client-go/util/retry", Name: "RetryOnConflict"}),
 		"watchInterface":            c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Interface"}),
 		"RESTClientInterface":       c.Universe.Type(types.Name{Package: "k8s.io/client-go/rest", Name: "Interface"}),
 		"schemeParameterCodec":      c.Universe.Variable(types.Name{Package: path.Join(g.clientsetPackage, "scheme"), Name: "ParameterCodec"}),
@@ -213,7 +224,19 @@ func (g *genClientForType) GenerateType(c *generator.Context, t *types.Type, w i
 		if len(extendedMethods) > 0 {
 			interfaceSuffix = "\n"
 		}
-		sw.Do("\n"+generateInterface(defaultVerbTemplates, tags)+interfaceSuffix, m)
+		interfaceMethods := generateInterface(defaultVerbTemplates, tags)
+		if tags.HasVerb("patch") {
+			interfaceMethods += "\n" + typedPatchInterfaceTemplate
+		}
+		if g.createOrUpdate && hasCreateOrUpdateVerbs(tags) {
+			interfaceMethods += "\n" + createOrUpdateInterfaceTemplate
+		}
+		if g.updateWithRetry {
+			for _, verb := range updateWithRetryVerbs(tags) {
+				interfaceMethods += "\n" + updateWithRetryInterfaceTemplates[verb]
+			}
+		}
+		sw.Do("\n"+interfaceMethods+interfaceSuffix, m)
 		// add extended verbs into interface
 		for _, v := range extendedMethods {
 			sw.Do(v.template+interfaceSuffix, v.args)
@@ -247,6 +270,20 @@ func (g *genClientForType) GenerateType(c *generator.Context, t *types.Type, w i
 	sw.Do(structType[listableOrAppliable], m)
 	sw.Do(newStruct[structNamespaced|listableOrAppliable], m)
 
+	if tags.HasVerb("patch") {
+		sw.Do(typedPatchTemplate, m)
+	}
+
+	if g.createOrUpdate && hasCreateOrUpdateVerbs(tags) {
+		sw.Do(createOrUpdateTemplate, m)
+	}
+
+	if g.updateWithRetry {
+		for _, verb := range updateWithRetryVerbs(tags) {
+			sw.Do(updateWithRetryTemplates[verb], m)
+		}
+	}
+
 	// generate expansion methods
 	for _, e := range tags.Extensions {
 		if e.HasVerb("apply") && !generateApply {
@@ -786,6 +823,139 @@ func (c *$.type|privatePlural$) $.verb$(ctx $.context|raw$, name string, pt $.Pa
 }
 `
 
+// typedPatchInterfaceTemplate declares the typed patch helpers which are
+// generated for every type supporting the patch verb.
+var typedPatchInterfaceTemplate = `MergePatch$.type|public$(ctx $.context|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error)
+StrategicMergePatch$.type|public$(ctx $.context|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error)`
+
+var typedPatchTemplate = `
+// MergePatch$.type|public$ marshals patch to JSON and applies it to the named $.type|private$ as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *$.type|privatePlural$) MergePatch$.type|public$(ctx $.context|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error) {
+	data, err := $.jsonMarshal|raw$(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, $.MergePatchType|raw$, data, opts)
+}
+
+// StrategicMergePatch$.type|public$ marshals patch to JSON and applies it to the named $.type|private$ as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatch$.type|public$ for them instead.
+func (c *$.type|privatePlural$) StrategicMergePatch$.type|public$(ctx $.context|raw$, name string, patch *$.type|raw$, opts $.PatchOptions|raw$) (*$.type|raw$, error) {
+	data, err := $.jsonMarshal|raw$(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, $.StrategicMergePatchType|raw$, data, opts)
+}
+`
+
+// hasCreateOrUpdateVerbs reports whether the client for a type has all the
+// verbs used by the CreateOrUpdate helper.
+func hasCreateOrUpdateVerbs(tags util.Tags) bool {
+	return tags.HasVerb("get") && tags.HasVerb("create") && tags.HasVerb("update")
+}
+
+// createOrUpdateInterfaceTemplate declares the CreateOrUpdate helper which is
+// generated when client-gen runs with --create-or-update.
+var createOrUpdateInterfaceTemplate = `CreateOrUpdate$.type|public$(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error)`
+
+var createOrUpdateTemplate = `
+// CreateOrUpdate$.type|public$ gets the $.type|private$ named like obj, applies mutate to it and updates it.
+// If the $.type|private$ does not exist, mutate is applied to a copy of obj which is then created.
+// The whole sequence is retried on conflicts and when a concurrent create wins the race.
+func (c *$.type|privatePlural$) CreateOrUpdate$.type|public$(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryOnError|raw$($.retryDefaultRetry|raw$, func(err error) bool {
+		return $.errorsIsConflict|raw$(err) || $.errorsIsAlreadyExists|raw$(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if $.errorsIsNotFound|raw$(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, $.CreateOptions|raw${DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`
+
+// updateWithRetryVerbs returns the update verbs of the client for a type
+// which get an UpdateWithRetry style helper. The helpers also need the get verb
+// to fetch the object they mutate.
+func updateWithRetryVerbs(tags util.Tags) []string {
+	if !tags.HasVerb("get") {
+		return nil
+	}
+	var verbs []string
+	for _, verb := range []string{"update", "updateStatus"} {
+		if tags.HasVerb(verb) {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs
+}
+
+// updateWithRetryInterfaceTemplates declares the helpers which are generated
+// when client-gen runs with --update-with-retry.
+var updateWithRetryInterfaceTemplates = map[string]string{
+	"update":       `UpdateWithRetry(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error)`,
+	"updateStatus": `UpdateStatusWithRetry(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error)`,
+}
+
+var updateWithRetryTemplates = map[string]string{
+	"update": `
+// UpdateWithRetry gets the $.type|private$ named like obj, applies mutate to it and updates it.
+// On conflicts the $.type|private$ is fetched again and mutate is applied to the fresh copy.
+func (c *$.type|privatePlural$) UpdateWithRetry(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryRetryOnConflict|raw$($.retryDefaultRetry|raw$, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`,
+	"updateStatus": `
+// UpdateStatusWithRetry gets the $.type|private$ named like obj, applies mutate to it and updates its status.
+// On conflicts the $.type|private$ is fetched again and mutate is applied to the fresh copy.
+func (c *$.type|privatePlural$) UpdateStatusWithRetry(ctx $.context|raw$, obj *$.type|raw$, mutate func(*$.type|raw$), opts $.UpdateOptions|raw$) (*$.type|raw$, error) {
+	var result *$.type|raw$
+	err := $.retryRetryOnConflict|raw$($.retryDefaultRetry|raw$, func() error {
+		existing, err := c.Get(ctx, obj.Name, $.GetOptions|raw${})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+`,
+}
+
 var applyTemplate = `
 // $.verb$ takes the given apply declarative configuration, applies it and returns the applied $.resultType|private$.
 func (c *$.type|privatePlural$) $.verb$(ctx $.context|raw$, $.inputType|private$ *$.inputApplyConfig|raw$, opts $.ApplyOptions|raw$) (result *$.resultType|raw$, err error) {
@@ -19,8 +19,10 @@ package util
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/gengo/v2"
 )
 
@@ -31,6 +33,9 @@ var supportedTags = []string{
 	"genclient:onlyVerbs",
 	"genclient:skipVerbs",
 	"genclient:noStatus",
+	"genclient:noResync",
+	"genclient:coResource",
+	"genclient:testListWatch",
 	"genclient:readonly",
 	"genclient:method",
 }
@@ -156,6 +161,29 @@ type Tags struct {
 	NonNamespaced bool
 	// +genclient:noStatus
 	NoStatus bool
+	// +genclient:noResync
+	NoResync bool
+	// +genclient:coResource=foostatuses
+	// CoResource is a resource whose watch stream delivers the status of the
+	// type separately from the type's own resource.
+	CoResource string
+	// +genclient:testListWatch=example.com/fixtures.NewFooListWatch
+	// TestListWatch is the function, qualified by its import path, which
+	// returns the ListerWatcher replacing the client of the type's informers
+	// in builds with the testlistwatch build tag.
+	TestListWatch string
+	// +informers:labelSelector=app=foo,tier!=cache
+	// LabelSelector is ANDed into the label selector of the lists and
+	// watches of the type's informers.
+	LabelSelector string
+	// +informers:objectMetaField=Metadata
+	// ObjectMetaField is the name of the member holding the ObjectMeta of the
+	// type, if it is not ObjectMeta.
+	ObjectMetaField string
+	// +lister:index=Spec.NodeName
+	// ListerIndexes are the paths of the string members by which the
+	// type's listers list objects through an index of the informer.
+	ListerIndexes []string
 	// +genclient:noVerbs
 	NoVerbs bool
 	// +genclient:skipVerbs=get,update
@@ -206,6 +234,37 @@ func ParseClientGenTags(lines []string) (Tags, error) {
 	}
 	_, ret.NoVerbs = values[genClientPrefix+"noVerbs"]
 	_, ret.NoStatus = values[genClientPrefix+"noStatus"]
+	_, ret.NoResync = values[genClientPrefix+"noResync"]
+	if v, exists := values[genClientPrefix+"coResource"]; exists {
+		if len(v[0]) == 0 {
+			return ret, fmt.Errorf("+genclient:coResource requires a resource, e.g. +genclient:coResource=foostatuses")
+		}
+		ret.CoResource = v[0]
+	}
+	if v, exists := values[genClientPrefix+"testListWatch"]; exists {
+		if i := strings.LastIndex(v[0], "."); i <= 0 || i == len(v[0])-1 {
+			return ret, fmt.Errorf("+genclient:testListWatch requires a function qualified by its import path, e.g. +genclient:testListWatch=example.com/fixtures.NewFooListWatch")
+		}
+		ret.TestListWatch = v[0]
+	}
+	if v, exists := values["informers:labelSelector"]; exists {
+		if _, err := labels.Parse(v[0]); err != nil || len(v[0]) == 0 {
+			return ret, fmt.Errorf("+informers:labelSelector=%s is not a valid label selector, e.g. +informers:labelSelector=app=foo", v[0])
+		}
+		ret.LabelSelector = v[0]
+	}
+	if v, exists := values["informers:objectMetaField"]; exists {
+		if len(v[0]) == 0 {
+			return ret, fmt.Errorf("+informers:objectMetaField requires the name of a member, e.g. +informers:objectMetaField=Metadata")
+		}
+		ret.ObjectMetaField = v[0]
+	}
+	for _, v := range values["lister:index"] {
+		if len(v) == 0 || slices.Contains(strings.Split(v, "."), "") {
+			return ret, fmt.Errorf("+lister:index=%s requires the path of a member, e.g. +lister:index=Spec.NodeName", v)
+		}
+		ret.ListerIndexes = append(ret.ListerIndexes, v)
+	}
 	onlyVerbs := []string{}
 	if _, isReadonly := values[genClientPrefix+"readonly"]; isReadonly {
 		onlyVerbs = ReadonlyVerbs
@@ -55,6 +55,58 @@ func TestParseTags(t *testing.T) {
 			lines:      []string{`+genclient`, `+genclient:noStatus`},
 			expectTags: Tags{GenerateClient: true, NoStatus: true},
 		},
+		"genclient:noResync": {
+			lines:      []string{`+genclient`, `+genclient:noResync`},
+			expectTags: Tags{GenerateClient: true, NoResync: true},
+		},
+		"genclient:coResource": {
+			lines:      []string{`+genclient`, `+genclient:coResource=teststatuses`},
+			expectTags: Tags{GenerateClient: true, CoResource: "teststatuses"},
+		},
+		"genclient:coResource without resource": {
+			lines:       []string{`+genclient`, `+genclient:coResource`},
+			expectError: true,
+		},
+		"genclient:testListWatch": {
+			lines:      []string{`+genclient`, `+genclient:testListWatch=example.com/fixtures.NewTestListWatch`},
+			expectTags: Tags{GenerateClient: true, TestListWatch: "example.com/fixtures.NewTestListWatch"},
+		},
+		"genclient:testListWatch without package": {
+			lines:       []string{`+genclient`, `+genclient:testListWatch=NewTestListWatch`},
+			expectError: true,
+		},
+		"informers:labelSelector": {
+			lines:      []string{`+genclient`, `+informers:labelSelector=app=foo,tier!=cache`},
+			expectTags: Tags{GenerateClient: true, LabelSelector: "app=foo,tier!=cache"},
+		},
+		"informers:labelSelector invalid": {
+			lines:       []string{`+genclient`, `+informers:labelSelector=app==`},
+			expectError: true,
+		},
+		"informers:labelSelector empty": {
+			lines:       []string{`+genclient`, `+informers:labelSelector`},
+			expectError: true,
+		},
+		"informers:objectMetaField": {
+			lines:      []string{`+genclient`, `+informers:objectMetaField=Metadata`},
+			expectTags: Tags{GenerateClient: true, ObjectMetaField: "Metadata"},
+		},
+		"informers:objectMetaField without member": {
+			lines:       []string{`+genclient`, `+informers:objectMetaField`},
+			expectError: true,
+		},
+		"lister:index": {
+			lines:      []string{`+genclient`, `+lister:index=Spec.NodeName`, `+lister:index=Status.Phase`},
+			expectTags: Tags{GenerateClient: true, ListerIndexes: []string{"Spec.NodeName", "Status.Phase"}},
+		},
+		"lister:index without member": {
+			lines:       []string{`+genclient`, `+lister:index`},
+			expectError: true,
+		},
+		"lister:index with an empty member": {
+			lines:       []string{`+genclient`, `+lister:index=Spec..NodeName`},
+			expectError: true,
+		},
 		"genclient:onlyVerbs": {
 			lines:      []string{`+genclient`, `+genclient:onlyVerbs=create,delete`},
 			expectTags: Tags{GenerateClient: true, SkipVerbs: []string{"update", "updateStatus", "deleteCollection", "get", "list", "watch", "patch", "apply", "applyStatus"}},
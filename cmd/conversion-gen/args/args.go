@@ -60,6 +60,11 @@ type Args struct {
 	// groups of generators (external API that depends on Kube generations) should
 	// keep tags distinct as well.
 	GeneratedBuildTag string
+
+	// GenerateRoundTripTests indicates whether to also emit a test file next to
+	// each generated conversion file, which runs the converted kinds through
+	// apimachinery's round-trip fuzzer.
+	GenerateRoundTripTests bool
 }
 
 // New returns default arguments for the generator.
@@ -84,6 +89,8 @@ func (args *Args) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&args.GoHeaderFile, "go-header-file", "",
 		"the path to a file containing boilerplate header text; the string \"YEAR\" will be replaced with the current 4-digit year")
 	fs.StringVar(&args.GeneratedBuildTag, "build-tag", args.GeneratedBuildTag, "A Go build tag to use to identify files generated by this command. Should be unique.")
+	fs.BoolVar(&args.GenerateRoundTripTests, "generate-roundtrip-tests", args.GenerateRoundTripTests,
+		"If true, also generate a <output-file>_test.go round-trip fuzz test for each package; the package and its internal peers must export SchemeGroupVersion and AddToScheme.")
 }
 
 // Validate checks the given arguments.
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -242,6 +243,7 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 	filteredInputs := make([]string, 0, len(context.Inputs))
 	otherPkgs := make([]string, 0, len(context.Inputs))
 	pkgToPeers := map[string][]string{}
+	pkgToTagPeers := map[string][]string{}
 	pkgToExternal := map[string]string{}
 	for _, i := range context.Inputs {
 		klog.V(3).Infof("pre-processing pkg %q", i)
@@ -269,6 +271,7 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 		} else {
 			// Save peers for each input
 			pkgToPeers[i] = peerPkgs
+			pkgToTagPeers[i] = slices.Clone(peerPkgs)
 		}
 		otherPkgs = append(otherPkgs, peerPkgs...)
 		// Keep this one for further processing.
@@ -356,9 +359,16 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 					return t.Name.Package == typesPkg.Path
 				},
 				GeneratorsFunc: func(c *generator.Context) (generators []generator.Generator) {
-					return []generator.Generator{
+					generators = []generator.Generator{
 						NewGenConversion(args.OutputFile, typesPkg.Path, pkg.Path, manualConversions, pkgToPeers[pkg.Path], unsafeEquality),
 					}
+					// Only kinds converted with a tag-specified peer can be
+					// registered in a scheme, so those are the ones tested.
+					if args.GenerateRoundTripTests && hasRoundTripKinds(c, typesPkg.Path, pkgToTagPeers[pkg.Path]) {
+						generators = append(generators,
+							NewGenRoundTripTest(RoundTripTestFilename(args.OutputFile), typesPkg.Path, pkg.Path, pkgToTagPeers[pkg.Path]))
+					}
+					return generators
 				},
 			})
 	}
@@ -559,15 +569,19 @@ func (n *namerPlusImportTracking) Name(t *types.Type) string {
 }
 
 func (g *genConversion) convertibleOnlyWithinPackage(inType, outType *types.Type) bool {
+	return convertibleOnlyWithinPackage(g.typesPackage, inType, outType)
+}
+
+func convertibleOnlyWithinPackage(typesPackage string, inType, outType *types.Type) bool {
 	var t *types.Type
 	var other *types.Type
-	if inType.Name.Package == g.typesPackage {
+	if inType.Name.Package == typesPackage {
 		t, other = inType, outType
 	} else {
 		t, other = outType, inType
 	}
 
-	if t.Name.Package != g.typesPackage {
+	if t.Name.Package != typesPackage {
 		return false
 	}
 	// If the type has opted out, skip it.
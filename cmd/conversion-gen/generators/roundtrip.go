@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+	"strings"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+)
+
+const (
+	metav1PackagePath     = "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schemaPackagePath     = "k8s.io/apimachinery/pkg/runtime/schema"
+	serializerPackagePath = "k8s.io/apimachinery/pkg/runtime/serializer"
+	utilRuntimePath       = "k8s.io/apimachinery/pkg/util/runtime"
+	fuzzerPackagePath     = "k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metaFuzzerPackagePath = "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	roundTripPackagePath  = "k8s.io/apimachinery/pkg/api/apitesting/roundtrip"
+)
+
+// RoundTripTestFilename returns the name of the round-trip test file emitted
+// next to the conversion file called outputFilename.
+func RoundTripTestFilename(outputFilename string) string {
+	return strings.TrimSuffix(outputFilename, ".go") + "_test.go"
+}
+
+// roundTripPeerFor returns the peer of t if t is a top-level kind (it embeds
+// metav1.TypeMeta) whose conversions are generated, and nil otherwise. Only
+// the peer packages named by the package's +k8s:conversion-gen tags are
+// considered, since those are the ones providing a scheme builder.
+func roundTripPeerFor(c *generator.Context, t *types.Type, typesPackage string, peerPkgs []string) *types.Type {
+	if t.Name.Package != typesPackage || !hasTypeMeta(t) {
+		return nil
+	}
+	peerType := getPeerTypeFor(c, t, peerPkgs)
+	if peerType == nil || !convertibleOnlyWithinPackage(typesPackage, t, peerType) {
+		return nil
+	}
+	return peerType
+}
+
+func hasTypeMeta(t *types.Type) bool {
+	for _, m := range t.Members {
+		if m.Embedded && m.Type.Name == (types.Name{Package: metav1PackagePath, Name: "TypeMeta"}) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRoundTripKinds reports whether any type in the context would be
+// exercised by the round-trip test generated for typesPackage.
+func hasRoundTripKinds(c *generator.Context, typesPackage string, peerPkgs []string) bool {
+	for _, t := range c.Order {
+		if roundTripPeerFor(c, t, typesPackage, peerPkgs) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// genRoundTripTest produces a test file which feeds the kinds converted by
+// the sibling conversion file through apimachinery's round-trip fuzzer.
+type genRoundTripTest struct {
+	generator.GoGenerator
+	typesPackage  string
+	outputPackage string
+	peerPackages  []string
+	imports       namer.ImportTracker
+	// kinds by peer package, in the order the peer packages were first seen
+	peers []string
+	kinds map[string][]string
+}
+
+func NewGenRoundTripTest(outputFilename, typesPackage, outputPackage string, peerPkgs []string) generator.Generator {
+	return &genRoundTripTest{
+		GoGenerator: generator.GoGenerator{
+			OutputFilename: outputFilename,
+		},
+		typesPackage:  typesPackage,
+		outputPackage: outputPackage,
+		peerPackages:  peerPkgs,
+		imports:       generator.NewImportTrackerForPackage(outputPackage),
+		kinds:         map[string][]string{},
+	}
+}
+
+func (g *genRoundTripTest) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *genRoundTripTest) Filter(c *generator.Context, t *types.Type) bool {
+	peerType := roundTripPeerFor(c, t, g.typesPackage, g.peerPackages)
+	if peerType == nil {
+		return false
+	}
+	pkg := peerType.Name.Package
+	if _, ok := g.kinds[pkg]; !ok {
+		g.peers = append(g.peers, pkg)
+	}
+	g.kinds[pkg] = append(g.kinds[pkg], t.Name.Name)
+	return true
+}
+
+func (g *genRoundTripTest) Imports(c *generator.Context) (imports []string) {
+	return g.imports.ImportLines()
+}
+
+func (g *genRoundTripTest) Init(c *generator.Context, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	m := map[string]interface{}{
+		"GroupVersion":                         types.Ref(schemaPackagePath, "GroupVersion"),
+		"Scheme":                               types.Ref(runtimePackagePath, "Scheme"),
+		"NewScheme":                            types.Ref(runtimePackagePath, "NewScheme"),
+		"Must":                                 types.Ref(utilRuntimePath, "Must"),
+		"NewCodecFactory":                      types.Ref(serializerPackagePath, "NewCodecFactory"),
+		"FuzzerFor":                            types.Ref(fuzzerPackagePath, "FuzzerFor"),
+		"metaFuzzerFuncs":                      types.Ref(metaFuzzerPackagePath, "Funcs"),
+		"NewSource":                            types.Ref("math/rand", "NewSource"),
+		"Int63":                                types.Ref("math/rand", "Int63"),
+		"testingT":                             types.Ref("testing", "T"),
+		"RoundTripSpecificKindWithoutProtobuf": types.Ref(roundTripPackagePath, "RoundTripSpecificKindWithoutProtobuf"),
+	}
+	sw.Do(roundTripTableHeader, m)
+	for _, peer := range g.peers {
+		sw.Do(roundTripTableEntry, map[string]interface{}{
+			"Scheme":               m["Scheme"],
+			"internalGroupVersion": types.Ref(peer, "SchemeGroupVersion"),
+			"internalAddToScheme":  types.Ref(peer, "AddToScheme"),
+			"externalGroupVersion": types.Ref(g.outputPackage, "SchemeGroupVersion"),
+			"externalAddToScheme":  types.Ref(g.outputPackage, "AddToScheme"),
+			"kinds":                `"` + strings.Join(g.kinds[peer], `", "`) + `"`,
+		})
+	}
+	sw.Do("}\n\n", nil)
+	sw.Do(roundTripTest, m)
+	return sw.Error()
+}
+
+var roundTripTableHeader = `
+// roundTripGroupVersions lists the kinds whose generated conversions are
+// exercised by TestRoundTripConversions, with the schemes they are registered in.
+var roundTripGroupVersions = []struct {
+	Internal    $.GroupVersion|raw$
+	External    $.GroupVersion|raw$
+	AddToScheme []func(*$.Scheme|raw$) error
+	Kinds       []string
+}{
+`
+
+var roundTripTableEntry = `{
+	Internal:    $.internalGroupVersion|raw$,
+	External:    $.externalGroupVersion|raw$,
+	AddToScheme: []func(*$.Scheme|raw$) error{$.internalAddToScheme|raw$, $.externalAddToScheme|raw$},
+	Kinds:       []string{$.kinds$},
+},
+`
+
+var roundTripTest = `
+// TestRoundTripConversions fuzzes every kind in roundTripGroupVersions and
+// checks that it survives a conversion to the external version and back.
+func TestRoundTripConversions(t *$.testingT|raw$) {
+	for _, gv := range roundTripGroupVersions {
+		scheme := $.NewScheme|raw$()
+		for _, addToScheme := range gv.AddToScheme {
+			$.Must|raw$(addToScheme(scheme))
+		}
+		codecs := $.NewCodecFactory|raw$(scheme)
+		filler := $.FuzzerFor|raw$($.metaFuzzerFuncs|raw$, $.NewSource|raw$($.Int63|raw$()), codecs)
+		for _, kind := range gv.Kinds {
+			t.Run(gv.External.WithKind(kind).String(), func(t *$.testingT|raw$) {
+				// The fuzzer only converts between versions of the same group,
+				// so anything else would pass without testing a conversion.
+				if gv.Internal.Group != gv.External.Group || !scheme.Recognizes(gv.External.WithKind(kind)) {
+					t.Fatalf("%v is not registered as an external version of %v", gv.External.WithKind(kind), gv.Internal.WithKind(kind))
+				}
+				$.RoundTripSpecificKindWithoutProtobuf|raw$(t, gv.Internal.WithKind(kind), scheme, codecs, filler, nil)
+			})
+		}
+	}
+}
+`
@@ -92,6 +92,14 @@ limitations under the License.
 // out of Conversion generation by specifying a comment on the of the form:
 //
 //	// +k8s:conversion-gen=false
+//
+// With `--generate-roundtrip-tests`, `conversion-gen` also writes a
+// `<output-file>_test.go` next to each conversion file.  It lists the
+// kinds (types embedding metav1.TypeMeta) converted with the packages
+// named in the `k8s:conversion-gen` tags, registers them through the
+// `AddToScheme` of both packages, and runs them through apimachinery's
+// round-trip fuzzer, so that a field dropped by a conversion fails
+// `go test`.
 package main
 
 import (
@@ -37,6 +37,7 @@ const (
 	tagEnabledName              = "k8s:deepcopy-gen"
 	interfacesTagName           = tagEnabledName + ":interfaces"
 	interfacesNonPointerTagName = tagEnabledName + ":nonpointer-interfaces" // attach the DeepCopy<Interface> methods to the
+	shallowTagName              = tagEnabledName + ":shallow"               // share the member between copies instead of copying it
 )
 
 // Known values for the comment tag.
@@ -797,6 +798,14 @@ func (g *genDeepCopy) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 
 	// Now fix-up fields as needed.
 	for _, m := range ut.Members {
+		shallow, err := isShallowMember(m)
+		if err != nil {
+			klog.Fatalf("Type %v: %v", t, err)
+		}
+		if shallow {
+			// the initial *out = *in shares the member
+			continue
+		}
 		ft := m.Type
 		uft := underlyingType(ft)
 
@@ -855,6 +864,29 @@ func (g *genDeepCopy) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 	}
 }
 
+// isShallowMember returns true if m has the shallow tag, which makes
+// DeepCopyInto share m between the copies instead of copying it. Only pointers
+// and slices can be shared, for values which are never modified once set.
+// Maps cannot: adding or deleting a key in a copy would change the map of the
+// object it was copied from.
+func isShallowMember(m types.Member) (bool, error) {
+	tags, err := genutil.ExtractCommentTagsWithoutArguments("+", []string{shallowTagName}, m.CommentLines)
+	if err != nil {
+		return false, err
+	}
+	if tags[shallowTagName] == nil {
+		return false, nil
+	}
+	switch ut := underlyingType(m.Type); ut.Kind {
+	case types.Pointer, types.Slice:
+		return true, nil
+	case types.Map:
+		return false, fmt.Errorf("member %s has the %s tag, but its keys would be shared between copies: maps cannot be copied shallowly", m.Name, shallowTagName)
+	default:
+		return false, fmt.Errorf("member %s has the %s tag, but it is a %s, not a pointer or a slice", m.Name, shallowTagName, ut.Kind)
+	}
+}
+
 // doPointer generates code for a pointer or an alias to a pointer. The generated code is
 // is the same for both cases, i.e. it's the code for the underlying type.
 func (g *genDeepCopy) doPointer(t *types.Type, sw *generator.SnippetWriter) {
@@ -694,3 +694,66 @@ func Test_extractInterfacesTag(t *testing.T) {
 		}
 	}
 }
+
+func Test_isShallowMember(t *testing.T) {
+	str := &types.Type{Name: types.Name{Name: "string"}, Kind: types.Builtin}
+	testCases := []struct {
+		name      string
+		member    types.Member
+		expect    bool
+		expectErr bool
+	}{
+		{
+			name:   "untagged pointer",
+			member: types.Member{Name: "Ptr", Type: &types.Type{Kind: types.Pointer, Elem: str}},
+			expect: false,
+		},
+		{
+			name:   "pointer",
+			member: types.Member{Name: "Ptr", Type: &types.Type{Kind: types.Pointer, Elem: str}, CommentLines: []string{"+k8s:deepcopy-gen:shallow"}},
+			expect: true,
+		},
+		{
+			name:   "slice",
+			member: types.Member{Name: "Slice", Type: &types.Type{Kind: types.Slice, Elem: str}, CommentLines: []string{"+k8s:deepcopy-gen:shallow"}},
+			expect: true,
+		},
+		{
+			name: "alias of a slice",
+			member: types.Member{Name: "Alias", Type: &types.Type{
+				Name:       types.Name{Package: "pkgname", Name: "Strings"},
+				Kind:       types.Alias,
+				Underlying: &types.Type{Kind: types.Slice, Elem: str},
+			}, CommentLines: []string{"+k8s:deepcopy-gen:shallow"}},
+			expect: true,
+		},
+		{
+			name:      "map",
+			member:    types.Member{Name: "Map", Type: &types.Type{Kind: types.Map, Key: str, Elem: str}, CommentLines: []string{"+k8s:deepcopy-gen:shallow"}},
+			expectErr: true,
+		},
+		{
+			name:      "builtin",
+			member:    types.Member{Name: "String", Type: str, CommentLines: []string{"+k8s:deepcopy-gen:shallow"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shallow, err := isShallowMember(tc.member)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if shallow != tc.expect {
+				t.Errorf("expected %v, got %v", tc.expect, shallow)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/interfaces"
 	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/maps"
 	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/pointer"
+	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/shallow"
 	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/slices"
 	"k8s.io/code-generator/cmd/deepcopy-gen/output_tests/structs"
 	"k8s.io/utils/dump"
@@ -79,6 +80,39 @@ func TestWithValueFuzzer(t *testing.T) {
 	}
 }
 
+// TestShallow verifies that members with the shallow tag are shared between
+// the copies, and that the other members are copied.
+func TestShallow(t *testing.T) {
+	original := &shallow.Ttest{
+		SharedSchema: &shallow.Schema{Fields: []string{"a"}},
+		SharedNames:  []string{"a", "b"},
+		Schema:       &shallow.Schema{Fields: []string{"a"}},
+		Names:        []string{"a", "b"},
+		Labels:       map[string]string{"a": "b"},
+	}
+	deepCopy := original.DeepCopy()
+	if !reflect.DeepEqual(original, deepCopy) {
+		t.Fatalf("original and deepCopy are different:\n\n  original = %s\n\n  deepCopy() = %s", dump.Pretty(original), dump.Pretty(deepCopy))
+	}
+
+	if deepCopy.SharedSchema != original.SharedSchema {
+		t.Errorf("SharedSchema was copied")
+	}
+	if &deepCopy.SharedNames[0] != &original.SharedNames[0] {
+		t.Errorf("SharedNames was copied")
+	}
+	if deepCopy.Schema == original.Schema || &deepCopy.Schema.Fields[0] == &original.Schema.Fields[0] {
+		t.Errorf("Schema was shared")
+	}
+	if &deepCopy.Names[0] == &original.Names[0] {
+		t.Errorf("Names was shared")
+	}
+	deepCopy.Labels["c"] = "d"
+	if _, ok := original.Labels["c"]; ok {
+		t.Errorf("Labels was shared")
+	}
+}
+
 func BenchmarkReflectDeepCopy(b *testing.B) {
 	fourtytwo := "fourtytwo"
 	fourtytwoPtr := &fourtytwo
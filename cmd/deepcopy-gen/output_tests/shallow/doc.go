@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +k8s:deepcopy-gen=package
+
+// This is a test package.
+package shallow
+
+// Schema is large and immutable once parsed, so that it can be shared.
+type Schema struct {
+	Fields []string
+}
+
+type Ttest struct {
+	// +k8s:deepcopy-gen:shallow
+	SharedSchema *Schema
+	// +k8s:deepcopy-gen:shallow
+	SharedNames []string
+
+	Schema *Schema
+	Names  []string
+	Labels map[string]string
+}
@@ -717,6 +717,9 @@ func (c *callTreeForType) build(t *types.Type, root bool) *callNode {
 	case types.Map:
 		if child := c.build(t.Elem, false); child != nil {
 			child.key = true
+			if t.Elem.Kind == types.Pointer {
+				child.elem = true
+			}
 			parent.children = append(parent.children, *child)
 		} else if member := populateDefaultValue(nil, t.Elem, "", t.Elem.CommentLines, t.Elem.Name.Package); member != nil {
 			member.key = true
@@ -1193,7 +1196,7 @@ func (n *callNode) WriteMethod(c *generator.Context, varName string, depth int,
 		"var":   varName,
 	}
 
-	isPointer := n.elem && !n.index
+	isPointer := n.elem && !n.index && !n.key
 	if isPointer && len(ancestors) > 0 {
 		sw.Do("if $.var$ != nil {\n", vars)
 	}
@@ -1223,6 +1226,20 @@ func (n *callNode) WriteMethod(c *generator.Context, varName string, depth int,
 			sw.Do("for $.index$ := range $.var$ {\n", vars)
 			n.writeDefaulter(c, varName, index, isPointer, sw)
 			sw.Do("}\n", nil)
+		} else {
+			// Map values are not addressable, so a value is defaulted through a
+			// local copy which is then stored back under the same key. Ranging
+			// over a nil map does nothing, so no entries are ever added to it.
+			sw.Do("for $.index$ := range $.var$ {\n", vars)
+			sw.Do("$.local$ := $.var$[$.index$]\n", vars)
+			n.writeCalls(local, n.elem, sw)
+			for i := range n.children {
+				n.children[i].WriteMethod(c, local, depth+1, append(ancestors, n), sw)
+			}
+			if !n.elem {
+				sw.Do("$.var$[$.index$] = $.local$\n", vars)
+			}
+			sw.Do("}\n", nil)
 		}
 	default:
 		n.writeDefaulter(c, varName, index, isPointer, sw)
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collections
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/utils/ptr"
+)
+
+func Test_Collections(t *testing.T) {
+	defaulted := Item{BoolField: ptr.To(true)}
+	unchanged := Item{BoolField: ptr.To(false)}
+
+	testcases := []struct {
+		name string
+		in   Ttest
+		out  Ttest
+	}{
+		{
+			name: "nil",
+			in:   Ttest{},
+			out: Ttest{
+				Tree: Node{Item: defaulted},
+			},
+		},
+		{
+			name: "elements",
+			in: Ttest{
+				Slice:        []Item{{}, unchanged},
+				PointerSlice: []*Item{{}, nil},
+				Map:          map[string]Item{"a": {}, "b": unchanged},
+				PointerMap:   map[string]*Item{"a": {}, "b": nil},
+				MapOfSlices:  map[string][]Item{"a": {{}, unchanged}, "b": nil},
+			},
+			out: Ttest{
+				Slice:        []Item{defaulted, unchanged},
+				PointerSlice: []*Item{&defaulted, nil},
+				Map:          map[string]Item{"a": defaulted, "b": unchanged},
+				PointerMap:   map[string]*Item{"a": &defaulted, "b": nil},
+				MapOfSlices:  map[string][]Item{"a": {defaulted, unchanged}, "b": nil},
+				Tree:         Node{Item: defaulted},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetObjectDefaults_Ttest(&tc.in)
+			if diff := cmp.Diff(tc.out, tc.in); len(diff) > 0 {
+				t.Errorf("Error: Expected and actual output are different \n %s\n", diff)
+			}
+		})
+	}
+}
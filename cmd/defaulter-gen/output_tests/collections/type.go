@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collections
+
+import (
+	"k8s.io/code-generator/cmd/defaulter-gen/output_tests/empty"
+)
+
+type Item struct {
+	BoolField *bool
+}
+
+// Node refers to itself through a map, so the generated defaulter must not
+// recurse while building it.
+type Node struct {
+	Item     Item
+	Children map[string]Node
+}
+
+// Only test
+type Ttest struct {
+	empty.TypeMeta
+	Slice        []Item
+	PointerSlice []*Item
+	Map          map[string]Item
+	PointerMap   map[string]*Item
+	MapOfSlices  map[string][]Item
+	Tree         Node
+}
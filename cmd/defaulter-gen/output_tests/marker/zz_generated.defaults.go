@@ -132,11 +132,26 @@ func SetObjectDefaults_Defaulted(in *Defaulted) {
 			panic(err)
 		}
 	}
+	for i := range in.StructMap {
+		a := in.StructMap[i]
+		if a.I == 0 {
+			a.I = 1
+		}
+		in.StructMap[i] = a
+	}
 	if in.PtrStructMap == nil {
 		if err := json.Unmarshal([]byte(`{"foo": {"S": "string", "I": 1}}`), &in.PtrStructMap); err != nil {
 			panic(err)
 		}
 	}
+	for i := range in.PtrStructMap {
+		a := in.PtrStructMap[i]
+		if a != nil {
+			if a.I == 0 {
+				a.I = 1
+			}
+		}
+	}
 	if in.AliasPtr == nil {
 		var ptrVar1 string = "banana"
 		in.AliasPtr = &ptrVar1
@@ -234,11 +249,26 @@ func SetObjectDefaults_DefaultedOmitempty(in *DefaultedOmitempty) {
 			panic(err)
 		}
 	}
+	for i := range in.StructMap {
+		a := in.StructMap[i]
+		if a.I == 0 {
+			a.I = 1
+		}
+		in.StructMap[i] = a
+	}
 	if in.PtrStructMap == nil {
 		if err := json.Unmarshal([]byte(`{"foo": {"S": "string", "I": 1}}`), &in.PtrStructMap); err != nil {
 			panic(err)
 		}
 	}
+	for i := range in.PtrStructMap {
+		a := in.PtrStructMap[i]
+		if a != nil {
+			if a.I == 0 {
+				a.I = 1
+			}
+		}
+	}
 	if in.AliasPtr == nil {
 		var ptrVar1 string = "banana"
 		in.AliasPtr = &ptrVar1
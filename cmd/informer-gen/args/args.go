@@ -18,6 +18,7 @@ package args
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/pflag"
 )
@@ -30,11 +31,51 @@ type Args struct {
 	VersionedClientSetPackage string // must be a Go import-path
 	InternalClientSetPackage  string // must be a Go import-path
 	ListersPackage            string // must be a Go import-path
+	ApplyConfigurationPackage string // must be a Go import-path
 	SingleDirectory           bool
 
+	// ExternalOnly skips internal packages, so that neither informers nor
+	// the factory of internal versions are generated.
+	ExternalOnly bool
+
 	// PluralExceptions define a list of pluralizer exceptions in Type:PluralType format.
 	// The default list is "Endpoints:Endpoints"
 	PluralExceptions []string
+
+	// AllowMissingObjectMeta makes packages whose genclient types lack
+	// ObjectMeta be reported and skipped, instead of failing generation.
+	AllowMissingObjectMeta bool
+
+	// ClientAccessorTemplates customize the method chains through which
+	// informers obtain the clients of their types from the clientset.
+	ClientAccessorTemplates []string
+
+	// PackageDoc is a one-line summary emitted as the package comment of
+	// every generated package, in a doc.go file.
+	PackageDoc string
+
+	// Dynamic makes informers be backed by a dynamic client and produce
+	// unstructured objects, instead of using a clientset and listers.
+	Dynamic bool
+
+	// MetadataOnly makes informers be backed by a metadata client and only
+	// produce the metadata of objects, as *metav1.PartialObjectMetadata,
+	// instead of using a clientset and listers.
+	MetadataOnly bool
+
+	// Controllers makes a <Type>Controller scaffold, which reconciles the
+	// keys of objects through a rate-limited work queue, be generated for
+	// every type.
+	Controllers bool
+
+	// PrometheusCollector makes the factories have a MetricsCollector, a
+	// prometheus.Collector of the cache sizes of their informers. The
+	// generated code then depends on the prometheus client library.
+	PrometheusCollector bool
+
+	// Parallelism is the number of input packages read at once when
+	// building the targets. GOMAXPROCS is used if it is 0.
+	Parallelism int
 }
 
 // New returns default arguments for the generator.
@@ -58,10 +99,37 @@ func (args *Args) AddFlags(fs *pflag.FlagSet) {
 		"the Go import-path of the versioned clientset to use")
 	fs.StringVar(&args.ListersPackage, "listers-package", args.ListersPackage,
 		"the Go import-path of the listers to use")
+	fs.StringVar(&args.ApplyConfigurationPackage, "apply-configuration-package", args.ApplyConfigurationPackage,
+		"the Go import-path of the apply configurations generated by applyconfiguration-gen; "+
+			"if set, <Type>ToApplyConfiguration helpers are generated for the external versions")
 	fs.BoolVar(&args.SingleDirectory, "single-directory", args.SingleDirectory,
 		"if true, omit the intermediate \"internalversion\" and \"externalversions\" subdirectories")
+	fs.BoolVar(&args.ExternalOnly, "external-only", args.ExternalOnly,
+		"if true, skip internal packages and only generate informers for external versions")
 	fs.StringSliceVar(&args.PluralExceptions, "plural-exceptions", args.PluralExceptions,
 		"list of comma separated plural exception definitions in Type:PluralizedType format")
+	fs.BoolVar(&args.AllowMissingObjectMeta, "allow-missing-objectmeta", args.AllowMissingObjectMeta,
+		"if true, packages with genclient types lacking ObjectMeta are reported and skipped instead of failing generation")
+	fs.StringSliceVar(&args.ClientAccessorTemplates, "client-accessor-template", args.ClientAccessorTemplates,
+		"list of comma separated Go templates of the method chains which obtain the client of a type from the clientset, "+
+			"either for all groups or in <group>=<template> form for a single API group; "+
+			"the templates get .Group, .Version, .Plural, .GroupName and .VersionName, the default is \"{{.Group}}{{.Version}}().{{.Plural}}\"")
+	fs.StringVar(&args.PackageDoc, "package-doc", args.PackageDoc,
+		"a one-line summary to emit as the package comment of every generated package, in a doc.go file")
+	fs.BoolVar(&args.Dynamic, "dynamic", args.Dynamic,
+		"if true, generate informers backed by a dynamic client which produce unstructured objects; "+
+			"neither a clientset nor listers are used, and internal versions are skipped")
+	fs.BoolVar(&args.MetadataOnly, "metadata-only", args.MetadataOnly,
+		"if true, generate informers backed by a metadata client which only produce the metadata of objects; "+
+			"neither a clientset nor listers are used, and internal versions are skipped")
+	fs.BoolVar(&args.Controllers, "controllers", args.Controllers,
+		"if true, generate a <Type>Controller scaffold for every type, which reconciles the keys of objects "+
+			"with worker goroutines fed by a rate-limited work queue")
+	fs.BoolVar(&args.PrometheusCollector, "prometheus-collector", args.PrometheusCollector,
+		"if true, generate a MetricsCollector for every factory, a prometheus.Collector of the cache sizes of its informers; "+
+			"the generated code then depends on github.com/prometheus/client_golang")
+	fs.IntVar(&args.Parallelism, "parallelism", args.Parallelism,
+		"the number of input packages to read at once when building the targets, GOMAXPROCS if 0")
 }
 
 // Validate checks the given arguments.
@@ -72,11 +140,20 @@ func (args *Args) Validate() error {
 	if len(args.OutputPkg) == 0 {
 		return fmt.Errorf("--output-pkg must be specified")
 	}
-	if len(args.VersionedClientSetPackage) == 0 {
+	if args.Dynamic && args.MetadataOnly {
+		return fmt.Errorf("--dynamic and --metadata-only are mutually exclusive")
+	}
+	if len(args.VersionedClientSetPackage) == 0 && !args.Dynamic && !args.MetadataOnly {
 		return fmt.Errorf("--versioned-clientset-package must be specified")
 	}
-	if len(args.ListersPackage) == 0 {
+	if len(args.ListersPackage) == 0 && !args.Dynamic && !args.MetadataOnly {
 		return fmt.Errorf("--listers-package must be specified")
 	}
+	if args.Parallelism < 0 {
+		return fmt.Errorf("--parallelism must not be negative")
+	}
+	if strings.Contains(args.PackageDoc, "\n") {
+		return fmt.Errorf("--package-doc must be a single line")
+	}
 	return nil
 }
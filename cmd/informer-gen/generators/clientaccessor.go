@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"strings"
+	"text/template"
+)
+
+// defaultClientAccessorTemplate matches the accessors of clientsets generated
+// by client-gen.
+const defaultClientAccessorTemplate = "{{.Group}}{{.Version}}().{{.Plural}}"
+
+// clientAccessorData is passed to client accessor templates.
+type clientAccessorData struct {
+	// Group and Version are the Go names of the group and version.
+	Group   string
+	Version string
+	// Plural is the plural Go name of the type.
+	Plural string
+	// GroupName and VersionName are the API group and version.
+	GroupName   string
+	VersionName string
+}
+
+// clientAccessors holds the templates of the method chains through which
+// informers obtain the client of a type from a clientset.
+type clientAccessors struct {
+	defaultTemplate *template.Template
+	groups          map[string]*template.Template
+}
+
+// newClientAccessors parses client accessor templates. Each spec is either a
+// template applying to all groups or a "<group>=<template>" pair applying to
+// a single API group.
+func newClientAccessors(specs []string) (*clientAccessors, error) {
+	accessors := &clientAccessors{groups: map[string]*template.Template{}}
+	var err error
+	if accessors.defaultTemplate, err = template.New("default").Parse(defaultClientAccessorTemplate); err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		group, text, found := strings.Cut(spec, "=")
+		if !found {
+			group, text = "", spec
+		}
+		tmpl, err := template.New(group).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client accessor template %q: %w", spec, err)
+		}
+		if group == "" {
+			accessors.defaultTemplate = tmpl
+		} else {
+			accessors.groups[group] = tmpl
+		}
+	}
+	return accessors, nil
+}
+
+// accessor returns the method chain which obtains the client of a type of
+// group from a clientset, without the call of the last method.
+func (a *clientAccessors) accessor(group string, data clientAccessorData) (string, error) {
+	tmpl, ok := a.groups[group]
+	if !ok {
+		tmpl = a.defaultTemplate
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute client accessor template for group %q: %w", group, err)
+	}
+	accessor := sb.String()
+	expr, err := parser.ParseExpr("client." + accessor + "(namespace)")
+	if err != nil {
+		return "", fmt.Errorf("client accessor %q of group %q is not a valid method chain: %w", accessor, group, err)
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", fmt.Errorf("client accessor %q of group %q is not a valid method chain", accessor, group)
+	}
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return "", fmt.Errorf("client accessor %q of group %q does not end with a method", accessor, group)
+	}
+	return accessor, nil
+}
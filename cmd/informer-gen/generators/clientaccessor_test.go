@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestClientAccessor(t *testing.T) {
+	data := clientAccessorData{Group: "Apps", Version: "V1", Plural: "Deployments", GroupName: "apps", VersionName: "v1"}
+	tests := []struct {
+		name    string
+		specs   []string
+		group   string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", group: "apps", want: "AppsV1().Deployments"},
+		{name: "all groups", specs: []string{"{{.Plural}}"}, group: "apps", want: "Deployments"},
+		{name: "matching group", specs: []string{"apps=Apps().{{.Plural}}"}, group: "apps", want: "Apps().Deployments"},
+		{name: "other group", specs: []string{"batch=Batch().{{.Plural}}"}, group: "apps", want: "AppsV1().Deployments"},
+		{name: "unknown field", specs: []string{"{{.Kind}}"}, group: "apps", wantErr: true},
+		{name: "invalid chain", specs: []string{"{{.Group}}()."}, group: "apps", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessors, err := newClientAccessors(tt.specs)
+			if err != nil {
+				t.Fatalf("failed to parse templates: %v", err)
+			}
+			got, err := accessors.accessor(tt.group, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClientAccessorCompiles verifies that a nonstandard accessor type checks
+// against a client which only provides that accessor.
+func TestClientAccessorCompiles(t *testing.T) {
+	accessors, err := newClientAccessors([]string{"apps=Apps().{{.Plural}}"})
+	if err != nil {
+		t.Fatalf("failed to parse templates: %v", err)
+	}
+	accessor, err := accessors.accessor("apps", clientAccessorData{Group: "Apps", Version: "V1", Plural: "Deployments"})
+	if err != nil {
+		t.Fatalf("failed to render accessor: %v", err)
+	}
+
+	src := `package client
+
+type DeploymentInterface interface{ List() error }
+
+type AppsInterface interface{ Deployments(namespace string) DeploymentInterface }
+
+type Interface interface{ Apps() AppsInterface }
+
+func list(client Interface, namespace string) error {
+	return client.` + accessor + `(namespace).List()
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if _, err := (&types.Config{}).Check("client", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("generated accessor does not compile: %v", err)
+	}
+}
@@ -0,0 +1,504 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+	codegennamer "k8s.io/code-generator/pkg/namer"
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+)
+
+// untypedInformers describes the informers of client-go which back the
+// informers generated without a clientset, either with --dynamic or with
+// --metadata-only.
+type untypedInformers struct {
+	// name is the name of the client and of the factory in doc comments.
+	name string
+	// objects describes the objects produced by the informers.
+	objects string
+	// objectType is the type of the objects produced by the informers.
+	objectType string
+
+	clientInterface       types.Name
+	newFilteredFactory    types.Name
+	sharedInformerFactory types.Name
+	tweakListOptionsFunc  types.Name
+	lister                types.Name
+	newLister             types.Name
+}
+
+// dynamicInformers produce unstructured objects through a dynamic client.
+var dynamicInformers = untypedInformers{
+	name:                  "dynamic",
+	objects:               "unstructured",
+	objectType:            "*unstructured.Unstructured",
+	clientInterface:       dynamicInterface,
+	newFilteredFactory:    dynamicinformerNewFilteredFactoryFunc,
+	sharedInformerFactory: dynamicinformerSharedInformerFactory,
+	tweakListOptionsFunc:  dynamicinformerTweakListOptionsFunc,
+	lister:                dynamiclisterLister,
+	newLister:             dynamiclisterNewFunc,
+}
+
+// metadataInformers produce the metadata of objects only, through a metadata
+// client.
+var metadataInformers = untypedInformers{
+	name:                  "metadata",
+	objects:               "metadata-only",
+	objectType:            "*metav1.PartialObjectMetadata",
+	clientInterface:       metadataInterface,
+	newFilteredFactory:    metadatainformerNewFilteredFactoryFunc,
+	sharedInformerFactory: metadatainformerSharedInformerFactory,
+	tweakListOptionsFunc:  metadatainformerTweakListOptionsFunc,
+	lister:                metadatalisterLister,
+	newLister:             metadatalisterNewFunc,
+}
+
+// dynamicFactoryGenerator generates the factory of informers backed by a
+// dynamic or a metadata client.
+type dynamicFactoryGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	informers     untypedInformers
+	imports       namer.ImportTracker
+	groupVersions map[string]clientgentypes.GroupVersions
+	gvGoNames     map[string]string
+	filtered      bool
+}
+
+var _ generator.Generator = &dynamicFactoryGenerator{}
+
+func (g *dynamicFactoryGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	if !g.filtered {
+		g.filtered = true
+		return true
+	}
+	return false
+}
+
+func (g *dynamicFactoryGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *dynamicFactoryGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+type dynamicGroupData struct {
+	GoName    string
+	Interface *types.Type
+	New       *types.Type
+}
+
+func (g *dynamicFactoryGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	groupPkgNames := make([]string, 0, len(g.groupVersions))
+	for groupPkgName := range g.groupVersions {
+		groupPkgNames = append(groupPkgNames, groupPkgName)
+	}
+	sort.Strings(groupPkgNames)
+	groups := make([]dynamicGroupData, 0, len(groupPkgNames))
+	for _, groupPkgName := range groupPkgNames {
+		groupPkg := path.Join(g.outputPackage, groupPkgName)
+		groups = append(groups, dynamicGroupData{
+			GoName:    g.gvGoNames[groupPkgName],
+			Interface: c.Universe.Type(types.Name{Package: groupPkg, Name: "Interface"}),
+			New:       c.Universe.Function(types.Name{Package: groupPkg, Name: "New"}),
+		})
+	}
+	m := map[string]interface{}{
+		"clientInterface":                     c.Universe.Type(g.informers.clientInterface),
+		"fmtErrorf":                           c.Universe.Function(fmtErrorfFunc),
+		"groups":                              groups,
+		"metadataOnly":                        g.informers.name == metadataInformers.name,
+		"name":                                g.informers.name,
+		"namespaceAll":                        c.Universe.Constant(metav1NamespaceAll),
+		"newFilteredFactory":                  c.Universe.Function(g.informers.newFilteredFactory),
+		"objectType":                          g.informers.objectType,
+		"runtimeDefaultUnstructuredConverter": c.Universe.Variable(runtimeDefaultUnstructuredConverter),
+		"runtimeObject":                       c.Universe.Type(runtimeObject),
+		"runtimeScheme":                       c.Universe.Type(runtimeScheme),
+		"runtimeUnstructured":                 c.Universe.Type(runtimeUnstructured),
+		"schemaGroupVersionKind":              c.Universe.Type(schemaGroupVersionKind),
+		"sharedInformerFactory":               c.Universe.Type(g.informers.sharedInformerFactory),
+		"timeDuration":                        c.Universe.Type(timeDuration),
+		"tweakListOptionsFunc":                c.Universe.Type(g.informers.tweakListOptionsFunc),
+	}
+
+	sw.Do(dynamicFactoryTemplate, m)
+
+	return sw.Error()
+}
+
+var dynamicFactoryTemplate = `
+// SharedInformerOption defines the functional option type for SharedInformerFactory.
+type SharedInformerOption func(*sharedInformerFactory) *sharedInformerFactory
+
+// SharedInformerFactory provides shared informers backed by a $.name$ client
+// for resources in all known API group versions. The informers produce
+// $.objectType$ objects.
+type SharedInformerFactory interface {
+	$.sharedInformerFactory|raw$
+
+	// Decode converts obj, an object produced by one of the informers of the
+	// factory, into the type which the scheme of the factory registers for
+	// kind, and applies the defaults of the scheme to it. It fails if the
+	// factory has no scheme, see WithScheme.
+	$- if .metadataOnly$
+	// Only the metadata of the decoded object is set, as the informers
+	// produce nothing else.
+	$- end$
+	Decode(kind $.schemaGroupVersionKind|raw$, obj $.runtimeObject|raw$) ($.runtimeObject|raw$, error)
+
+	$range .groups -$
+	$.GoName$() $.Interface|raw$
+	$end$
+}
+
+type sharedInformerFactory struct {
+	$.sharedInformerFactory|raw$
+
+	namespace        string
+	tweakListOptions $.tweakListOptionsFunc|raw$
+	scheme           *$.runtimeScheme|raw$
+}
+
+// WithTweakListOptions sets a custom filter on all listers of the configured SharedInformerFactory.
+func WithTweakListOptions(tweakListOptions $.tweakListOptionsFunc|raw$) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.tweakListOptions = tweakListOptions
+		return factory
+	}
+}
+
+// WithNamespace limits the SharedInformerFactory to the specified namespace.
+// Informers of cluster-scoped resources cannot be obtained from a factory limited to a namespace.
+func WithNamespace(namespace string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.namespace = namespace
+		return factory
+	}
+}
+
+// WithScheme sets the scheme which Decode converts the objects produced by
+// the informers with. The scheme must register the types to decode into;
+// its defaulting functions are applied to the decoded objects.
+func WithScheme(scheme *$.runtimeScheme|raw$) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.scheme = scheme
+		return factory
+	}
+}
+
+// NewSharedInformerFactory constructs a new instance of SharedInformerFactory for all namespaces.
+func NewSharedInformerFactory(client $.clientInterface|raw$, defaultResync $.timeDuration|raw$) SharedInformerFactory {
+	return NewSharedInformerFactoryWithOptions(client, defaultResync)
+}
+
+// NewFilteredSharedInformerFactory constructs a new instance of SharedInformerFactory.
+// Listers obtained via this factory will be subject to the same filters as specified here.
+// Informers of cluster-scoped resources cannot be obtained from a factory limited to a namespace.
+func NewFilteredSharedInformerFactory(client $.clientInterface|raw$, defaultResync $.timeDuration|raw$, namespace string, tweakListOptions $.tweakListOptionsFunc|raw$) SharedInformerFactory {
+	return NewSharedInformerFactoryWithOptions(client, defaultResync, WithNamespace(namespace), WithTweakListOptions(tweakListOptions))
+}
+
+// NewSharedInformerFactoryWithOptions constructs a new instance of SharedInformerFactory with additional options.
+func NewSharedInformerFactoryWithOptions(client $.clientInterface|raw$, defaultResync $.timeDuration|raw$, options ...SharedInformerOption) SharedInformerFactory {
+	factory := &sharedInformerFactory{
+		namespace: $.namespaceAll|raw$,
+	}
+
+	// Apply all options
+	for _, opt := range options {
+		factory = opt(factory)
+	}
+
+	factory.$.sharedInformerFactory.Name.Name$ = $.newFilteredFactory|raw$(client, defaultResync, factory.namespace, factory.tweakListOptions)
+	return factory
+}
+
+func (f *sharedInformerFactory) Decode(kind $.schemaGroupVersionKind|raw$, obj $.runtimeObject|raw$) ($.runtimeObject|raw$, error) {
+	if f.scheme == nil {
+		return nil, $.fmtErrorf|raw$("cannot decode %v: the factory has no scheme", kind)
+	}
+	var content map[string]interface{}
+	if u, ok := obj.($.runtimeUnstructured|raw$); ok {
+		content = u.UnstructuredContent()
+	} else {
+		var err error
+		if content, err = $.runtimeDefaultUnstructuredConverter|raw$.ToUnstructured(obj); err != nil {
+			return nil, $.fmtErrorf|raw$("cannot decode %v: %w", kind, err)
+		}
+	}
+	decoded, err := f.scheme.New(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := $.runtimeDefaultUnstructuredConverter|raw$.FromUnstructured(content, decoded); err != nil {
+		return nil, $.fmtErrorf|raw$("cannot decode %v: %w", kind, err)
+	}
+	decoded.GetObjectKind().SetGroupVersionKind(kind)
+	f.scheme.Default(decoded)
+	return decoded, nil
+}
+
+$range .groups$
+func (f *sharedInformerFactory) $.GoName$() $.Interface|raw$ {
+	return $.New|raw$(f)
+}
+$end$
+`
+
+// dynamicGroupInterfaceGenerator generates the per-group interface file of
+// informers backed by a dynamic or a metadata client.
+type dynamicGroupInterfaceGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	informers     untypedInformers
+	imports       namer.ImportTracker
+	groupVersions clientgentypes.GroupVersions
+	filtered      bool
+}
+
+var _ generator.Generator = &dynamicGroupInterfaceGenerator{}
+
+func (g *dynamicGroupInterfaceGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	if !g.filtered {
+		g.filtered = true
+		return true
+	}
+	return false
+}
+
+func (g *dynamicGroupInterfaceGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *dynamicGroupInterfaceGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *dynamicGroupInterfaceGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	versions := make([]versionData, 0, len(g.groupVersions.Versions))
+	for _, version := range g.groupVersions.Versions {
+		versionPackage := path.Join(g.outputPackage, strings.ToLower(version.Version.NonEmpty()))
+		versions = append(versions, versionData{
+			Name:      namer.IC(version.Version.NonEmpty()),
+			Interface: c.Universe.Type(types.Name{Package: versionPackage, Name: "Interface"}),
+			New:       c.Universe.Function(types.Name{Package: versionPackage, Name: "New"}),
+		})
+	}
+	m := map[string]interface{}{
+		"sharedInformerFactory": c.Universe.Type(g.informers.sharedInformerFactory),
+		"versions":              versions,
+	}
+
+	sw.Do(dynamicGroupTemplate, m)
+
+	return sw.Error()
+}
+
+var dynamicGroupTemplate = `
+// Interface provides access to each of this group's versions.
+type Interface interface {
+	$range .versions -$
+		// $.Name$ provides access to shared informers for resources in $.Name$.
+		$.Name$() $.Interface|raw$
+	$end$
+}
+
+type group struct {
+	factory $.sharedInformerFactory|raw$
+}
+
+// New returns a new Interface.
+func New(f $.sharedInformerFactory|raw$) Interface {
+	return &group{factory: f}
+}
+
+$range .versions$
+// $.Name$ returns a new $.Interface|raw$.
+func (g *group) $.Name$() $.Interface|raw$ {
+	return $.New|raw$(g.factory)
+}
+$end$
+`
+
+// dynamicVersionInterfaceGenerator generates the per-version interface file
+// of informers backed by a dynamic or a metadata client.
+type dynamicVersionInterfaceGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	informers     untypedInformers
+	imports       namer.ImportTracker
+	types         []*types.Type
+	filtered      bool
+}
+
+var _ generator.Generator = &dynamicVersionInterfaceGenerator{}
+
+func (g *dynamicVersionInterfaceGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	if !g.filtered {
+		g.filtered = true
+		return true
+	}
+	return false
+}
+
+func (g *dynamicVersionInterfaceGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *dynamicVersionInterfaceGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *dynamicVersionInterfaceGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{
+		"sharedInformerFactory": c.Universe.Type(g.informers.sharedInformerFactory),
+		"types":                 g.types,
+	}
+
+	sw.Do(dynamicVersionTemplate, m)
+
+	return sw.Error()
+}
+
+var dynamicVersionTemplate = `
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	$range .types -$
+		// $.|publicPlural$ returns a $.|public$Informer.
+		$.|publicPlural$() $.|public$Informer
+	$end$
+}
+
+type version struct {
+	factory $.sharedInformerFactory|raw$
+}
+
+// New returns a new Interface.
+func New(f $.sharedInformerFactory|raw$) Interface {
+	return &version{factory: f}
+}
+`
+
+// dynamicInformerGenerator produces the file of the informer backed by a
+// dynamic or a metadata client of a given GroupVersion and type.
+type dynamicInformerGenerator struct {
+	generator.GoGenerator
+	outputPackage    string
+	informers        untypedInformers
+	imports          namer.ImportTracker
+	groupVersion     clientgentypes.GroupVersion
+	typeToGenerate   *types.Type
+	pluralExceptions map[string]string
+}
+
+var _ generator.Generator = &dynamicInformerGenerator{}
+
+func (g *dynamicInformerGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.typeToGenerate
+}
+
+func (g *dynamicInformerGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw":      namer.NewRawNamer(g.outputPackage, g.imports),
+		"resource": codegennamer.NewTagOverrideNamer("resourceName", namer.NewAllLowercasePluralNamer(g.pluralExceptions)),
+	}
+}
+
+func (g *dynamicInformerGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *dynamicInformerGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	m := map[string]interface{}{
+		"cacheSharedIndexInformer":   c.Universe.Type(cacheSharedIndexInformer),
+		"groupName":                  g.groupVersion.Group.String(),
+		"lister":                     c.Universe.Type(g.informers.lister),
+		"name":                       g.informers.name,
+		"newLister":                  c.Universe.Function(g.informers.newLister),
+		"objects":                    g.informers.objects,
+		"schemaGroupVersionKind":     c.Universe.Type(schemaGroupVersionKind),
+		"schemaGroupVersionResource": c.Universe.Type(schemaGroupVersionResource),
+		"sharedInformerFactory":      c.Universe.Type(g.informers.sharedInformerFactory),
+		"type":                       t,
+		"versionName":                g.groupVersion.Version.String(),
+	}
+
+	sw.Do(dynamicTypeInformerTemplate, m)
+
+	return sw.Error()
+}
+
+var dynamicTypeInformerTemplate = `
+// $.type|public$Resource is the resource which the informer of $.type|publicPlural$
+// is keyed by in the $.name$ factory.
+var $.type|public$Resource = $.schemaGroupVersionResource|raw${Group: "$.groupName$", Version: "$.versionName$", Resource: "$.type|resource$"}
+
+// $.type|public$Kind is the kind which the factory decodes $.type|publicPlural$ as.
+var $.type|public$Kind = $.schemaGroupVersionKind|raw${Group: "$.groupName$", Version: "$.versionName$", Kind: "$.type|public$"}
+
+// $.type|public$Informer provides access to a shared informer and lister for
+// $.type|publicPlural$, as $.objects$ objects.
+type $.type|public$Informer interface {
+	Informer() $.cacheSharedIndexInformer|raw$
+	Lister() $.lister|raw$
+}
+
+type $.type|private$Informer struct {
+	factory $.sharedInformerFactory|raw$
+}
+
+// $.type|publicPlural$ returns a $.type|public$Informer.
+func (v *version) $.type|publicPlural$() $.type|public$Informer {
+	return &$.type|private$Informer{factory: v.factory}
+}
+
+func (f *$.type|private$Informer) Informer() $.cacheSharedIndexInformer|raw$ {
+	return f.factory.ForResource($.type|public$Resource).Informer()
+}
+
+func (f *$.type|private$Informer) Lister() $.lister|raw$ {
+	return $.newLister|raw$(f.Informer().GetIndexer(), $.type|public$Resource)
+}
+`
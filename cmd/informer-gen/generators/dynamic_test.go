@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"path/filepath"
+	"testing"
+
+	"k8s.io/gengo/v2/types"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+)
+
+func TestMetadataInformersGolden(t *testing.T) {
+	c := goldenContext(t, goldenPackage)
+	gv := clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}
+	groupVersions := map[string]clientgentypes.GroupVersions{
+		"example": {PackageName: "example", Group: gv.Group, Versions: []clientgentypes.PackageVersion{{Version: gv.Version, Package: goldenPackage}}},
+	}
+	typesToGenerate := []*types.Type{c.Universe.Type(types.Name{Package: goldenPackage, Name: "Labeled"})}
+	boilerplate := []byte("// boilerplate\n")
+	dir := filepath.Join(t.TempDir(), "metadatainformers")
+	if err := c.ExecuteTarget(dynamicFactoryTarget(dir, "example.com/metadatainformers", boilerplate, map[string]string{"example": "Example"}, groupVersions, metadataInformers)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ExecuteTarget(dynamicVersionTarget(dir, "example.com/metadatainformers", "example", gv, boilerplate, typesToGenerate, nil, metadataInformers)); err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, filepath.Join(dir, "factory.go"), filepath.Join("testdata", "metadata", "factory.go.golden"))
+	checkGolden(t, filepath.Join(dir, "example", "v1", "labeled.go"), filepath.Join("testdata", "metadata", "labeled.go.golden"))
+}
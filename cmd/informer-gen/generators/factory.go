@@ -38,7 +38,9 @@ type factoryGenerator struct {
 	gvGoNames                 map[string]string
 	clientSetPackage          string
 	internalInterfacesPackage string
-	filtered                  bool
+	// prometheusCollector makes the factory have a MetricsCollector.
+	prometheusCollector bool
+	filtered            bool
 }
 
 var _ generator.Generator = &factoryGenerator{}
@@ -74,36 +76,135 @@ func (g *factoryGenerator) GenerateType(c *generator.Context, t *types.Type, w i
 		gvNewFuncs[groupPkgName] = c.Universe.Function(types.Name{Package: path.Join(g.outputPackage, groupPkgName), Name: "New"})
 	}
 	m := map[string]interface{}{
-		"cacheDoneChecker":               c.Universe.Type(cacheDoneChecker),
-		"cacheInformerName":              c.Universe.Type(cacheInformerName),
-		"cacheSharedIndexInformer":       c.Universe.Type(cacheSharedIndexInformer),
-		"cacheSyncResult":                c.Universe.Type(cacheSyncResult),
-		"cacheTransformFunc":             c.Universe.Type(cacheTransformFunc),
-		"cacheWaitFor":                   c.Universe.Function(cacheWaitForFunc),
-		"contextContext":                 c.Universe.Type(contextContext),
-		"contextCause":                   c.Universe.Function(contextCauseFunc),
-		"fmtErrorf":                      c.Universe.Function(fmtErrorfFunc),
-		"groupVersions":                  g.groupVersions,
-		"gvInterfaces":                   gvInterfaces,
-		"gvNewFuncs":                     gvNewFuncs,
-		"gvGoNames":                      g.gvGoNames,
-		"interfacesNewInformerFunc":      c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "NewInformerFunc"}),
-		"interfacesTweakListOptionsFunc": c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
-		"informerFactoryInterface":       c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
-		"clientSetInterface":             c.Universe.Type(types.Name{Package: g.clientSetPackage, Name: "Interface"}),
-		"reflectType":                    c.Universe.Type(reflectType),
-		"runtimeObject":                  c.Universe.Type(runtimeObject),
-		"schemaGroupVersionResource":     c.Universe.Type(schemaGroupVersionResource),
-		"stringsBuilder":                 c.Universe.Type(stringsBuilder),
-		"syncMutex":                      c.Universe.Type(syncMutex),
-		"timeDuration":                   c.Universe.Type(timeDuration),
-		"namespaceAll":                   c.Universe.Type(metav1NamespaceAll),
-		"object":                         c.Universe.Type(metav1Object),
-		"waitContextForChannel":          c.Universe.Function(waitContextForChannelFunc),
+		"atomicUint64":                              c.Universe.Type(atomicUint64),
+		"authzResourceAttributes":                   c.Universe.Type(authorizationv1ResourceAttributes),
+		"authzSelfSubjectAccessReview":              c.Universe.Type(authorizationv1SelfSubjectAccessReview),
+		"authzSelfSubjectAccessReviewSpec":          c.Universe.Type(authorizationv1SelfSubjectAccessReviewSpec),
+		"authzSelfSubjectAccessReviewsGetter":       c.Universe.Type(authzclientSelfSubjectAccessReviewsGetter),
+		"cacheHandlerOptions":                       c.Universe.Type(cacheHandlerOptions),
+		"cacheHistogramMetric":                      c.Universe.Type(cacheHistogramMetric),
+		"cacheDefaultWatchErrorHandler":             c.Universe.Function(cacheDefaultWatchErrorHandlerFunc),
+		"cacheDeletedFinalStateUnknown":             c.Universe.Type(cacheDeletedFinalStateUnknown),
+		"cacheDeletionHandlingMetaNamespaceKeyFunc": c.Universe.Function(cacheDeletionHandlingMetaNamespaceKeyFunc),
+		"cacheDoneChecker":                          c.Universe.Type(cacheDoneChecker),
+		"cacheInformerName":                         c.Universe.Type(cacheInformerName),
+		"cacheNewInformerName":                      c.Universe.Function(cacheNewInformerNameFunc),
+		"cacheReflector":                            c.Universe.Type(cacheReflector),
+		"cacheResourceEventHandler":                 c.Universe.Type(cacheResourceEventHandler),
+		"cacheResourceEventHandlerFuncs":            c.Universe.Type(cacheResourceEventHandlerFuncs),
+		"cacheResourceEventHandlerRegistration":     c.Universe.Type(cacheResourceEventHandlerRegistration),
+		"cacheSharedIndexInformer":                  c.Universe.Type(cacheSharedIndexInformer),
+		"cacheStore":                                c.Universe.Type(cacheStore),
+		"cacheSyncResult":                           c.Universe.Type(cacheSyncResult),
+		"cacheTransformFunc":                        c.Universe.Type(cacheTransformFunc),
+		"cacheWaitFor":                              c.Universe.Function(cacheWaitForFunc),
+		"cacheWaitForNamedCacheSync":                c.Universe.Function(cacheWaitForNamedCacheSyncFunc),
+		"cacheInformerSynced":                       c.Universe.Type(cacheInformerSynced),
+		"cacheWatchErrorHandler":                    c.Universe.Type(cacheWatchErrorHandler),
+		"cacheWatchErrorHandlerWithContext":         c.Universe.Type(cacheWatchErrorHandlerWithContext),
+		"contextBackground":                         c.Universe.Function(contextBackgroundFunc),
+		"contextContext":                            c.Universe.Type(contextContext),
+		"contextCancelCauseFunc":                    c.Universe.Type(contextCancelCauseFunc),
+		"contextCause":                              c.Universe.Function(contextCauseFunc),
+		"contextWithCancelCause":                    c.Universe.Function(contextWithCancelCauseFunc),
+		"errorsJoin":                                c.Universe.Function(errorsJoinFunc),
+		"errorsNew":                                 c.Universe.Function(errorsNewFunc),
+		"eventTypeWarning":                          c.Universe.Type(corev1EventTypeWarning),
+		"eventsEventRecorder":                       c.Universe.Type(eventsEventRecorder),
+		"featuresGates":                             c.Universe.Type(featuresGates),
+		"fmtErrorf":                                 c.Universe.Function(fmtErrorfFunc),
+		"groupVersions":                             g.groupVersions,
+		"gvInterfaces":                              gvInterfaces,
+		"gvNewFuncs":                                gvNewFuncs,
+		"gvGoNames":                                 g.gvGoNames,
+		"ioEOF":                                     c.Universe.Variable(ioEOF),
+		"ioReader":                                  c.Universe.Type(ioReader),
+		"ioWriter":                                  c.Universe.Type(ioWriter),
+		"jsonMarshal":                               c.Universe.Function(jsonMarshalFunc),
+		"jsonNewEncoder":                            c.Universe.Function(jsonNewEncoderFunc),
+		"klogLogger":                                c.Universe.Type(klogLogger),
+		"labelsSelector":                            c.Universe.Type(labelsSelector),
+		"metav1CreateOptions":                       c.Universe.Type(metav1CreateOptions),
+		"metav1List":                                c.Universe.Type(metav1List),
+		"metav1ListMeta":                            c.Universe.Type(metav1ListMeta),
+		"metav1ResourceVersionMatch":                c.Universe.Type(metav1ResourceVersionMatch),
+		"metav1ResourceVersionMatchExact":           c.Universe.Constant(metav1ResourceVersionMatchExact),
+		"metav1ResourceVersionMatchNotOlderThan":    c.Universe.Constant(metav1ResourceVersionMatchNotOlderThan),
+		"runtimeRawExtension":                       c.Universe.Type(runtimeRawExtension),
+		"metaListAccessor":                          c.Universe.Function(metaListAccessorFunc),
+		"metaSetList":                               c.Universe.Function(metaSetListFunc),
+		"interfacesNewInformerFunc":                 c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "NewInformerFunc"}),
+		"interfacesTweakListOptionsFunc":            c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
+		"interfacesIngestValidator":                 c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "IngestValidator"}),
+		"interfacesInformerOptions":                 c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "InformerOptions"}),
+		"interfacesNewIngestValidator":              c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewIngestValidator"}),
+		"interfacesNewRetweaker":                    c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewRetweaker"}),
+		"interfacesCacheBackend":                    c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "CacheBackend"}),
+		"interfacesSizedCacheBackend":               c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SizedCacheBackend"}),
+		"interfacesStartable":                       c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "Startable"}),
+		"interfacesLeadershipGate":                  c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "LeadershipGate"}),
+		"interfacesNewLeadershipGate":               c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewLeadershipGate"}),
+		"interfacesAddPriorityEventHandlers":        c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "AddPriorityEventHandlers"}),
+		"interfacesPriorityEventHandlers":           c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "PriorityEventHandlers"}),
+		"interfacesRetweaker":                       c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "Retweaker"}),
+		"informerFactoryInterface":                  c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
+		"clientSetInterface":                        c.Universe.Type(types.Name{Package: g.clientSetPackage, Name: "Interface"}),
+		"metaAccessor":                              c.Universe.Function(metaAccessorFunc),
+		"reflectType":                               c.Universe.Type(reflectType),
+		"reflectTypeOf":                             c.Universe.Function(reflectTypeOfFunc),
+		"runtimeCodec":                              c.Universe.Type(runtimeCodec),
+		"runtimeDecoder":                            c.Universe.Type(runtimeDecoder),
+		"runtimeObject":                             c.Universe.Type(runtimeObject),
+		"schemaGroupVersionResource":                c.Universe.Type(schemaGroupVersionResource),
+		"slicesContains":                            c.Universe.Function(slicesContainsFunc),
+		"slicesClone":                               c.Universe.Function(slicesCloneFunc),
+		"slicesSortFunc":                            c.Universe.Function(slicesSortFunc),
+		"stringsCompare":                            c.Universe.Function(stringsCompare),
+		"stringsBuilder":                            c.Universe.Type(stringsBuilder),
+		"syncMutex":                                 c.Universe.Type(syncMutex),
+		"syncRWMutex":                               c.Universe.Type(syncRWMutex),
+		"timeDuration":                              c.Universe.Type(timeDuration),
+		"v1ListOptions":                             c.Universe.Type(v1ListOptions),
+		"timeMinute":                                c.Universe.Type(timeMinute),
+		"timeNewTimer":                              c.Universe.Function(timeNewTimerFunc),
+		"timeNow":                                   c.Universe.Function(timeNowFunc),
+		"timeTime":                                  c.Universe.Type(timeTime),
+		"typesUID":                                  c.Universe.Type(typesUID),
+		"namespaceAll":                              c.Universe.Type(metav1NamespaceAll),
+		"object":                                    c.Universe.Type(metav1Object),
+		"utilruntimeHandleError":                    c.Universe.Function(utilruntimeHandleErrorFunc),
+		"waitContextForChannel":                     c.Universe.Function(waitContextForChannelFunc),
+		"prometheusCollector":                       g.prometheusCollector,
+	}
+	if g.prometheusCollector {
+		m["prometheusCollectorInterface"] = c.Universe.Type(prometheusCollector)
+		m["prometheusDesc"] = c.Universe.Type(prometheusDesc)
+		m["prometheusGaugeValue"] = c.Universe.Variable(prometheusGaugeValue)
+		m["prometheusMetric"] = c.Universe.Type(prometheusMetric)
+		m["prometheusMustNewConstMetric"] = c.Universe.Function(prometheusMustNewConstMetricFunc)
+		m["prometheusNewDesc"] = c.Universe.Function(prometheusNewDescFunc)
+		m["prometheusObserverVec"] = c.Universe.Type(prometheusObserverVec)
 	}
 
 	sw.Do(sharedInformerFactoryStruct, m)
 	sw.Do(sharedInformerFactoryInterface, m)
+	sw.Do(sharedInformerFactoryStats, m)
+	sw.Do(sharedInformerFactoryLeadership, m)
+	sw.Do(sharedInformerFactoryState, m)
+	if g.prometheusCollector {
+		sw.Do(sharedInformerFactoryMetricsCollector, m)
+	}
+	sw.Do(sharedInformerFactoryRBACPrecheck, m)
+	sw.Do(sharedInformerFactorySnapshot, m)
+	sw.Do(sharedInformerFactoryHandlers, m)
+	sw.Do(sharedInformerFactoryConsumers, m)
+	sw.Do(sharedInformerFactoryLatency, m)
+	sw.Do(sharedInformerFactoryEquality, m)
+	sw.Do(sharedInformerFactoryResync, m)
+	sw.Do(sharedInformerFactoryWaitForCondition, m)
+	sw.Do(sharedInformerFactoryMemoryBudget, m)
+	sw.Do(sharedInformerFactoryStalenessWatchdog, m)
+	sw.Do(compositeFactory, m)
 
 	return sw.Error()
 }
@@ -114,6 +215,9 @@ type SharedInformerOption func(*sharedInformerFactory) *sharedInformerFactory
 
 type sharedInformerFactory struct {
 	client {{.clientSetInterface|raw}}
+	// options are the options the factory was created with, which
+	// CloneForNamespace applies to its clones.
+	options []SharedInformerOption
 	namespace string
 	tweakListOptions {{.interfacesTweakListOptionsFunc|raw}}
 	lock {{.syncMutex|raw}}
@@ -122,10 +226,180 @@ type sharedInformerFactory struct {
 	transform {{.cacheTransformFunc|raw}}
 	informerName *{{.cacheInformerName|raw}}
 
+	// namespaceSelectors holds the label selectors of the namespaces which
+	// namespaced informers are limited to. It is nil unless
+	// WithNamespaceSelectors was used.
+	namespaceSelectors map[string]{{.labelsSelector|raw}}
+
+	// featureGateStripper strips the fields of objects which are disabled in
+	// featureGates before the objects enter the informer caches. It is nil
+	// unless WithFeatureGateTransform was used.
+	featureGates        {{.featuresGates|raw}}
+	featureGateStripper func({{.object|raw}}, {{.featuresGates|raw}})
+
+	// memoryBudget strips fields from objects when the estimated size of the
+	// informer caches exceeds it. It is nil unless WithSharedMemoryBudget was
+	// used.
+	memoryBudget *memoryBudget
+
+	// ingestTimes records when objects entered the informer caches, keyed by UID.
+	// It is nil unless WithIngestTimestamps was used.
+	ingestTimes map[{{.typesUID|raw}}]{{.timeTime|raw}}
+	ingestLock {{.syncRWMutex|raw}}
+
+	// queueCounters tracks the queue of each informer. It is nil unless
+	// WithInformerStats was used.
+	queueCounters map[{{.reflectType|raw}}]*informerQueueCounter
+
+	// cacheGenerations count the mutations of the cache of each informer for
+	// which CacheGeneration was called.
+	cacheGenerations map[{{.reflectType|raw}}]*{{.atomicUint64|raw}}
+
+	// keyNormalizers hold the functions normalizing the names in the keys of
+	// the caches of informers, keyed by resource. It is only written by
+	// WithKeyNormalizer.
+	keyNormalizers map[{{.schemaGroupVersionResource|raw}}]func(name string) string
+
+	// cacheCapacities hold the expected numbers of objects of informers,
+	// keyed by resource. It is only written by WithInitialCacheCapacity.
+	cacheCapacities map[{{.schemaGroupVersionResource|raw}}]int
+
+	// cacheBackend creates the indexers of the generated informers. It is nil
+	// unless WithCacheBackend was used.
+	cacheBackend {{.interfacesCacheBackend|raw}}
+
+	// leadershipGate pauses the generated informers while leadership is not
+	// held. It is nil unless WithLeadershipGate was used. It is shared with
+	// the clones of the factory, and so is leadershipGateRunner, which records
+	// the signals in it while any of them is started.
+	leadershipGate       *{{.interfacesLeadershipGate|raw}}
+	leadershipGateRunner *leadershipGateRunner
+	// leadershipGateRunning is set by the first start which starts
+	// informers, whose context keeps leadershipGateRunner running.
+	leadershipGateRunning bool
+
+	// watchRotation is the maximum lifetime of the watches of the generated
+	// informers. It is zero unless WithWatchRotation was used.
+	watchRotation {{.timeDuration|raw}}
+
+	// logger logs a summary of the informers started by each Start. It is nil
+	// unless WithLogger was used.
+	logger *{{.klogLogger|raw}}
+
+	// watchErrorHandler replaces the default handling of watch errors if set.
+	watchErrorHandler {{.cacheWatchErrorHandler|raw}}
+
+	// reconnectObserver is called whenever the watch of a generated informer
+	// is established. It is nil unless WithReconnectObserver was used.
+	reconnectObserver func(resource {{.schemaGroupVersionResource|raw}}, at {{.timeTime|raw}})
+
+	// eventRecorder emits events about watch failures regarding involvedObject.
+	// It is nil unless WithEventRecorder was used.
+	eventRecorder {{.eventsEventRecorder|raw}}
+	involvedObject {{.runtimeObject|raw}}
+
+	// cacheSnapshots holds the snapshots to warm informer caches from, keyed
+	// by resource. It is only written by WithCacheSnapshot.
+	cacheSnapshots map[{{.schemaGroupVersionResource|raw}}]cacheSnapshot
+
+	// initialResourceVersions holds the resource versions to pin the first
+	// list of informers to, keyed by resource. It is only written by
+	// WithInitialResourceVersion.
+	initialResourceVersions map[{{.schemaGroupVersionResource|raw}}]string
+
+	// listResourceVersion and listResourceVersionMatch constrain the first
+	// list of the informers which are not pinned by WithInitialResourceVersion.
+	// They are only written by WithListResourceVersionMatch.
+	listResourceVersion      string
+	listResourceVersionMatch {{.metav1ResourceVersionMatch|raw}}
+
+	// optionErrs holds the errors of the options which were ignored because
+	// they were invalid. They are returned by StartWithError.
+	optionErrs []error
+
+	// watchListPageSizes holds the list chunk sizes of informers, keyed by
+	// resource. It is only written by WithWatchListPageSize.
+	watchListPageSizes map[{{.schemaGroupVersionResource|raw}}]int64
+
+	// equalityFuncs holds the equality functions used to drop the updates of
+	// informers, keyed by resource. It is only written by WithEqualityFunc.
+	equalityFuncs map[{{.schemaGroupVersionResource|raw}}]EqualityFunc
+
+	// ingestValidators hold the validators of the objects ingested by
+	// informers, keyed by resource. It is only written by WithIngestValidator.
+	ingestValidators map[{{.schemaGroupVersionResource|raw}}]*{{.interfacesIngestValidator|raw}}
+
+	// objectFilter drops the objects which no informer may cache. It is nil
+	// unless WithGlobalObjectFilter was used.
+	objectFilter func(obj {{.object|raw}}) bool
+
+	// retweakers hold the list options tweaks of the generated informers,
+	// keyed by resource.
+	retweakers map[{{.schemaGroupVersionResource|raw}}]*{{.interfacesRetweaker|raw}}
+
+	// latencyHistograms hold the histograms observing the event processing
+	// latencies of informers, keyed by resource. It is only written by
+	// WithLatencyHistogram.
+	latencyHistograms map[{{.schemaGroupVersionResource|raw}}]{{.cacheHistogramMetric|raw}}
+{{- if .prometheusCollector}}
+
+	// latencyObserverVec observes the event processing latencies of the
+	// informers without a histogram of WithLatencyHistogram. It is only
+	// written by WithLatencyObserverVec.
+	latencyObserverVec {{.prometheusObserverVec|raw}}
+{{- end}}
+
+	// stalenessWatchdogs hold the watchdogs of informers, keyed by resource.
+	// It is only written by WithStalenessWatchdog.
+	stalenessWatchdogs map[{{.schemaGroupVersionResource|raw}}]*stalenessWatchdog
+
+	// panicHandler handles the panics of the event handlers added by the
+	// generated handler helpers. Panics are not recovered if it is nil.
+	panicHandler func(resource {{.schemaGroupVersionResource|raw}}, recovered interface{})
+
+	// informerCreateHook is consulted before the generated informers are
+	// created. It is nil unless WithInformerCreateHook was used.
+	informerCreateHook func(resource {{.schemaGroupVersionResource|raw}}) error
+	// vetoedInformers holds the errors returned by informerCreateHook for
+	// the informers it vetoed. These informers are never started.
+	vetoedInformers map[{{.reflectType|raw}}]error
+
+	// rbacPrecheck reviews the access to the resources of the informers
+	// before they are started. It is nil unless WithRBACPrecheck was used.
+	rbacPrecheck {{.authzSelfSubjectAccessReviewsGetter|raw}}
+	// precheckedInformers tracks the informers whose access was reviewed.
+	precheckedInformers map[{{.reflectType|raw}}]bool
+	// skippedInformers holds the reasons of the informers which are never
+	// started because their resources may not be listed or watched.
+	skippedInformers map[{{.reflectType|raw}}]string
+
 	informers map[{{.reflectType|raw}}]{{.cacheSharedIndexInformer|raw}}
+	// handlerCounts counts the event handlers which were added to informers
+	// through the generated handler helpers.
+	handlerCounts map[{{.reflectType|raw}}]int
+	// resyncHandlers holds the handlers added through AddResyncHandler, to
+	// which Resync and ReplayKey deliver the cached objects.
+	resyncHandlers map[{{.reflectType|raw}}]map[{{.cacheResourceEventHandlerRegistration|raw}}]*resyncableHandler
+	// priorityEventHandlers holds the dispatchers of the handlers added with
+	// a priority, which are added to informers on demand.
+	priorityEventHandlers map[{{.reflectType|raw}}]*{{.interfacesPriorityEventHandlers|raw}}
+	// eventSequence is the last sequence number assigned to an event
+	// delivered to a sequenced handler.
+	eventSequence {{.atomicUint64|raw}}
+	// consumers holds the names registered by RegisterConsumer by resource.
+	consumers map[{{.schemaGroupVersionResource|raw}}][]string
 	// startedInformers is used for tracking which informers have been started.
 	// This allows Start() to be called multiple times safely.
 	startedInformers map[{{.reflectType|raw}}]bool
+	// deferredInformers tracks the informers which were started but wait for
+	// the informers of the previous stage of WithSyncOrder to sync.
+	deferredInformers map[{{.reflectType|raw}}]bool
+	// syncStages holds the stage of WithSyncOrder of each resource.
+	syncStages     map[{{.schemaGroupVersionResource|raw}}]int
+	syncStageCount int
+	// cancelFuncs cancel the contexts of the informers started by
+	// StartWithContext. They are called by Shutdown.
+	cancelFuncs []{{.contextCancelCauseFunc|raw}}
 	// wg tracks how many goroutines were started.
 	wg sync.WaitGroup
 	// shuttingDown is true when Shutdown has been called. It may still be running
@@ -159,6 +433,23 @@ func WithNamespace(namespace string) SharedInformerOption {
 	}
 }
 
+// WithNamespaceSelectors limits the namespaced informers of the
+// SharedInformerFactory to the namespaces of selectors, instead of the
+// namespace of WithNamespace. Each namespace is listed and watched with its
+// label selector, or with the label selector of WithTweakListOptions if its
+// selector is nil. Each namespace is watched from its own resource version,
+// but an error of the watch of one of them makes the informers relist all
+// of them, see NewMultiNamespaceListerWatcher in internalinterfaces.
+func WithNamespaceSelectors(selectors map[string]{{.labelsSelector|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.namespaceSelectors = make(map[string]{{.labelsSelector|raw}}, len(selectors))
+		for namespace, selector := range selectors {
+			factory.namespaceSelectors[namespace] = selector
+		}
+		return factory
+	}
+}
+
 // WithTransform sets a transform on all informers.
 func WithTransform(transform {{.cacheTransformFunc|raw}}) SharedInformerOption {
 	return func(factory *sharedInformerFactory) *sharedInformerFactory {
@@ -167,6 +458,35 @@ func WithTransform(transform {{.cacheTransformFunc|raw}}) SharedInformerOption {
 	}
 }
 
+// WithFeatureGateTransform passes every object to stripper with gates before
+// the object enters an informer cache, after the transform of WithTransform.
+// stripper may modify the object in place, typically to clear the fields
+// guarded by feature gates which are disabled in gates. gates is consulted
+// for every object, so a changed gate applies to objects ingested later.
+func WithFeatureGateTransform(gates {{.featuresGates|raw}}, stripper func({{.object|raw}}, {{.featuresGates|raw}})) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.featureGates = gates
+		factory.featureGateStripper = stripper
+		return factory
+	}
+}
+
+// WithSharedMemoryBudget limits the estimated total size of the objects in
+// all informer caches of the factory to bytes, by progressively stripping
+// fields from objects before they enter a cache. An object which would exceed
+// the budget escalates the stripping by one stage at a time, which applies to
+// it and to the objects ingested after it: first managed fields are dropped,
+// then annotations. Whenever the estimated total size falls below three
+// quarters of bytes, the stripping is relaxed by one stage. Objects which are
+// already cached are not stripped until they change. The size of an object is
+// estimated by its JSON encoding; objects without a UID are not accounted for.
+func WithSharedMemoryBudget(bytes int64) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.memoryBudget = &memoryBudget{limit: bytes, sizes: make(map[{{.typesUID|raw}}]int64)}
+		return factory
+	}
+}
+
 // WithInformerName sets the InformerName for informer identity used in metrics.
 // The InformerName must be created via cache.NewInformerName() at startup,
 // which validates global uniqueness. Each informer type will register its
@@ -178,10 +498,561 @@ func WithInformerName(informerName *{{.cacheInformerName|raw}}) SharedInformerOp
 	}
 }
 
+// WithIngestTimestamps records the time at which each object is ingested into
+// an informer cache. The timestamps are kept in a side map keyed by UID, so the
+// cached objects are never modified, even when a transform is also configured.
+// Use IngestTime to retrieve them.
+func WithIngestTimestamps() SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.ingestTimes = make(map[{{.typesUID|raw}}]{{.timeTime|raw}})
+		return factory
+	}
+}
+
+// WithInformerStats tracks the changes queued by each informer until all of its
+// event handlers processed them, so that Stats can report a queue length. Without it, Stats only reports object counts.
+func WithInformerStats() SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.queueCounters = make(map[{{.reflectType|raw}}]*informerQueueCounter)
+		return factory
+	}
+}
+
+// WithWatchErrorHandler sets the handler which is called by all informers of the
+// factory whenever their watch fails, instead of cache.DefaultWatchErrorHandler.
+// Options are applied before any informer is created, so the handler is always
+// set before the informers are started.
+func WithWatchErrorHandler(handler {{.cacheWatchErrorHandler|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.watchErrorHandler = handler
+		return factory
+	}
+}
+
+// WithReconnectObserver sets an observer which is called with the resource and
+// the time whenever the watch of a generated informer of the factory is
+// established, both initially and after every reconnection. It is called
+// synchronously by the reflector, so it must not block.
+func WithReconnectObserver(observer func(resource {{.schemaGroupVersionResource|raw}}, at {{.timeTime|raw}})) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.reconnectObserver = observer
+		return factory
+	}
+}
+
+// WithEventRecorder emits a Warning event regarding involvedObject when the watch
+// of an informer fails repeatedly. At most one event is emitted per informer and
+// minute, so a persistently failing watch does not flood the API server.
+func WithEventRecorder(recorder {{.eventsEventRecorder|raw}}, involvedObject {{.runtimeObject|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.eventRecorder = recorder
+		factory.involvedObject = involvedObject
+		return factory
+	}
+}
+
+// WithCacheSnapshot warms the cache of the informer for resource from snapshot,
+// a list as written by SnapshotCache with the same codec. A nil codec stands
+// for JSON. The snapshot is read by the first list of the informer instead of
+// listing from the server. The informer then watches from the resource version
+// of the snapshot, and relists from the server if that resource version is too
+// old. A snapshot which cannot be decoded is reported and ignored.
+func WithCacheSnapshot(resource {{.schemaGroupVersionResource|raw}}, snapshot {{.ioReader|raw}}, codec {{.runtimeCodec|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.cacheSnapshots == nil {
+			factory.cacheSnapshots = make(map[{{.schemaGroupVersionResource|raw}}]cacheSnapshot)
+		}
+		factory.cacheSnapshots[resource] = cacheSnapshot{snapshot: snapshot, codec: codec}
+		return factory
+	}
+}
+
+// WithInitialResourceVersion pins the first list of the informer for resource
+// to resourceVersion, so that its cache starts from exactly that state of the
+// server, for example to reproduce what was observed at a known point in time.
+// The informer then watches from resourceVersion. The list fails if the server
+// no longer serves resourceVersion, in which case the informer relists at the
+// latest resource version like after any other failed list.
+func WithInitialResourceVersion(resource {{.schemaGroupVersionResource|raw}}, resourceVersion string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.initialResourceVersions == nil {
+			factory.initialResourceVersions = make(map[{{.schemaGroupVersionResource|raw}}]string)
+		}
+		factory.initialResourceVersions[resource] = resourceVersion
+		return factory
+	}
+}
+
+// WithListResourceVersionMatch makes the first list of each generated informer
+// request resourceVersion with match, unless WithInitialResourceVersion pins it
+// for the resource of the informer. For example, {{.metav1ResourceVersionMatchNotOlderThan|raw}}
+// with "0" lets the API server serve the list from its watch cache, which may
+// be stale, instead of from etcd. The informers then watch from the resource
+// version of their first list; later lists are not constrained. Streaming
+// lists are not used by these informers, because they would bypass the
+// constrained list. match must be Exact or NotOlderThan, resourceVersion must
+// not be empty, and Exact does not accept "0". Invalid combinations are
+// ignored and reported by StartWithError.
+func WithListResourceVersionMatch(match {{.metav1ResourceVersionMatch|raw}}, resourceVersion string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if err := validateListResourceVersionMatch(match, resourceVersion); err != nil {
+			factory.optionErrs = append(factory.optionErrs, err)
+			return factory
+		}
+		factory.listResourceVersion = resourceVersion
+		factory.listResourceVersionMatch = match
+		return factory
+	}
+}
+
+// validateListResourceVersionMatch checks match and resourceVersion like the
+// API server checks the list options.
+func validateListResourceVersionMatch(match {{.metav1ResourceVersionMatch|raw}}, resourceVersion string) error {
+	switch {
+	case match != {{.metav1ResourceVersionMatchExact|raw}} && match != {{.metav1ResourceVersionMatchNotOlderThan|raw}}:
+		return {{.fmtErrorf|raw}}("unsupported resource version match %q", match)
+	case resourceVersion == "":
+		return {{.fmtErrorf|raw}}("resource version match %s requires a resource version", match)
+	case match == {{.metav1ResourceVersionMatchExact|raw}} && resourceVersion == "0":
+		return {{.fmtErrorf|raw}}("resource version match %s does not accept resource version \"0\"", match)
+	}
+	return nil
+}
+
+// WithWatchListPageSize sets the requested chunk size of the lists of the
+// informer for resource, like cache.Reflector.WatchListPageSize. Informers
+// list when streaming lists are disabled or not supported by the server;
+// streaming lists are sent as a watch and are not affected. Paginated lists
+// are always served from etcd, so this should be used carefully.
+func WithWatchListPageSize(resource {{.schemaGroupVersionResource|raw}}, pageSize int64) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.watchListPageSizes == nil {
+			factory.watchListPageSizes = make(map[{{.schemaGroupVersionResource|raw}}]int64)
+		}
+		factory.watchListPageSizes[resource] = pageSize
+		return factory
+	}
+}
+
+// WithEqualityFunc drops the updates of the informer for resource for which
+// equal returns true, so that the handlers added through the informers
+// returned by the factory only see updates which are relevant to them, for
+// example changes of the spec or labels but not of a volatile status. Resyncs
+// deliver the same object as old and new object, so they are dropped, too,
+// unless equal says otherwise.
+func WithEqualityFunc(resource {{.schemaGroupVersionResource|raw}}, equal EqualityFunc) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.equalityFuncs == nil {
+			factory.equalityFuncs = make(map[{{.schemaGroupVersionResource|raw}}]EqualityFunc)
+		}
+		factory.equalityFuncs[resource] = equal
+		return factory
+	}
+}
+
+// WithGlobalObjectFilter makes every generated informer of the
+// SharedInformerFactory drop the objects for which filter returns false, for
+// example the objects of blocked namespaces, as they are listed and watched,
+// so that they are never cached nor delivered to event handlers. An update
+// which makes an object be filtered out is delivered as its deletion. The
+// filter applies in addition to the validators of WithIngestValidator, but
+// the objects it drops are not reported as invalid.
+func WithGlobalObjectFilter(filter func(obj {{.object|raw}}) bool) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.objectFilter = filter
+		return factory
+	}
+}
+
+// IngestValidationMode says what happens to the objects rejected by the
+// validator of WithIngestValidator.
+type IngestValidationMode int
+
+const (
+	// DropInvalidObjects keeps invalid objects out of the informer's cache.
+	// An update which makes a cached object invalid removes it from the cache.
+	DropInvalidObjects IngestValidationMode = iota
+	// KeepInvalidObjects caches invalid objects like valid ones.
+	KeepInvalidObjects
+)
+
+// WithIngestValidator validates the objects which the informer for resource
+// lists and watches with validate, to catch objects which violate invariants,
+// for example because of a bug of their controller. The objects rejected by
+// validate are reported by InvalidObjects until they are valid again or
+// deleted, and are dropped or kept according to mode.
+func WithIngestValidator(resource {{.schemaGroupVersionResource|raw}}, validate func(obj {{.runtimeObject|raw}}) error, mode IngestValidationMode) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.ingestValidators == nil {
+			factory.ingestValidators = make(map[{{.schemaGroupVersionResource|raw}}]*{{.interfacesIngestValidator|raw}})
+		}
+		factory.ingestValidators[resource] = {{.interfacesNewIngestValidator|raw}}(validate, mode == KeepInvalidObjects)
+		return factory
+	}
+}
+
+// WithPanicHandler recovers the panics of the event handlers added by the
+// generated handler helpers, such as the RegisterHandlers method of groups,
+// and passes them to handler with the resource of the informer. Without it,
+// such panics crash the process like those of any other event handler.
+func WithPanicHandler(handler func(resource {{.schemaGroupVersionResource|raw}}, recovered interface{})) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.panicHandler = handler
+		return factory
+	}
+}
+
+// WithInformerCreateHook consults hook with the resource of each generated
+// informer before the informer is created, for example to log it or to check
+// that its configuration is supported. If hook returns an error, the informer
+// is still returned, but the factory never starts it; StartWithError reports
+// the error instead. Informers created by custom InformerFor functions are not
+// passed to hook.
+func WithInformerCreateHook(hook func(resource {{.schemaGroupVersionResource|raw}}) error) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.informerCreateHook = hook
+		return factory
+	}
+}
+
+// WithRBACPrecheck reviews through authClient whether the resources of the
+// informers may be listed and watched before the informers are started.
+// Informers whose resources may not be are skipped instead of retrying
+// forbidden requests; SkippedInformers reports why. Informers whose access
+// cannot be reviewed are started. Cluster-scoped resources are reviewed in
+// all namespaces, and namespaced resources in the namespace of the factory,
+// or in each namespace of WithNamespaceSelectors if it was used.
+func WithRBACPrecheck(authClient {{.authzSelfSubjectAccessReviewsGetter|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.rbacPrecheck = authClient
+		return factory
+	}
+}
+
+// WithSyncOrder starts informers in stages. The informers for the resources
+// of a stage are only started once all informers of the previous stage which
+// were requested from the factory have synced. Informers for resources which
+// are not part of any stage are started right away, like those of the first
+// stage. WaitForCacheSync also waits for the informers of later stages.
+func WithSyncOrder(stages [][]{{.schemaGroupVersionResource|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.syncStages = make(map[{{.schemaGroupVersionResource|raw}}]int)
+		for stage, resources := range stages {
+			for _, resource := range resources {
+				factory.syncStages[resource] = stage
+			}
+		}
+		factory.syncStageCount = len(stages)
+		return factory
+	}
+}
+
+// WithStalenessWatchdog calls onStale with resource whenever the informer for
+// resource delivered no event for maxStaleness, for example because its watch
+// connection silently stopped delivering changes without failing. Adds,
+// updates, deletes and resyncs all count as events; the window starts when
+// the informer is started. onStale is called again after every further window
+// without events. It is called from a goroutine of the factory and must not
+// block.
+//
+// The watchdog cannot tell a stale watch from a resource which does not
+// change, so the informer should resync more often than maxStaleness.
+func WithStalenessWatchdog(resource {{.schemaGroupVersionResource|raw}}, maxStaleness {{.timeDuration|raw}}, onStale func({{.schemaGroupVersionResource|raw}})) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.stalenessWatchdogs == nil {
+			factory.stalenessWatchdogs = make(map[{{.schemaGroupVersionResource|raw}}]*stalenessWatchdog)
+		}
+		factory.stalenessWatchdogs[resource] = &stalenessWatchdog{maxStaleness: maxStaleness, onStale: onStale}
+		return factory
+	}
+}
+
+// WithKeyNormalizer makes the cache of the informer for resource key objects
+// by their namespace and their name as returned by normalize, for resources
+// whose names are case-insensitive or otherwise canonicalized by the server,
+// so that equivalent names map to a single entry. The lister finds objects by
+// any of their equivalent names. Objects with distinct names on the server
+// which normalize to the same name collide: only the last added or updated
+// one is cached, and deleting one removes the entry even if others still
+// exist, until they are updated or the informer relists. Like
+// WithCacheBackend, the normalized cache mirrors the in-memory cache of
+// client-go, whose keys cannot be changed.
+func WithKeyNormalizer(resource {{.schemaGroupVersionResource|raw}}, normalize func(name string) string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.keyNormalizers == nil {
+			factory.keyNormalizers = make(map[{{.schemaGroupVersionResource|raw}}]func(name string) string)
+		}
+		factory.keyNormalizers[resource] = normalize
+		return factory
+	}
+}
+
+// WithInitialCacheCapacity sizes the cache of the informer for resource for n
+// objects, for resources with a known large number of objects. It requires a
+// SizedCacheBackend passed to WithCacheBackend, whose indexers are filled one
+// object at a time; without one, the option is ignored and reported by
+// StartWithError. The in-memory caches of client-go are not affected, as
+// every list replaces them with maps sized for the listed objects.
+func WithInitialCacheCapacity(resource {{.schemaGroupVersionResource|raw}}, n int) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.cacheCapacities == nil {
+			factory.cacheCapacities = make(map[{{.schemaGroupVersionResource|raw}}]int)
+		}
+		factory.cacheCapacities[resource] = n
+		return factory
+	}
+}
+
+// WithCacheBackend makes the generated informers of the SharedInformerFactory
+// store their caches in indexers of backend, which are returned by their
+// GetStore and GetIndexer and used by their listers. The caches are mirrored
+// from the in-memory caches of client-go, which remain.
+func WithCacheBackend(backend {{.interfacesCacheBackend|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.cacheBackend = backend
+		return factory
+	}
+}
+
+// WithLeadershipGate makes the generated informers of the SharedInformerFactory
+// only list and watch while leadership is held. Leadership is acquired whenever
+// a value is received from acquired, and lost whenever a value is received from
+// lost; the channels must not be closed. Start starts the informers right
+// away, but they wait for leadership to be acquired before their first list,
+// so WaitForCacheSync waits for it, too. When leadership is lost, the watches
+// of the informers are stopped and they wait for leadership to be acquired
+// again to resume watching. Their caches and event handlers are kept in the
+// meantime, because client-go informers cannot be run again once they were
+// stopped; the caches are not updated until leadership is acquired again.
+// The clones of the factory share its leadership.
+func WithLeadershipGate(acquired <-chan struct{}, lost <-chan struct{}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.leadershipGate = {{.interfacesNewLeadershipGate|raw}}()
+		factory.leadershipGateRunner = &leadershipGateRunner{gate: factory.leadershipGate, acquired: acquired, lost: lost}
+		return factory
+	}
+}
+
+// WithWatchRotation makes the generated informers of the SharedInformerFactory
+// stop their watches once they have been open for interval and establish them
+// again, so that no watch pins the resources of the API server for longer.
+// The informers watch again from the last resource version they observed, so
+// their caches are kept and no events are replayed; they only relist if that
+// resource version is too old, or if the watch was stopped within a second
+// without delivering any event. A non-positive interval disables rotation.
+func WithWatchRotation(interval {{.timeDuration|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.watchRotation = interval
+		return factory
+	}
+}
+
+// WithLogger makes the SharedInformerFactory log a structured summary of the
+// informers started by each Start, with their resources and resync periods
+// and the namespace and selectors they are limited to, with logger.
+func WithLogger(logger {{.klogLogger|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.logger = &logger
+		return factory
+	}
+}
+
+// WithLatencyHistogram observes the event processing latency of the informer
+// for resource with histogram, in seconds. histogram is any Observe(float64)
+// observer, such as a prometheus.Observer. The latency of an event is
+// measured from the time at which the informer received the change until the
+// event handler it is delivered to returns; it is measured from the time of
+// delivery for resyncs and for objects without a UID. Only the handlers added
+// through the informers returned by the factory are observed.
+//
+// WithLatencyHistogram implies WithIngestTimestamps.
+func WithLatencyHistogram(resource {{.schemaGroupVersionResource|raw}}, histogram {{.cacheHistogramMetric|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.latencyHistograms == nil {
+			factory.latencyHistograms = make(map[{{.schemaGroupVersionResource|raw}}]{{.cacheHistogramMetric|raw}})
+		}
+		factory.latencyHistograms[resource] = histogram
+		if factory.ingestTimes == nil {
+			factory.ingestTimes = make(map[{{.typesUID|raw}}]{{.timeTime|raw}})
+		}
+		return factory
+	}
+}
+{{- if .prometheusCollector}}
+
+// WithLatencyObserverVec observes the event processing latency of every
+// informer like WithLatencyHistogram, with the observer of vec for the labels
+// group, version and resource of the informer, like MetricsCollector. The
+// histograms of WithLatencyHistogram take precedence.
+//
+// WithLatencyObserverVec implies WithIngestTimestamps.
+func WithLatencyObserverVec(vec {{.prometheusObserverVec|raw}}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.latencyObserverVec = vec
+		if factory.ingestTimes == nil {
+			factory.ingestTimes = make(map[{{.typesUID|raw}}]{{.timeTime|raw}})
+		}
+		return factory
+	}
+}
+{{- end}}
+
 func (f *sharedInformerFactory) InformerName() *{{.cacheInformerName|raw}} {
 	return f.informerName
 }
 
+// InformerOptionsFor returns the options of the factory for the informer for
+// obj's type. Its Retweaker is the one which RetweakInformer retweaks. It is
+// called by InformerFor while f.lock is held.
+func (f *sharedInformerFactory) InformerOptionsFor(obj {{.runtimeObject|raw}}) {{.interfacesInformerOptions|raw}} {
+	options := {{.interfacesInformerOptions|raw}}{
+		InformerName:       f.informerName,
+		ReconnectObserver:  f.reconnectObserver,
+		ObjectFilter:       f.objectFilter,
+		NamespaceSelectors: f.namespaceSelectors,
+		CacheBackend:       f.cacheBackend,
+		LeadershipGate:     f.leadershipGate,
+		WatchRotation:      f.watchRotation,
+	}
+	resource, ok := resourceForType({{.reflectTypeOf|raw}}(obj))
+	if !ok {
+		return options
+	}
+	if snapshot, ok := f.cacheSnapshots[resource]; ok {
+		options.CacheSnapshot = snapshot.snapshot
+		options.CacheSnapshotDecoder = snapshot.codec
+	}
+	options.InitialResourceVersion = f.listResourceVersion
+	options.InitialResourceVersionMatch = f.listResourceVersionMatch
+	if resourceVersion, pinned := f.initialResourceVersions[resource]; pinned {
+		options.InitialResourceVersion = resourceVersion
+		options.InitialResourceVersionMatch = {{.metav1ResourceVersionMatchExact|raw}}
+	}
+	options.WatchListPageSize = f.watchListPageSizes[resource]
+	if f.retweakers[resource] == nil {
+		f.retweakers[resource] = {{.interfacesNewRetweaker|raw}}(nil)
+	}
+	options.Retweaker = f.retweakers[resource]
+	options.IngestValidator = f.ingestValidators[resource]
+	options.InitialCacheCapacity = f.cacheCapacities[resource]
+	options.KeyNormalizer = f.keyNormalizers[resource]
+	return options
+}
+
+func (f *sharedInformerFactory) IngestTime(obj {{.object|raw}}) ({{.timeTime|raw}}, bool) {
+	f.ingestLock.RLock()
+	defer f.ingestLock.RUnlock()
+
+	ingested, ok := f.ingestTimes[obj.GetUID()]
+	return ingested, ok
+}
+
+// informerTransform returns the transform to set on a new informer. It wraps the
+// configured transform with ingest timestamp recording and queue counting when
+// those are enabled.
+func (f *sharedInformerFactory) informerTransform(counter *informerQueueCounter) {{.cacheTransformFunc|raw}} {
+	if f.featureGateStripper == nil && f.memoryBudget == nil && f.ingestTimes == nil && counter == nil {
+		return f.transform
+	}
+	transform := f.transform
+	return func(obj interface{}) (interface{}, error) {
+		if transform != nil {
+			var err error
+			if obj, err = transform(obj); err != nil {
+				return nil, err
+			}
+		}
+		if f.featureGateStripper != nil {
+			if object, ok := obj.({{.object|raw}}); ok {
+				f.featureGateStripper(object, f.featureGates)
+			}
+		}
+		if f.memoryBudget != nil {
+			if object, ok := obj.({{.object|raw}}); ok {
+				f.memoryBudget.admit(object)
+			}
+		}
+		if f.ingestTimes != nil {
+			if accessor, err := {{.metaAccessor|raw}}(obj); err == nil {
+				f.ingestLock.Lock()
+				f.ingestTimes[accessor.GetUID()] = {{.timeNow|raw}}()
+				f.ingestLock.Unlock()
+			}
+		}
+		if counter != nil {
+			counter.queued(obj)
+		}
+		return obj, nil
+	}
+}
+
+// forgetIngestTime drops the ingest timestamp of a deleted object.
+func (f *sharedInformerFactory) forgetIngestTime(obj interface{}) {
+	if tombstone, ok := obj.({{.cacheDeletedFinalStateUnknown|raw}}); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := {{.metaAccessor|raw}}(obj)
+	if err != nil {
+		return
+	}
+	f.ingestLock.Lock()
+	defer f.ingestLock.Unlock()
+	delete(f.ingestTimes, accessor.GetUID())
+}
+
+const (
+	// watchFailureThreshold is the number of consecutive watch failures of an
+	// informer after which a Warning event is emitted.
+	watchFailureThreshold = 3
+	// watchFailureWindow is the time after which a watch failure no longer
+	// counts as consecutive with the previous one.
+	watchFailureWindow = 5 * {{.timeMinute|raw}}
+	// watchFailureEventInterval is the minimum time between two Warning events
+	// about the watch of the same informer.
+	watchFailureEventInterval = {{.timeMinute|raw}}
+)
+
+// informerWatchErrorHandler returns the watch error handler of the informer for
+// informerType. It calls the configured watch error handler or the default one,
+// and emits a Warning event once the watch failed watchFailureThreshold times
+// in a row if an event recorder is configured.
+func (f *sharedInformerFactory) informerWatchErrorHandler(informerType {{.reflectType|raw}}) {{.cacheWatchErrorHandlerWithContext|raw}} {
+	handler := func(ctx {{.contextContext|raw}}, r *{{.cacheReflector|raw}}, err error) {
+		if f.watchErrorHandler != nil {
+			f.watchErrorHandler(r, err)
+			return
+		}
+		{{.cacheDefaultWatchErrorHandler|raw}}(ctx, r, err)
+	}
+	if f.eventRecorder == nil {
+		return handler
+	}
+
+	// The reflector of an informer calls its handler sequentially, so this
+	// state needs no locking.
+	var failures int
+	var lastFailure, lastEvent {{.timeTime|raw}}
+	return func(ctx {{.contextContext|raw}}, r *{{.cacheReflector|raw}}, err error) {
+		handler(ctx, r, err)
+		if err == {{.ioEOF|raw}} {
+			// The watch was closed normally.
+			failures = 0
+			return
+		}
+		now := {{.timeNow|raw}}()
+		if now.Sub(lastFailure) > watchFailureWindow {
+			failures = 0
+		}
+		failures++
+		lastFailure = now
+		if failures < watchFailureThreshold || now.Sub(lastEvent) < watchFailureEventInterval {
+			return
+		}
+		lastEvent = now
+		f.eventRecorder.Eventf(f.involvedObject, nil, {{.eventTypeWarning|raw}}, "WatchFailed", "Watch", "Watch of %v failed %d times in a row: %v", informerType, failures, err)
+	}
+}
+
 // NewSharedInformerFactory constructs a new instance of sharedInformerFactory for all namespaces.
 func NewSharedInformerFactory(client {{.clientSetInterface|raw}}, defaultResync {{.timeDuration|raw}}) SharedInformerFactory {
 	return NewSharedInformerFactoryWithOptions(client, defaultResync)
@@ -199,47 +1070,239 @@ func NewFilteredSharedInformerFactory(client {{.clientSetInterface|raw}}, defaul
 // NewSharedInformerFactoryWithOptions constructs a new instance of a SharedInformerFactory with additional options.
 func NewSharedInformerFactoryWithOptions(client {{.clientSetInterface|raw}}, defaultResync {{.timeDuration|raw}}, options ...SharedInformerOption) SharedInformerFactory {
 	factory := &sharedInformerFactory{
-		client:           client,
-		namespace:        v1.NamespaceAll,
-		defaultResync:    defaultResync,
-		informers:        make(map[{{.reflectType|raw}}]{{.cacheSharedIndexInformer|raw}}),
-		startedInformers: make(map[{{.reflectType|raw}}]bool),
-		customResync:     make(map[{{.reflectType|raw}}]{{.timeDuration|raw}}),
+		client:            client,
+		namespace:         v1.NamespaceAll,
+		defaultResync:     defaultResync,
+		informers:         make(map[{{.reflectType|raw}}]{{.cacheSharedIndexInformer|raw}}),
+		startedInformers:  make(map[{{.reflectType|raw}}]bool),
+		customResync:      make(map[{{.reflectType|raw}}]{{.timeDuration|raw}}),
+		handlerCounts:     make(map[{{.reflectType|raw}}]int),
+		vetoedInformers:   make(map[{{.reflectType|raw}}]error),
+		deferredInformers: make(map[{{.reflectType|raw}}]bool),
+		retweakers:        make(map[{{.schemaGroupVersionResource|raw}}]*{{.interfacesRetweaker|raw}}),
+		options:           append([]SharedInformerOption(nil), options...),
 	}
 
 	// Apply all options
 	for _, opt := range options {
 		factory = opt(factory)
 	}
+	if _, sized := factory.cacheBackend.({{.interfacesSizedCacheBackend|raw}}); !sized && len(factory.cacheCapacities) > 0 {
+		factory.optionErrs = append(factory.optionErrs, {{.errorsNew|raw}}("WithInitialCacheCapacity requires a SizedCacheBackend passed to WithCacheBackend"))
+		factory.cacheCapacities = nil
+	}
 
 	return factory
 }
 
+func (f *sharedInformerFactory) CloneForNamespace(namespace string) SharedInformerFactory {
+	options := append(append([]SharedInformerOption(nil), f.options...), WithNamespace(namespace))
+	clone := NewSharedInformerFactoryWithOptions(f.client, f.defaultResync, options...).(*sharedInformerFactory)
+	// The snapshots hold the objects of all namespaces, and their readers
+	// can only be consumed once. The namespace selectors would override
+	// namespace.
+	clone.cacheSnapshots = nil
+	clone.namespaceSelectors = nil
+	// The name of the parent would be released by the Shutdown of the clone,
+	// and registering the same resources twice under it disables the metrics
+	// of the clone's informers.
+	clone.informerName = nil
+	// Each signal of the leadership channels is received once, so the clone
+	// shares the gate of its parent rather than competing for them.
+	clone.leadershipGate = f.leadershipGate
+	clone.leadershipGateRunner = f.leadershipGateRunner
+	if f.informerName != nil {
+		informerName, err := {{.cacheNewInformerName|raw}}(f.informerName.Name() + "/" + namespace)
+		if err != nil {
+			{{.utilruntimeHandleError|raw}}({{.fmtErrorf|raw}}("the informers of the clone for namespace %q are not named: %w", namespace, err))
+		} else {
+			clone.informerName = informerName
+		}
+	}
+	return clone
+}
+
 func (f *sharedInformerFactory) Start(stopCh <-chan struct{}) {
 	f.StartWithContext({{.waitContextForChannel|raw}}(stopCh))
 }
 
 func (f *sharedInformerFactory) StartWithContext(ctx {{.contextContext|raw}}) {
+	if err := f.StartWithError(ctx); err != nil {
+		{{.utilruntimeHandleError|raw}}(err)
+	}
+}
+
+func (f *sharedInformerFactory) StartWithError(ctx {{.contextContext|raw}}) error {
+	f.precheckInformers(ctx)
+
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
 	if f.shuttingDown {
-		return
+		return nil
 	}
 
-	for informerType, informer := range f.informers {
+	errs := {{.slicesClone|raw}}(f.optionErrs)
+	for informerType, err := range f.vetoedInformers {
 		if !f.startedInformers[informerType] {
+			errs = append(errs, err)
+		}
+	}
+	{{.slicesSortFunc|raw}}(errs, func(a, b error) int {
+		return {{.stringsCompare|raw}}(a.Error(), b.Error())
+	})
+
+	// The informers pass ctx on to their list and watch calls, so canceling
+	// it aborts the requests which are in flight. Shutdown cancels it, too.
+	ctx, cancel := {{.contextWithCancelCause|raw}}(ctx)
+	started := false
+	var summaries []informerStartSummary
+	deferred := make([][]{{.reflectType|raw}}, f.syncStageCount)
+	for informerType, informer := range f.informers {
+		if _, vetoed := f.vetoedInformers[informerType]; vetoed || f.skippedInformers[informerType] != "" || f.startedInformers[informerType] || f.deferredInformers[informerType] {
+			continue
+		}
+		if f.logger != nil {
+			summaries = append(summaries, f.startSummary(informerType))
+		}
+		if stage := f.syncStage(informerType); stage > 0 {
+			deferred[stage] = append(deferred[stage], informerType)
+			f.deferredInformers[informerType] = true
+		} else {
+			f.startInformer(ctx, informerType, informer)
+		}
+		started = true
+	}
+	for stage, informerTypes := range deferred {
+		if len(informerTypes) > 0 {
 			f.wg.Go(func() {
-				informer.RunWithContext(ctx)
+				f.startStage(ctx, stage, informerTypes)
 			})
-			f.startedInformers[informerType] = true
 		}
 	}
-}
+	if !started {
+		cancel(nil)
+		return {{.errorsJoin|raw}}(errs...)
+	}
+	f.cancelFuncs = append(f.cancelFuncs, cancel)
+	if f.leadershipGate != nil && !f.leadershipGateRunning {
+		f.leadershipGateRunning = true
+		f.wg.Go(func() {
+			f.leadershipGateRunner.run(ctx)
+		})
+	}
+	if f.logger != nil {
+		{{.slicesSortFunc|raw}}(summaries, func(a, b informerStartSummary) int {
+			return {{.stringsCompare|raw}}(a.Resource, b.Resource)
+		})
+		var namespaces []string
+		for namespace := range f.namespaceSelectors {
+			namespaces = append(namespaces, namespace)
+		}
+		{{.slicesSortFunc|raw}}(namespaces, {{.stringsCompare|raw}})
+		f.logger.Info("Started informers", "count", len(summaries), "namespace", f.namespace, "namespaces", namespaces, "defaultResync", f.defaultResync, "informers", summaries)
+	}
+	return {{.errorsJoin|raw}}(errs...)
+}
+
+// informerStartSummary describes an informer in the summary logged by Start.
+type informerStartSummary struct {
+	Resource      string
+	Resync        {{.timeDuration|raw}}
+	LabelSelector string
+	FieldSelector string
+	// Deferred is true if the informer waits for the informers of a previous
+	// stage of WithSyncOrder.
+	Deferred bool
+}
+
+// startSummary returns the summary of the informer for informerType. f.lock
+// must be held.
+func (f *sharedInformerFactory) startSummary(informerType {{.reflectType|raw}}) informerStartSummary {
+	summary := informerStartSummary{Resource: informerType.String(), Resync: f.defaultResync}
+	if resyncPeriod, exists := f.customResync[informerType]; exists {
+		summary.Resync = resyncPeriod
+	}
+	var opts {{.v1ListOptions|raw}}
+	resource, ok := resourceForType(informerType)
+	if ok {
+		summary.Resource = resource.String()
+		summary.Deferred = f.syncStages[resource] > 0
+	}
+	if retweaker := f.retweakers[resource]; retweaker != nil {
+		retweaker.TweakListOptions(&opts)
+	} else if f.tweakListOptions != nil {
+		f.tweakListOptions(&opts)
+	}
+	summary.LabelSelector = opts.LabelSelector
+	summary.FieldSelector = opts.FieldSelector
+	return summary
+}
+
+// startInformer runs informer until ctx is canceled. f.lock must be held.
+func (f *sharedInformerFactory) startInformer(ctx {{.contextContext|raw}}, informerType {{.reflectType|raw}}, informer {{.cacheSharedIndexInformer|raw}}) {
+	f.wg.Go(func() {
+		informer.RunWithContext(ctx)
+	})
+	if resource, ok := resourceForType(informerType); ok && f.stalenessWatchdogs[resource] != nil {
+		f.wg.Go(func() {
+			f.stalenessWatchdogs[resource].run(ctx, resource)
+		})
+	}
+	f.startedInformers[informerType] = true
+}
+
+// syncStage returns the stage of WithSyncOrder of the informer for
+// informerType, or 0 if it is not part of any stage.
+func (f *sharedInformerFactory) syncStage(informerType {{.reflectType|raw}}) int {
+	resource, ok := resourceForType(informerType)
+	if !ok {
+		return 0
+	}
+	return f.syncStages[resource]
+}
+
+// startStage starts the deferred informers of stage once the informers of the
+// previous stages have synced.
+func (f *sharedInformerFactory) startStage(ctx {{.contextContext|raw}}, stage int, informerTypes []{{.reflectType|raw}}) {
+	for previous := 0; previous < stage; previous++ {
+		if !{{.cacheWaitFor|raw}}(ctx, "" /* no logging */, f.stageCheckers(previous)...) {
+			return
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.shuttingDown {
+		return
+	}
+	for _, informerType := range informerTypes {
+		delete(f.deferredInformers, informerType)
+		f.startInformer(ctx, informerType, f.informers[informerType])
+	}
+}
+
+// stageCheckers returns the checkers of the started informers of stage.
+func (f *sharedInformerFactory) stageCheckers(stage int) []{{.cacheDoneChecker|raw}} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var checkers []{{.cacheDoneChecker|raw}}
+	for informerType, informer := range f.informers {
+		if (f.startedInformers[informerType] || f.deferredInformers[informerType]) && f.syncStage(informerType) == stage {
+			checkers = append(checkers, informer.HasSyncedChecker())
+		}
+	}
+	return checkers
+}
 
 func (f *sharedInformerFactory) Shutdown() {
 	f.lock.Lock()
 	f.shuttingDown = true
+	for _, cancel := range f.cancelFuncs {
+		cancel({{.errorsNew|raw}}("the informer factory is shutting down"))
+	}
+	f.cancelFuncs = nil
 	f.lock.Unlock()
 
 
@@ -260,7 +1323,7 @@ func (f *sharedInformerFactory) WaitForCacheSyncWithContext(ctx context.Context)
 
 		informers := map[{{.reflectType|raw}}]{{.cacheSharedIndexInformer|raw}}{}
 		for informerType, informer := range f.informers {
-			if f.startedInformers[informerType] {
+			if f.startedInformers[informerType] || f.deferredInformers[informerType] {
 				informers[informerType] = informer
 			}
 		}
@@ -295,6 +1358,19 @@ func (f *sharedInformerFactory) WaitForCacheSyncWithContext(ctx context.Context)
 	return res
 }
 
+func (f *sharedInformerFactory) WaitForNamedCacheSync(controllerName string, stopCh <-chan struct{}, cacheSyncs ...{{.cacheInformerSynced|raw}}) bool {
+	if len(cacheSyncs) == 0 {
+		f.lock.Lock()
+		for informerType, informer := range f.informers {
+			if f.startedInformers[informerType] || f.deferredInformers[informerType] {
+				cacheSyncs = append(cacheSyncs, informer.HasSynced)
+			}
+		}
+		f.lock.Unlock()
+	}
+	return {{.cacheWaitForNamedCacheSync|raw}}(controllerName, stopCh, cacheSyncs...)
+}
+
 // InformerFor returns the SharedIndexInformer for obj using an internal
 // client.
 func (f *sharedInformerFactory) InformerFor(obj {{.runtimeObject|raw}}, newFunc {{.interfacesNewInformerFunc|raw}}) {{.cacheSharedIndexInformer|raw}} {
@@ -313,8 +1389,42 @@ func (f *sharedInformerFactory) InformerFor(obj {{.runtimeObject|raw}}, newFunc
   }
 
   informer = newFunc(f.client, resyncPeriod)
-  if f.transform != nil {
-    informer.SetTransform(f.transform)
+  var counter *informerQueueCounter
+  if f.queueCounters != nil {
+    counter = &informerQueueCounter{}
+    f.queueCounters[informerType] = counter
+  }
+  if transform := f.informerTransform(counter); transform != nil {
+    informer.SetTransform(transform)
+  }
+  if f.ingestTimes != nil {
+    informer.AddEventHandler({{.cacheResourceEventHandlerFuncs|raw}}{DeleteFunc: f.forgetIngestTime})
+  }
+  if f.memoryBudget != nil {
+    informer.AddEventHandler({{.cacheResourceEventHandlerFuncs|raw}}{DeleteFunc: f.memoryBudget.forget})
+  }
+  if resource, ok := resourceForType(informerType); ok && f.stalenessWatchdogs[resource] != nil {
+    informer.AddEventHandler(f.stalenessWatchdogs[resource].handler())
+  }
+  if counter != nil {
+    informer = &queueCountingInformer{SharedIndexInformer: informer, counter: counter}
+    // A change also waits for this handler, so that it is not delivered
+    // before the informer processed it when no other handler was added.
+    informer.AddEventHandler({{.cacheResourceEventHandlerFuncs|raw}}{})
+  }
+  if f.watchErrorHandler != nil || f.eventRecorder != nil {
+    // This fails if newFunc returned an informer which was already started.
+    if err := informer.SetWatchErrorHandlerWithContext(f.informerWatchErrorHandler(informerType)); err != nil {
+      {{.utilruntimeHandleError|raw}}({{.fmtErrorf|raw}}("failed to set the watch error handler of the %v informer: %w", informerType, err))
+    }
+  }
+  if f.equalityFuncs != nil {
+    if resource, ok := resourceForType(informerType); ok && f.equalityFuncs[resource] != nil {
+      informer = &dedupingInformer{SharedIndexInformer: informer, equal: f.equalityFuncs[resource]}
+    }
+  }
+  if histogram := f.latencyHistogram(informerType); histogram != nil {
+    informer = &latencyObservingInformer{SharedIndexInformer: informer, factory: f, histogram: histogram}
   }
   f.informers[informerType] = informer
 
@@ -370,17 +1480,33 @@ type SharedInformerFactory interface {
 	Start(stopCh <-chan struct{})
 
 	// StartWithContext initializes all requested informers. They are handled in goroutines
-	// which run until the context gets canceled.
+	// which run until the context gets canceled or Shutdown is called. The context is
+	// passed on to the list and watch calls of the informers, so canceling it also
+	// aborts requests which are in flight.
 	// Warning: StartWithContext does not block. When run in a go-routine, it will race with a later WaitForCacheSync.
 	StartWithContext(ctx context.Context)
 
+	// RetweakInformer replaces the list options tweak of the informer for
+	// resource, including the one of WithTweakListOptions, with tweak and
+	// makes the informer relist, if it is running. The relist evicts the
+	// objects which do not match the new list options from the informer
+	// cache; handlers see them deleted. RetweakInformer fails if no informer
+	// for resource was requested, or if it was created by a custom
+	// InformerFor function.
+	RetweakInformer(resource {{.schemaGroupVersionResource|raw}}, tweak {{.interfacesTweakListOptionsFunc|raw}}) error
+
+	// StartWithError works like StartWithContext, but also returns the errors
+	// of the informers which were vetoed by the hook of WithInformerCreateHook.
+	// These informers are not started; the others are started regardless.
+	// StartWithContext passes such errors to utilruntime.HandleError.
+	StartWithError(ctx {{.contextContext|raw}}) error
+
 	// Shutdown marks a factory as shutting down. At that point no new
 	// informers can be started anymore and Start will return without
 	// doing anything.
 	//
-	// In addition, Shutdown blocks until all goroutines have terminated. For that
-	// to happen, the close channel(s) that they were started with must be closed,
-	// either before Shutdown gets called or while it is waiting.
+	// In addition, Shutdown stops all started informers and blocks until all
+	// goroutines have terminated.
 	//
 	// Shutdown may be called multiple times, even concurrently. All such calls will
 	// block until all goroutines have terminated.
@@ -396,6 +1522,12 @@ type SharedInformerFactory interface {
 	// or the context gets canceled.
 	WaitForCacheSyncWithContext(ctx {{.contextContext|raw}}) {{.cacheSyncResult|raw}}
 
+	// WaitForNamedCacheSync blocks until cacheSyncs, or all started informers'
+	// caches if none are given, were synced or the stop channel gets closed,
+	// like cache.WaitForNamedCacheSync. It logs the name of the controller
+	// when it starts waiting and when the caches failed to sync.
+	WaitForNamedCacheSync(controllerName string, stopCh <-chan struct{}, cacheSyncs ...{{.cacheInformerSynced|raw}}) bool
+
 	// ForResource gives generic access to a shared informer of the matching type.
 	ForResource(resource {{.schemaGroupVersionResource|raw}}) (GenericInformer, error)
 
@@ -403,6 +1535,113 @@ type SharedInformerFactory interface {
 	// client.
 	InformerFor(obj {{.runtimeObject|raw}}, newFunc {{.interfacesNewInformerFunc|raw}}) {{.cacheSharedIndexInformer|raw}}
 
+	// IngestTime returns the time at which obj was last ingested into an informer
+	// cache. It only reports timestamps when the factory was created with
+	// WithIngestTimestamps.
+	IngestTime(obj {{.object|raw}}) ({{.timeTime|raw}}, bool)
+
+	// Stats returns a snapshot of the informer for obj's type. It returns false
+	// if no informer was requested for that type.
+	Stats(obj {{.runtimeObject|raw}}) (InformerStats, bool)
+
+	// CacheGeneration returns the number of mutations of the cache of the
+	// informer for obj's type so far, which increases whenever an object is
+	// added, updated or deleted, but not on resyncs. Consumers can compare it
+	// to a generation they saw earlier to tell whether anything changed since.
+	// It is zero if no informer was requested for that type. The mutations
+	// are counted by an event handler which the first call for a type adds to
+	// its informer; the objects cached by then are counted once each, as they
+	// are delivered to the handler.
+	CacheGeneration(obj {{.runtimeObject|raw}}) uint64
+
+	// PendingDeltas returns the number of objects of resource whose latest
+	// change was queued by the informer but not yet processed by all of its
+	// event handlers, for example to publish it as an autoscaling metric. It is
+	// zero unless the factory was created with WithInformerStats.
+	PendingDeltas(resource {{.schemaGroupVersionResource|raw}}) int
+
+	// CloneForNamespace returns a new factory limited to namespace, which is
+	// created with the client, default resync period and options of this
+	// factory. The clone has its own informers, which it starts and stops
+	// independently of this factory. The snapshots of WithCacheSnapshot and
+	// the namespaces of WithNamespaceSelectors are not used by the clone. If
+	// this factory has an InformerName, the clone gets its own one, named
+	// after it with a "/<namespace>" suffix, which its Shutdown releases.
+	CloneForNamespace(namespace string) SharedInformerFactory
+
+	// DumpState returns a description of all informers requested from the
+	// factory, for debugging.
+	DumpState() FactoryState
+
+	// SkippedInformers returns the reasons why the informers which were skipped
+	// by the precheck of WithRBACPrecheck are not started, by resource.
+	SkippedInformers() map[{{.schemaGroupVersionResource|raw}}]string
+
+{{if .prometheusCollector}}	// MetricsCollector returns a prometheus collector of the cache sizes of the
+	// informers requested from the factory, which are only computed when
+	// scraped.
+	MetricsCollector() *MetricsCollector
+
+{{end}}	// InformersWithHandlers returns the resources of the informers to which
+	// event handlers were added through the generated handler helpers, such as
+	// RegisterHandlers of a group, ordered by resource. Handlers which were
+	// added to an informer directly, or removed later, are not observed.
+	InformersWithHandlers() []{{.schemaGroupVersionResource|raw}}
+
+	// InvalidObjects returns the objects which are currently rejected by the
+	// validators of WithIngestValidator, ordered by resource and key.
+	InvalidObjects() []InvalidObject
+
+	// RegisterConsumer records that the consumer called name, such as a
+	// controller, uses the informer for resource. It is only metadata for
+	// DependencyGraph and does not request the informer. Registering a name
+	// again for the same resource has no effect.
+	RegisterConsumer(resource {{.schemaGroupVersionResource|raw}}, name string)
+
+	// DependencyGraph returns the names of the consumers registered for each
+	// resource, in the order in which they were registered.
+	DependencyGraph() map[{{.schemaGroupVersionResource|raw}}][]string
+
+	// AddResyncHandler adds handler to the informer for obj's type, which
+	// must have been requested from the factory, like AddEventHandler. Besides
+	// the notifications of the informer, handler gets the ones of Resync and
+	// ReplayKey, which the handlers added to the informer directly do not.
+	AddResyncHandler(obj {{.runtimeObject|raw}}, handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error)
+
+	// RemoveResyncHandler removes a handler added by AddResyncHandler from
+	// the informer for obj's type.
+	RemoveResyncHandler(obj {{.runtimeObject|raw}}, registration {{.cacheResourceEventHandlerRegistration|raw}}) error
+
+	// Resync delivers every object in the cache of the synced informer for
+	// obj's type to the event handlers added through AddResyncHandler, as an
+	// update from the object to itself, like a periodic resync. The handlers
+	// are called from the calling goroutine, never concurrently with their
+	// other notifications, and Resync returns once all were called. Unlike a
+	// periodic resync, the updates are not ordered with the notifications
+	// which are queued for the handlers, so a handler may see an older
+	// version of an object after Resync.
+	Resync(obj {{.runtimeObject|raw}}) error
+
+	// ReplayKey delivers the object with key in the cache of the synced
+	// informer for obj's type to the event handlers added through
+	// AddResyncHandler like Resync, as an update from the object to itself.
+	// It fails if there is no such object.
+	ReplayKey(obj {{.runtimeObject|raw}}, key string) error
+
+	// WaitForCondition blocks until pred holds for the cache of the informer
+	// for obj's type, which must have been requested from the factory. pred
+	// is evaluated first, then again after notifications of the informer,
+	// including resyncs; notifications arriving during an evaluation are
+	// coalesced into a single new evaluation. It returns the cause of ctx
+	// being done if it is done first.
+	WaitForCondition(ctx {{.contextContext|raw}}, obj {{.runtimeObject|raw}}, pred func(store {{.cacheStore|raw}}) bool) error
+
+	// SnapshotCache writes the objects in the cache of the synced informer for
+	// resource to w, as a list encoded by codec which WithCacheSnapshot can
+	// warm the cache of another factory from. A nil codec writes JSON. The
+	// codec must know the list type of resource.
+	SnapshotCache(resource {{.schemaGroupVersionResource|raw}}, w {{.ioWriter|raw}}, codec {{.runtimeCodec|raw}}) error
+
 	{{$gvInterfaces := .gvInterfaces}}
 	{{$gvGoNames := .gvGoNames}}
 	{{range $groupName, $group := .groupVersions}}{{index $gvGoNames $groupName}}() {{index $gvInterfaces $groupName|raw}}
@@ -417,3 +1656,1212 @@ func (f *sharedInformerFactory) {{index $gvGoNames $groupPkgName}}() {{index $gv
 }
 {{end}}
 `
+
+var sharedInformerFactoryStats = `
+// InformerStats is a point-in-time snapshot of a shared informer.
+//
+// client-go does not expose the controller or the queue of a shared informer,
+// so the queue length is derived from the changes seen by the informer's
+// transform and by its event handlers.
+type InformerStats struct {
+	// QueueLength is the number of objects whose latest change was queued by
+	// the informer but not yet processed by all of its event handlers. Like the
+	// keys of a DeltaFIFO, several changes of one object count once. It is
+	// always zero unless the factory was created with WithInformerStats.
+	QueueLength int
+	// ObjectCount is the number of objects in the informer's cache.
+	ObjectCount int
+}
+
+func (f *sharedInformerFactory) Stats(obj {{.runtimeObject|raw}}) (InformerStats, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return InformerStats{}, false
+	}
+	stats := InformerStats{ObjectCount: len(informer.GetStore().ListKeys())}
+	if counter := f.queueCounters[informerType]; counter != nil {
+		stats.QueueLength = counter.length()
+	}
+	return stats, true
+}
+
+func (f *sharedInformerFactory) CacheGeneration(obj {{.runtimeObject|raw}}) uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	if generation := f.cacheGenerations[informerType]; generation != nil {
+		return generation.Load()
+	}
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return 0
+	}
+	generation := &{{.atomicUint64|raw}}{}
+	if _, err := informer.AddEventHandler(cacheGenerationHandler(generation)); err != nil {
+		{{.utilruntimeHandleError|raw}}({{.fmtErrorf|raw}}("failed to count the cache generations of the %v informer: %w", informerType, err))
+		return 0
+	}
+	if f.cacheGenerations == nil {
+		f.cacheGenerations = make(map[{{.reflectType|raw}}]*{{.atomicUint64|raw}})
+	}
+	f.cacheGenerations[informerType] = generation
+	return generation.Load()
+}
+
+// cacheGenerationHandler returns an event handler which increments generation
+// for the notifications of an informer, which are delivered after its cache
+// was mutated. Resyncs are recognized by the old and new objects of an update
+// being the same object and do not count.
+func cacheGenerationHandler(generation *{{.atomicUint64|raw}}) {{.cacheResourceEventHandlerFuncs|raw}} {
+	return {{.cacheResourceEventHandlerFuncs|raw}}{
+		AddFunc: func(obj interface{}) {
+			generation.Add(1)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj != newObj {
+				generation.Add(1)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			generation.Add(1)
+		},
+	}
+}
+
+func (f *sharedInformerFactory) PendingDeltas(resource {{.schemaGroupVersionResource|raw}}) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, counter := range f.queueCounters {
+		if r, ok := resourceForType(informerType); ok && r == resource {
+			return counter.length()
+		}
+	}
+	return 0
+}
+
+// informerQueueCounter tracks the objects whose latest change was queued by an
+// informer, as seen by its transform, until every event handler added to the
+// informer processed that change. Changes are told apart by resource version,
+// so a change which supersedes a queued one, including the relisted objects
+// of a Replace, replaces it rather than being counted again. Resyncs do not
+// pass through the transform and are not counted.
+type informerQueueCounter struct {
+	lock {{.syncMutex|raw}}
+	// handlers are the event handlers which a change is delivered to.
+	handlers map[*queueCountingHandler]struct{}
+	// registrations map the registrations of handlers to the handlers.
+	registrations map[{{.cacheResourceEventHandlerRegistration|raw}}]*queueCountingHandler
+	// pending maps the keys of objects to their latest queued change.
+	pending map[string]*queuedChange
+}
+
+// queuedChange is a change which was not yet processed by all event handlers.
+type queuedChange struct {
+	resourceVersion string
+	waiting         map[*queueCountingHandler]struct{}
+}
+
+// changeOf returns the key and resource version of the object of a change.
+func changeOf(obj interface{}) (string, string, bool) {
+	key, err := {{.cacheDeletionHandlingMetaNamespaceKeyFunc|raw}}(obj)
+	if err != nil {
+		return "", "", false
+	}
+	if tombstone, ok := obj.({{.cacheDeletedFinalStateUnknown|raw}}); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := {{.metaAccessor|raw}}(obj)
+	if err != nil {
+		return "", "", false
+	}
+	return key, accessor.GetResourceVersion(), true
+}
+
+func (c *informerQueueCounter) queued(obj interface{}) {
+	key, resourceVersion, ok := changeOf(obj)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.handlers) == 0 {
+		return
+	}
+	if c.pending == nil {
+		c.pending = make(map[string]*queuedChange)
+	}
+	change := &queuedChange{resourceVersion: resourceVersion, waiting: make(map[*queueCountingHandler]struct{}, len(c.handlers))}
+	for handler := range c.handlers {
+		change.waiting[handler] = struct{}{}
+	}
+	c.pending[key] = change
+}
+
+// delivered records that handler processed a notification for obj. Only the
+// notification of the latest queued change of an object clears it, since the
+// notifications of the changes it superseded are delivered before it.
+func (c *informerQueueCounter) delivered(handler *queueCountingHandler, obj interface{}) {
+	key, resourceVersion, ok := changeOf(obj)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	change := c.pending[key]
+	if change == nil || change.resourceVersion != resourceVersion {
+		return
+	}
+	delete(change.waiting, handler)
+	if len(change.waiting) == 0 {
+		delete(c.pending, key)
+	}
+}
+
+// add adds a handler wrapping handler, which register adds to the informer.
+// Changes which are queued from then on wait for it.
+func (c *informerQueueCounter) add(handler {{.cacheResourceEventHandler|raw}}, register func({{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error)) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	counting := &queueCountingHandler{counter: c, handler: handler}
+	c.lock.Lock()
+	if c.handlers == nil {
+		c.handlers = make(map[*queueCountingHandler]struct{})
+	}
+	c.handlers[counting] = struct{}{}
+	c.lock.Unlock()
+
+	registration, err := register(counting)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err != nil {
+		c.forget(counting)
+		return registration, err
+	}
+	if c.registrations == nil {
+		c.registrations = make(map[{{.cacheResourceEventHandlerRegistration|raw}}]*queueCountingHandler)
+	}
+	c.registrations[registration] = counting
+	return registration, nil
+}
+
+// remove stops changes from waiting for the handler of registration.
+func (c *informerQueueCounter) remove(registration {{.cacheResourceEventHandlerRegistration|raw}}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if counting, ok := c.registrations[registration]; ok {
+		delete(c.registrations, registration)
+		c.forget(counting)
+	}
+}
+
+// forget removes handler from the handlers and from the queued changes.
+// c.lock must be held.
+func (c *informerQueueCounter) forget(handler *queueCountingHandler) {
+	delete(c.handlers, handler)
+	for key, change := range c.pending {
+		delete(change.waiting, handler)
+		if len(change.waiting) == 0 {
+			delete(c.pending, key)
+		}
+	}
+}
+
+func (c *informerQueueCounter) length() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.pending)
+}
+
+// queueCountingInformer wraps the event handlers added to it, so that their
+// informer's queue counter knows when they processed a change.
+type queueCountingInformer struct {
+	{{.cacheSharedIndexInformer|raw}}
+	counter *informerQueueCounter
+}
+
+func (i *queueCountingInformer) AddEventHandler(handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.counter.add(handler, i.SharedIndexInformer.AddEventHandler)
+}
+
+func (i *queueCountingInformer) AddEventHandlerWithResyncPeriod(handler {{.cacheResourceEventHandler|raw}}, resyncPeriod {{.timeDuration|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.counter.add(handler, func(handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+		return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	})
+}
+
+func (i *queueCountingInformer) AddEventHandlerWithOptions(handler {{.cacheResourceEventHandler|raw}}, options {{.cacheHandlerOptions|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.counter.add(handler, func(handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+		return i.SharedIndexInformer.AddEventHandlerWithOptions(handler, options)
+	})
+}
+
+func (i *queueCountingInformer) RemoveEventHandler(registration {{.cacheResourceEventHandlerRegistration|raw}}) error {
+	if err := i.SharedIndexInformer.RemoveEventHandler(registration); err != nil {
+		return err
+	}
+	i.counter.remove(registration)
+	return nil
+}
+
+// queueCountingHandler marks the changes it processed as delivered to it.
+type queueCountingHandler struct {
+	counter *informerQueueCounter
+	handler {{.cacheResourceEventHandler|raw}}
+}
+
+func (h *queueCountingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.handler.OnAdd(obj, isInInitialList)
+	h.counter.delivered(h, obj)
+}
+
+func (h *queueCountingHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.handler.OnUpdate(oldObj, newObj)
+	h.counter.delivered(h, newObj)
+}
+
+func (h *queueCountingHandler) OnDelete(obj interface{}) {
+	h.handler.OnDelete(obj)
+	h.counter.delivered(h, obj)
+}
+`
+
+var sharedInformerFactoryLeadership = `
+// leadershipGateRunner records the signals of the leadership channels of
+// WithLeadershipGate in the gate shared by a factory and its clones. The
+// channels are read by a single goroutine, which runs while the context of
+// at least one run call is not done.
+type leadershipGateRunner struct {
+	gate     *{{.interfacesLeadershipGate|raw}}
+	acquired <-chan struct{}
+	lost     <-chan struct{}
+
+	lock  {{.syncMutex|raw}}
+	users int
+	stop  func()
+}
+
+// run makes sure the signals are recorded until ctx is done.
+func (r *leadershipGateRunner) run(ctx {{.contextContext|raw}}) {
+	r.lock.Lock()
+	r.users++
+	if r.users == 1 {
+		runCtx, cancel := {{.contextWithCancelCause|raw}}({{.contextBackground|raw}}())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.gate.Run(runCtx, r.acquired, r.lost)
+		}()
+		r.stop = func() {
+			cancel(nil)
+			<-done
+		}
+	}
+	r.lock.Unlock()
+
+	<-ctx.Done()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.users--
+	if r.users == 0 {
+		r.stop()
+	}
+}
+`
+
+var sharedInformerFactoryState = `
+// FactoryState is a point-in-time description of a SharedInformerFactory.
+type FactoryState struct {
+	// Informers describes the informers of the factory, ordered by resource.
+	Informers []InformerState
+}
+
+// InformerState is a point-in-time description of a shared informer.
+type InformerState struct {
+	// Resource is the resource served by the informer. It is empty for
+	// informers of types which this factory does not know about.
+	Resource {{.schemaGroupVersionResource|raw}}
+	// Started is true if the informer was started.
+	Started bool
+	// Synced is true if the informer's cache is synced.
+	Synced bool
+	// ObjectCount is the number of objects in the informer's cache.
+	ObjectCount int
+	// LastSyncResourceVersion is the resource version observed by the last
+	// list or watch of the informer.
+	LastSyncResourceVersion string
+}
+
+// GroupSynced returns true if all informers for resources of group which
+// were requested from the factory have synced.
+func (f *sharedInformerFactory) GroupSynced(group string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, informer := range f.informers {
+		if resource, ok := resourceForType(informerType); ok && resource.Group == group && !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *sharedInformerFactory) DumpState() FactoryState {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	state := FactoryState{Informers: make([]InformerState, 0, len(f.informers))}
+	for informerType, informer := range f.informers {
+		resource, _ := resourceForType(informerType)
+		state.Informers = append(state.Informers, InformerState{
+			Resource:                resource,
+			Started:                 f.startedInformers[informerType],
+			Synced:                  informer.HasSynced(),
+			ObjectCount:             len(informer.GetStore().ListKeys()),
+			LastSyncResourceVersion: informer.LastSyncResourceVersion(),
+		})
+	}
+	{{.slicesSortFunc|raw}}(state.Informers, func(a, b InformerState) int {
+		return {{.stringsCompare|raw}}(a.Resource.String(), b.Resource.String())
+	})
+	return state
+}
+`
+
+var sharedInformerFactoryMetricsCollector = `
+// CacheSizeMetricName is the name of the gauge of the cache sizes reported by
+// a MetricsCollector, with the labels group, version and resource.
+const CacheSizeMetricName = "informer_cache_size"
+
+// MetricsCollector is a {{.prometheusCollectorInterface|raw}} which reports the number of
+// objects in the cache of each informer of a factory at the time it is
+// scraped, so that nothing is counted on events.
+type MetricsCollector struct {
+	factory *sharedInformerFactory
+	desc    *{{.prometheusDesc|raw}}
+}
+
+var _ {{.prometheusCollectorInterface|raw}} = &MetricsCollector{}
+
+// MetricsCollector returns a collector of the cache sizes of the informers
+// requested from the factory. Register it with a prometheus registry.
+func (f *sharedInformerFactory) MetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		factory: f,
+		desc:    {{.prometheusNewDesc|raw}}(CacheSizeMetricName, "Number of objects in the cache of an informer.", []string{"group", "version", "resource"}, nil),
+	}
+}
+
+// Describe sends the description of the cache size gauge.
+func (c *MetricsCollector) Describe(ch chan<- *{{.prometheusDesc|raw}}) {
+	ch <- c.desc
+}
+
+// Collect sends the cache size of each informer of a known type.
+func (c *MetricsCollector) Collect(ch chan<- {{.prometheusMetric|raw}}) {
+	for _, informer := range c.factory.DumpState().Informers {
+		if !informer.Resource.Empty() {
+			ch <- {{.prometheusMustNewConstMetric|raw}}(c.desc, {{.prometheusGaugeValue|raw}}, float64(informer.ObjectCount), informer.Resource.Group, informer.Resource.Version, informer.Resource.Resource)
+		}
+	}
+}
+`
+
+var sharedInformerFactoryHandlers = `
+// TrackEventHandler records that an event handler was added to informer,
+// which must have been created by InformerFor.
+func (f *sharedInformerFactory) TrackEventHandler(informer {{.cacheSharedIndexInformer|raw}}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, i := range f.informers {
+		if i == informer {
+			f.handlerCounts[informerType]++
+			return
+		}
+	}
+}
+
+// PriorityEventHandlers returns the dispatcher of the handlers of informer
+// which were added with a priority, adding it to informer first if needed.
+// informer must have been created by InformerFor.
+func (f *sharedInformerFactory) PriorityEventHandlers(informer {{.cacheSharedIndexInformer|raw}}) (*{{.interfacesPriorityEventHandlers|raw}}, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, i := range f.informers {
+		if i != informer {
+			continue
+		}
+		if handlers, exists := f.priorityEventHandlers[informerType]; exists {
+			return handlers, nil
+		}
+		handlers, err := {{.interfacesAddPriorityEventHandlers|raw}}(informer)
+		if err != nil {
+			return nil, err
+		}
+		if f.priorityEventHandlers == nil {
+			f.priorityEventHandlers = make(map[{{.reflectType|raw}}]*{{.interfacesPriorityEventHandlers|raw}})
+		}
+		f.priorityEventHandlers[informerType] = handlers
+		return handlers, nil
+	}
+	return nil, {{.errorsNew|raw}}("the informer was not created by the factory")
+}
+
+// NextEventSequence returns the sequence number of the next event delivered to
+// a sequenced handler of any informer of the factory. Sequence numbers start at
+// 1 and increase monotonically.
+func (f *sharedInformerFactory) NextEventSequence() uint64 {
+	return f.eventSequence.Add(1)
+}
+
+func (f *sharedInformerFactory) InformersWithHandlers() []{{.schemaGroupVersionResource|raw}} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var resources []{{.schemaGroupVersionResource|raw}}
+	for informerType, count := range f.handlerCounts {
+		if resource, ok := resourceForType(informerType); ok && count > 0 {
+			resources = append(resources, resource)
+		}
+	}
+	{{.slicesSortFunc|raw}}(resources, func(a, b {{.schemaGroupVersionResource|raw}}) int {
+		return {{.stringsCompare|raw}}(a.String(), b.String())
+	})
+	return resources
+}
+`
+
+var sharedInformerFactoryConsumers = `
+func (f *sharedInformerFactory) RegisterConsumer(resource {{.schemaGroupVersionResource|raw}}, name string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.consumers == nil {
+		f.consumers = make(map[{{.schemaGroupVersionResource|raw}}][]string)
+	}
+	if !{{.slicesContains|raw}}(f.consumers[resource], name) {
+		f.consumers[resource] = append(f.consumers[resource], name)
+	}
+}
+
+func (f *sharedInformerFactory) DependencyGraph() map[{{.schemaGroupVersionResource|raw}}][]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	graph := make(map[{{.schemaGroupVersionResource|raw}}][]string, len(f.consumers))
+	for resource, names := range f.consumers {
+		graph[resource] = append([]string(nil), names...)
+	}
+	return graph
+}
+`
+
+var sharedInformerFactorySnapshot = `
+// cacheSnapshot is a snapshot passed to WithCacheSnapshot.
+type cacheSnapshot struct {
+	snapshot {{.ioReader|raw}}
+	// codec decodes snapshot. It is nil for JSON.
+	codec {{.runtimeCodec|raw}}
+}
+
+// CheckInformerCreate consults the informer create hook for obj's type and
+// records its error, if any, so that the informer is never started. It is
+// called by InformerFor while f.lock is held.
+func (f *sharedInformerFactory) CheckInformerCreate(obj {{.runtimeObject|raw}}) {
+	if f.informerCreateHook == nil {
+		return
+	}
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	resource, _ := resourceForType(informerType)
+	if err := f.informerCreateHook(resource); err != nil {
+		f.vetoedInformers[informerType] = {{.fmtErrorf|raw}}("the %v informer was vetoed: %w", resource, err)
+	}
+}
+
+// InvalidObject is an object rejected by the validator of WithIngestValidator.
+type InvalidObject struct {
+	// Resource is the resource of the object.
+	Resource {{.schemaGroupVersionResource|raw}}
+	// Key is the namespace/name key of the object.
+	Key string
+	// Err is the error returned by the validator.
+	Err error
+}
+
+func (f *sharedInformerFactory) InvalidObjects() []InvalidObject {
+	var invalid []InvalidObject
+	for resource, validator := range f.ingestValidators {
+		for key, err := range validator.Invalid() {
+			invalid = append(invalid, InvalidObject{Resource: resource, Key: key, Err: err})
+		}
+	}
+	{{.slicesSortFunc|raw}}(invalid, func(a, b InvalidObject) int {
+		if c := {{.stringsCompare|raw}}(a.Resource.String(), b.Resource.String()); c != 0 {
+			return c
+		}
+		return {{.stringsCompare|raw}}(a.Key, b.Key)
+	})
+	return invalid
+}
+
+func (f *sharedInformerFactory) RetweakInformer(resource {{.schemaGroupVersionResource|raw}}, tweak {{.interfacesTweakListOptionsFunc|raw}}) error {
+	f.lock.Lock()
+	retweaker := f.retweakers[resource]
+	f.lock.Unlock()
+	if retweaker == nil {
+		return {{.fmtErrorf|raw}}("no informer for %v was requested from the factory", resource)
+	}
+	retweaker.Retweak(tweak)
+	return nil
+}
+
+// PanicHandler returns the function to pass the recovered panics of event
+// handlers of the informer for obj's type to, or nil if panics are not
+// recovered.
+func (f *sharedInformerFactory) PanicHandler(obj {{.runtimeObject|raw}}) func(recovered interface{}) {
+	if f.panicHandler == nil {
+		return nil
+	}
+	resource, _ := resourceForType({{.reflectTypeOf|raw}}(obj))
+	return func(recovered interface{}) {
+		f.panicHandler(resource, recovered)
+	}
+}
+
+func (f *sharedInformerFactory) SnapshotCache(resource {{.schemaGroupVersionResource|raw}}, w {{.ioWriter|raw}}, codec {{.runtimeCodec|raw}}) error {
+	f.lock.Lock()
+	var informer {{.cacheSharedIndexInformer|raw}}
+	for informerType, i := range f.informers {
+		if r, ok := resourceForType(informerType); ok && r == resource {
+			informer = i
+			break
+		}
+	}
+	f.lock.Unlock()
+
+	if informer == nil {
+		return {{.fmtErrorf|raw}}("no informer was requested for %v", resource)
+	}
+	if !informer.HasSynced() {
+		return {{.fmtErrorf|raw}}("the informer for %v has not synced", resource)
+	}
+	// The resource version is read before the objects are listed, so that a
+	// watch started from it replays any change made while they are listed.
+	resourceVersion := informer.LastSyncResourceVersion()
+	if codec != nil {
+		// Codecs encode the typed list, which they decode into again.
+		list, ok := newListForResource(resource)
+		if !ok {
+			return {{.fmtErrorf|raw}}("no list type is known for %v", resource)
+		}
+		var items []{{.runtimeObject|raw}}
+		for _, obj := range informer.GetStore().List() {
+			items = append(items, obj.({{.runtimeObject|raw}}))
+		}
+		if err := {{.metaSetList|raw}}(list, items); err != nil {
+			return err
+		}
+		listMeta, err := {{.metaListAccessor|raw}}(list)
+		if err != nil {
+			return err
+		}
+		listMeta.SetResourceVersion(resourceVersion)
+		return codec.Encode(list, w)
+	}
+	list := &{{.metav1List|raw}}{ListMeta: {{.metav1ListMeta|raw}}{ResourceVersion: resourceVersion}}
+	for _, obj := range informer.GetStore().List() {
+		data, err := {{.jsonMarshal|raw}}(obj)
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, {{.runtimeRawExtension|raw}}{Raw: data})
+	}
+	return {{.jsonNewEncoder|raw}}(w).Encode(list)
+}
+`
+
+var sharedInformerFactoryLatency = `
+// latencyHistogram returns the histogram observing the event processing
+// latency of the informer for informerType, or nil.
+func (f *sharedInformerFactory) latencyHistogram(informerType {{.reflectType|raw}}) {{.cacheHistogramMetric|raw}} {
+	resource, ok := resourceForType(informerType)
+	if !ok {
+		return nil
+	}
+	if histogram := f.latencyHistograms[resource]; histogram != nil {
+		return histogram
+	}
+{{- if .prometheusCollector}}
+	if f.latencyObserverVec != nil {
+		return f.latencyObserverVec.WithLabelValues(resource.Group, resource.Version, resource.Resource)
+	}
+{{- end}}
+	return nil
+}
+
+// latencyObservingInformer observes the event processing latency of the
+// handlers added to it.
+type latencyObservingInformer struct {
+	{{.cacheSharedIndexInformer|raw}}
+	factory   *sharedInformerFactory
+	histogram {{.cacheHistogramMetric|raw}}
+}
+
+func (i *latencyObservingInformer) AddEventHandler(handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandler(&latencyObservingHandler{informer: i, handler: handler})
+}
+
+func (i *latencyObservingInformer) AddEventHandlerWithResyncPeriod(handler {{.cacheResourceEventHandler|raw}}, resyncPeriod {{.timeDuration|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(&latencyObservingHandler{informer: i, handler: handler}, resyncPeriod)
+}
+
+func (i *latencyObservingInformer) AddEventHandlerWithOptions(handler {{.cacheResourceEventHandler|raw}}, options {{.cacheHandlerOptions|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithOptions(&latencyObservingHandler{informer: i, handler: handler}, options)
+}
+
+// receiveTime returns the time at which the latest change of obj was received
+// by the informer, or the current time if it is unknown.
+func (i *latencyObservingInformer) receiveTime(obj interface{}) {{.timeTime|raw}} {
+	if tombstone, ok := obj.({{.cacheDeletedFinalStateUnknown|raw}}); ok {
+		obj = tombstone.Obj
+	}
+	if accessor, err := {{.metaAccessor|raw}}(obj); err == nil && accessor.GetUID() != "" {
+		if received, ok := i.factory.IngestTime(accessor); ok {
+			return received
+		}
+	}
+	return {{.timeNow|raw}}()
+}
+
+func (i *latencyObservingInformer) observe(start {{.timeTime|raw}}) {
+	i.histogram.Observe({{.timeNow|raw}}().Sub(start).Seconds())
+}
+
+// latencyObservingHandler wraps an event handler to observe its latency.
+type latencyObservingHandler struct {
+	informer *latencyObservingInformer
+	handler  {{.cacheResourceEventHandler|raw}}
+}
+
+func (h *latencyObservingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	start := h.informer.receiveTime(obj)
+	h.handler.OnAdd(obj, isInInitialList)
+	h.informer.observe(start)
+}
+
+func (h *latencyObservingHandler) OnUpdate(oldObj, newObj interface{}) {
+	start := {{.timeNow|raw}}()
+	// Resyncs deliver the cached object again, so its ingest time is stale.
+	oldAccessor, oldErr := {{.metaAccessor|raw}}(oldObj)
+	newAccessor, newErr := {{.metaAccessor|raw}}(newObj)
+	if oldErr != nil || newErr != nil || oldAccessor.GetResourceVersion() != newAccessor.GetResourceVersion() {
+		start = h.informer.receiveTime(newObj)
+	}
+	h.handler.OnUpdate(oldObj, newObj)
+	h.informer.observe(start)
+}
+
+func (h *latencyObservingHandler) OnDelete(obj interface{}) {
+	start := h.informer.receiveTime(obj)
+	h.handler.OnDelete(obj)
+	h.informer.observe(start)
+}
+`
+
+var sharedInformerFactoryEquality = `
+// EqualityFunc returns true if newObj is equal to oldObj, in which case the
+// update from oldObj to newObj is not delivered to event handlers.
+type EqualityFunc func(oldObj, newObj {{.runtimeObject|raw}}) bool
+
+// dedupingInformer drops the updates which its equality function considers
+// equal before they reach the handlers added to it.
+type dedupingInformer struct {
+	{{.cacheSharedIndexInformer|raw}}
+	equal EqualityFunc
+}
+
+func (i *dedupingInformer) AddEventHandler(handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandler(&dedupingHandler{equal: i.equal, handler: handler})
+}
+
+func (i *dedupingInformer) AddEventHandlerWithResyncPeriod(handler {{.cacheResourceEventHandler|raw}}, resyncPeriod {{.timeDuration|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(&dedupingHandler{equal: i.equal, handler: handler}, resyncPeriod)
+}
+
+func (i *dedupingInformer) AddEventHandlerWithOptions(handler {{.cacheResourceEventHandler|raw}}, options {{.cacheHandlerOptions|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithOptions(&dedupingHandler{equal: i.equal, handler: handler}, options)
+}
+
+// dedupingHandler wraps an event handler to drop equal updates.
+type dedupingHandler struct {
+	equal   EqualityFunc
+	handler {{.cacheResourceEventHandler|raw}}
+}
+
+func (h *dedupingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.handler.OnAdd(obj, isInInitialList)
+}
+
+func (h *dedupingHandler) OnUpdate(oldObj, newObj interface{}) {
+	oldObject, oldOK := oldObj.({{.runtimeObject|raw}})
+	newObject, newOK := newObj.({{.runtimeObject|raw}})
+	if oldOK && newOK && h.equal(oldObject, newObject) {
+		return
+	}
+	h.handler.OnUpdate(oldObj, newObj)
+}
+
+func (h *dedupingHandler) OnDelete(obj interface{}) {
+	h.handler.OnDelete(obj)
+}
+`
+
+var sharedInformerFactoryMemoryBudget = `
+// memoryBudgetStages are the stages of stripping applied by a memoryBudget.
+// Each stage also applies the stages before it.
+var memoryBudgetStages = []func({{.object|raw}}){
+	func(obj {{.object|raw}}) { obj.SetManagedFields(nil) },
+	func(obj {{.object|raw}}) { obj.SetAnnotations(nil) },
+}
+
+// memoryBudget tracks the estimated sizes of the objects in the informer
+// caches of a factory and strips fields from new objects when they exceed the
+// limit.
+type memoryBudget struct {
+	limit int64
+
+	lock  {{.syncMutex|raw}}
+	used  int64
+	sizes map[{{.typesUID|raw}}]int64
+	// stage is the number of memoryBudgetStages applied to new objects.
+	stage int
+}
+
+// admit strips obj according to the current stage and accounts for its size.
+// If obj does not fit in the budget, the stage is escalated and obj is
+// stripped further, until it fits or all stages are applied.
+func (b *memoryBudget) admit(obj {{.object|raw}}) {
+	b.lock.Lock()
+	stage := b.stage
+	b.lock.Unlock()
+	for applied := 0; ; {
+		for ; applied < stage; applied++ {
+			memoryBudgetStages[applied](obj)
+		}
+		if obj.GetUID() == "" {
+			return
+		}
+		data, err := {{.jsonMarshal|raw}}(obj)
+		if err != nil {
+			return
+		}
+
+		b.lock.Lock()
+		used := b.used + int64(len(data)) - b.sizes[obj.GetUID()]
+		if used > b.limit && applied < len(memoryBudgetStages) {
+			b.stage = max(b.stage, applied+1)
+			stage = b.stage
+			b.lock.Unlock()
+			continue
+		}
+		b.used = used
+		b.sizes[obj.GetUID()] = int64(len(data))
+		b.relax()
+		b.lock.Unlock()
+		return
+	}
+}
+
+// relax lowers the stage by one if the used size is below the low-water mark
+// of three quarters of the limit. b.lock must be held.
+func (b *memoryBudget) relax() {
+	if b.stage > 0 && b.used < b.limit-b.limit/4 {
+		b.stage--
+	}
+}
+
+// forget drops the size of a deleted object.
+func (b *memoryBudget) forget(obj interface{}) {
+	if tombstone, ok := obj.({{.cacheDeletedFinalStateUnknown|raw}}); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := {{.metaAccessor|raw}}(obj)
+	if err != nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.used -= b.sizes[accessor.GetUID()]
+	delete(b.sizes, accessor.GetUID())
+	b.relax()
+}
+`
+
+var sharedInformerFactoryRBACPrecheck = `
+// precheckInformers reviews the access to the resources of the informers
+// which were not reviewed yet, if WithRBACPrecheck was used. The reviews are
+// made without holding f.lock.
+func (f *sharedInformerFactory) precheckInformers(ctx {{.contextContext|raw}}) {
+	if f.rbacPrecheck == nil {
+		return
+	}
+	f.lock.Lock()
+	resources := map[{{.reflectType|raw}}]{{.schemaGroupVersionResource|raw}}{}
+	for informerType := range f.informers {
+		if resource, ok := resourceForType(informerType); ok && !f.precheckedInformers[informerType] {
+			resources[informerType] = resource
+		}
+	}
+	f.lock.Unlock()
+
+	for informerType, resource := range resources {
+		reason, err := f.reviewAccess(ctx, resource)
+		if err != nil {
+			{{.utilruntimeHandleError|raw}}({{.fmtErrorf|raw}}("failed to review the access to %v, starting its informer anyway: %w", resource, err))
+		}
+		f.lock.Lock()
+		if f.precheckedInformers == nil {
+			f.precheckedInformers = make(map[{{.reflectType|raw}}]bool)
+			f.skippedInformers = make(map[{{.reflectType|raw}}]string)
+		}
+		f.precheckedInformers[informerType] = true
+		if reason != "" {
+			f.skippedInformers[informerType] = reason
+		}
+		f.lock.Unlock()
+	}
+}
+
+// reviewAccess returns why resource may not be listed or watched in the
+// namespaces of the factory, or an empty string if it may be. Cluster-scoped
+// resources are reviewed in all namespaces, and namespaced resources in each
+// namespace of WithNamespaceSelectors if it was used.
+func (f *sharedInformerFactory) reviewAccess(ctx {{.contextContext|raw}}, resource {{.schemaGroupVersionResource|raw}}) (string, error) {
+	namespaces := []string{f.namespace}
+	selected := false
+	if isClusterScoped(resource) {
+		namespaces = []string{ {{- .namespaceAll|raw -}} }
+	} else if f.namespaceSelectors != nil {
+		namespaces, selected = nil, true
+		for namespace := range f.namespaceSelectors {
+			namespaces = append(namespaces, namespace)
+		}
+		{{.slicesSortFunc|raw}}(namespaces, {{.stringsCompare|raw}})
+	}
+	for _, namespace := range namespaces {
+		for _, verb := range []string{"list", "watch"} {
+			review := &{{.authzSelfSubjectAccessReview|raw}}{
+				Spec: {{.authzSelfSubjectAccessReviewSpec|raw}}{
+					ResourceAttributes: &{{.authzResourceAttributes|raw}}{
+						Namespace: namespace,
+						Verb:      verb,
+						Group:     resource.Group,
+						Version:   resource.Version,
+						Resource:  resource.Resource,
+					},
+				},
+			}
+			result, err := f.rbacPrecheck.SelfSubjectAccessReviews().Create(ctx, review, {{.metav1CreateOptions|raw}}{})
+			if err != nil {
+				return "", err
+			}
+			if !result.Status.Allowed {
+				reason := verb + " is not allowed"
+				if selected {
+					reason += " in namespace " + namespace
+				}
+				if result.Status.Reason != "" {
+					reason += ": " + result.Status.Reason
+				}
+				return reason, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (f *sharedInformerFactory) SkippedInformers() map[{{.schemaGroupVersionResource|raw}}]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	skipped := make(map[{{.schemaGroupVersionResource|raw}}]string, len(f.skippedInformers))
+	for informerType, reason := range f.skippedInformers {
+		resource, _ := resourceForType(informerType)
+		skipped[resource] = reason
+	}
+	return skipped
+}
+`
+
+var sharedInformerFactoryStalenessWatchdog = `
+// stalenessWatchdog reports an informer which delivered no event for longer
+// than maxStaleness.
+type stalenessWatchdog struct {
+	maxStaleness {{.timeDuration|raw}}
+	onStale      func({{.schemaGroupVersionResource|raw}})
+
+	lock      {{.syncMutex|raw}}
+	lastEvent {{.timeTime|raw}}
+}
+
+func (w *stalenessWatchdog) observe() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lastEvent = {{.timeNow|raw}}()
+}
+
+// handler returns an event handler recording the time of every event.
+func (w *stalenessWatchdog) handler() {{.cacheResourceEventHandlerFuncs|raw}} {
+	return {{.cacheResourceEventHandlerFuncs|raw}}{
+		AddFunc:    func(interface{}) { w.observe() },
+		UpdateFunc: func(_, _ interface{}) { w.observe() },
+		DeleteFunc: func(interface{}) { w.observe() },
+	}
+}
+
+// run calls onStale with resource after every window of maxStaleness without
+// events until ctx is canceled.
+func (w *stalenessWatchdog) run(ctx {{.contextContext|raw}}, resource {{.schemaGroupVersionResource|raw}}) {
+	w.observe()
+	timer := {{.timeNewTimer|raw}}(w.maxStaleness)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		w.lock.Lock()
+		now := {{.timeNow|raw}}()
+		idle := now.Sub(w.lastEvent)
+		if idle >= w.maxStaleness {
+			w.lastEvent = now
+		}
+		w.lock.Unlock()
+		if idle >= w.maxStaleness {
+			w.onStale(resource)
+			timer.Reset(w.maxStaleness)
+		} else {
+			timer.Reset(w.maxStaleness - idle)
+		}
+	}
+}
+`
+
+var sharedInformerFactoryResync = `
+func (f *sharedInformerFactory) AddResyncHandler(obj {{.runtimeObject|raw}}, handler {{.cacheResourceEventHandler|raw}}) ({{.cacheResourceEventHandlerRegistration|raw}}, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return nil, {{.fmtErrorf|raw}}("no informer for %T was requested from the factory", obj)
+	}
+	wrapped := &resyncableHandler{handler: handler}
+	registration, err := informer.AddEventHandler(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if f.resyncHandlers == nil {
+		f.resyncHandlers = make(map[{{.reflectType|raw}}]map[{{.cacheResourceEventHandlerRegistration|raw}}]*resyncableHandler)
+	}
+	if f.resyncHandlers[informerType] == nil {
+		f.resyncHandlers[informerType] = make(map[{{.cacheResourceEventHandlerRegistration|raw}}]*resyncableHandler)
+	}
+	f.resyncHandlers[informerType][registration] = wrapped
+	return registration, nil
+}
+
+func (f *sharedInformerFactory) RemoveResyncHandler(obj {{.runtimeObject|raw}}, registration {{.cacheResourceEventHandlerRegistration|raw}}) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return {{.fmtErrorf|raw}}("no informer for %T was requested from the factory", obj)
+	}
+	delete(f.resyncHandlers[informerType], registration)
+	return informer.RemoveEventHandler(registration)
+}
+
+func (f *sharedInformerFactory) Resync(obj {{.runtimeObject|raw}}) error {
+	informer, handlers, err := f.resyncTarget(obj)
+	if err != nil {
+		return err
+	}
+	objs := informer.GetStore().List()
+	for _, handler := range handlers {
+		handler.resync(objs)
+	}
+	return nil
+}
+
+func (f *sharedInformerFactory) ReplayKey(obj {{.runtimeObject|raw}}, key string) error {
+	informer, handlers, err := f.resyncTarget(obj)
+	if err != nil {
+		return err
+	}
+	item, exists, err := informer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return {{.fmtErrorf|raw}}("%q is not in the cache of the informer for %T", key, obj)
+	}
+	for _, handler := range handlers {
+		handler.resync([]interface{}{item})
+	}
+	return nil
+}
+
+// resyncTarget returns the synced informer for obj's type and the handlers
+// added to it through AddResyncHandler.
+func (f *sharedInformerFactory) resyncTarget(obj {{.runtimeObject|raw}}) ({{.cacheSharedIndexInformer|raw}}, []*resyncableHandler, error) {
+	f.lock.Lock()
+	informerType := {{.reflectTypeOf|raw}}(obj)
+	informer, exists := f.informers[informerType]
+	handlers := make([]*resyncableHandler, 0, len(f.resyncHandlers[informerType]))
+	for _, handler := range f.resyncHandlers[informerType] {
+		handlers = append(handlers, handler)
+	}
+	f.lock.Unlock()
+
+	if !exists {
+		return nil, nil, {{.fmtErrorf|raw}}("no informer for %T was requested from the factory", obj)
+	}
+	if !informer.HasSynced() {
+		return nil, nil, {{.fmtErrorf|raw}}("the informer for %T has not synced", obj)
+	}
+	return informer, handlers, nil
+}
+
+// resyncableHandler serializes the notifications of an event handler with
+// the ones of Resync.
+type resyncableHandler struct {
+	lock    {{.syncMutex|raw}}
+	handler {{.cacheResourceEventHandler|raw}}
+}
+
+func (h *resyncableHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnAdd(obj, isInInitialList)
+}
+
+func (h *resyncableHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnUpdate(oldObj, newObj)
+}
+
+func (h *resyncableHandler) OnDelete(obj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnDelete(obj)
+}
+
+func (h *resyncableHandler) resync(objs []interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, obj := range objs {
+		h.handler.OnUpdate(obj, obj)
+	}
+}
+`
+
+var sharedInformerFactoryWaitForCondition = `
+func (f *sharedInformerFactory) WaitForCondition(ctx {{.contextContext|raw}}, obj {{.runtimeObject|raw}}, pred func(store {{.cacheStore|raw}}) bool) error {
+	f.lock.Lock()
+	informer, exists := f.informers[{{.reflectTypeOf|raw}}(obj)]
+	f.lock.Unlock()
+	if !exists {
+		return {{.fmtErrorf|raw}}("no informer for %T was requested from the factory", obj)
+	}
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	registration, err := informer.AddEventHandler({{.cacheResourceEventHandlerFuncs|raw}}{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = informer.RemoveEventHandler(registration)
+	}()
+
+	for {
+		if pred(informer.GetStore()) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return {{.contextCause|raw}}(ctx)
+		case <-changed:
+		}
+	}
+}
+`
+
+var compositeFactory = `
+// CompositeFactory drives the lifecycles of several factories as one, for
+// example the SharedInformerFactories generated for different APIs. It is a
+// Startable itself, so composites can be nested.
+type CompositeFactory struct {
+	factories []{{.interfacesStartable|raw}}
+}
+
+var _ {{.interfacesStartable|raw}} = &CompositeFactory{}
+var _ {{.interfacesStartable|raw}} = &sharedInformerFactory{}
+
+// NewCompositeFactory returns a CompositeFactory of factories.
+func NewCompositeFactory(factories ...{{.interfacesStartable|raw}}) *CompositeFactory {
+	return &CompositeFactory{factories: factories}
+}
+
+// StartWithContext starts the requested informers of all factories, in order.
+func (c *CompositeFactory) StartWithContext(ctx {{.contextContext|raw}}) {
+	for _, factory := range c.factories {
+		factory.StartWithContext(ctx)
+	}
+}
+
+// WaitForCacheSyncWithContext waits for the caches of the started informers of
+// all factories to sync and merges the results. Err is the error of the first
+// factory whose caches did not sync.
+func (c *CompositeFactory) WaitForCacheSyncWithContext(ctx {{.contextContext|raw}}) {{.cacheSyncResult|raw}} {
+	result := {{.cacheSyncResult|raw}}{Synced: map[{{.reflectType|raw}}]bool{}}
+	for _, factory := range c.factories {
+		factoryResult := factory.WaitForCacheSyncWithContext(ctx)
+		for informerType, synced := range factoryResult.Synced {
+			result.Synced[informerType] = synced
+		}
+		if result.Err == nil {
+			result.Err = factoryResult.Err
+		}
+	}
+	return result
+}
+
+// Shutdown shuts all factories down, in reverse order, and blocks until all
+// their goroutines have terminated.
+func (c *CompositeFactory) Shutdown() {
+	for i := len(c.factories) - 1; i >= 0; i-- {
+		c.factories[i].Shutdown()
+	}
+}
+`
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/v2/types"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+)
+
+func TestFactoryGolden(t *testing.T) {
+	c := goldenContext(t, goldenPackage)
+	gv := clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}
+	groupVersions := map[string]clientgentypes.GroupVersions{
+		"example": {PackageName: "example", Group: gv.Group, Versions: []clientgentypes.PackageVersion{{Version: gv.Version, Package: goldenPackage}}},
+	}
+	typesForGroupVersion := map[clientgentypes.GroupVersion][]*types.Type{
+		gv: {
+			c.Universe.Type(types.Name{Package: goldenPackage, Name: "Labeled"}),
+			c.Universe.Type(types.Name{Package: goldenPackage, Name: "Unlabeled"}),
+		},
+	}
+	dir := filepath.Join(t.TempDir(), "informers")
+	target := factoryTarget(dir, "example.com/informers", []byte("// boilerplate\n"), map[string]string{"example": "Example"}, nil, groupVersions, "example.com/clientset", typesForGroupVersion, false)
+	if err := c.ExecuteTarget(target); err != nil {
+		t.Fatal(err)
+	}
+
+	checkGolden(t, filepath.Join(dir, "factory.go"), filepath.Join("testdata", "factory.go.golden"))
+	checkGolden(t, filepath.Join(dir, "generic.go"), filepath.Join("testdata", "generic.go.golden"))
+
+	generic, err := os.ReadFile(filepath.Join(dir, "generic.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := string(generic[strings.Index(string(generic), "var InformerConstructors"):])
+	for _, resource := range []string{"labeleds", "unlabeleds"} {
+		if !strings.Contains(registry, `WithResource("`+resource+`"): func(f SharedInformerFactory)`) {
+			t.Errorf("expected %s in InformerConstructors", resource)
+		}
+	}
+}
+
+func TestFactoryPrometheusCollector(t *testing.T) {
+	c := goldenContext(t, goldenPackage)
+	gv := clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}
+	groupVersions := map[string]clientgentypes.GroupVersions{
+		"example": {PackageName: "example", Group: gv.Group, Versions: []clientgentypes.PackageVersion{{Version: gv.Version, Package: goldenPackage}}},
+	}
+	typesForGroupVersion := map[clientgentypes.GroupVersion][]*types.Type{
+		gv: {c.Universe.Type(types.Name{Package: goldenPackage, Name: "Labeled"})},
+	}
+	dir := filepath.Join(t.TempDir(), "informers")
+	target := factoryTarget(dir, "example.com/informers", []byte("// boilerplate\n"), map[string]string{"example": "Example"}, nil, groupVersions, "example.com/clientset", typesForGroupVersion, true)
+	if err := c.ExecuteTarget(target); err != nil {
+		t.Fatal(err)
+	}
+
+	factory, err := os.ReadFile(filepath.Join(dir, "factory.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"github.com/prometheus/client_golang/prometheus"`,
+		"\n\tSkippedInformers() map[schema.GroupVersionResource]string\n\n\t// MetricsCollector returns",
+		"\n\tMetricsCollector() *MetricsCollector\n\n\t// InformersWithHandlers",
+		"var _ prometheus.Collector = &MetricsCollector{}",
+		"func (c *MetricsCollector) Collect(ch chan<- prometheus.Metric) {",
+	} {
+		if !strings.Contains(string(factory), want) {
+			t.Errorf("expected %q in factory.go", want)
+		}
+	}
+}
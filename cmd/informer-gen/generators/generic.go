@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strings"
 
+	"k8s.io/code-generator/cmd/client-gen/generators/util"
 	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
 	codegennamer "k8s.io/code-generator/pkg/namer"
 	"k8s.io/gengo/v2/generator"
@@ -82,6 +83,8 @@ type version struct {
 	Name      string
 	GoName    string
 	Resources []*types.Type
+	// ClusterScoped holds the resources whose objects are not namespaced.
+	ClusterScoped []*types.Type
 }
 
 type versionSort []*version
@@ -97,6 +100,7 @@ func (g *genericGenerator) GenerateType(c *generator.Context, t *types.Type, w i
 
 	groups := []group{}
 	schemeGVs := make(map[*version]*types.Type)
+	listTypes := make(map[*types.Type]*types.Type)
 
 	orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
 	for groupPackageName, groupVersions := range g.groupVersions {
@@ -115,6 +119,16 @@ func (g *genericGenerator) GenerateType(c *generator.Context, t *types.Type, w i
 			func() {
 				schemeGVs[version] = c.Universe.Variable(types.Name{Package: g.typesForGroupVersion[gv][0].Name.Package, Name: "SchemeGroupVersion"})
 			}()
+			for _, t := range version.Resources {
+				listTypes[t] = c.Universe.Type(types.Name{Package: t.Name.Package, Name: t.Name.Name + "List"})
+				tags, err := util.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+				if err != nil {
+					return err
+				}
+				if tags.NonNamespaced {
+					version.ClusterScoped = append(version.ClusterScoped, t)
+				}
+			}
 			group.Versions = append(group.Versions, version)
 		}
 		sort.Sort(versionSort(group.Versions))
@@ -128,6 +142,10 @@ func (g *genericGenerator) GenerateType(c *generator.Context, t *types.Type, w i
 		"cacheSharedIndexInformer":   c.Universe.Type(cacheSharedIndexInformer),
 		"fmtErrorf":                  c.Universe.Type(fmtErrorfFunc),
 		"groups":                     groups,
+		"reflectType":                c.Universe.Type(reflectType),
+		"listTypes":                  listTypes,
+		"reflectTypeOf":              c.Universe.Function(reflectTypeOfFunc),
+		"runtimeObject":              c.Universe.Type(runtimeObject),
 		"schemeGVs":                  schemeGVs,
 		"schemaGroupResource":        c.Universe.Type(schemaGroupResource),
 		"schemaGroupVersionResource": c.Universe.Type(schemaGroupVersionResource),
@@ -135,6 +153,10 @@ func (g *genericGenerator) GenerateType(c *generator.Context, t *types.Type, w i
 
 	sw.Do(genericInformer, m)
 	sw.Do(forResource, m)
+	sw.Do(resourceForType, m)
+	sw.Do(isClusterScoped, m)
+	sw.Do(newListForResource, m)
+	sw.Do(informerConstructors, m)
 
 	return sw.Error()
 }
@@ -182,3 +204,86 @@ func (f *sharedInformerFactory) ForResource(resource {{.schemaGroupVersionResour
 	return nil, {{.fmtErrorf|raw}}("no informer found for %v", resource)
 }
 `
+
+var resourceForType = `
+// resourceForType returns the resource served by the informers for informerType.
+func resourceForType(informerType {{.reflectType|raw}}) ({{.schemaGroupVersionResource|raw}}, bool) {
+	switch informerType {
+		{{range $group := .groups -}}
+			{{range $version := .Versions -}}
+	// Group={{$group.Name}}, Version={{.Name}}
+				{{range .Resources -}}
+	case {{$.reflectTypeOf|raw}}(&{{.|raw}}{}):
+		return {{index $.schemeGVs $version|raw}}.WithResource("{{.|resource}}"), true
+				{{end}}
+			{{end}}
+		{{end -}}
+	}
+
+	return {{.schemaGroupVersionResource|raw}}{}, false
+}
+`
+
+var isClusterScoped = `
+// isClusterScoped returns whether the objects of resource are not namespaced.
+func isClusterScoped(resource {{.schemaGroupVersionResource|raw}}) bool {
+	switch resource {
+{{- range $group := .groups}}{{range $version := .Versions}}{{range .ClusterScoped}}
+	case {{index $.schemeGVs $version|raw}}.WithResource("{{.|resource}}"):
+		return true
+{{- end}}{{end}}{{end}}
+	}
+	return false
+}
+`
+
+var newListForResource = `
+// newListForResource returns an empty list of the type which holds the objects
+// of resource.
+func newListForResource(resource {{.schemaGroupVersionResource|raw}}) ({{.runtimeObject|raw}}, bool) {
+	switch resource {
+		{{range $group := .groups -}}
+			{{range $version := .Versions -}}
+	// Group={{$group.Name}}, Version={{.Name}}
+				{{range .Resources -}}
+	case {{index $.schemeGVs $version|raw}}.WithResource("{{.|resource}}"):
+		return &{{index $.listTypes .|raw}}{}, true
+				{{end}}
+			{{end}}
+		{{end -}}
+	}
+
+	return nil, false
+}
+`
+
+var informerConstructors = `
+// InformerConstructors holds, for every resource of the factory, the function
+// which returns the shared informer of the resource from a factory. The error
+// is the one of the informer create hook, if it vetoed the informer.
+var InformerConstructors = map[{{.schemaGroupVersionResource|raw}}]func(SharedInformerFactory) ({{.cacheSharedIndexInformer|raw}}, error){
+	{{range $group := .groups -}}{{$GroupGoName := .GroupGoName -}}
+		{{range $version := .Versions -}}
+	// Group={{$group.Name}}, Version={{.Name}}
+			{{range .Resources -}}
+	{{index $.schemeGVs $version|raw}}.WithResource("{{.|resource}}"): func(f SharedInformerFactory) ({{$.cacheSharedIndexInformer|raw}}, error) {
+		informer := f.{{$GroupGoName}}().{{$version.GoName}}().{{.|publicPlural}}().Informer()
+		return informer, vetoError(f, &{{.|raw}}{})
+	},
+			{{end}}
+		{{end}}
+	{{end -}}
+}
+
+// vetoError returns the error of the informer create hook of f for the
+// informer of obj's type, if it vetoed the informer.
+func vetoError(f SharedInformerFactory, obj {{.runtimeObject|raw}}) error {
+	factory, ok := f.(*sharedInformerFactory)
+	if !ok {
+		return nil
+	}
+	factory.lock.Lock()
+	defer factory.lock.Unlock()
+	return factory.vetoedInformers[{{.reflectTypeOf|raw}}(obj)]
+}
+`
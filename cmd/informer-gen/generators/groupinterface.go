@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+	codegennamer "k8s.io/code-generator/pkg/namer"
 	"k8s.io/gengo/v2/generator"
 	"k8s.io/gengo/v2/namer"
 	"k8s.io/gengo/v2/types"
@@ -33,6 +34,8 @@ type groupInterfaceGenerator struct {
 	outputPackage             string
 	imports                   namer.ImportTracker
 	groupVersions             clientgentypes.GroupVersions
+	pluralExceptions          map[string]string
+	typesForGroupVersion      map[clientgentypes.GroupVersion][]*types.Type
 	filtered                  bool
 	internalInterfacesPackage string
 }
@@ -49,7 +52,9 @@ func (g *groupInterfaceGenerator) Filter(c *generator.Context, t *types.Type) bo
 
 func (g *groupInterfaceGenerator) Namers(c *generator.Context) namer.NameSystems {
 	return namer.NameSystems{
-		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+		"raw":          namer.NewRawNamer(g.outputPackage, g.imports),
+		"publicPlural": namer.NewPublicPluralNamer(g.pluralExceptions),
+		"resource":     codegennamer.NewTagOverrideNamer("resourceName", namer.NewAllLowercasePluralNamer(g.pluralExceptions)),
 	}
 }
 
@@ -64,10 +69,18 @@ type versionData struct {
 	New       *types.Type
 }
 
+type resourceData struct {
+	Version            string
+	SchemeGroupVersion *types.Type
+	Type               *types.Type
+}
+
 func (g *groupInterfaceGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 
+	orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
 	versions := make([]versionData, 0, len(g.groupVersions.Versions))
+	var resources []resourceData
 	for _, version := range g.groupVersions.Versions {
 		gv := clientgentypes.GroupVersion{Group: g.groupVersions.Group, Version: version.Version}
 		versionPackage := path.Join(g.outputPackage, strings.ToLower(gv.Version.NonEmpty()))
@@ -77,11 +90,27 @@ func (g *groupInterfaceGenerator) GenerateType(c *generator.Context, t *types.Ty
 			Interface: iface,
 			New:       c.Universe.Function(types.Name{Package: versionPackage, Name: "New"}),
 		})
+		for _, t := range orderer.OrderTypes(g.typesForGroupVersion[gv]) {
+			resources = append(resources, resourceData{
+				Version:            namer.IC(version.Version.NonEmpty()),
+				SchemeGroupVersion: c.Universe.Variable(types.Name{Package: t.Name.Package, Name: "SchemeGroupVersion"}),
+				Type:               t,
+			})
+		}
 	}
 	m := map[string]interface{}{
-		"interfacesTweakListOptionsFunc":  c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
-		"interfacesSharedInformerFactory": c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
-		"versions":                        versions,
+		"cacheResourceEventHandler":  c.Universe.Type(cacheResourceEventHandler),
+		"cacheSharedIndexInformer":   c.Universe.Type(cacheSharedIndexInformer),
+		"fmtErrorf":                  c.Universe.Function(fmtErrorfFunc),
+		"groupName":                  g.groupVersions.Group.String(),
+		"resources":                  resources,
+		"runtimeObject":              c.Universe.Type(runtimeObject),
+		"schemaGroupVersionResource": c.Universe.Type(schemaGroupVersionResource),
+		"interfacesNewPanicRecoveringEventHandler":  c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewPanicRecoveringEventHandler"}),
+		"interfacesTweakListOptionsFunc":            c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
+		"interfacesSharedInformerFactory":           c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
+		"interfacesSharedInformerFactoryExtensions": c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactoryExtensions"}),
+		"versions": versions,
 	}
 
 	sw.Do(groupTemplate, m)
@@ -96,6 +125,14 @@ type Interface interface {
 		// $.Name$ provides access to shared informers for resources in $.Name$.
 		$.Name$() $.Interface|raw$
 	$end$
+	// RegisterHandlers adds each handler to the shared informer of the resource
+	// it is keyed by. If any resource is not part of this group, it returns an
+	// error without adding any handler.
+	RegisterHandlers(handlers map[$.schemaGroupVersionResource|raw$]$.cacheResourceEventHandler|raw$) error
+	// Ready returns true once the informers of this group which were
+	// requested from the factory have all synced. It is true if none were
+	// requested.
+	Ready() bool
 }
 
 type group struct {
@@ -115,4 +152,54 @@ func (g *group) $.Name$() $.Interface|raw$ {
 	return $.New|raw$(g.factory, g.namespace, g.tweakListOptions)
 }
 $end$
+
+// RegisterHandlers adds each handler to the shared informer of the resource
+// it is keyed by.
+func (g *group) RegisterHandlers(handlers map[$.schemaGroupVersionResource|raw$]$.cacheResourceEventHandler|raw$) error {
+	informers := make(map[$.schemaGroupVersionResource|raw$]func() $.cacheSharedIndexInformer|raw$, len(handlers))
+	objects := make(map[$.schemaGroupVersionResource|raw$]$.runtimeObject|raw$, len(handlers))
+	for resource := range handlers {
+		informer, obj, ok := g.informerFor(resource)
+		if !ok {
+			return $.fmtErrorf|raw$("no informer found for %v", resource)
+		}
+		informers[resource] = informer
+		objects[resource] = obj
+	}
+	factory, fromFactory := g.factory.($.interfacesSharedInformerFactoryExtensions|raw$)
+	for resource, handler := range handlers {
+		informer := informers[resource]()
+		if fromFactory {
+			handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, factory.PanicHandler(objects[resource]))
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return $.fmtErrorf|raw$("failed to add event handler for %v: %w", resource, err)
+		}
+		if fromFactory {
+			factory.TrackEventHandler(informer)
+		}
+	}
+	return nil
+}
+
+// Ready returns true once the informers of this group which were requested
+// from the factory have all synced. It is always false for factories which do
+// not implement SharedInformerFactoryExtensions.
+func (g *group) Ready() bool {
+	factory, ok := g.factory.($.interfacesSharedInformerFactoryExtensions|raw$)
+	return ok && factory.GroupSynced("$.groupName$")
+}
+
+// informerFor returns the function returning the shared informer for
+// resource and an object of its type, or false if resource is not part of
+// this group.
+func (g *group) informerFor(resource $.schemaGroupVersionResource|raw$) (func() $.cacheSharedIndexInformer|raw$, $.runtimeObject|raw$, bool) {
+	switch resource {
+	$- range .resources $
+	case $.SchemeGroupVersion|raw$.WithResource("$.Type|resource$"):
+		return g.$.Version$().$.Type|publicPlural$().Informer, &$.Type|raw${}, true
+	$- end $
+	}
+	return nil, nil, false
+}
 `
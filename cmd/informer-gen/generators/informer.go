@@ -19,6 +19,7 @@ package generators
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"k8s.io/gengo/v2/generator"
@@ -43,7 +44,10 @@ type informerGenerator struct {
 	imports                   namer.ImportTracker
 	clientSetPackage          string
 	listersPackage            string
+	applyConfigurationPackage string
+	controllers               bool
 	internalInterfacesPackage string
+	clientAccessors           *clientAccessors
 }
 
 var _ generator.Generator = &informerGenerator{}
@@ -77,41 +81,120 @@ func (g *informerGenerator) GenerateType(c *generator.Context, t *types.Type, w
 		return err
 	}
 
+	clientAccessor, err := g.clientAccessors.accessor(g.groupVersion.Group.String(), clientAccessorData{
+		Group:       namer.IC(g.groupGoName),
+		Version:     namer.IC(g.groupVersion.Version.String()),
+		Plural:      c.Namers["publicPlural"].Name(t),
+		GroupName:   g.groupVersion.Group.String(),
+		VersionName: g.groupVersion.Version.String(),
+	})
+	if err != nil {
+		return err
+	}
+	var labelSelector string
+	if tags.LabelSelector != "" {
+		labelSelector = strconv.Quote(tags.LabelSelector)
+	}
+	if tags.CoResource != "" && !hasMember(t, "Status") {
+		return fmt.Errorf("type %v is tagged +genclient:coResource but has no Status field", t.Name)
+	}
+
 	m := map[string]interface{}{
-		"apiScheme":                                c.Universe.Type(apiScheme),
-		"cacheIndexers":                            c.Universe.Type(cacheIndexers),
-		"cacheListWatch":                           c.Universe.Type(cacheListWatch),
-		"cacheMetaNamespaceIndexFunc":              c.Universe.Function(cacheMetaNamespaceIndexFunc),
-		"cacheNamespaceIndex":                      c.Universe.Variable(cacheNamespaceIndex),
-		"cacheNewSharedIndexInformer":              c.Universe.Function(cacheNewSharedIndexInformer),
-		"cacheNewSharedIndexInformerWithOptions":   c.Universe.Function(cacheNewSharedIndexInformerWithOptions),
-		"cacheSharedIndexInformer":                 c.Universe.Type(cacheSharedIndexInformer),
-		"cacheSharedIndexInformerOptions":          c.Universe.Type(cacheSharedIndexInformerOptions),
-		"cacheToListWatcherWithWatchListSemantics": c.Universe.Function(cacheToListWatcherWithWatchListSemanticsFunc),
-		"cacheInformerName":                        c.Universe.Type(cacheInformerName),
-		"clientSetInterface":                       clientSetInterface,
-		"contextContext":                           c.Universe.Type(contextContext),
-		"contextBackground":                        c.Universe.Function(contextBackgroundFunc),
-		"group":                                    namer.IC(g.groupGoName),
-		"groupName":                                g.groupVersion.Group.String(),
-		"informerFor":                              informerFor,
-		"interfacesInformerOptions":                c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "InformerOptions"}),
-		"interfacesTweakListOptionsFunc":           c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
-		"interfacesSharedInformerFactory":          c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
-		"listOptions":                              c.Universe.Type(listOptions),
-		"lister":                                   c.Universe.Type(types.Name{Package: listerPackage, Name: t.Name.Name + "Lister"}),
-		"namespaceAll":                             c.Universe.Type(metav1NamespaceAll),
-		"namespaced":                               !tags.NonNamespaced,
-		"newLister":                                c.Universe.Function(types.Name{Package: listerPackage, Name: "New" + t.Name.Name + "Lister"}),
-		"resourceName":                             strings.ToLower(t.Name.Name) + "s",
-		"runtimeObject":                            c.Universe.Type(runtimeObject),
-		"schemaGroupVersionResource":               c.Universe.Type(schemaGroupVersionResource),
-		"timeDuration":                             c.Universe.Type(timeDuration),
-		"type":                                     t,
-		"v1ListOptions":                            c.Universe.Type(v1ListOptions),
-		"version":                                  namer.IC(g.groupVersion.Version.String()),
-		"versionName":                              g.groupVersion.Version.String(),
-		"watchInterface":                           c.Universe.Type(watchInterface),
+		"clientAccessor":                               clientAccessor,
+		"coResource":                                   tags.CoResource,
+		"testListWatch":                                tags.TestListWatch != "",
+		"testListWatchBuildTag":                        testListWatchBuildTag,
+		"apiScheme":                                    c.Universe.Type(apiScheme),
+		"cacheDeletionHandlingKeyFunc":                 c.Universe.Function(cacheDeletionHandlingMetaNamespaceKeyFunc),
+		"cacheDeletedFinalStateUnknown":                c.Universe.Type(cacheDeletedFinalStateUnknown),
+		"cacheFilteringResourceEventHandler":           c.Universe.Type(cacheFilteringResourceEventHandler),
+		"cacheIndexers":                                c.Universe.Type(cacheIndexers),
+		"cacheListWatch":                               c.Universe.Type(cacheListWatch),
+		"cacheMetaNamespaceIndexFunc":                  c.Universe.Function(cacheMetaNamespaceIndexFunc),
+		"cacheNamespaceIndex":                          c.Universe.Variable(cacheNamespaceIndex),
+		"cacheNewSharedIndexInformer":                  c.Universe.Function(cacheNewSharedIndexInformer),
+		"cacheNewSharedIndexInformerWithOptions":       c.Universe.Function(cacheNewSharedIndexInformerWithOptions),
+		"cacheResourceEventHandler":                    c.Universe.Type(cacheResourceEventHandler),
+		"cacheResourceEventHandlerDetailedFuncs":       c.Universe.Type(cacheResourceEventHandlerDetailedFuncs),
+		"cacheResourceEventHandlerFuncs":               c.Universe.Type(cacheResourceEventHandlerFuncs),
+		"cacheResourceEventHandlerRegistration":        c.Universe.Type(cacheResourceEventHandlerRegistration),
+		"cacheSharedIndexInformer":                     c.Universe.Type(cacheSharedIndexInformer),
+		"cacheSharedIndexInformerOptions":              c.Universe.Type(cacheSharedIndexInformerOptions),
+		"cacheToListWatcherWithWatchListSemantics":     c.Universe.Function(cacheToListWatcherWithWatchListSemanticsFunc),
+		"cacheInformerName":                            c.Universe.Type(cacheInformerName),
+		"clientSetInterface":                           clientSetInterface,
+		"contextContext":                               c.Universe.Type(contextContext),
+		"contextTODO":                                  c.Universe.Function(contextTODOFunc),
+		"contextBackground":                            c.Universe.Function(contextBackgroundFunc),
+		"fmtErrorf":                                    c.Universe.Function(fmtErrorfFunc),
+		"groupClientAccessor":                          clientAccessor[:strings.LastIndex(clientAccessor, ".")],
+		"groupName":                                    g.groupVersion.Group.String(),
+		"informerFor":                                  informerFor,
+		"interfacesInformerOptions":                    c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "InformerOptions"}),
+		"interfacesTweakListOptionsFunc":               c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "TweakListOptionsFunc"}),
+		"interfacesWithLabelSelector":                  c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "WithLabelSelector"}),
+		"interfacesAndLabelSelectors":                  c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "AndLabelSelectors"}),
+		"interfacesSharedInformerFactory":              c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactory"}),
+		"interfacesSharedInformerFactoryExtensions":    c.Universe.Type(types.Name{Package: g.internalInterfacesPackage, Name: "SharedInformerFactoryExtensions"}),
+		"interfacesNewCacheBackendInformer":            c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewCacheBackendInformer"}),
+		"interfacesNewCacheSnapshotListerWatcher":      c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewCacheSnapshotListerWatcher"}),
+		"interfacesNewContextBoundListerWatcher":       c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewContextBoundListerWatcher"}),
+		"interfacesNewCoResourceListerWatcher":         c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewCoResourceListerWatcher"}),
+		"interfacesNewMultiNamespaceListerWatcher":     c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewMultiNamespaceListerWatcher"}),
+		"labelSelector":                                labelSelector,
+		"labelsSelector":                               c.Universe.Type(labelsSelector),
+		"interfacesNewFilteredIndexer":                 c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewFilteredIndexer"}),
+		"interfacesNewKeyNormalizingInformer":          c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewKeyNormalizingInformer"}),
+		"interfacesNewLeadershipGatedListerWatcher":    c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewLeadershipGatedListerWatcher"}),
+		"interfacesNewListerWatcherWithoutWatchList":   c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewListerWatcherWithoutWatchList"}),
+		"interfacesNewObjectFilter":                    c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewObjectFilter"}),
+		"interfacesNewPanicRecoveringEventHandler":     c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewPanicRecoveringEventHandler"}),
+		"interfacesNewRotatingListerWatcher":           c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewRotatingListerWatcher"}),
+		"interfacesNewRetweakableListerWatcher":        c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewRetweakableListerWatcher"}),
+		"interfacesNewValidatingListerWatcher":         c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "NewValidatingListerWatcher"}),
+		"interfacesRecoverEventHandlerPanic":           c.Universe.Function(types.Name{Package: g.internalInterfacesPackage, Name: "RecoverEventHandlerPanic"}),
+		"cacheListerWatcher":                           c.Universe.Type(cacheListerWatcher),
+		"cacheWaitForCacheSync":                        c.Universe.Function(cacheWaitForCacheSyncFunc),
+		"syncWaitGroup":                                c.Universe.Type(syncWaitGroup),
+		"workqueueDefaultTypedControllerRateLimiter":   c.Universe.Function(workqueueDefaultTypedControllerRateLimiterFunc),
+		"workqueueNewTypedRateLimitingQueueWithConfig": c.Universe.Function(workqueueNewTypedRateLimitingQueueWithConfigFunc),
+		"workqueueTypedRateLimitingInterface":          c.Universe.Type(workqueueTypedRateLimitingInterface),
+		"workqueueTypedRateLimitingQueueConfig":        c.Universe.Type(workqueueTypedRateLimitingQueueConfig),
+		"metav1ListMeta":                               c.Universe.Type(metav1ListMeta),
+		"metav1ParameterCodec":                         c.Universe.Variable(metav1ParameterCodec),
+		"metav1ResourceVersionMatchExact":              c.Universe.Constant(metav1ResourceVersionMatchExact),
+		"metav1TypeMeta":                               c.Universe.Type(metav1TypeMeta),
+		"listOptions":                                  c.Universe.Type(listOptions),
+		"klogKObj":                                     c.Universe.Function(klogKObjFunc),
+		"lister":                                       c.Universe.Type(types.Name{Package: listerPackage, Name: t.Name.Name + "Lister"}),
+		"managedfieldsExtractInto":                     c.Universe.Function(managedfieldsExtractIntoFunc),
+		"namespaceAll":                                 c.Universe.Type(metav1NamespaceAll),
+		"namespaced":                                   !tags.NonNamespaced,
+		"noResync":                                     tags.NoResync,
+		"newLister":                                    c.Universe.Function(types.Name{Package: listerPackage, Name: "New" + t.Name.Name + "Lister"}),
+		"resourceName":                                 strings.ToLower(t.Name.Name) + "s",
+		"runtimeObject":                                c.Universe.Type(runtimeObject),
+		"schemaGroupVersionResource":                   c.Universe.Type(schemaGroupVersionResource),
+		"slicesSortFunc":                               c.Universe.Function(slicesSortFunc),
+		"stringsCompare":                               c.Universe.Function(stringsCompare),
+		"strconvParseUint":                             c.Universe.Function(strconvParseUintFunc),
+		"syncMutex":                                    c.Universe.Type(syncMutex),
+		"syncOnce":                                     c.Universe.Type(syncOnce),
+		"timeAfterFunc":                                c.Universe.Function(timeAfterFuncFunc),
+		"timeDuration":                                 c.Universe.Type(timeDuration),
+		"timeNow":                                      c.Universe.Function(timeNowFunc),
+		"timeTimer":                                    c.Universe.Type(timeTimer),
+		"type":                                         t,
+		"typeList":                                     c.Universe.Type(types.Name{Package: t.Name.Package, Name: t.Name.Name + "List"}),
+		"typedDeducedParseableType":                    c.Universe.Variable(typedDeducedParseableType),
+		"utilruntimeHandleErrorWithContext":            c.Universe.Function(utilruntimeHandleErrorWithContextFunc),
+		"v1ListOptions":                                c.Universe.Type(v1ListOptions),
+		"versionName":                                  g.groupVersion.Version.String(),
+		"watchAdded":                                   c.Universe.Constant(watchAdded),
+		"watchDeleted":                                 c.Universe.Constant(watchDeleted),
+		"watchEventType":                               c.Universe.Type(watchEventType),
+		"watchInterface":                               c.Universe.Type(watchInterface),
+		"watchModified":                                c.Universe.Constant(watchModified),
 	}
 
 	sw.Do(typeInformerInterface, m)
@@ -122,6 +205,32 @@ func (g *informerGenerator) GenerateType(c *generator.Context, t *types.Type, w
 	sw.Do(typeInformerConstructor, m)
 	sw.Do(typeInformerInformer, m)
 	sw.Do(typeInformerLister, m)
+	sw.Do(typeInformerFactory, m)
+	sw.Do(typeInformerFactoryExtensions, m)
+	sw.Do(typeInformerEventHandler, m)
+	sw.Do(typeInformerEventHandlerFuncs, m)
+	sw.Do(typeInformerPriorityHandler, m)
+	sw.Do(typeInformerResyncHandler, m)
+	sw.Do(typeInformerSpecChangeHandler, m)
+	sw.Do(typeInformerDiffHandler, m)
+	sw.Do(typeInformerSequencedHandler, m)
+	sw.Do(typeInformerDebouncedHandler, m)
+	sw.Do(typeInformerBatchHandler, m)
+	sw.Do(typeInformerTracedHandler, m)
+	sw.Do(typeInformerResumingHandler, m)
+	sw.Do(typeInformerPostSyncHandler, m)
+	sw.Do(typeInformerSyncedHook, m)
+	sw.Do(typeInformerStreamServer, m)
+	sw.Do(typeInformerFilteredView, m)
+	sw.Do(typeInformerExportList, m)
+	if len(g.applyConfigurationPackage) != 0 {
+		applyConfigurationPackage := fmt.Sprintf("%s/%s/%s", g.applyConfigurationPackage, g.groupPkgName, strings.ToLower(g.groupVersion.Version.NonEmpty()))
+		m["applyConfiguration"] = c.Universe.Type(types.Name{Package: applyConfigurationPackage, Name: t.Name.Name + "ApplyConfiguration"})
+		sw.Do(typeInformerToApplyConfiguration, m)
+	}
+	if g.controllers {
+		sw.Do(typeInformerController, m)
+	}
 
 	return sw.Error()
 }
@@ -132,6 +241,10 @@ var typeInformerInterface = `
 type $.type|public$Informer interface {
 	Informer() $.cacheSharedIndexInformer|raw$
 	Lister() $.lister|raw$
+	// Factory returns the factory which owns the informer. It is the
+	// SharedInformerFactory of the informers package, which cannot be named
+	// here without an import cycle; use a type assertion to access it.
+	Factory() $.interfacesSharedInformerFactory|raw$
 }
 `
 
@@ -141,6 +254,13 @@ type $.type|private$Informer struct {
 	tweakListOptions $.interfacesTweakListOptionsFunc|raw$
 	$if .namespaced$namespace string$end$
 }
+$- if .testListWatch $
+
+// $.type|private$TestListWatch, if set, returns the ListerWatcher which replaces the client
+// of the $.type|public$ informers, whatever their namespace and list options. It is only set
+// in builds with the $.testListWatchBuildTag$ build tag.
+var $.type|private$TestListWatch func() $.cacheListerWatcher|raw$
+$- end $
 `
 
 var typeInformerPublicConstructor = `
@@ -148,7 +268,15 @@ var typeInformerPublicConstructor = `
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func New$.type|public$Informer(client $.clientSetInterface|raw$$if .namespaced$, namespace string$end$, resyncPeriod $.timeDuration|raw$, indexers $.cacheIndexers|raw$) $.cacheSharedIndexInformer|raw$ {
-	return New$.type|public$InformerWithOptions(client$if .namespaced$, namespace$end$, $.interfacesInformerOptions|raw${ResyncPeriod: resyncPeriod, Indexers: indexers})
+	return New$.type|public$InformerWithContext($.contextTODO|raw$(), client$if .namespaced$, namespace$end$, resyncPeriod, indexers)
+}
+
+// New$.type|public$InformerWithContext constructs a new informer for $.type|public$ type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func New$.type|public$InformerWithContext(ctx $.contextContext|raw$, client $.clientSetInterface|raw$$if .namespaced$, namespace string$end$, resyncPeriod $.timeDuration|raw$, indexers $.cacheIndexers|raw$) $.cacheSharedIndexInformer|raw$ {
+	return New$.type|public$InformerWithOptions(client$if .namespaced$, namespace$end$, $.interfacesInformerOptions|raw${ResyncPeriod: resyncPeriod, Indexers: indexers, Context: ctx})
 }
 `
 
@@ -157,7 +285,15 @@ var typeFilteredInformerPublicConstructor = `
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewFiltered$.type|public$Informer(client $.clientSetInterface|raw$$if .namespaced$, namespace string$end$, resyncPeriod $.timeDuration|raw$, indexers $.cacheIndexers|raw$, tweakListOptions $.interfacesTweakListOptionsFunc|raw$) $.cacheSharedIndexInformer|raw$ {
-	return New$.type|public$InformerWithOptions(client$if .namespaced$, namespace$end$, $.interfacesInformerOptions|raw${ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions})
+	return NewFiltered$.type|public$InformerWithContext($.contextTODO|raw$(), client$if .namespaced$, namespace$end$, resyncPeriod, indexers, tweakListOptions)
+}
+
+// NewFiltered$.type|public$InformerWithContext constructs a new informer for $.type|public$ type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFiltered$.type|public$InformerWithContext(ctx $.contextContext|raw$, client $.clientSetInterface|raw$$if .namespaced$, namespace string$end$, resyncPeriod $.timeDuration|raw$, indexers $.cacheIndexers|raw$, tweakListOptions $.interfacesTweakListOptionsFunc|raw$) $.cacheSharedIndexInformer|raw$ {
+	return New$.type|public$InformerWithOptions(client$if .namespaced$, namespace$end$, $.interfacesInformerOptions|raw${ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions, Context: ctx})
 }
 `
 
@@ -169,33 +305,148 @@ func New$.type|public$InformerWithOptions(client $.clientSetInterface|raw$$if .n
 	gvr := $.schemaGroupVersionResource|raw${Group: "$.groupName$", Version: "$.versionName$", Resource: "$.resourceName$"}
 	identifier := options.InformerName.WithResource(gvr)
 	tweakListOptions := options.TweakListOptions
-	return $.cacheNewSharedIndexInformerWithOptions|raw$(
-		$.cacheToListWatcherWithWatchListSemantics|raw$(&$.cacheListWatch|raw${
-			ListFunc: func(opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.$.group$$.version$().$.type|publicPlural$($if .namespaced$namespace$end$).List($.contextBackground|raw$(), opts)
-			},
-			WatchFunc: func(opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.$.group$$.version$().$.type|publicPlural$($if .namespaced$namespace$end$).Watch($.contextBackground|raw$(), opts)
-			},
-			ListWithContextFunc: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
+	if options.Retweaker != nil {
+		tweakListOptions = options.Retweaker.TweakListOptions
+	}
+$- if .labelSelector $
+	// The label selector of +informers:labelSelector is ANDed into the one of tweakListOptions.
+	tweakListOptions = $.interfacesWithLabelSelector|raw$(tweakListOptions, $.labelSelector$)
+$- end $
+	// initialResourceVersion is cleared by the first list. Lists are never
+	// called concurrently by a reflector.
+	initialResourceVersion := options.InitialResourceVersion
+	initialResourceVersionMatch := options.InitialResourceVersionMatch
+	if initialResourceVersionMatch == "" {
+		initialResourceVersionMatch = $.metav1ResourceVersionMatchExact|raw$
+	}
+	pageSize := options.WatchListPageSize
+	observeWatch := func(w $.watchInterface|raw$, err error) ($.watchInterface|raw$, error) {
+		if err == nil && options.ReconnectObserver != nil {
+			options.ReconnectObserver(gvr, $.timeNow|raw$())
+		}
+		return w, err
+	}
+	var lw $.cacheListerWatcher|raw$ = $.cacheToListWatcherWithWatchListSemantics|raw$(&$.cacheListWatch|raw${
+		ListFunc: func(opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.$.clientAccessor$($if .namespaced$namespace$end$).List($.contextBackground|raw$(), opts)
+		},
+		WatchFunc: func(opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.$.clientAccessor$($if .namespaced$namespace$end$).Watch($.contextBackground|raw$(), opts))
+		},
+		ListWithContextFunc: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.$.clientAccessor$($if .namespaced$namespace$end$).List(ctx, opts)
+		},
+		WatchFuncWithContext: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.$.clientAccessor$($if .namespaced$namespace$end$).Watch(ctx, opts))
+		},
+	}, client)
+$- if .namespaced $
+	if len(options.NamespaceSelectors) > 0 {
+		lw = $.interfacesNewMultiNamespaceListerWatcher|raw$(options.NamespaceSelectors, func(namespace string, selector $.labelsSelector|raw$) $.cacheListerWatcher|raw$ {
+			tweak := func(opts *$.v1ListOptions|raw$) {
 				if tweakListOptions != nil {
-					tweakListOptions(&opts)
+					tweakListOptions(opts)
 				}
-				return client.$.group$$.version$().$.type|publicPlural$($if .namespaced$namespace$end$).List(ctx, opts)
-			},
-			WatchFuncWithContext: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
+				if selector != nil {
+$- if .labelSelector $
+					opts.LabelSelector = $.interfacesAndLabelSelectors|raw$(selector.String(), $.labelSelector$)
+$- else $
+					opts.LabelSelector = selector.String()
+$- end $
 				}
-				return client.$.group$$.version$().$.type|publicPlural$($if .namespaced$namespace$end$).Watch(ctx, opts)
-			},
-		}, client),
+			}
+			// Like initialResourceVersion, but cleared by the first list of the namespace.
+			nsInitialResourceVersion := options.InitialResourceVersion
+			return &$.cacheListWatch|raw${
+				ListWithContextFunc: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
+					if pageSize > 0 {
+						opts.Limit = pageSize
+					}
+					tweak(&opts)
+					if nsInitialResourceVersion != "" {
+						opts.ResourceVersion = nsInitialResourceVersion
+						opts.ResourceVersionMatch = initialResourceVersionMatch
+						nsInitialResourceVersion = ""
+					}
+					return client.$.clientAccessor$(namespace).List(ctx, opts)
+				},
+				WatchFuncWithContext: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
+					tweak(&opts)
+					return observeWatch(client.$.clientAccessor$(namespace).Watch(ctx, opts))
+				},
+			}
+		})
+	}
+$- end $
+$- if .coResource $
+	// The status of $.type|publicPlural$ is watched through $.coResource$.
+	coLW := &$.cacheListWatch|raw${
+		ListWithContextFunc: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.runtimeObject|raw$, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			result := &$.typeList|raw${}
+			err := client.$.groupClientAccessor$.RESTClient().Get()$if .namespaced$.Namespace(namespace)$end$.Resource("$.coResource$").VersionedParams(&opts, $.metav1ParameterCodec|raw$).Do(ctx).Into(result)
+			return result, err
+		},
+		WatchFuncWithContext: func(ctx $.contextContext|raw$, opts $.v1ListOptions|raw$) ($.watchInterface|raw$, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			opts.Watch = true
+			return client.$.groupClientAccessor$.RESTClient().Get()$if .namespaced$.Namespace(namespace)$end$.Resource("$.coResource$").VersionedParams(&opts, $.metav1ParameterCodec|raw$).Watch(ctx)
+		},
+	}
+	lw = $.interfacesNewCoResourceListerWatcher|raw$(lw, coLW, func(obj, coObj $.runtimeObject|raw$) $.runtimeObject|raw$ {
+		merged := obj.(*$.type|raw$).DeepCopy()
+		merged.Status = coObj.(*$.type|raw$).DeepCopy().Status
+		return merged
+	})
+$- end $
+$- if .testListWatch $
+	if $.type|private$TestListWatch != nil {
+		lw = $.interfacesNewListerWatcherWithoutWatchList|raw$($.type|private$TestListWatch())
+	}
+$- end $
+	if options.InitialResourceVersion != "" {
+		lw = $.interfacesNewListerWatcherWithoutWatchList|raw$(lw)
+	}
+	lw = $.interfacesNewValidatingListerWatcher|raw$(lw, options.IngestValidator)
+	lw = $.interfacesNewValidatingListerWatcher|raw$(lw, $.interfacesNewObjectFilter|raw$(options.ObjectFilter))
+	lw = $.interfacesNewRetweakableListerWatcher|raw$(lw, options.Retweaker)
+	lw = $.interfacesNewRotatingListerWatcher|raw$(lw, options.WatchRotation)
+	lw = $.interfacesNewLeadershipGatedListerWatcher|raw$(lw, options.LeadershipGate)
+	lw = $.interfacesNewContextBoundListerWatcher|raw$(lw, options.Context)
+	informer := $.cacheNewSharedIndexInformerWithOptions|raw$(
+		$.interfacesNewCacheSnapshotListerWatcher|raw$(lw, options.CacheSnapshot, options.CacheSnapshotDecoder, &$.typeList|raw${}),
 		&$.type|raw${},
 		$.cacheSharedIndexInformerOptions|raw${
 			ResyncPeriod: options.ResyncPeriod,
@@ -203,12 +454,30 @@ func New$.type|public$InformerWithOptions(client $.clientSetInterface|raw$$if .n
 			Identifier:   identifier,
 		},
 	)
+	informer = $.interfacesNewCacheBackendInformer|raw$(informer, options.CacheBackend, options.Indexers, options.InitialCacheCapacity)
+	return $.interfacesNewKeyNormalizingInformer|raw$(informer, options.KeyNormalizer, options.Indexers)
 }
 `
 
 var typeInformerConstructor = `
 func (f *$.type|private$Informer) defaultInformer(client $.clientSetInterface|raw$, resyncPeriod $.timeDuration|raw$) $.cacheSharedIndexInformer|raw$ {
-	return New$.type|public$InformerWithOptions(client$if .namespaced$, f.namespace$end$, $.interfacesInformerOptions|raw${ResyncPeriod: resyncPeriod, Indexers: $.cacheIndexers|raw${$.cacheNamespaceIndex|raw$: $.cacheMetaNamespaceIndexFunc|raw$}, InformerName: f.factory.InformerName(), TweakListOptions: f.tweakListOptions})
+$- if .noResync $
+	// $.type|public$ is tagged +genclient:noResync, so it is never resynced,
+	// whatever the resync period of the factory.
+	resyncPeriod = 0
+$- end $
+	options := $.interfacesInformerOptions|raw${InformerName: f.factory.InformerName()}
+	if factory, ok := f.factory.($.interfacesSharedInformerFactoryExtensions|raw$); ok {
+		factory.CheckInformerCreate(&$.type|raw${})
+		options = factory.InformerOptionsFor(&$.type|raw${})
+	}
+	options.ResyncPeriod = resyncPeriod
+	options.Indexers = $.cacheIndexers|raw${$.cacheNamespaceIndex|raw$: $.cacheMetaNamespaceIndexFunc|raw$}
+	options.TweakListOptions = f.tweakListOptions
+	if options.Retweaker != nil {
+		options.Retweaker.Retweak(f.tweakListOptions)
+	}
+	return New$.type|public$InformerWithOptions(client$if .namespaced$, f.namespace$end$, options)
 }
 `
 
@@ -223,3 +492,915 @@ func (f *$.type|private$Informer) Lister() $.lister|raw$ {
 	return $.newLister|raw$(f.Informer().GetIndexer())
 }
 `
+
+var typeInformerFactory = `
+func (f *$.type|private$Informer) Factory() $.interfacesSharedInformerFactory|raw$ {
+	return f.factory
+}
+`
+
+var typeInformerFactoryExtensions = `
+// $.type|private$FactoryExtensions returns the extensions of the factory of informer, if
+// informer was obtained from a factory which implements them.
+func $.type|private$FactoryExtensions(informer $.type|public$Informer) ($.interfacesSharedInformerFactoryExtensions|raw$, bool) {
+	factoryInformer, ok := informer.(*$.type|private$Informer)
+	if !ok {
+		return nil, false
+	}
+	factory, ok := factoryInformer.factory.($.interfacesSharedInformerFactoryExtensions|raw$)
+	return factory, ok
+}
+`
+
+var typeInformerEventHandler = `
+// Add$.type|public$EventHandler adds handler to the shared informer of informer. Panics of
+// handler are handled by the panic handler of the factory of informer, if any. The returned
+// registration reports through HasSynced when the replay of the informer's cache to handler
+// is over, independently of other handlers.
+func Add$.type|public$EventHandler(informer $.type|public$Informer, handler $.cacheResourceEventHandler|raw$) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, factory.PanicHandler(&$.type|raw${}))
+	}
+	registration, err := sharedInformer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerEventHandlerFuncs = `
+// Add$.type|public$EventHandlerFuncs adds an event handler to the shared informer of informer
+// which invokes the given callbacks with typed $.type|publicPlural$; any of them may be nil.
+// Deletions whose final state is unknown are delivered to deleteFunc with the last known state
+// of the object. Objects of other types are ignored. The handler is added through
+// Add$.type|public$EventHandler.
+func Add$.type|public$EventHandlerFuncs(informer $.type|public$Informer, addFunc func(*$.type|raw$), updateFunc func(oldObj, newObj *$.type|raw$), deleteFunc func(*$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	handler := $.cacheResourceEventHandlerFuncs|raw${}
+	if addFunc != nil {
+		handler.AddFunc = func(obj interface{}) {
+			if item, ok := obj.(*$.type|raw$); ok {
+				addFunc(item)
+			}
+		}
+	}
+	if updateFunc != nil {
+		handler.UpdateFunc = func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*$.type|raw$)
+			newItem, newOK := newObj.(*$.type|raw$)
+			if oldOK && newOK {
+				updateFunc(oldItem, newItem)
+			}
+		}
+	}
+	if deleteFunc != nil {
+		handler.DeleteFunc = func(obj interface{}) {
+			if unknown, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*$.type|raw$); ok {
+				deleteFunc(item)
+			}
+		}
+	}
+	return Add$.type|public$EventHandler(informer, handler)
+}
+`
+
+var typeInformerPriorityHandler = `
+// Add$.type|public$EventHandlerWithPriority adds handler to the shared informer of informer,
+// which must be obtained from a factory. The handlers added with a priority are invoked one
+// after another in ascending priority, and in the order they were added for equal priorities;
+// other handlers are invoked independently of them. Panics of handler are handled by the
+// panic handler of the factory, if any. The objects in the cache of the informer are delivered
+// to handler before it returns.
+func Add$.type|public$EventHandlerWithPriority(informer $.type|public$Informer, priority int, handler $.cacheResourceEventHandler|raw$) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if !fromFactory {
+		return nil, $.fmtErrorf|raw$("handler priorities require an informer obtained from a factory")
+	}
+	sharedInformer := informer.Informer()
+	handlers, err := factory.PriorityEventHandlers(sharedInformer)
+	if err != nil {
+		return nil, err
+	}
+	handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, factory.PanicHandler(&$.type|raw${}))
+	registration := handlers.Add(priority, handler, sharedInformer.GetStore())
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+`
+
+var typeInformerResyncHandler = `
+// Add$.type|public$ResyncHandler adds an event handler to the shared informer of informer
+// which invokes fn for periodic resyncs of $.type|publicPlural$ only, not for changes.
+// Resyncs are recognized by the old and new objects of an update being the same
+// object. fn is never invoked if the informer has no resync period.
+func Add$.type|public$ResyncHandler(informer $.type|public$Informer, fn func(*$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*$.type|raw$)
+			newItem, newOK := newObj.(*$.type|raw$)
+			if oldOK && newOK && oldItem == newItem {
+				defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerSpecChangeHandler = `
+// Add$.type|public$SpecChangeHandler adds an event handler to the shared informer of informer
+// which invokes fn with the new object of the updates of $.type|publicPlural$ which change
+// their metadata.generation, ignoring updates of their status or metadata only. It is not
+// invoked for adds or deletes, nor for resources whose generation is not maintained by the
+// API server.
+func Add$.type|public$SpecChangeHandler(informer $.type|public$Informer, fn func(*$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*$.type|raw$)
+			newItem, newOK := newObj.(*$.type|raw$)
+			if oldOK && newOK && oldItem.Generation != newItem.Generation {
+				defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerDiffHandler = `
+// Add$.type|public$DiffHandler adds an event handler to the shared informer of informer
+// which invokes fn with the old and new states of the added, updated and deleted
+// $.type|publicPlural$: oldObj is nil for adds and newObj is nil for deletes. The last known
+// state of $.type|publicPlural$ whose deletion the informer missed is passed as oldObj.
+// Resyncs are passed as updates with the same old and new object.
+func Add$.type|public$DiffHandler(informer $.type|public$Informer, fn func(oldObj, newObj *$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	diff := func(oldItem, newItem *$.type|raw$) {
+		defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+		fn(oldItem, newItem)
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: func(obj interface{}) {
+			if item, ok := obj.(*$.type|raw$); ok {
+				diff(nil, item)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*$.type|raw$)
+			newItem, newOK := newObj.(*$.type|raw$)
+			if oldOK && newOK {
+				diff(oldItem, newItem)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*$.type|raw$); ok {
+				diff(item, nil)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerSequencedHandler = `
+// $.type|public$Event is an event of a $.type|public$ delivered to a sequenced handler.
+type $.type|public$Event struct {
+	// Type is $.watchAdded|raw$, $.watchModified|raw$ or $.watchDeleted|raw$.
+	Type $.watchEventType|raw$
+	// Object is the added, updated or deleted $.type|public$. Deleted objects
+	// may be stale if the informer missed their deletion.
+	Object *$.type|raw$
+	// OldObject is the previous state of an updated $.type|public$, or nil.
+	OldObject *$.type|raw$
+	// IsInInitialList is true for the adds of the initial list of the informer.
+	IsInInitialList bool
+}
+
+// Add$.type|public$SequencedHandler adds an event handler to the shared informer of informer,
+// which must be obtained from a factory, which invokes fn for the added, updated and deleted
+// $.type|publicPlural$ with a sequence number. The sequence numbers are shared by all the
+// sequenced handlers of the factory and increase monotonically in the order in which the
+// events are dispatched, so they order the events of different informers. fn may be invoked
+// concurrently for the events of different informers.
+func Add$.type|public$SequencedHandler(informer $.type|public$Informer, fn func(seq uint64, ev $.type|public$Event)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if !fromFactory {
+		return nil, $.fmtErrorf|raw$("sequenced handlers require an informer obtained from a factory")
+	}
+	panicHandler := factory.PanicHandler(&$.type|raw${})
+	dispatch := func(ev $.type|public$Event) {
+		defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+		fn(factory.NextEventSequence(), ev)
+	}
+	sharedInformer := informer.Informer()
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerDetailedFuncs|raw${
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if item, ok := obj.(*$.type|raw$); ok {
+				dispatch($.type|public$Event{Type: $.watchAdded|raw$, Object: item, IsInInitialList: isInInitialList})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*$.type|raw$)
+			newItem, newOK := newObj.(*$.type|raw$)
+			if oldOK && newOK {
+				dispatch($.type|public$Event{Type: $.watchModified|raw$, Object: newItem, OldObject: oldItem})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*$.type|raw$); ok {
+				dispatch($.type|public$Event{Type: $.watchDeleted|raw$, Object: item})
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+`
+
+var typeInformerDebouncedHandler = `
+// Add$.type|public$DebouncedHandler adds an event handler to the shared informer of informer
+// which invokes fn with the latest version of a $.type|public$ once it was not added or
+// updated for window. Deleting a $.type|public$ cancels its pending invocation. fn is
+// invoked in a goroutine of its own, so invocations for different $.type|publicPlural$ may
+// run concurrently. Invocations which are pending when the handler is removed still run.
+func Add$.type|public$DebouncedHandler(informer $.type|public$Informer, window $.timeDuration|raw$, fn func(*$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	type pendingCall struct {
+		item  *$.type|raw$
+		timer *$.timeTimer|raw$
+	}
+	var lock $.syncMutex|raw$
+	pending := map[string]*pendingCall{}
+	schedule := func(obj interface{}) {
+		item, ok := obj.(*$.type|raw$)
+		if !ok {
+			return
+		}
+		key, err := $.cacheDeletionHandlingKeyFunc|raw$(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if call, ok := pending[key]; ok {
+			call.timer.Stop()
+		}
+		call := &pendingCall{item: item}
+		call.timer = $.timeAfterFunc|raw$(window, func() {
+			lock.Lock()
+			// The call was superseded if an update or delete raced with the timer.
+			current := pending[key] == call
+			if current {
+				delete(pending, key)
+			}
+			lock.Unlock()
+			if current {
+				defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+				fn(call.item)
+			}
+		})
+		pending[key] = call
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: schedule,
+		UpdateFunc: func(_, newObj interface{}) {
+			schedule(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := $.cacheDeletionHandlingKeyFunc|raw$(obj)
+			if err != nil {
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if call, ok := pending[key]; ok {
+				call.timer.Stop()
+				delete(pending, key)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerBatchHandler = `
+// Add$.type|public$BatchHandler adds an event handler to the shared informer of informer
+// which collects the added, updated and deleted $.type|publicPlural$ into batches and invokes
+// fn with each batch. A batch holds the latest version of each $.type|public$ once, in the
+// order in which they first changed. Deleted $.type|publicPlural$ are included with their
+// last state and are not marked as deleted; fn has to look them up in the informer's lister
+// to tell them apart. A batch is flushed once it holds maxBatch $.type|publicPlural$, which
+// invokes fn in the informer's notification goroutine, or maxDelay after its first change.
+// When ctx is done, the handler is removed from the shared informer, the pending batch is
+// flushed and no further changes are collected. Invocations of fn never overlap.
+func Add$.type|public$BatchHandler(ctx $.contextContext|raw$, informer $.type|public$Informer, maxBatch int, maxDelay $.timeDuration|raw$, fn func([]*$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	if maxBatch <= 0 {
+		return nil, $.fmtErrorf|raw$("invalid batch size %d", maxBatch)
+	}
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	// flushLock serializes the invocations of fn. lock guards the batch.
+	var flushLock, lock $.syncMutex|raw$
+	var batch []*$.type|raw$
+	positions := map[string]int{}
+	var timer *$.timeTimer|raw$
+	flush := func() {
+		flushLock.Lock()
+		defer flushLock.Unlock()
+		lock.Lock()
+		items := batch
+		batch, positions = nil, map[string]int{}
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		lock.Unlock()
+		if len(items) > 0 {
+			defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+			fn(items)
+		}
+	}
+	collect := func(obj interface{}) {
+		if unknown, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*$.type|raw$)
+		if !ok {
+			return
+		}
+		key, err := $.cacheDeletionHandlingKeyFunc|raw$(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		if ctx.Err() != nil {
+			lock.Unlock()
+			return
+		}
+		if position, ok := positions[key]; ok {
+			batch[position] = item
+		} else {
+			positions[key] = len(batch)
+			batch = append(batch, item)
+		}
+		if timer == nil {
+			timer = $.timeAfterFunc|raw$(maxDelay, flush)
+		}
+		full := len(batch) >= maxBatch
+		lock.Unlock()
+		if full {
+			flush()
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: collect,
+		UpdateFunc: func(_, newObj interface{}) {
+			collect(newObj)
+		},
+		DeleteFunc: collect,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := sharedInformer.RemoveEventHandler(registration); err != nil {
+			$.utilruntimeHandleErrorWithContext|raw$(ctx, err, "Failed to remove the $.type|public$ batch handler")
+		}
+		flush()
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerTracedHandler = `
+// Add$.type|public$TracedHandler adds an event handler to the shared informer of informer
+// which invokes fn for added, updated and deleted $.type|publicPlural$ with the trace context
+// stored in their annotations. extract returns the context carried by the annotations,
+// derived from its ctx; it must not modify them. An OpenTelemetry propagator is used as
+//
+//	func(ctx context.Context, carrier map[string]string) context.Context {
+//		return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+//	}
+//
+// Deleted $.type|publicPlural$ are passed with their last state.
+func Add$.type|public$TracedHandler(informer $.type|public$Informer, extract func(ctx $.contextContext|raw$, carrier map[string]string) $.contextContext|raw$, fn func(ctx $.contextContext|raw$, obj *$.type|raw$)) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	handle := func(obj interface{}) {
+		if unknown, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*$.type|raw$)
+		if !ok {
+			return
+		}
+		defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+		fn(extract($.contextBackground|raw$(), item.Annotations), item)
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: handle,
+		UpdateFunc: func(_, newObj interface{}) {
+			handle(newObj)
+		},
+		DeleteFunc: handle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerSyncedHook = `
+// On$.type|public$Synced invokes fn once, in its own goroutine, as soon as the shared informer
+// of informer has synced for the first time, or right away if it already has. fn is not invoked
+// if ctx is done first. Panics of fn are handled by the panic handler of the factory of
+// informer, if any.
+func On$.type|public$Synced(ctx $.contextContext|raw$, informer $.type|public$Informer, fn func()) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	if factory, fromFactory := $.type|private$FactoryExtensions(informer); fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+	}
+	go func() {
+		select {
+		case <-sharedInformer.HasSyncedChecker().Done():
+		case <-ctx.Done():
+			return
+		}
+		defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+		fn()
+	}()
+}
+`
+
+var typeInformerStreamServer = `
+// $.type|public$EventStream is the part of a gRPC server stream which is used to send
+// $.type|public$ events as messages of type M, like the Foo_WatchServer interface which
+// protoc-gen-go-grpc generates for a method "rpc Watch(...) returns (stream M)".
+type $.type|public$EventStream[M any] interface {
+	Send(M) error
+	Context() $.contextContext|raw$
+}
+
+// $.type|public$ProtoMarshaler converts a $.type|public$ event into the message sent on a stream.
+type $.type|public$ProtoMarshaler[M any] func(eventType $.watchEventType|raw$, obj *$.type|raw$) (M, error)
+
+// Add$.type|public$StreamServer adds an event handler to the shared informer of informer which
+// marshals every $.type|public$ event with marshal and sends the result on stream. The event
+// handler is removed once the context of stream is done, so a gRPC method can serve a watch
+// by calling Add$.type|public$StreamServer and then waiting for the context of its stream.
+// Events which cannot be marshaled or sent are reported to utilruntime.HandleError and skipped.
+func Add$.type|public$StreamServer[M any](informer $.type|public$Informer, stream $.type|public$EventStream[M], marshal $.type|public$ProtoMarshaler[M]) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	ctx := stream.Context()
+	sharedInformer := informer.Informer()
+	send := func(eventType $.watchEventType|raw$, obj interface{}) {
+		if tombstone, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+			obj = tombstone.Obj
+		}
+		item, ok := obj.(*$.type|raw$)
+		if !ok || ctx.Err() != nil {
+			return
+		}
+		message, err := marshal(eventType, item)
+		if err != nil {
+			$.utilruntimeHandleErrorWithContext|raw$(ctx, err, "Failed to marshal $.type|public$ event", "eventType", eventType, "object", $.klogKObj|raw$(item))
+			return
+		}
+		if err := stream.Send(message); err != nil && ctx.Err() == nil {
+			$.utilruntimeHandleErrorWithContext|raw$(ctx, err, "Failed to send $.type|public$ event", "eventType", eventType, "object", $.klogKObj|raw$(item))
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: func(obj interface{}) {
+			send($.watchAdded|raw$, obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			send($.watchModified|raw$, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			send($.watchDeleted|raw$, obj)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if factory, ok := $.type|private$FactoryExtensions(informer); ok {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = sharedInformer.RemoveEventHandler(registration)
+	}()
+	return registration, nil
+}
+`
+
+var typeInformerFilteredView = `
+// Filtered$.type|public$Informer provides access to the $.type|publicPlural$ of a shared informer
+// which match a predicate.
+type Filtered$.type|public$Informer interface {
+	// AddEventHandler adds handler to the shared informer. handler only sees matching
+	// $.type|publicPlural$: an update which makes an object match is delivered as an addition,
+	// and an update which makes it stop matching as a deletion.
+	AddEventHandler(handler $.cacheResourceEventHandler|raw$) ($.cacheResourceEventHandlerRegistration|raw$, error)
+	// RemoveEventHandler removes a handler added by AddEventHandler.
+	RemoveEventHandler(registration $.cacheResourceEventHandlerRegistration|raw$) error
+	// HasSynced returns true once the shared informer has synced.
+	HasSynced() bool
+	// Lister returns a lister which only lists and gets matching $.type|publicPlural$.
+	Lister() $.lister|raw$
+}
+
+// Filtered$.type|public$ returns a view of informer which only surfaces the $.type|publicPlural$
+// for which pred returns true. This allows filtering on anything a label or field selector
+// cannot express, such as the status. The view shares the watch and cache of informer,
+// so pred is evaluated whenever an object is read or an event is delivered.
+func Filtered$.type|public$(informer $.type|public$Informer, pred func(*$.type|raw$) bool) Filtered$.type|public$Informer {
+	return &filtered$.type|public$Informer{informer: informer, pred: pred}
+}
+
+type filtered$.type|public$Informer struct {
+	informer $.type|public$Informer
+	pred     func(*$.type|raw$) bool
+}
+
+func (f *filtered$.type|public$Informer) matches(obj interface{}) bool {
+	if tombstone, ok := obj.($.cacheDeletedFinalStateUnknown|raw$); ok {
+		obj = tombstone.Obj
+	}
+	item, ok := obj.(*$.type|raw$)
+	return ok && f.pred(item)
+}
+
+func (f *filtered$.type|public$Informer) AddEventHandler(handler $.cacheResourceEventHandler|raw$) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := f.informer.Informer()
+	factory, fromFactory := $.type|private$FactoryExtensions(f.informer)
+	if fromFactory {
+		handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, factory.PanicHandler(&$.type|raw${}))
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheFilteringResourceEventHandler|raw${FilterFunc: f.matches, Handler: handler})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+func (f *filtered$.type|public$Informer) RemoveEventHandler(registration $.cacheResourceEventHandlerRegistration|raw$) error {
+	return f.informer.Informer().RemoveEventHandler(registration)
+}
+
+func (f *filtered$.type|public$Informer) HasSynced() bool {
+	return f.informer.Informer().HasSynced()
+}
+
+func (f *filtered$.type|public$Informer) Lister() $.lister|raw$ {
+	return $.newLister|raw$($.interfacesNewFilteredIndexer|raw$(f.informer.Informer().GetIndexer(), f.matches))
+}
+`
+
+var typeInformerResumingHandler = `
+// Add$.type|public$HandlerFromResourceVersion adds handler to the shared informer of informer,
+// resuming from a checkpoint at resourceVersion. Additions and updates of $.type|publicPlural$
+// whose resource version is not newer than resourceVersion are not delivered, because they
+// were processed before the checkpoint. This skips the replay of the informer's cache to the
+// new handler as well as relisted $.type|publicPlural$ which did not change since. Deletions
+// are always delivered. Resource versions are compared as integers; $.type|publicPlural$ whose
+// resource version is not an integer are always delivered.
+func Add$.type|public$HandlerFromResourceVersion(informer $.type|public$Informer, resourceVersion string, handler $.cacheResourceEventHandler|raw$) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	checkpoint, err := $.strconvParseUint|raw$(resourceVersion, 10, 64)
+	if err != nil {
+		return nil, $.fmtErrorf|raw$("invalid resource version %q to resume from: %w", resourceVersion, err)
+	}
+	sharedInformer := informer.Informer()
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, factory.PanicHandler(&$.type|raw${}))
+	}
+	processed := func(obj interface{}) bool {
+		item, ok := obj.(*$.type|raw$)
+		if !ok {
+			return false
+		}
+		itemResourceVersion, err := $.strconvParseUint|raw$(item.ResourceVersion, 10, 64)
+		return err == nil && itemResourceVersion <= checkpoint
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerDetailedFuncs|raw${
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !processed(obj) {
+				handler.OnAdd(obj, isInInitialList)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !processed(newObj) {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: handler.OnDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerPostSyncHandler = `
+// Add$.type|public$PostSyncHandler adds handler to the shared informer of informer, suppressing
+// all events until the handler has synced, i.e. until the informer has synced and the replay
+// of its cache to the handler is over. handler only receives the changes of $.type|publicPlural$
+// after that. synced, if not nil, is invoked once when the handler has synced, before any event
+// is delivered to handler, unless ctx is done first.
+func Add$.type|public$PostSyncHandler(ctx $.contextContext|raw$, informer $.type|public$Informer, handler $.cacheResourceEventHandler|raw$, synced func()) ($.cacheResourceEventHandlerRegistration|raw$, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := $.type|private$FactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&$.type|raw${})
+		handler = $.interfacesNewPanicRecoveringEventHandler|raw$(handler, panicHandler)
+	}
+	var syncedOnce $.syncOnce|raw$
+	notifySynced := func() {
+		syncedOnce.Do(func() {
+			if synced != nil {
+				defer $.interfacesRecoverEventHandlerPanic|raw$(panicHandler)
+				synced()
+			}
+		})
+	}
+	// The events wait for the registration, which is only known once
+	// AddEventHandler returns.
+	var registration $.cacheResourceEventHandlerRegistration|raw$
+	registered := make(chan struct{})
+	postSync := func() bool {
+		<-registered
+		if !registration.HasSynced() {
+			return false
+		}
+		notifySynced()
+		return true
+	}
+	registration, err := sharedInformer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnAdd(obj, false)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if postSync() {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnDelete(obj)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	close(registered)
+	go func() {
+		select {
+		case <-registration.HasSyncedChecker().Done():
+			notifySynced()
+		case <-ctx.Done():
+		}
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+`
+
+var typeInformerExportList = `
+// Export$.type|public$List returns the $.type|publicPlural$ in the cache of informer$if .namespaced$ in
+// namespace, or in all namespaces for $.namespaceAll|raw$,$end$ which match selector as a
+// $.typeList|public$ with its kind and API version set, for example to write a snapshot of
+// the cache. The items are copies sorted by $if .namespaced$namespace and $end$name, and the
+// resource version of the list is the last one synced by the informer.
+func Export$.type|public$List(informer $.type|public$Informer$if .namespaced$, namespace string$end$, selector $.labelsSelector|raw$) (*$.typeList|raw$, error) {
+	$if .namespaced$var objs []*$.type|raw$
+	var err error
+	if namespace == $.namespaceAll|raw$ {
+		objs, err = informer.Lister().List(selector)
+	} else {
+		objs, err = informer.Lister().$.type|publicPlural$(namespace).List(selector)
+	}$else$objs, err := informer.Lister().List(selector)$end$
+	if err != nil {
+		return nil, err
+	}
+	$.slicesSortFunc|raw$(objs, func(a, b *$.type|raw$) int {
+		$if .namespaced$if c := $.stringsCompare|raw$(a.ObjectMeta.Namespace, b.ObjectMeta.Namespace); c != 0 {
+			return c
+		}
+		$end$return $.stringsCompare|raw$(a.ObjectMeta.Name, b.ObjectMeta.Name)
+	})
+	list := &$.typeList|raw${
+		TypeMeta: $.metav1TypeMeta|raw${Kind: "$.typeList|public$", APIVersion: "$if .groupName$$.groupName$/$end$$.versionName$"},
+		ListMeta: $.metav1ListMeta|raw${ResourceVersion: informer.Informer().LastSyncResourceVersion()},
+		Items:    make([]$.type|raw$, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.DeepCopy())
+	}
+	return list, nil
+}
+`
+
+var typeInformerToApplyConfiguration = `
+// $.type|public$ToApplyConfiguration extracts the apply configuration owned by
+// fieldManager from obj, typically returned by a lister, for an
+// extract/modify-in-place/apply workflow. Only the fields which fieldManager
+// applied and still owns are set, besides the name$if .namespaced$, namespace$end$, kind and API
+// version. The schema of obj is deduced from its value, so lists are treated
+// as atomic. obj is not modified.
+func $.type|public$ToApplyConfiguration(obj *$.type|raw$, fieldManager string) (*$.applyConfiguration|raw$, error) {
+	b := &$.applyConfiguration|raw${}
+	if err := $.managedfieldsExtractInto|raw$(obj, $.typedDeducedParseableType|raw$, fieldManager, b, ""); err != nil {
+		return nil, err
+	}
+	b.WithName(obj.ObjectMeta.Name)
+	$if .namespaced$b.WithNamespace(obj.ObjectMeta.Namespace)
+	$end$b.WithKind("$.type|public$")
+	b.WithAPIVersion("$if .groupName$$.groupName$/$end$$.versionName$")
+	return b, nil
+}
+`
+
+var typeInformerController = `
+// $.type|public$Controller calls a reconcile function for the keys of the $.type|publicPlural$
+// which are added, updated or deleted in the cache of an informer. Keys are queued in a
+// rate-limited work queue, so that a key is never reconciled by several workers at once, and
+// keys whose reconciliation fails are requeued with an exponential backoff.
+//
+// If informer comes from a factory created with WithInformerStats, the PendingDeltas of the
+// factory count a change until the controller queued its key. The keys waiting for
+// reconciliation are not counted there; the work queue reports them in the workqueue metrics
+// under the name "$.type|allLowercasePlural$".
+type $.type|public$Controller struct {
+	informer  $.type|public$Informer
+	reconcile func(key string) error
+	queue     $.workqueueTypedRateLimitingInterface|raw$[string]
+}
+
+// New$.type|public$Controller returns a controller which reconciles the $.type|publicPlural$ of
+// informer with reconcile. Keys are in the <namespace>/<name> form of
+// cache.MetaNamespaceKeyFunc, and reconcile must look the object up in the lister of informer
+// to tell whether it still exists. The controller does nothing until it is run.
+func New$.type|public$Controller(informer $.type|public$Informer, reconcile func(key string) error) *$.type|public$Controller {
+	return &$.type|public$Controller{
+		informer:  informer,
+		reconcile: reconcile,
+		queue: $.workqueueNewTypedRateLimitingQueueWithConfig|raw$(
+			$.workqueueDefaultTypedControllerRateLimiter|raw$[string](),
+			$.workqueueTypedRateLimitingQueueConfig|raw$[string]{Name: "$.type|allLowercasePlural$"},
+		),
+	}
+}
+
+// Run adds the event handler of the controller to the shared informer of c, waits for the
+// handler to sync and runs workers goroutines reconciling the queued keys until ctx is done.
+// The informer must be started separately. Run shuts the queue down and waits for the workers
+// to return before it returns; it can only be called once.
+func (c *$.type|public$Controller) Run(ctx $.contextContext|raw$, workers int) error {
+	defer c.queue.ShutDown()
+	enqueue := func(obj interface{}) {
+		key, err := $.cacheDeletionHandlingKeyFunc|raw$(obj)
+		if err != nil {
+			$.utilruntimeHandleErrorWithContext|raw$(ctx, err, "Failed to compute the key of an object", "resource", "$.type|allLowercasePlural$")
+			return
+		}
+		c.queue.Add(key)
+	}
+	registration, err := Add$.type|public$EventHandler(c.informer, $.cacheResourceEventHandlerFuncs|raw${
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, newObj interface{}) { enqueue(newObj) },
+		DeleteFunc: enqueue,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.informer.Informer().RemoveEventHandler(registration) }()
+	if !$.cacheWaitForCacheSync|raw$(ctx.Done(), registration.HasSynced) {
+		return $.fmtErrorf|raw$("failed to wait for $.type|publicPlural$ to sync: %w", ctx.Err())
+	}
+
+	var wg $.syncWaitGroup|raw$
+	for range workers {
+		wg.Go(func() {
+			for c.processNextKey(ctx) {
+			}
+		})
+	}
+	<-ctx.Done()
+	c.queue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+// processNextKey reconciles the next key of the queue, and returns false once the queue is
+// shut down.
+func (c *$.type|public$Controller) processNextKey(ctx $.contextContext|raw$) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+	if err := c.reconcile(key); err != nil {
+		$.utilruntimeHandleErrorWithContext|raw$(ctx, err, "Failed to reconcile, requeuing", "resource", "$.type|allLowercasePlural$", "key", key)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+`
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/parser"
+	"k8s.io/gengo/v2/types"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestLabelSelectorGolden(t *testing.T) {
+	c := goldenContext(t, goldenPackage)
+	accessors, err := newClientAccessors(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typesToGenerate := []*types.Type{
+		c.Universe.Type(types.Name{Package: goldenPackage, Name: "Labeled"}),
+		c.Universe.Type(types.Name{Package: goldenPackage, Name: "Unlabeled"}),
+	}
+	dir := t.TempDir()
+	target := versionTarget(dir, "example.com/informers", "example", clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}, "Example", []byte("// boilerplate\n"), typesToGenerate, "example.com/clientset", "example.com/listers", "", false, accessors)
+	if err := c.ExecuteTarget(target); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"labeled.go", "unlabeled.go"} {
+		checkGolden(t, filepath.Join(dir, "example", "v1", name), filepath.Join("testdata", "labelselector", name+".golden"))
+	}
+}
+
+// TestScopeGolden checks that only the informers of namespaced types take a
+// namespace, in their constructors and in the factory.
+func TestScopeGolden(t *testing.T) {
+	const pkg = "k8s.io/code-generator/cmd/informer-gen/generators/testdata/scope/v1"
+	c := goldenContext(t, pkg)
+	accessors, err := newClientAccessors(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typesToGenerate := []*types.Type{
+		c.Universe.Type(types.Name{Package: pkg, Name: "Clustered"}),
+		c.Universe.Type(types.Name{Package: pkg, Name: "Namespaced"}),
+	}
+	dir := t.TempDir()
+	target := versionTarget(dir, "example.com/informers", "example", clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}, "Example", []byte("// boilerplate\n"), typesToGenerate, "example.com/clientset", "example.com/listers", "", false, accessors)
+	if err := c.ExecuteTarget(target); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"clustered.go", "namespaced.go"} {
+		checkGolden(t, filepath.Join(dir, "example", "v1", name), filepath.Join("testdata", "scope", name+".golden"))
+	}
+}
+
+// goldenPackage is the API package of the golden files. It has a type with
+// and a type without +informers:labelSelector.
+const goldenPackage = "k8s.io/code-generator/cmd/informer-gen/generators/testdata/labelselector/v1"
+
+// goldenContext returns the context of generators for pkgs.
+func goldenContext(t *testing.T, pkgs ...string) *generator.Context {
+	t.Helper()
+	p := parser.New()
+	if err := p.LoadPackages(pkgs...); err != nil {
+		t.Fatal(err)
+	}
+	c, err := generator.NewContext(p, NameSystems(nil), DefaultNameSystem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// checkGolden compares the generated file with the golden file, or updates
+// the golden file with -update.
+func checkGolden(t *testing.T, file, golden string) {
+	t.Helper()
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from %s, run the test with -update if the change is intended:\n%s", filepath.Base(file), golden, got)
+	}
+}
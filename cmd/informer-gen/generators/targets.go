@@ -18,9 +18,14 @@ package generators
 
 import (
 	"fmt"
+	"maps"
 	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"k8s.io/code-generator/cmd/client-gen/generators/util"
 	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
@@ -60,30 +65,77 @@ func DefaultNameSystem() string {
 }
 
 // objectMetaForPackage returns the type of ObjectMeta used by package p.
+// The ObjectMeta of a type is its member named ObjectMeta, or as its
+// +informers:objectMetaField tag says, possibly promoted from embedded
+// members.
 func objectMetaForPackage(p *types.Package) (*types.Type, bool, error) {
-	generatingForPackage := false
+	var missing []string
 	for _, t := range p.Types {
-		if !util.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...)).GenerateClient {
+		tags := util.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+		if !tags.GenerateClient {
 			continue
 		}
-		generatingForPackage = true
-		for _, member := range t.Members {
-			if member.Name == "ObjectMeta" {
-				return member.Type, isInternal(member), nil
-			}
+		field := tags.ObjectMetaField
+		if field == "" {
+			field = "ObjectMeta"
+		}
+		if member, ok := findMember(t, field, map[*types.Type]bool{}); ok {
+			return member.Type, isInternal(member), nil
 		}
+		missing = append(missing, fmt.Sprintf("%s (no member %s)", t.Name.Name, field))
 	}
-	if generatingForPackage {
-		return nil, false, fmt.Errorf("unable to find ObjectMeta for any types in package %s", p.Path)
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, false, fmt.Errorf("unable to find ObjectMeta for any types in package %s: %s", p.Path, strings.Join(missing, ", "))
 	}
 	return nil, false, nil
 }
 
+// findMember returns the member of t of the given name, looking into its
+// embedded members like Go promotes their fields.
+func findMember(t *types.Type, name string, visited map[*types.Type]bool) (types.Member, bool) {
+	for t.Kind == types.Pointer || t.Kind == types.Alias {
+		if t.Kind == types.Pointer {
+			t = t.Elem
+		} else {
+			t = t.Underlying
+		}
+	}
+	if t.Kind != types.Struct || visited[t] {
+		return types.Member{}, false
+	}
+	visited[t] = true
+	for _, member := range t.Members {
+		if member.Name == name {
+			return member, true
+		}
+	}
+	for _, member := range t.Members {
+		if !member.Embedded {
+			continue
+		}
+		if found, ok := findMember(member.Type, name, visited); ok {
+			return found, true
+		}
+	}
+	return types.Member{}, false
+}
+
 // isInternal returns true if the tags for a member do not contain a json tag
 func isInternal(m types.Member) bool {
 	return !strings.Contains(m.Tags, "json")
 }
 
+// hasMember returns true if t is a struct with a member of the given name.
+func hasMember(t *types.Type, name string) bool {
+	for _, member := range t.Members {
+		if member.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 const subdirForInternalInterfaces = "internalinterfaces"
 
 // GetTargets makes the client target definition.
@@ -104,42 +156,63 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 		externalVersionOutputPkg = path.Join(externalVersionOutputPkg, "externalversions")
 	}
 
-	var targetList []generator.Target
-	typesForGroupVersion := make(map[clientgentypes.GroupVersion][]*types.Type)
+	clientAccessors, err := newClientAccessors(args.ClientAccessorTemplates)
+	if err != nil {
+		klog.Fatalf("Failed parsing client accessor templates: %v", err)
+	}
 
-	externalGroupVersions := make(map[string]clientgentypes.GroupVersions)
-	internalGroupVersions := make(map[string]clientgentypes.GroupVersions)
-	groupGoNames := make(map[string]string)
-	for _, inputPkg := range context.Inputs {
-		p := context.Universe.Package(inputPkg)
+	// Informers backed by a dynamic or a metadata client use neither a
+	// clientset nor listers.
+	untyped := args.Dynamic || args.MetadataOnly
+	informers := dynamicInformers
+	if args.MetadataOnly {
+		informers = metadataInformers
+	}
 
+	pluralExceptions := genutil.PluralExceptionListToMapOrDie(args.PluralExceptions)
+
+	// The input packages are read concurrently, then merged in their
+	// order so that the targets do not depend on the scheduling.
+	pkgs := make([]*types.Package, 0, len(context.Inputs))
+	for _, inputPkg := range context.Inputs {
+		pkgs = append(pkgs, context.Universe.Package(inputPkg))
+	}
+	versionPackages := mapPackages(pkgs, args.Parallelism, func(p *types.Package) *versionPackage {
 		objectMeta, internal, err := objectMetaForPackage(p)
 		if err != nil {
-			klog.Fatal(err)
+			if !args.AllowMissingObjectMeta {
+				klog.Fatal(err)
+			}
+			klog.Warningf("Skipping package %s: %v", p.Path, err)
+			return nil
 		}
 		if objectMeta == nil {
 			// no types in this package had genclient
-			continue
+			return nil
+		}
+		if internal && args.ExternalOnly {
+			klog.V(2).Infof("Skipping internal package %s: only external versions are generated", p.Path)
+			return nil
+		}
+		if internal && untyped {
+			klog.Warningf("Skipping internal package %s: dynamic and metadata informers are only generated for external versions", p.Path)
+			return nil
 		}
 
-		var gv clientgentypes.GroupVersion
-		var targetGroupVersions map[string]clientgentypes.GroupVersions
-
+		v := &versionPackage{internal: internal}
 		if internal {
 			lastSlash := strings.LastIndex(p.Path, "/")
 			if lastSlash == -1 {
 				klog.Fatalf("error constructing internal group version for package %q", p.Path)
 			}
-			gv.Group = clientgentypes.Group(p.Path[lastSlash+1:])
-			targetGroupVersions = internalGroupVersions
+			v.gv.Group = clientgentypes.Group(p.Path[lastSlash+1:])
 		} else {
 			parts := strings.Split(p.Path, "/")
-			gv.Group = clientgentypes.Group(parts[len(parts)-2])
-			gv.Version = clientgentypes.Version(parts[len(parts)-1])
-			targetGroupVersions = externalGroupVersions
+			v.gv.Group = clientgentypes.Group(parts[len(parts)-2])
+			v.gv.Version = clientgentypes.Version(parts[len(parts)-1])
 		}
-		groupPackageName := gv.Group.NonEmpty()
-		gvPackage := path.Clean(p.Path)
+		v.groupPackageName = v.gv.Group.NonEmpty()
+		v.gvPackage = path.Clean(p.Path)
 
 		// If there's a comment of the form "// +groupName=somegroup" or
 		// "// +groupName=somegroup.foo.bar.io", use the first field (somegroup) as the name of the
@@ -149,69 +222,98 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 			klog.Fatalf("error extracting groupName tags: %v", err)
 		}
 		if override["groupName"] != nil {
-			gv.Group = clientgentypes.Group(override["groupName"][0])
+			v.gv.Group = clientgentypes.Group(override["groupName"][0])
 		}
 
 		// If there's a comment of the form "// +groupGoName=SomeUniqueShortName", use that as
 		// the Go group identifier in CamelCase. It defaults
-		groupGoNames[groupPackageName] = namer.IC(strings.Split(gv.Group.NonEmpty(), ".")[0])
+		v.groupGoName = namer.IC(strings.Split(v.gv.Group.NonEmpty(), ".")[0])
 		override, err = genutil.ExtractCommentTagsWithoutArguments("+", []string{"groupGoName"}, p.Comments)
 		if err != nil {
 			klog.Fatalf("error extracting groupGoName tags: %v", err)
 		}
 		if override["groupGoName"] != nil {
-			groupGoNames[groupPackageName] = namer.IC(override["groupGoName"][0])
+			v.groupGoName = namer.IC(override["groupGoName"][0])
 		}
 
-		var typesToGenerate []*types.Type
 		for _, t := range p.Types {
 			tags := util.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
 			if !tags.GenerateClient || tags.NoVerbs || !tags.HasVerb("list") || !tags.HasVerb("watch") {
 				continue
 			}
+			v.types = append(v.types, t)
+		}
+		if len(v.types) == 0 {
+			return v
+		}
 
-			typesToGenerate = append(typesToGenerate, t)
+		orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
+		v.types = orderer.OrderTypes(v.types)
+
+		if untyped {
+			v.target = dynamicVersionTarget(
+				args.OutputDir, args.OutputPkg,
+				v.groupPackageName, v.gv, boilerplate, v.types,
+				pluralExceptions, informers)
+		} else if internal {
+			v.target = versionTarget(
+				internalVersionOutputDir, internalVersionOutputPkg,
+				v.groupPackageName, v.gv, v.groupGoName,
+				boilerplate, v.types,
+				args.InternalClientSetPackage, args.ListersPackage, "", args.Controllers, clientAccessors)
+		} else {
+			v.target = versionTarget(
+				externalVersionOutputDir, externalVersionOutputPkg,
+				v.groupPackageName, v.gv, v.groupGoName,
+				boilerplate, v.types,
+				args.VersionedClientSetPackage, args.ListersPackage, args.ApplyConfigurationPackage, args.Controllers, clientAccessors)
+		}
+		return v
+	})
 
-			if _, ok := typesForGroupVersion[gv]; !ok {
-				typesForGroupVersion[gv] = []*types.Type{}
-			}
-			typesForGroupVersion[gv] = append(typesForGroupVersion[gv], t)
+	var targetList []generator.Target
+	typesForGroupVersion := make(map[clientgentypes.GroupVersion][]*types.Type)
+
+	externalGroupVersions := make(map[string]clientgentypes.GroupVersions)
+	internalGroupVersions := make(map[string]clientgentypes.GroupVersions)
+	groupGoNames := make(map[string]string)
+	for _, v := range versionPackages {
+		if v == nil {
+			continue
 		}
-		if len(typesToGenerate) == 0 {
+		groupGoNames[v.groupPackageName] = v.groupGoName
+		if len(v.types) == 0 {
 			continue
 		}
+		typesForGroupVersion[v.gv] = append(typesForGroupVersion[v.gv], v.types...)
 
-		groupVersionsEntry, ok := targetGroupVersions[groupPackageName]
+		targetGroupVersions := externalGroupVersions
+		if v.internal {
+			targetGroupVersions = internalGroupVersions
+		}
+		groupVersionsEntry, ok := targetGroupVersions[v.groupPackageName]
 		if !ok {
 			groupVersionsEntry = clientgentypes.GroupVersions{
-				PackageName: groupPackageName,
-				Group:       gv.Group,
+				PackageName: v.groupPackageName,
+				Group:       v.gv.Group,
 			}
 		}
-		groupVersionsEntry.Versions = append(groupVersionsEntry.Versions, clientgentypes.PackageVersion{Version: gv.Version, Package: gvPackage})
-		targetGroupVersions[groupPackageName] = groupVersionsEntry
+		groupVersionsEntry.Versions = append(groupVersionsEntry.Versions, clientgentypes.PackageVersion{Version: v.gv.Version, Package: v.gvPackage})
+		targetGroupVersions[v.groupPackageName] = groupVersionsEntry
 
-		orderer := namer.Orderer{Namer: namer.NewPrivateNamer(0)}
-		typesToGenerate = orderer.OrderTypes(typesToGenerate)
+		targetList = append(targetList, v.target)
+	}
 
-		if internal {
+	if untyped {
+		if len(externalGroupVersions) != 0 {
 			targetList = append(targetList,
-				versionTarget(
-					internalVersionOutputDir, internalVersionOutputPkg,
-					groupPackageName, gv, groupGoNames[groupPackageName],
-					boilerplate, typesToGenerate,
-					args.InternalClientSetPackage, args.ListersPackage))
-		} else {
-			targetList = append(targetList,
-				versionTarget(
-					externalVersionOutputDir, externalVersionOutputPkg,
-					groupPackageName, gv, groupGoNames[groupPackageName],
-					boilerplate, typesToGenerate,
-					args.VersionedClientSetPackage, args.ListersPackage))
+				dynamicFactoryTarget(args.OutputDir, args.OutputPkg, boilerplate, groupGoNames, externalGroupVersions, informers))
+			for _, group := range slices.Sorted(maps.Keys(externalGroupVersions)) {
+				targetList = append(targetList,
+					dynamicGroupTarget(args.OutputDir, args.OutputPkg, externalGroupVersions[group], boilerplate, informers))
+			}
 		}
-	}
-
-	if len(externalGroupVersions) != 0 {
+	} else if len(externalGroupVersions) != 0 {
 		targetList = append(targetList,
 			factoryInterfaceTarget(
 				externalVersionOutputDir, externalVersionOutputPkg,
@@ -219,11 +321,11 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 		targetList = append(targetList,
 			factoryTarget(
 				externalVersionOutputDir, externalVersionOutputPkg,
-				boilerplate, groupGoNames, genutil.PluralExceptionListToMapOrDie(args.PluralExceptions),
-				externalGroupVersions, args.VersionedClientSetPackage, typesForGroupVersion))
-		for _, gvs := range externalGroupVersions {
+				boilerplate, groupGoNames, pluralExceptions,
+				externalGroupVersions, args.VersionedClientSetPackage, typesForGroupVersion, args.PrometheusCollector))
+		for _, group := range slices.Sorted(maps.Keys(externalGroupVersions)) {
 			targetList = append(targetList,
-				groupTarget(externalVersionOutputDir, externalVersionOutputPkg, gvs, boilerplate))
+				groupTarget(externalVersionOutputDir, externalVersionOutputPkg, externalGroupVersions[group], boilerplate, pluralExceptions, typesForGroupVersion))
 		}
 	}
 
@@ -233,19 +335,77 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 		targetList = append(targetList,
 			factoryTarget(
 				internalVersionOutputDir, internalVersionOutputPkg,
-				boilerplate, groupGoNames, genutil.PluralExceptionListToMapOrDie(args.PluralExceptions),
-				internalGroupVersions, args.InternalClientSetPackage, typesForGroupVersion))
-		for _, gvs := range internalGroupVersions {
+				boilerplate, groupGoNames, pluralExceptions,
+				internalGroupVersions, args.InternalClientSetPackage, typesForGroupVersion, args.PrometheusCollector))
+		for _, group := range slices.Sorted(maps.Keys(internalGroupVersions)) {
 			targetList = append(targetList,
-				groupTarget(internalVersionOutputDir, internalVersionOutputPkg, gvs, boilerplate))
+				groupTarget(internalVersionOutputDir, internalVersionOutputPkg, internalGroupVersions[group], boilerplate, pluralExceptions, typesForGroupVersion))
+		}
+	}
+
+	if args.PackageDoc != "" {
+		for i, target := range targetList {
+			if tagged, ok := target.(*buildTaggedTarget); ok {
+				withPackageDoc(tagged.SimpleTarget, args.PackageDoc)
+				continue
+			}
+			targetList[i] = withPackageDoc(target.(*generator.SimpleTarget), args.PackageDoc)
 		}
 	}
 
 	return targetList
 }
 
+// versionPackage is what GetTargets reads from an input package: its group
+// version, its types to generate informers for and their target.
+type versionPackage struct {
+	gv               clientgentypes.GroupVersion
+	internal         bool
+	groupPackageName string
+	groupGoName      string
+	gvPackage        string
+	types            []*types.Type
+	target           generator.Target
+}
+
+// mapPackages returns f of every package of pkgs, in their order. At most
+// parallelism calls of f run at once, or runtime.GOMAXPROCS(0) if
+// parallelism is not positive.
+func mapPackages[T any](pkgs []*types.Package, parallelism int, f func(*types.Package) T) []T {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	results := make([]T, len(pkgs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallelism, len(pkgs)) {
+		wg.Go(func() {
+			for i := range indexes {
+				results[i] = f(pkgs[i])
+			}
+		})
+	}
+	for i := range pkgs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// withPackageDoc makes target emit doc as its package comment, after the
+// boilerplate of a doc.go file.
+func withPackageDoc(target *generator.SimpleTarget, doc string) generator.Target {
+	target.PkgDocComment = []byte("// " + doc + "\n")
+	generatorsFunc := target.GeneratorsFunc
+	target.GeneratorsFunc = func(c *generator.Context) []generator.Generator {
+		return append(generatorsFunc(c), generator.GoGenerator{OutputFilename: "doc.go"})
+	}
+	return target
+}
+
 func factoryTarget(outputDirBase, outputPkgBase string, boilerplate []byte, groupGoNames, pluralExceptions map[string]string, groupVersions map[string]clientgentypes.GroupVersions, clientSetPackage string,
-	typesForGroupVersion map[clientgentypes.GroupVersion][]*types.Type) generator.Target {
+	typesForGroupVersion map[clientgentypes.GroupVersion][]*types.Type, prometheusCollector bool) generator.Target {
 	return &generator.SimpleTarget{
 		PkgName:       path.Base(outputDirBase),
 		PkgPath:       outputPkgBase,
@@ -262,6 +422,7 @@ func factoryTarget(outputDirBase, outputPkgBase string, boilerplate []byte, grou
 				clientSetPackage:          clientSetPackage,
 				internalInterfacesPackage: path.Join(outputPkgBase, subdirForInternalInterfaces),
 				gvGoNames:                 groupGoNames,
+				prometheusCollector:       prometheusCollector,
 			})
 
 			generators = append(generators, &genericGenerator{
@@ -305,7 +466,8 @@ func factoryInterfaceTarget(outputDirBase, outputPkgBase string, boilerplate []b
 	}
 }
 
-func groupTarget(outputDirBase, outputPackageBase string, groupVersions clientgentypes.GroupVersions, boilerplate []byte) generator.Target {
+func groupTarget(outputDirBase, outputPackageBase string, groupVersions clientgentypes.GroupVersions, boilerplate []byte, pluralExceptions map[string]string,
+	typesForGroupVersion map[clientgentypes.GroupVersion][]*types.Type) generator.Target {
 	outputDir := filepath.Join(outputDirBase, groupVersions.PackageName)
 	outputPkg := path.Join(outputPackageBase, groupVersions.PackageName)
 	groupPkgName := strings.Split(string(groupVersions.PackageName), ".")[0]
@@ -322,6 +484,8 @@ func groupTarget(outputDirBase, outputPackageBase string, groupVersions clientge
 				},
 				outputPackage:             outputPkg,
 				groupVersions:             groupVersions,
+				pluralExceptions:          pluralExceptions,
+				typesForGroupVersion:      typesForGroupVersion,
 				imports:                   generator.NewImportTrackerForPackage(outputPkg),
 				internalInterfacesPackage: path.Join(outputPackageBase, subdirForInternalInterfaces),
 			})
@@ -334,12 +498,12 @@ func groupTarget(outputDirBase, outputPackageBase string, groupVersions clientge
 	}
 }
 
-func versionTarget(outputDirBase, outputPkgBase string, groupPkgName string, gv clientgentypes.GroupVersion, groupGoName string, boilerplate []byte, typesToGenerate []*types.Type, clientSetPackage, listersPackage string) generator.Target {
+func versionTarget(outputDirBase, outputPkgBase string, groupPkgName string, gv clientgentypes.GroupVersion, groupGoName string, boilerplate []byte, typesToGenerate []*types.Type, clientSetPackage, listersPackage, applyConfigurationPackage string, controllers bool, clientAccessors *clientAccessors) generator.Target {
 	subdir := []string{groupPkgName, strings.ToLower(gv.Version.NonEmpty())}
 	outputDir := filepath.Join(outputDirBase, filepath.Join(subdir...))
 	outputPkg := path.Join(outputPkgBase, path.Join(subdir...))
 
-	return &generator.SimpleTarget{
+	return &buildTaggedTarget{SimpleTarget: &generator.SimpleTarget{
 		PkgName:       strings.ToLower(gv.Version.NonEmpty()),
 		PkgPath:       outputPkg,
 		PkgDir:        outputDir,
@@ -368,7 +532,115 @@ func versionTarget(outputDirBase, outputPkgBase string, groupPkgName string, gv
 					imports:                   generator.NewImportTrackerForPackage(outputPkg),
 					clientSetPackage:          clientSetPackage,
 					listersPackage:            listersPackage,
+					applyConfigurationPackage: applyConfigurationPackage,
+					controllers:               controllers,
 					internalInterfacesPackage: path.Join(outputPkgBase, subdirForInternalInterfaces),
+					clientAccessors:           clientAccessors,
+				})
+				tags := util.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+				if tags.TestListWatch != "" {
+					generators = append(generators, &testListWatchGenerator{
+						GoGenerator: generator.GoGenerator{
+							OutputFilename: strings.ToLower(t.Name.Name) + testListWatchFileSuffix,
+						},
+						outputPackage:  outputPkg,
+						typeToGenerate: t,
+						testListWatch:  tags.TestListWatch,
+						imports:        generator.NewImportTrackerForPackage(outputPkg),
+					})
+				}
+			}
+			return generators
+		},
+		FilterFunc: func(c *generator.Context, t *types.Type) bool {
+			tags := util.MustParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
+			return tags.GenerateClient && tags.HasVerb("list") && tags.HasVerb("watch")
+		},
+	}, fileSuffix: testListWatchFileSuffix, buildTag: testListWatchBuildTag}
+}
+
+// dynamicFactoryTarget makes the target of the factory of informers backed by
+// a dynamic or a metadata client. These informers have no internal interfaces
+// package and are generated directly in outputDirBase.
+func dynamicFactoryTarget(outputDirBase, outputPkgBase string, boilerplate []byte, groupGoNames map[string]string, groupVersions map[string]clientgentypes.GroupVersions, informers untypedInformers) generator.Target {
+	return &generator.SimpleTarget{
+		PkgName:       path.Base(outputDirBase),
+		PkgPath:       outputPkgBase,
+		PkgDir:        outputDirBase,
+		HeaderComment: boilerplate,
+		GeneratorsFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = append(generators, &dynamicFactoryGenerator{
+				GoGenerator: generator.GoGenerator{
+					OutputFilename: "factory.go",
+				},
+				outputPackage: outputPkgBase,
+				informers:     informers,
+				imports:       generator.NewImportTrackerForPackage(outputPkgBase),
+				groupVersions: groupVersions,
+				gvGoNames:     groupGoNames,
+			})
+			return generators
+		},
+	}
+}
+
+func dynamicGroupTarget(outputDirBase, outputPackageBase string, groupVersions clientgentypes.GroupVersions, boilerplate []byte, informers untypedInformers) generator.Target {
+	outputDir := filepath.Join(outputDirBase, groupVersions.PackageName)
+	outputPkg := path.Join(outputPackageBase, groupVersions.PackageName)
+	groupPkgName := strings.Split(string(groupVersions.PackageName), ".")[0]
+
+	return &generator.SimpleTarget{
+		PkgName:       groupPkgName,
+		PkgPath:       outputPkg,
+		PkgDir:        outputDir,
+		HeaderComment: boilerplate,
+		GeneratorsFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = append(generators, &dynamicGroupInterfaceGenerator{
+				GoGenerator: generator.GoGenerator{
+					OutputFilename: "interface.go",
+				},
+				outputPackage: outputPkg,
+				informers:     informers,
+				imports:       generator.NewImportTrackerForPackage(outputPkg),
+				groupVersions: groupVersions,
+			})
+			return generators
+		},
+	}
+}
+
+func dynamicVersionTarget(outputDirBase, outputPkgBase string, groupPkgName string, gv clientgentypes.GroupVersion, boilerplate []byte, typesToGenerate []*types.Type, pluralExceptions map[string]string, informers untypedInformers) generator.Target {
+	subdir := []string{groupPkgName, strings.ToLower(gv.Version.NonEmpty())}
+	outputDir := filepath.Join(outputDirBase, filepath.Join(subdir...))
+	outputPkg := path.Join(outputPkgBase, path.Join(subdir...))
+
+	return &generator.SimpleTarget{
+		PkgName:       strings.ToLower(gv.Version.NonEmpty()),
+		PkgPath:       outputPkg,
+		PkgDir:        outputDir,
+		HeaderComment: boilerplate,
+		GeneratorsFunc: func(c *generator.Context) (generators []generator.Generator) {
+			generators = append(generators, &dynamicVersionInterfaceGenerator{
+				GoGenerator: generator.GoGenerator{
+					OutputFilename: "interface.go",
+				},
+				outputPackage: outputPkg,
+				informers:     informers,
+				imports:       generator.NewImportTrackerForPackage(outputPkg),
+				types:         typesToGenerate,
+			})
+
+			for _, t := range typesToGenerate {
+				generators = append(generators, &dynamicInformerGenerator{
+					GoGenerator: generator.GoGenerator{
+						OutputFilename: strings.ToLower(t.Name.Name) + ".go",
+					},
+					outputPackage:    outputPkg,
+					informers:        informers,
+					imports:          generator.NewImportTrackerForPackage(outputPkg),
+					groupVersion:     gv,
+					typeToGenerate:   t,
+					pluralExceptions: pluralExceptions,
 				})
 			}
 			return generators
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/types"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+	"k8s.io/code-generator/cmd/informer-gen/args"
+)
+
+func TestWithPackageDoc(t *testing.T) {
+	boilerplate := []byte("// boilerplate\n")
+	target := factoryTarget("informers", "example.com/informers", boilerplate, nil, nil, nil, "example.com/clientset", nil, false)
+	target = withPackageDoc(target.(*generator.SimpleTarget), "Package informers has the informers of the example API.")
+
+	if got, want := string(target.Header("doc.go")), "// boilerplate\n// Package informers has the informers of the example API.\n"; got != want {
+		t.Errorf("got doc.go header %q, want %q", got, want)
+	}
+	if got := string(target.Header("factory.go")); strings.Contains(got, "Package informers") {
+		t.Errorf("expected the package doc only in doc.go, got factory.go header %q", got)
+	}
+	var filenames []string
+	for _, g := range target.Generators(nil) {
+		filenames = append(filenames, g.Filename())
+	}
+	if got, want := strings.Join(filenames, ","), "factory.go,generic.go,doc.go"; got != want {
+		t.Errorf("got files %s, want %s", got, want)
+	}
+}
+
+func TestBuildTaggedTarget(t *testing.T) {
+	boilerplate := []byte("// boilerplate\n")
+	target := versionTarget("informers", "example.com/informers", "example", clientgentypes.GroupVersion{Group: "example.com", Version: "v1"}, "Example", boilerplate, nil, "example.com/clientset", "example.com/listers", "", false, nil)
+	tagged := target.(*buildTaggedTarget)
+	withPackageDoc(tagged.SimpleTarget, "Package v1 has the informers of the example API.")
+
+	if got, want := string(target.Header("foo_testlistwatch.go")), "//go:build testlistwatch\n\n// boilerplate\n"; got != want {
+		t.Errorf("got foo_testlistwatch.go header %q, want %q", got, want)
+	}
+	if got, want := string(target.Header("foo.go")), "// boilerplate\n"; got != want {
+		t.Errorf("got foo.go header %q, want %q", got, want)
+	}
+	if got, want := string(target.Header("doc.go")), "// boilerplate\n// Package v1 has the informers of the example API.\n"; got != want {
+		t.Errorf("got doc.go header %q, want %q", got, want)
+	}
+}
+
+func TestObjectMetaForPackage(t *testing.T) {
+	const testdata = "k8s.io/code-generator/cmd/informer-gen/generators/testdata/objectmeta/"
+	tests := map[string]struct {
+		internal    bool
+		expectError string
+	}{
+		"embedded": {},
+		"override": {internal: true},
+		"missing":  {expectError: "Misnamed (no member Metadata), Missing (no member ObjectMeta)"},
+	}
+	c := goldenContext(t, testdata+"embedded", testdata+"override", testdata+"missing")
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			objectMeta, internal, err := objectMetaForPackage(c.Universe.Package(testdata + name))
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Fatalf("expected an error naming %s, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if objectMeta == nil || objectMeta.Name != (types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"}) {
+				t.Errorf("expected metav1.ObjectMeta, got %v", objectMeta)
+			}
+			if internal != tc.internal {
+				t.Errorf("got internal %v, want %v", internal, tc.internal)
+			}
+		})
+	}
+}
+
+func TestExternalOnly(t *testing.T) {
+	const testdata = "k8s.io/code-generator/cmd/informer-gen/generators/testdata/mixed/example"
+	c := goldenContext(t, testdata, testdata+"/v1")
+	for _, singleDirectory := range []bool{false, true} {
+		for _, externalOnly := range []bool{false, true} {
+			dir := filepath.Join(t.TempDir(), "informers")
+			targets := GetTargets(c, &args.Args{
+				OutputDir:                 dir,
+				OutputPkg:                 "example.com/informers",
+				VersionedClientSetPackage: "example.com/clientset/versioned",
+				InternalClientSetPackage:  "example.com/clientset/internalversion",
+				ListersPackage:            "example.com/listers",
+				SingleDirectory:           singleDirectory,
+				ExternalOnly:              externalOnly,
+			})
+			if err := c.ExecuteTargets(targets); err != nil {
+				t.Fatal(err)
+			}
+
+			var internalDirs, externalDirs []string
+			if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				switch {
+				case err != nil:
+					return err
+				case d.IsDir() && d.Name() == "internalversion":
+					internalDirs = append(internalDirs, path)
+				case d.IsDir() && d.Name() == "v1":
+					externalDirs = append(externalDirs, path)
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if len(externalDirs) == 0 {
+				t.Errorf("single directory %v, external only %v: expected the informers of v1", singleDirectory, externalOnly)
+			}
+			if externalOnly && len(internalDirs) > 0 {
+				t.Errorf("single directory %v: expected no internalversion directory, got %v", singleDirectory, internalDirs)
+			}
+			if !externalOnly && len(internalDirs) == 0 {
+				t.Errorf("single directory %v: expected an internalversion directory without --external-only", singleDirectory)
+			}
+		}
+	}
+}
+
+// syntheticContext returns a context with the given number of external API
+// groups, each with a single version of ten types.
+func syntheticContext(groups int) *generator.Context {
+	u := types.Universe{}
+	objectMeta := u.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"})
+	objectMeta.Kind = types.Struct
+	c := &generator.Context{Universe: u}
+	for g := range groups {
+		pkg := fmt.Sprintf("example.com/apis/group%d/v1", g)
+		u.Package(pkg).Name = "v1"
+		for i := range 10 {
+			t := u.Type(types.Name{Package: pkg, Name: fmt.Sprintf("Kind%d", i)})
+			t.Kind = types.Struct
+			t.CommentLines = []string{"+genclient"}
+			t.Members = []types.Member{{Name: "ObjectMeta", Embedded: true, Type: objectMeta, Tags: `json:"metadata,omitempty"`}}
+		}
+		c.Inputs = append(c.Inputs, pkg)
+	}
+	return c
+}
+
+func syntheticArgs(parallelism int) *args.Args {
+	return &args.Args{
+		OutputDir:                 "informers",
+		OutputPkg:                 "example.com/informers",
+		VersionedClientSetPackage: "example.com/clientset/versioned",
+		ListersPackage:            "example.com/listers",
+		Parallelism:               parallelism,
+	}
+}
+
+func TestGetTargetsParallelism(t *testing.T) {
+	c := syntheticContext(50)
+	var want []string
+	for _, target := range GetTargets(c, syntheticArgs(1)) {
+		want = append(want, target.Path())
+	}
+	for _, parallelism := range []int{0, 4, 64} {
+		var got []string
+		for _, target := range GetTargets(c, syntheticArgs(parallelism)) {
+			got = append(got, target.Path())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("parallelism %d: expected the targets %v, got %v", parallelism, want, got)
+		}
+	}
+}
+
+func BenchmarkGetTargets(b *testing.B) {
+	c := syntheticContext(50)
+	for _, parallelism := range []int{1, 0} {
+		b.Run(fmt.Sprintf("parallelism=%d", parallelism), func(b *testing.B) {
+			for b.Loop() {
+				GetTargets(c, syntheticArgs(parallelism))
+			}
+		})
+	}
+}
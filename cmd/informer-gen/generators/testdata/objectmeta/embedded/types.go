@@ -0,0 +1,34 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package embedded has a type whose ObjectMeta is promoted from an embedded
+// struct.
+package embedded
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Meta embeds the type and object metadata.
+type Meta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// +genclient
+
+// Embedded gets its ObjectMeta through Meta.
+type Embedded struct {
+	*Meta `json:",inline"`
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+	"strings"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+// testListWatchBuildTag is the build tag of the files which replace the
+// clients of informers with the ListerWatchers of +genclient:testListWatch.
+const testListWatchBuildTag = "testlistwatch"
+
+// testListWatchFileSuffix is the suffix of the names of these files.
+const testListWatchFileSuffix = "_testlistwatch.go"
+
+// testListWatchGenerator produces the file which sets the ListerWatcher of
+// +genclient:testListWatch for a type, in builds with testListWatchBuildTag.
+type testListWatchGenerator struct {
+	generator.GoGenerator
+	outputPackage  string
+	typeToGenerate *types.Type
+	testListWatch  string
+	imports        namer.ImportTracker
+}
+
+var _ generator.Generator = &testListWatchGenerator{}
+
+func (g *testListWatchGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.typeToGenerate
+}
+
+func (g *testListWatchGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *testListWatchGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *testListWatchGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+
+	klog.V(5).Infof("processing type %v", t)
+
+	i := strings.LastIndex(g.testListWatch, ".")
+	m := map[string]interface{}{
+		"buildTag":      testListWatchBuildTag,
+		"testListWatch": c.Universe.Function(types.Name{Package: g.testListWatch[:i], Name: g.testListWatch[i+1:]}),
+		"type":          t,
+	}
+
+	sw.Do(testListWatchTemplate, m)
+
+	return sw.Error()
+}
+
+var testListWatchTemplate = `
+func init() {
+	$.type|private$TestListWatch = $.testListWatch|raw$
+}
+`
+
+// buildTaggedTarget is a target whose files with fileSuffix are only built
+// with buildTag.
+type buildTaggedTarget struct {
+	*generator.SimpleTarget
+	fileSuffix string
+	buildTag   string
+}
+
+func (t *buildTaggedTarget) Header(filename string) []byte {
+	header := t.SimpleTarget.Header(filename)
+	if !strings.HasSuffix(filename, t.fileSuffix) {
+		return header
+	}
+	return append([]byte("//go:build "+t.buildTag+"\n\n"), header...)
+}
@@ -19,38 +19,161 @@ package generators
 import "k8s.io/gengo/v2/types"
 
 var (
-	apiScheme                                    = types.Name{Package: "k8s.io/kubernetes/pkg/api/legacyscheme", Name: "Scheme"}
-	cacheDoneChecker                             = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "DoneChecker"}
-	cacheGenericLister                           = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "GenericLister"}
-	cacheIndexers                                = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexers"}
-	cacheInformerName                            = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "InformerName"}
-	cacheListWatch                               = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ListWatch"}
-	cacheMetaNamespaceIndexFunc                  = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "MetaNamespaceIndexFunc"}
-	cacheNamespaceIndex                          = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NamespaceIndex"}
-	cacheNewGenericLister                        = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewGenericLister"}
-	cacheNewSharedIndexInformer                  = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewSharedIndexInformer"}
-	cacheNewSharedIndexInformerWithOptions       = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewSharedIndexInformerWithOptions"}
-	cacheSharedIndexInformer                     = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformer"}
-	cacheSharedIndexInformerOptions              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformerOptions"}
-	cacheSyncResult                              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SyncResult"}
-	cacheTransformFunc                           = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "TransformFunc"}
-	cacheToListWatcherWithWatchListSemanticsFunc = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ToListWatcherWithWatchListSemantics"}
-	cacheWaitForFunc                             = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WaitFor"}
-	contextBackgroundFunc                        = types.Name{Package: "context", Name: "Background"}
-	contextCauseFunc                             = types.Name{Package: "context", Name: "Cause"}
-	contextContext                               = types.Name{Package: "context", Name: "Context"}
-	fmtErrorfFunc                                = types.Name{Package: "fmt", Name: "Errorf"}
-	listOptions                                  = types.Name{Package: "k8s.io/kubernetes/pkg/apis/core", Name: "ListOptions"}
-	reflectType                                  = types.Name{Package: "reflect", Name: "Type"}
-	runtimeObject                                = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Object"}
-	schemaGroupResource                          = types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupResource"}
-	schemaGroupVersionResource                   = types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupVersionResource"}
-	stringsBuilder                               = types.Name{Package: "strings", Name: "Builder"}
-	syncMutex                                    = types.Name{Package: "sync", Name: "Mutex"}
-	timeDuration                                 = types.Name{Package: "time", Name: "Duration"}
-	v1ListOptions                                = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ListOptions"}
-	metav1NamespaceAll                           = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "NamespaceAll"}
-	metav1Object                                 = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Object"}
-	waitContextForChannelFunc                    = types.Name{Package: "k8s.io/apimachinery/pkg/util/wait", Name: "ContextForChannel"}
-	watchInterface                               = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Interface"}
+	atomicUint64                                     = types.Name{Package: "sync/atomic", Name: "Uint64"}
+	apiScheme                                        = types.Name{Package: "k8s.io/kubernetes/pkg/api/legacyscheme", Name: "Scheme"}
+	apierrorsNewResourceExpiredFunc                  = types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "NewResourceExpired"}
+	authorizationv1ResourceAttributes                = types.Name{Package: "k8s.io/api/authorization/v1", Name: "ResourceAttributes"}
+	authorizationv1SelfSubjectAccessReview           = types.Name{Package: "k8s.io/api/authorization/v1", Name: "SelfSubjectAccessReview"}
+	authorizationv1SelfSubjectAccessReviewSpec       = types.Name{Package: "k8s.io/api/authorization/v1", Name: "SelfSubjectAccessReviewSpec"}
+	authzclientSelfSubjectAccessReviewsGetter        = types.Name{Package: "k8s.io/client-go/kubernetes/typed/authorization/v1", Name: "SelfSubjectAccessReviewsGetter"}
+	cacheDefaultWatchErrorHandlerFunc                = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "DefaultWatchErrorHandler"}
+	cacheDoneChecker                                 = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "DoneChecker"}
+	cacheFilteringResourceEventHandler               = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "FilteringResourceEventHandler"}
+	cacheGenericLister                               = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "GenericLister"}
+	cacheHandlerOptions                              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "HandlerOptions"}
+	cacheHistogramMetric                             = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "HistogramMetric"}
+	cacheIndexer                                     = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexer"}
+	cacheIndexers                                    = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexers"}
+	cacheInformerName                                = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "InformerName"}
+	cacheNewInformerNameFunc                         = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewInformerName"}
+	cacheInformerSynced                              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "InformerSynced"}
+	cacheListerWatcher                               = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ListerWatcher"}
+	cacheListerWatcherWithContext                    = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ListerWatcherWithContext"}
+	cacheListWatch                                   = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ListWatch"}
+	cacheMetaNamespaceKeyFunc                        = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "MetaNamespaceKeyFunc"}
+	cacheNewIndexerFunc                              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewIndexer"}
+	cacheSplitMetaNamespaceKeyFunc                   = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SplitMetaNamespaceKey"}
+	cacheMetaNamespaceIndexFunc                      = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "MetaNamespaceIndexFunc"}
+	cacheNamespaceIndex                              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NamespaceIndex"}
+	cacheNewGenericLister                            = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewGenericLister"}
+	cacheNewSharedIndexInformer                      = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewSharedIndexInformer"}
+	cacheNewSharedIndexInformerWithOptions           = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "NewSharedIndexInformerWithOptions"}
+	cacheDeletionHandlingMetaNamespaceKeyFunc        = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "DeletionHandlingMetaNamespaceKeyFunc"}
+	cacheDeletedFinalStateUnknown                    = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "DeletedFinalStateUnknown"}
+	cacheReflector                                   = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Reflector"}
+	cacheResourceEventHandler                        = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ResourceEventHandler"}
+	cacheResourceEventHandlerDetailedFuncs           = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ResourceEventHandlerDetailedFuncs"}
+	cacheResourceEventHandlerRegistration            = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ResourceEventHandlerRegistration"}
+	cacheResourceEventHandlerFuncs                   = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ResourceEventHandlerFuncs"}
+	cacheSharedIndexInformer                         = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformer"}
+	cacheSharedIndexInformerOptions                  = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformerOptions"}
+	cacheStore                                       = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Store"}
+	cacheSyncResult                                  = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SyncResult"}
+	cacheToListerWatcherWithContextFunc              = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ToListerWatcherWithContext"}
+	cacheTransformFunc                               = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "TransformFunc"}
+	cacheToListWatcherWithWatchListSemanticsFunc     = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ToListWatcherWithWatchListSemantics"}
+	cacheWaitForFunc                                 = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WaitFor"}
+	cacheWaitForNamedCacheSyncFunc                   = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WaitForNamedCacheSync"}
+	cacheWaitForCacheSyncFunc                        = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WaitForCacheSync"}
+	cacheWatchErrorHandler                           = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WatchErrorHandler"}
+	cacheWatchErrorHandlerWithContext                = types.Name{Package: "k8s.io/client-go/tools/cache", Name: "WatchErrorHandlerWithContext"}
+	contextAfterFuncFunc                             = types.Name{Package: "context", Name: "AfterFunc"}
+	contextBackgroundFunc                            = types.Name{Package: "context", Name: "Background"}
+	contextCanceled                                  = types.Name{Package: "context", Name: "Canceled"}
+	contextCancelFunc                                = types.Name{Package: "context", Name: "CancelFunc"}
+	contextCancelCauseFunc                           = types.Name{Package: "context", Name: "CancelCauseFunc"}
+	contextCauseFunc                                 = types.Name{Package: "context", Name: "Cause"}
+	contextContext                                   = types.Name{Package: "context", Name: "Context"}
+	contextTODOFunc                                  = types.Name{Package: "context", Name: "TODO"}
+	contextWithCancelCauseFunc                       = types.Name{Package: "context", Name: "WithCancelCause"}
+	corev1EventTypeWarning                           = types.Name{Package: "k8s.io/api/core/v1", Name: "EventTypeWarning"}
+	dynamicInterface                                 = types.Name{Package: "k8s.io/client-go/dynamic", Name: "Interface"}
+	dynamicinformerNewFilteredFactoryFunc            = types.Name{Package: "k8s.io/client-go/dynamic/dynamicinformer", Name: "NewFilteredDynamicSharedInformerFactory"}
+	dynamicinformerSharedInformerFactory             = types.Name{Package: "k8s.io/client-go/dynamic/dynamicinformer", Name: "DynamicSharedInformerFactory"}
+	dynamicinformerTweakListOptionsFunc              = types.Name{Package: "k8s.io/client-go/dynamic/dynamicinformer", Name: "TweakListOptionsFunc"}
+	dynamiclisterLister                              = types.Name{Package: "k8s.io/client-go/dynamic/dynamiclister", Name: "Lister"}
+	dynamiclisterNewFunc                             = types.Name{Package: "k8s.io/client-go/dynamic/dynamiclister", Name: "New"}
+	metadataInterface                                = types.Name{Package: "k8s.io/client-go/metadata", Name: "Interface"}
+	metadatainformerNewFilteredFactoryFunc           = types.Name{Package: "k8s.io/client-go/metadata/metadatainformer", Name: "NewFilteredSharedInformerFactory"}
+	metadatainformerSharedInformerFactory            = types.Name{Package: "k8s.io/client-go/metadata/metadatainformer", Name: "SharedInformerFactory"}
+	metadatainformerTweakListOptionsFunc             = types.Name{Package: "k8s.io/client-go/metadata/metadatainformer", Name: "TweakListOptionsFunc"}
+	metadatalisterLister                             = types.Name{Package: "k8s.io/client-go/metadata/metadatalister", Name: "Lister"}
+	metadatalisterNewFunc                            = types.Name{Package: "k8s.io/client-go/metadata/metadatalister", Name: "New"}
+	errorsJoinFunc                                   = types.Name{Package: "errors", Name: "Join"}
+	errorsNewFunc                                    = types.Name{Package: "errors", Name: "New"}
+	eventsEventRecorder                              = types.Name{Package: "k8s.io/client-go/tools/events", Name: "EventRecorder"}
+	featuresGates                                    = types.Name{Package: "k8s.io/client-go/features", Name: "Gates"}
+	fmtErrorfFunc                                    = types.Name{Package: "fmt", Name: "Errorf"}
+	ioEOF                                            = types.Name{Package: "io", Name: "EOF"}
+	ioReadAllFunc                                    = types.Name{Package: "io", Name: "ReadAll"}
+	ioReader                                         = types.Name{Package: "io", Name: "Reader"}
+	ioWriter                                         = types.Name{Package: "io", Name: "Writer"}
+	jsonMarshalFunc                                  = types.Name{Package: "encoding/json", Name: "Marshal"}
+	jsonNewDecoderFunc                               = types.Name{Package: "encoding/json", Name: "NewDecoder"}
+	jsonNewEncoderFunc                               = types.Name{Package: "encoding/json", Name: "NewEncoder"}
+	klogKObjFunc                                     = types.Name{Package: "k8s.io/klog/v2", Name: "KObj"}
+	klogLogger                                       = types.Name{Package: "k8s.io/klog/v2", Name: "Logger"}
+	metaAccessorFunc                                 = types.Name{Package: "k8s.io/apimachinery/pkg/api/meta", Name: "Accessor"}
+	labelsSelector                                   = types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Selector"}
+	listOptions                                      = types.Name{Package: "k8s.io/kubernetes/pkg/apis/core", Name: "ListOptions"}
+	metaExtractListFunc                              = types.Name{Package: "k8s.io/apimachinery/pkg/api/meta", Name: "ExtractList"}
+	metaListAccessorFunc                             = types.Name{Package: "k8s.io/apimachinery/pkg/api/meta", Name: "ListAccessor"}
+	metav1CreateOptions                              = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "CreateOptions"}
+	metav1List                                       = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "List"}
+	metav1ResourceVersionMatch                       = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ResourceVersionMatch"}
+	metav1ResourceVersionMatchExact                  = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ResourceVersionMatchExact"}
+	metav1ResourceVersionMatchNotOlderThan           = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ResourceVersionMatchNotOlderThan"}
+	metaSetListFunc                                  = types.Name{Package: "k8s.io/apimachinery/pkg/api/meta", Name: "SetList"}
+	metav1ListMeta                                   = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ListMeta"}
+	metav1TypeMeta                                   = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "TypeMeta"}
+	reflectType                                      = types.Name{Package: "reflect", Name: "Type"}
+	prometheusCollector                              = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "Collector"}
+	prometheusDesc                                   = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "Desc"}
+	prometheusGaugeValue                             = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "GaugeValue"}
+	prometheusMetric                                 = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "Metric"}
+	prometheusMustNewConstMetricFunc                 = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "MustNewConstMetric"}
+	prometheusObserverVec                            = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "ObserverVec"}
+	prometheusNewDescFunc                            = types.Name{Package: "github.com/prometheus/client_golang/prometheus", Name: "NewDesc"}
+	reflectTypeOfFunc                                = types.Name{Package: "reflect", Name: "TypeOf"}
+	runtimeCodec                                     = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Codec"}
+	runtimeDecoder                                   = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Decoder"}
+	runtimeDefaultUnstructuredConverter              = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "DefaultUnstructuredConverter"}
+	runtimeObject                                    = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Object"}
+	runtimeScheme                                    = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Scheme"}
+	runtimeUnstructured                              = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Unstructured"}
+	runtimeRawExtension                              = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "RawExtension"}
+	schemaGroupResource                              = types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupResource"}
+	schemaGroupVersionKind                           = types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupVersionKind"}
+	schemaGroupVersionResource                       = types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupVersionResource"}
+	slicesContainsFunc                               = types.Name{Package: "slices", Name: "Contains"}
+	slicesIndexFuncFunc                              = types.Name{Package: "slices", Name: "IndexFunc"}
+	slicesInsertFunc                                 = types.Name{Package: "slices", Name: "Insert"}
+	slicesCloneFunc                                  = types.Name{Package: "slices", Name: "Clone"}
+	slicesSortFunc                                   = types.Name{Package: "slices", Name: "SortFunc"}
+	strconvParseUintFunc                             = types.Name{Package: "strconv", Name: "ParseUint"}
+	stringsBuilder                                   = types.Name{Package: "strings", Name: "Builder"}
+	stringsCompare                                   = types.Name{Package: "strings", Name: "Compare"}
+	syncMutex                                        = types.Name{Package: "sync", Name: "Mutex"}
+	syncOnce                                         = types.Name{Package: "sync", Name: "Once"}
+	syncRWMutex                                      = types.Name{Package: "sync", Name: "RWMutex"}
+	syncWaitGroup                                    = types.Name{Package: "sync", Name: "WaitGroup"}
+	timeAfterFuncFunc                                = types.Name{Package: "time", Name: "AfterFunc"}
+	timeDuration                                     = types.Name{Package: "time", Name: "Duration"}
+	timeMinute                                       = types.Name{Package: "time", Name: "Minute"}
+	timeNewTimerFunc                                 = types.Name{Package: "time", Name: "NewTimer"}
+	timeNowFunc                                      = types.Name{Package: "time", Name: "Now"}
+	timeTime                                         = types.Name{Package: "time", Name: "Time"}
+	timeTimer                                        = types.Name{Package: "time", Name: "Timer"}
+	typesUID                                         = types.Name{Package: "k8s.io/apimachinery/pkg/types", Name: "UID"}
+	utilruntimeHandleErrorFunc                       = types.Name{Package: "k8s.io/apimachinery/pkg/util/runtime", Name: "HandleError"}
+	utilruntimeHandleErrorWithContextFunc            = types.Name{Package: "k8s.io/apimachinery/pkg/util/runtime", Name: "HandleErrorWithContext"}
+	v1ListOptions                                    = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ListOptions"}
+	managedfieldsExtractIntoFunc                     = types.Name{Package: "k8s.io/apimachinery/pkg/util/managedfields", Name: "ExtractInto"}
+	typedDeducedParseableType                        = types.Name{Package: "sigs.k8s.io/structured-merge-diff/v6/typed", Name: "DeducedParseableType"}
+	metav1ParameterCodec                             = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ParameterCodec"}
+	metav1NamespaceAll                               = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "NamespaceAll"}
+	metav1Object                                     = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Object"}
+	waitContextForChannelFunc                        = types.Name{Package: "k8s.io/apimachinery/pkg/util/wait", Name: "ContextForChannel"}
+	watchBookmark                                    = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Bookmark"}
+	watchAdded                                       = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Added"}
+	watchDeleted                                     = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Deleted"}
+	watchError                                       = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Error"}
+	watchEvent                                       = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Event"}
+	watchEventType                                   = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "EventType"}
+	watchInterface                                   = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Interface"}
+	watchModified                                    = types.Name{Package: "k8s.io/apimachinery/pkg/watch", Name: "Modified"}
+	workqueueDefaultTypedControllerRateLimiterFunc   = types.Name{Package: "k8s.io/client-go/util/workqueue", Name: "DefaultTypedControllerRateLimiter"}
+	workqueueNewTypedRateLimitingQueueWithConfigFunc = types.Name{Package: "k8s.io/client-go/util/workqueue", Name: "NewTypedRateLimitingQueueWithConfig"}
+	workqueueTypedRateLimitingInterface              = types.Name{Package: "k8s.io/client-go/util/workqueue", Name: "TypedRateLimitingInterface"}
+	workqueueTypedRateLimitingQueueConfig            = types.Name{Package: "k8s.io/client-go/util/workqueue", Name: "TypedRateLimitingQueueConfig"}
 )
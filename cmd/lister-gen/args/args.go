@@ -18,8 +18,10 @@ package args
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Args is used by the gengo framework to pass args specific to this generator.
@@ -31,6 +33,18 @@ type Args struct {
 	// PluralExceptions specify list of exceptions used when pluralizing certain types.
 	// For example 'Endpoints:Endpoints', otherwise the pluralizer will generate 'Endpointes'.
 	PluralExceptions []string
+
+	// TenantLabel is the label which assigns objects to tenants. If set,
+	// listers get a ListByTenant method backed by an index on that label.
+	TenantLabel string
+
+	// PopulateTypeMeta makes listers return copies of the cached objects with
+	// their API version and kind set, instead of the cached objects.
+	PopulateTypeMeta bool
+
+	// AllowMissingObjectMeta makes packages whose genclient types lack
+	// ObjectMeta be reported and skipped, instead of failing generation.
+	AllowMissingObjectMeta bool
 }
 
 // New returns default arguments for the generator.
@@ -46,6 +60,12 @@ func (args *Args) AddFlags(fs *pflag.FlagSet) {
 		"the base Go import-path under which to generate results")
 	fs.StringSliceVar(&args.PluralExceptions, "plural-exceptions", args.PluralExceptions,
 		"list of comma separated plural exception definitions in Type:PluralizedType format")
+	fs.StringVar(&args.TenantLabel, "tenant-label", "",
+		"the label which assigns objects to tenants; if set, listers get a ListByTenant method backed by an index on that label")
+	fs.BoolVar(&args.PopulateTypeMeta, "populate-type-meta", args.PopulateTypeMeta,
+		"if true, listers return copies of the cached objects with their API version and kind set")
+	fs.BoolVar(&args.AllowMissingObjectMeta, "allow-missing-objectmeta", args.AllowMissingObjectMeta,
+		"if true, packages with genclient types lacking ObjectMeta are reported and skipped instead of failing generation")
 	fs.StringVar(&args.GoHeaderFile, "go-header-file", "",
 		"the path to a file containing boilerplate header text; the string \"YEAR\" will be replaced with the current 4-digit year")
 }
@@ -58,5 +78,10 @@ func (args *Args) Validate() error {
 	if len(args.OutputPkg) == 0 {
 		return fmt.Errorf("--output-pkg must be specified")
 	}
+	if len(args.TenantLabel) > 0 {
+		if errs := validation.IsQualifiedName(args.TenantLabel); len(errs) > 0 {
+			return fmt.Errorf("--tenant-label must be a valid label key: %s", strings.Join(errs, "; "))
+		}
+	}
 	return nil
 }
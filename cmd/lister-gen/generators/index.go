@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/v2/types"
+)
+
+// listerIndex is an index of the objects of a type by one of its string
+// members, from a +lister:index tag.
+type listerIndex struct {
+	// Name is Path without the dots, for the names of the generated
+	// functions and methods.
+	Name string
+	// Path is the path of the member from the type, e.g. Spec.NodeName.
+	Path string
+	// Convert is true if the member has a named string type, whose values
+	// are converted to strings.
+	Convert bool
+}
+
+// listerIndexes returns the indexes of t by the members at paths. Every path
+// must go through struct members to a member whose type is a string.
+func listerIndexes(t *types.Type, paths []string) ([]listerIndex, error) {
+	var indexes []listerIndex
+	for _, p := range paths {
+		member := t
+		for _, name := range strings.Split(p, ".") {
+			if member.Kind == types.Alias {
+				member = member.Underlying
+			}
+			found := false
+			if member.Kind == types.Struct {
+				for _, m := range member.Members {
+					if m.Name == name {
+						member, found = m.Type, true
+						break
+					}
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("+lister:index=%s of %s: no member %s", p, t.Name, name)
+			}
+		}
+		index := listerIndex{Name: strings.ReplaceAll(p, ".", ""), Path: p}
+		switch {
+		case member == types.String:
+		case member.Kind == types.Alias && member.Underlying == types.String:
+			index.Convert = true
+		default:
+			return nil, fmt.Errorf("+lister:index=%s of %s: %s is not a string", p, t.Name, member.Name)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+var typeListerIndexers = `
+// $.type|public$Indexers returns the indexers which the ListBy methods of
+// $.type|public$Lister rely on. They must be added to the informer of
+// $.type|publicPlural$ before it is started:
+//
+//	informer.AddIndexers($.type|public$Indexers())
+func $.type|public$Indexers() $.cacheIndexers|raw$ {
+	return $.cacheIndexers|raw${
+$- range .indexes $
+		$.type|public$$.Name$Index: $.type|public$$.Name$IndexFunc,
+$- end $
+	}
+}
+`
+
+var typeListerIndex = `
+// $.type|public$$.index.Name$Index is the name of the index of $.type|publicPlural$ by $.index.Path$.
+const $.type|public$$.index.Name$Index = "$.index.Path$"
+
+// $.type|public$$.index.Name$IndexFunc indexes $.type|publicPlural$ by $.index.Path$.
+// $.type|publicPlural$ whose $.index.Path$ is empty are not indexed.
+func $.type|public$$.index.Name$IndexFunc(obj interface{}) ([]string, error) {
+	item, ok := obj.(*$.type|raw$)
+	if !ok {
+		return nil, $.fmtErrorf|raw$("expected *$.type|raw$, got %T", obj)
+	}
+	if value := $if .index.Convert$string(item.$.index.Path$)$else$item.$.index.Path$$end$; value != "" {
+		return []string{value}, nil
+	}
+	return nil, nil
+}
+
+// ListBy$.index.Name$ lists all $.type|publicPlural$ in the indexer whose $.index.Path$ is value.
+// The indexer must have the $.type|public$$.index.Name$Index index, see $.type|public$Indexers.
+func (s *$.type|private$Lister) ListBy$.index.Name$(value string) (ret []*$.type|raw$, err error) {
+	objs, err := s.indexer.ByIndex($.type|public$$.index.Name$Index, value)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		item := obj.(*$.type|raw$)
+		ret = append(ret, $if .typeMeta$with$.type|public$TypeMeta(item)$else$item$end$)
+	}
+	return ret, nil
+}
+`
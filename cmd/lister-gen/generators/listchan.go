@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+)
+
+// listChanGenerator produces the streaming helper shared by the ListChan
+// methods of the listers of a package.
+type listChanGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	imports       namer.ImportTracker
+	types         []*types.Type
+}
+
+var _ generator.Generator = &listChanGenerator{}
+
+// We only want to call GenerateType() once per group.
+func (g *listChanGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.types[0]
+}
+
+func (g *listChanGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *listChanGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *listChanGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	m := map[string]interface{}{
+		"contextContext": c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
+		"labelsSelector": c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Selector"}),
+	}
+	sw.Do(listChan, m)
+	return sw.Error()
+}
+
+var listChan = `
+// listChan sends the items returned by listFunc for selector on the returned
+// channel, one at a time, and closes the channel when all items were received
+// or ctx is done. The items are listed before the first one is sent, so the
+// consumer sees a snapshot of the indexer. The channel is also closed without
+// any item if listFunc fails.
+func listChan[T any](ctx $.contextContext|raw$, selector $.labelsSelector|raw$, listFunc func($.labelsSelector|raw$) ([]T, error)) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		items, err := listFunc(selector)
+		if err != nil {
+			return
+		}
+		for _, item := range items {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+`
@@ -73,7 +73,11 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 
 		objectMeta, internal, err := objectMetaForPackage(p)
 		if err != nil {
-			klog.Fatal(err)
+			if !args.AllowMissingObjectMeta {
+				klog.Fatal(err)
+			}
+			klog.Warningf("Skipping package %s: %v", p.Path, err)
+			continue
 		}
 		if objectMeta == nil {
 			// no types in this package had genclient
@@ -144,6 +148,33 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 					outputPath: outputDir,
 					types:      typesToGenerate,
 				})
+				generators = append(generators, &selectorCacheGenerator{
+					GoGenerator: generator.GoGenerator{
+						OutputFilename: "selector_cache.go",
+					},
+					outputPackage: outputPkg,
+					imports:       generator.NewImportTrackerForPackage(outputPkg),
+					types:         typesToGenerate,
+				})
+				generators = append(generators, &listChanGenerator{
+					GoGenerator: generator.GoGenerator{
+						OutputFilename: "list_chan.go",
+					},
+					outputPackage: outputPkg,
+					imports:       generator.NewImportTrackerForPackage(outputPkg),
+					types:         typesToGenerate,
+				})
+				if len(args.TenantLabel) > 0 {
+					generators = append(generators, &tenantIndexGenerator{
+						GoGenerator: generator.GoGenerator{
+							OutputFilename: "tenant_index.go",
+						},
+						outputPackage: outputPkg,
+						imports:       generator.NewImportTrackerForPackage(outputPkg),
+						types:         typesToGenerate,
+						tenantLabel:   args.TenantLabel,
+					})
+				}
 
 				for _, t := range typesToGenerate {
 					generators = append(generators, &listerGenerator{
@@ -156,6 +187,8 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 						typeToGenerate: t,
 						imports:        generator.NewImportTrackerForPackage(outputPkg),
 						objectMeta:     objectMeta,
+						tenantIndex:    len(args.TenantLabel) > 0,
+						typeMeta:       args.PopulateTypeMeta && !internal,
 					})
 				}
 				return generators
@@ -187,6 +220,16 @@ func objectMetaForPackage(p *types.Package) (*types.Type, bool, error) {
 	return nil, false, nil
 }
 
+// hasTypeMeta returns true if t embeds TypeMeta.
+func hasTypeMeta(t *types.Type) bool {
+	for _, member := range t.Members {
+		if member.Embedded && member.Name == "TypeMeta" {
+			return true
+		}
+	}
+	return false
+}
+
 // isInternal returns true if the tags for a member do not contain a json tag
 func isInternal(m types.Member) bool {
 	return !strings.Contains(m.Tags, "json")
@@ -202,6 +245,12 @@ type listerGenerator struct {
 	typeToGenerate *types.Type
 	imports        namer.ImportTracker
 	objectMeta     *types.Type
+	// tenantIndex is true if the package has a tenant index and the listers
+	// get a ListByTenant method.
+	tenantIndex bool
+	// typeMeta is true if the listers return copies of the cached objects
+	// with their API version and kind set.
+	typeMeta bool
 }
 
 var _ generator.Generator = &listerGenerator{}
@@ -226,20 +275,44 @@ func (g *listerGenerator) GenerateType(c *generator.Context, t *types.Type, w io
 
 	klog.V(5).Infof("processing type %v", t)
 	m := map[string]interface{}{
-		"Resource":               c.Universe.Function(types.Name{Package: t.Name.Package, Name: "Resource"}),
-		"labelsSelector":         c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Selector"}),
-		"listersResourceIndexer": c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "ResourceIndexer"}),
-		"listersNew":             c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "New"}),
-		"listersNewNamespaced":   c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "NewNamespaced"}),
-		"cacheIndexer":           c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexer"}),
-		"type":                   t,
-		"objectMeta":             g.objectMeta,
+		"Resource":                 c.Universe.Function(types.Name{Package: t.Name.Package, Name: "Resource"}),
+		"labelsSet":                c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Set"}),
+		"labelsSelector":           c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Selector"}),
+		"listersResourceIndexer":   c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "ResourceIndexer"}),
+		"listersNew":               c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "New"}),
+		"listersNewNamespaced":     c.Universe.Function(types.Name{Package: "k8s.io/client-go/listers", Name: "NewNamespaced"}),
+		"contextContext":           c.Universe.Type(types.Name{Package: "context", Name: "Context"}),
+		"cacheIndexer":             c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexer"}),
+		"cacheIndexers":            c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexers"}),
+		"cacheSharedIndexInformer": c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformer"}),
+		"fmtErrorf":                c.Universe.Function(types.Name{Package: "fmt", Name: "Errorf"}),
+		"errorsIsNotFound":         c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "IsNotFound"}),
+		"errorsNewNotFound":        c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/errors", Name: "NewNotFound"}),
+		"metav1Object":             c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Object"}),
+		"schemaParseGroupVersion":  c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "ParseGroupVersion"}),
+		"type":                     t,
+		"objectMeta":               g.objectMeta,
+		"tenantIndex":              g.tenantIndex,
+		"typeMeta":                 g.typeMeta && hasTypeMeta(t),
+		"version":                  g.groupVersion.Version.String(),
 	}
 
 	tags, err := util.ParseClientGenTags(append(t.SecondClosestCommentLines, t.CommentLines...))
 	if err != nil {
 		return err
 	}
+	m["namespaced"] = !tags.NonNamespaced
+	indexes, err := listerIndexes(t, tags.ListerIndexes)
+	if err != nil {
+		return err
+	}
+	// The templates range over the indexes with the type, which they
+	// cannot reach from within a range otherwise.
+	var indexItems []map[string]interface{}
+	for _, index := range indexes {
+		indexItems = append(indexItems, map[string]interface{}{"type": t, "Name": index.Name, "Path": index.Path})
+	}
+	m["indexes"] = indexItems
 
 	if tags.NonNamespaced {
 		sw.Do(typeListerInterfaceNonNamespaced, m)
@@ -249,14 +322,34 @@ func (g *listerGenerator) GenerateType(c *generator.Context, t *types.Type, w io
 
 	sw.Do(typeListerStruct, m)
 	sw.Do(typeListerConstructor, m)
+	sw.Do(typeListerWithSelectorCacheConstructor, m)
+	sw.Do(typeListerOwner, m)
+	if g.tenantIndex {
+		sw.Do(typeListerListByTenant, m)
+	}
+	if m["typeMeta"] == true {
+		sw.Do(typeListerTypeMeta, m)
+	}
+	if len(indexes) > 0 {
+		for _, index := range indexes {
+			m["index"] = index
+			sw.Do(typeListerIndex, m)
+		}
+		sw.Do(typeListerIndexers, m)
+	}
 
 	if tags.NonNamespaced {
 		return sw.Error()
 	}
 
 	sw.Do(typeListerNamespaceLister, m)
+	sw.Do(typeCachingListerNamespaceLister, m)
 	sw.Do(namespaceListerInterface, m)
 	sw.Do(namespaceListerStruct, m)
+	if m["typeMeta"] == true {
+		sw.Do(namespaceListerTypeMeta, m)
+	}
+	sw.Do(cachingNamespaceListerStruct, m)
 
 	return sw.Error()
 }
@@ -268,6 +361,31 @@ type $.type|public$Lister interface {
 	// List lists all $.type|publicPlural$ in the indexer.
 	// Objects returned here must be treated as read-only.
 	List(selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error)
+	// ListChan sends all $.type|publicPlural$ in the indexer matching selector on the returned channel,
+	// which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx $.contextContext|raw$, selector $.labelsSelector|raw$) <-chan *$.type|raw$
+	// GetByKeys retrieves the $.type|publicPlural$ with the given indexer keys, which are
+	// of the form namespace/name. It returns the $.type|publicPlural$ which were found in
+	// the order of keys, and the keys which were not.
+	// Objects returned here must be treated as read-only.
+	GetByKeys(keys []string) (found []*$.type|raw$, missing []string, err error)
+	// Owner$.type|public$ retrieves the $.type|public$ which owns obj according to the owner
+	// references of obj, from the namespace of obj. It returns a NotFound error if obj
+	// has no owner reference to a $.type|public$ in the indexer.
+	// Objects returned here must be treated as read-only.
+	Owner$.type|public$(obj $.metav1Object|raw$) (*$.type|raw$, error)
+$- if .tenantIndex $
+	// ListByTenant lists all $.type|publicPlural$ in the indexer for a given tenant.
+	// Objects returned here must be treated as read-only.
+	ListByTenant(tenant string, selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error)
+$- end $
+$- range .indexes $
+	// ListBy$.Name$ lists all $.type|publicPlural$ in the indexer whose $.Path$ is value.
+	// The indexer must have the $.type|public$$.Name$Index index, see $.type|public$Indexers.
+	// Objects returned here must be treated as read-only.
+	ListBy$.Name$(value string) (ret []*$.type|raw$, err error)
+$- end $
 	// $.type|publicPlural$ returns an object that can list and get $.type|publicPlural$.
 	$.type|publicPlural$(namespace string) $.type|public$NamespaceLister
 	$.type|public$ListerExpansion
@@ -281,6 +399,31 @@ type $.type|public$Lister interface {
 	// List lists all $.type|publicPlural$ in the indexer.
 	// Objects returned here must be treated as read-only.
 	List(selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error)
+	// ListChan sends all $.type|publicPlural$ in the indexer matching selector on the returned channel,
+	// which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx $.contextContext|raw$, selector $.labelsSelector|raw$) <-chan *$.type|raw$
+	// GetByKeys retrieves the $.type|publicPlural$ with the given indexer keys, which are
+	// their names. It returns the $.type|publicPlural$ which were found in the order of
+	// keys, and the keys which were not.
+	// Objects returned here must be treated as read-only.
+	GetByKeys(keys []string) (found []*$.type|raw$, missing []string, err error)
+	// Owner$.type|public$ retrieves the $.type|public$ which owns obj according to the owner
+	// references of obj. It returns a NotFound error if obj has no owner reference to a
+	// $.type|public$ in the indexer.
+	// Objects returned here must be treated as read-only.
+	Owner$.type|public$(obj $.metav1Object|raw$) (*$.type|raw$, error)
+$- if .tenantIndex $
+	// ListByTenant lists all $.type|publicPlural$ in the indexer for a given tenant.
+	// Objects returned here must be treated as read-only.
+	ListByTenant(tenant string, selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error)
+$- end $
+$- range .indexes $
+	// ListBy$.Name$ lists all $.type|publicPlural$ in the indexer whose $.Path$ is value.
+	// The indexer must have the $.type|public$$.Name$Index index, see $.type|public$Indexers.
+	// Objects returned here must be treated as read-only.
+	ListBy$.Name$(value string) (ret []*$.type|raw$, err error)
+$- end $
 	// Get retrieves the $.type|public$ from the index for a given name.
 	// Objects returned here must be treated as read-only.
 	Get(name string) (*$.type|raw$, error)
@@ -295,16 +438,145 @@ var typeListerStruct = `
 // $.type|private$Lister implements the $.type|public$Lister interface.
 type $.type|private$Lister struct {
 	$.listersResourceIndexer|raw$[*$.type|raw$]
+	indexer $.cacheIndexer|raw$
 }
 `
 
 var typeListerConstructor = `
 // New$.type|public$Lister returns a new $.type|public$Lister.
 func New$.type|public$Lister(indexer $.cacheIndexer|raw$) $.type|public$Lister {
-	return &$.type|private$Lister{$.listersNew|raw$[*$.type|raw$](indexer, $.Resource|raw$("$.type|lowercaseSingular$"))}
+	return &$.type|private$Lister{$.listersNew|raw$[*$.type|raw$](indexer, $.Resource|raw$("$.type|lowercaseSingular$")), indexer}
+}
+
+// ListChan sends all $.type|publicPlural$ in the indexer matching selector on the returned channel.
+func (s *$.type|private$Lister) ListChan(ctx $.contextContext|raw$, selector $.labelsSelector|raw$) <-chan *$.type|raw$ {
+	return listChan(ctx, selector, s.List)
+}
+
+// GetByKeys retrieves the $.type|publicPlural$ with the given indexer keys, and returns the keys which were not found.
+func (s *$.type|private$Lister) GetByKeys(keys []string) (found []*$.type|raw$, missing []string, err error) {
+	for _, key := range keys {
+		obj, exists, err := s.indexer.GetByKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			missing = append(missing, key)
+			continue
+		}
+		found = append(found, $if .typeMeta$with$.type|public$TypeMeta(obj.(*$.type|raw$))$else$obj.(*$.type|raw$)$end$)
+	}
+	return found, missing, nil
+}
+`
+
+var typeListerWithSelectorCacheConstructor = `
+// New$.type|public$ListerWithSelectorCache returns a $.type|public$Lister which memoizes
+// the results of List per selector. Memoized results are invalidated whenever
+// informer delivers a change notification, so they may lag behind the indexer
+// by as long as the informer takes to notify its event handlers.
+func New$.type|public$ListerWithSelectorCache(informer $.cacheSharedIndexInformer|raw$) ($.type|public$Lister, error) {
+	memo, err := newSelectorCache[*$.type|raw$](informer)
+	if err != nil {
+		return nil, err
+	}
+	lister := &$.type|private$Lister{$.listersNew|raw$[*$.type|raw$](informer.GetIndexer(), $.Resource|raw$("$.type|lowercaseSingular$")), informer.GetIndexer()}
+	return &$.type|private$CachingLister{$.type|private$Lister: lister, cache: memo}, nil
+}
+
+// $.type|private$CachingLister implements the $.type|public$Lister interface
+// with memoized List results.
+type $.type|private$CachingLister struct {
+	*$.type|private$Lister
+	cache *selectorCache[*$.type|raw$]
+}
+
+// List lists all $.type|publicPlural$ in the indexer, reusing the memoized result for selector if possible.
+func (s *$.type|private$CachingLister) List(selector $.labelsSelector|raw$) ([]*$.type|raw$, error) {
+	return s.cache.list("", selector, s.$.type|private$Lister.List)
+}
+`
+
+var typeListerOwner = `
+// Owner$.type|public$ retrieves the $.type|public$ which owns obj. Owner references match if
+// their kind is $.type|public$, their API version is of the group of $.type|publicPlural$ and
+// their UID is the one of the cached $.type|public$. Any version of the group matches,
+// because the cache holds the same object whichever version it was read in.
+func (s *$.type|private$Lister) Owner$.type|public$(obj $.metav1Object|raw$) (*$.type|raw$, error) {
+	resource := $.Resource|raw$("$.type|lowercaseSingular$")
+	name := ""
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := $.schemaParseGroupVersion|raw$(ref.APIVersion)
+		if err != nil || gv.Group != resource.Group || ref.Kind != "$.type|public$" {
+			continue
+		}
+		name = ref.Name
+		owner, err := s.$if .namespaced$$.type|publicPlural$(obj.GetNamespace()).$end$Get(ref.Name)
+		if $.errorsIsNotFound|raw$(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if owner.UID == ref.UID {
+			return owner, nil
+		}
+	}
+	return nil, $.errorsNewNotFound|raw$(resource, name)
 }
 `
 
+var typeListerListByTenant = `
+// ListByTenant lists all $.type|publicPlural$ in the indexer for a given tenant.
+// The indexer must have the TenantIndex index.
+func (s *$.type|private$Lister) ListByTenant(tenant string, selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error) {
+	objs, err := s.indexer.ByIndex(TenantIndex, tenant)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		item := obj.(*$.type|raw$)
+		if selector.Matches($.labelsSet|raw$(item.GetLabels())) {
+			ret = append(ret, $if .typeMeta$with$.type|public$TypeMeta(item)$else$item$end$)
+		}
+	}
+	return ret, nil
+}
+`
+
+var typeListerTypeMeta = `
+// $.type|private$GroupVersionKind is set on the $.type|publicPlural$ returned by the lister.
+var $.type|private$GroupVersionKind = $.Resource|raw$("$.type|lowercaseSingular$").WithVersion("$.version$").GroupVersion().WithKind("$.type|public$")
+
+// with$.type|public$TypeMeta returns a copy of obj with its API version and kind set.
+// The cached object is left as is, because the informer cache must not be modified.
+func with$.type|public$TypeMeta(obj *$.type|raw$) *$.type|raw$ {
+	obj = obj.DeepCopy()
+	obj.GetObjectKind().SetGroupVersionKind($.type|private$GroupVersionKind)
+	return obj
+}
+
+// List lists all $.type|publicPlural$ in the indexer, with their API version and kind set.
+func (s *$.type|private$Lister) List(selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error) {
+	ret, err = s.ResourceIndexer.List(selector)
+	for i := range ret {
+		ret[i] = with$.type|public$TypeMeta(ret[i])
+	}
+	return ret, err
+}
+$- if not .namespaced $
+
+// Get retrieves the $.type|public$ from the index for a given name, with its API version and kind set.
+func (s *$.type|private$Lister) Get(name string) (*$.type|raw$, error) {
+	obj, err := s.ResourceIndexer.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return with$.type|public$TypeMeta(obj), nil
+}
+$- end $
+`
+
 var typeListerNamespaceLister = `
 // $.type|publicPlural$ returns an object that can list and get $.type|publicPlural$.
 func (s *$.type|private$Lister) $.type|publicPlural$(namespace string) $.type|public$NamespaceLister {
@@ -312,6 +584,17 @@ func (s *$.type|private$Lister) $.type|publicPlural$(namespace string) $.type|pu
 }
 `
 
+var typeCachingListerNamespaceLister = `
+// $.type|publicPlural$ returns an object that can list and get $.type|publicPlural$, reusing memoized List results.
+func (s *$.type|private$CachingLister) $.type|publicPlural$(namespace string) $.type|public$NamespaceLister {
+	return $.type|private$CachingNamespaceLister{
+		$.type|private$NamespaceLister: $.type|private$NamespaceLister{$.listersNewNamespaced|raw$[*$.type|raw$](s.ResourceIndexer, namespace)},
+		namespace: namespace,
+		cache:     s.cache,
+	}
+}
+`
+
 var namespaceListerInterface = `
 // $.type|public$NamespaceLister helps list and get $.type|publicPlural$.
 // All objects returned here must be treated as read-only.
@@ -319,6 +602,10 @@ type $.type|public$NamespaceLister interface {
 	// List lists all $.type|publicPlural$ in the indexer for a given namespace.
 	// Objects returned here must be treated as read-only.
 	List(selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error)
+	// ListChan sends all $.type|publicPlural$ in the indexer for a given namespace matching selector
+	// on the returned channel, which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx $.contextContext|raw$, selector $.labelsSelector|raw$) <-chan *$.type|raw$
 	// Get retrieves the $.type|public$ from the indexer for a given namespace and name.
 	// Objects returned here must be treated as read-only.
 	Get(name string) (*$.type|raw$, error)
@@ -335,4 +622,44 @@ var namespaceListerStruct = `
 type $.type|private$NamespaceLister struct {
 	$.listersResourceIndexer|raw$[*$.type|raw$]
 }
+
+// ListChan sends all $.type|publicPlural$ in the indexer for the namespace matching selector on the returned channel.
+func (s $.type|private$NamespaceLister) ListChan(ctx $.contextContext|raw$, selector $.labelsSelector|raw$) <-chan *$.type|raw$ {
+	return listChan(ctx, selector, s.List)
+}
+`
+
+var namespaceListerTypeMeta = `
+// List lists all $.type|publicPlural$ in the indexer for the namespace, with their API version and kind set.
+func (s $.type|private$NamespaceLister) List(selector $.labelsSelector|raw$) (ret []*$.type|raw$, err error) {
+	ret, err = s.ResourceIndexer.List(selector)
+	for i := range ret {
+		ret[i] = with$.type|public$TypeMeta(ret[i])
+	}
+	return ret, err
+}
+
+// Get retrieves the $.type|public$ from the indexer for the namespace and name, with its API version and kind set.
+func (s $.type|private$NamespaceLister) Get(name string) (*$.type|raw$, error) {
+	obj, err := s.ResourceIndexer.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return with$.type|public$TypeMeta(obj), nil
+}
+`
+
+var cachingNamespaceListerStruct = `
+// $.type|private$CachingNamespaceLister implements the $.type|public$NamespaceLister
+// interface with memoized List results.
+type $.type|private$CachingNamespaceLister struct {
+	$.type|private$NamespaceLister
+	namespace string
+	cache     *selectorCache[*$.type|raw$]
+}
+
+// List lists all $.type|publicPlural$ in the indexer for the namespace, reusing the memoized result for selector if possible.
+func (s $.type|private$CachingNamespaceLister) List(selector $.labelsSelector|raw$) ([]*$.type|raw$, error) {
+	return s.cache.list(s.namespace, selector, s.$.type|private$NamespaceLister.List)
+}
 `
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/code-generator/cmd/lister-gen/args"
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/parser"
+	"k8s.io/gengo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestGetTargetsAllowMissingObjectMeta(t *testing.T) {
+	universe := types.Universe{}
+	objectMeta := universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"})
+	addType := func(pkg string, withObjectMeta bool) {
+		typ := universe.Type(types.Name{Package: pkg, Name: "Widget"})
+		typ.Kind = types.Struct
+		typ.CommentLines = []string{"+genclient"}
+		if withObjectMeta {
+			typ.Members = []types.Member{{Name: "ObjectMeta", Type: objectMeta, Tags: `json:"metadata,omitempty"`}}
+		}
+	}
+	addType("example.com/apis/apps/v1", true)
+	addType("example.com/apis/broken/v1", false)
+	addType("example.com/apis/storage/v1", true)
+
+	var logs bytes.Buffer
+	klog.LogToStderr(false)
+	klog.SetOutput(&logs)
+	defer klog.LogToStderr(true)
+
+	context := &generator.Context{
+		Universe: universe,
+		Inputs:   []string{"example.com/apis/apps/v1", "example.com/apis/broken/v1", "example.com/apis/storage/v1"},
+	}
+	targets := GetTargets(context, &args.Args{
+		OutputDir:              t.TempDir(),
+		OutputPkg:              "example.com/listers",
+		AllowMissingObjectMeta: true,
+	})
+	klog.Flush()
+
+	var got []string
+	for _, target := range targets {
+		got = append(got, target.Path())
+	}
+	want := []string{"example.com/listers/apps/v1", "example.com/listers/storage/v1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("targets: got %v, want %v", got, want)
+	}
+	if !strings.Contains(logs.String(), "Skipping package example.com/apis/broken/v1") {
+		t.Errorf("broken package was not reported, logs:\n%s", logs.String())
+	}
+}
+
+// TestIndexGolden checks the listers of a type with +lister:index tags and of
+// a type without.
+func TestIndexGolden(t *testing.T) {
+	const pkg = "k8s.io/code-generator/cmd/lister-gen/generators/testdata/index/v1"
+	p := parser.New()
+	if err := p.LoadPackages(pkg); err != nil {
+		t.Fatal(err)
+	}
+	c, err := generator.NewContext(p, NameSystems(nil), DefaultNameSystem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	targets := GetTargets(c, &args.Args{OutputDir: dir, OutputPkg: "example.com/listers"})
+	if err := c.ExecuteTargets(targets); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"indexed.go", "plain.go"} {
+		checkGolden(t, filepath.Join(dir, "index", "v1", name), filepath.Join("testdata", "index", name+".golden"))
+	}
+}
+
+func TestListerIndexes(t *testing.T) {
+	universe := types.Universe{}
+	uid := universe.Type(types.Name{Package: "example.com/apis/apps/v1", Name: "UID"})
+	uid.Kind = types.Alias
+	uid.Underlying = types.String
+	spec := universe.Type(types.Name{Package: "example.com/apis/apps/v1", Name: "WidgetSpec"})
+	spec.Kind = types.Struct
+	spec.Members = []types.Member{{Name: "NodeName", Type: types.String}, {Name: "OwnerUID", Type: uid}, {Name: "Replicas", Type: types.Int32}}
+	widget := universe.Type(types.Name{Package: "example.com/apis/apps/v1", Name: "Widget"})
+	widget.Kind = types.Struct
+	widget.Members = []types.Member{{Name: "Spec", Type: spec}}
+
+	indexes, err := listerIndexes(widget, []string{"Spec.NodeName", "Spec.OwnerUID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []listerIndex{{Name: "SpecNodeName", Path: "Spec.NodeName"}, {Name: "SpecOwnerUID", Path: "Spec.OwnerUID", Convert: true}}
+	if len(indexes) != len(want) || indexes[0] != want[0] || indexes[1] != want[1] {
+		t.Errorf("indexes: got %+v, want %+v", indexes, want)
+	}
+
+	for _, path := range []string{"Spec.Missing", "Spec.Replicas", "Spec", "Spec.NodeName.Length"} {
+		if _, err := listerIndexes(widget, []string{path}); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+}
+
+// checkGolden compares the generated file with the golden file, or updates
+// the golden file with -update.
+func checkGolden(t *testing.T, file, golden string) {
+	t.Helper()
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from %s, run the test with -update if the change is intended:\n%s", filepath.Base(file), golden, got)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+)
+
+// selectorCacheGenerator produces the memoization helper shared by the
+// selector caching listers of a package.
+type selectorCacheGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	imports       namer.ImportTracker
+	types         []*types.Type
+}
+
+var _ generator.Generator = &selectorCacheGenerator{}
+
+// We only want to call GenerateType() once per group.
+func (g *selectorCacheGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.types[0]
+}
+
+func (g *selectorCacheGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *selectorCacheGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *selectorCacheGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	m := map[string]interface{}{
+		"atomicUint64":                   c.Universe.Type(types.Name{Package: "sync/atomic", Name: "Uint64"}),
+		"cacheResourceEventHandlerFuncs": c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "ResourceEventHandlerFuncs"}),
+		"cacheSharedIndexInformer":       c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "SharedIndexInformer"}),
+		"labelsSelector":                 c.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/labels", Name: "Selector"}),
+		"syncRWMutex":                    c.Universe.Type(types.Name{Package: "sync", Name: "RWMutex"}),
+	}
+	sw.Do(selectorCache, m)
+	return sw.Error()
+}
+
+var selectorCache = `
+// selectorCache memoizes List results per namespace and selector string.
+//
+// Every change notification delivered by the informer increments the cache
+// generation, which invalidates all memoized results. Notifications are
+// delivered asynchronously, so a memoized result may lag behind the indexer
+// by as long as the informer takes to deliver a notification to its handlers.
+type selectorCache[T any] struct {
+	generation $.atomicUint64|raw$
+
+	lock    $.syncRWMutex|raw$
+	entries map[string]selectorCacheEntry[T]
+}
+
+type selectorCacheEntry[T any] struct {
+	generation uint64
+	items      []T
+}
+
+// newSelectorCache returns a selectorCache invalidated by the change
+// notifications of informer.
+func newSelectorCache[T any](informer $.cacheSharedIndexInformer|raw$) (*selectorCache[T], error) {
+	c := &selectorCache[T]{entries: map[string]selectorCacheEntry[T]{}}
+	invalidate := func(interface{}) { c.generation.Add(1) }
+	_, err := informer.AddEventHandler($.cacheResourceEventHandlerFuncs|raw${
+		AddFunc:    invalidate,
+		UpdateFunc: func(_, newObj interface{}) { invalidate(newObj) },
+		DeleteFunc: invalidate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// list returns the memoized result for namespace and selector, calling
+// listFunc if there is no result for the current generation.
+func (c *selectorCache[T]) list(namespace string, selector $.labelsSelector|raw$, listFunc func($.labelsSelector|raw$) ([]T, error)) ([]T, error) {
+	key := namespace + "/" + selector.String()
+	generation := c.generation.Load()
+
+	c.lock.RLock()
+	entry, ok := c.entries[key]
+	c.lock.RUnlock()
+	if ok && entry.generation == generation {
+		return entry.items, nil
+	}
+
+	items, err := listFunc(selector)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for k, e := range c.entries {
+		if e.generation != generation {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = selectorCacheEntry[T]{generation: generation, items: items}
+	return items, nil
+}
+`
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"io"
+
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/namer"
+	"k8s.io/gengo/v2/types"
+)
+
+// tenantIndexGenerator produces the tenant index used by the ListByTenant
+// methods of the listers of a package.
+type tenantIndexGenerator struct {
+	generator.GoGenerator
+	outputPackage string
+	imports       namer.ImportTracker
+	types         []*types.Type
+	tenantLabel   string
+}
+
+var _ generator.Generator = &tenantIndexGenerator{}
+
+// We only want to call GenerateType() once per group.
+func (g *tenantIndexGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return t == g.types[0]
+}
+
+func (g *tenantIndexGenerator) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.outputPackage, g.imports),
+	}
+}
+
+func (g *tenantIndexGenerator) Imports(c *generator.Context) (imports []string) {
+	imports = append(imports, g.imports.ImportLines()...)
+	return
+}
+
+func (g *tenantIndexGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	m := map[string]interface{}{
+		"cacheIndexers": c.Universe.Type(types.Name{Package: "k8s.io/client-go/tools/cache", Name: "Indexers"}),
+		"metaAccessor":  c.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/api/meta", Name: "Accessor"}),
+		"tenantLabel":   g.tenantLabel,
+	}
+	sw.Do(tenantIndex, m)
+	return sw.Error()
+}
+
+var tenantIndex = `
+const (
+	// TenantLabel is the label which assigns objects to tenants.
+	TenantLabel = "$.tenantLabel$"
+	// TenantIndex is the name of the index of objects by the value of their
+	// TenantLabel label. Informers backing listers which are used with
+	// ListByTenant must be given this index, see TenantIndexers.
+	TenantIndex = "tenant"
+)
+
+// TenantIndexers returns the indexers which ListByTenant relies on. They must
+// be added to an informer before it is started.
+func TenantIndexers() $.cacheIndexers|raw$ {
+	return $.cacheIndexers|raw${TenantIndex: TenantIndexFunc}
+}
+
+// TenantIndexFunc indexes objects by the value of their TenantLabel label.
+// Objects without that label are not indexed.
+func TenantIndexFunc(obj interface{}) ([]string, error) {
+	accessor, err := $.metaAccessor|raw$(obj)
+	if err != nil {
+		return nil, err
+	}
+	if tenant, ok := accessor.GetLabels()[TenantLabel]; ok {
+		return []string{tenant}, nil
+	}
+	return nil, nil
+}
+`
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1 has the types of the golden files of +lister:index.
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +genclient
+// +lister:index=Spec.NodeName
+// +lister:index=Spec.Zone
+
+// Indexed is listed by its node and its zone through indexes.
+type Indexed struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IndexedSpec `json:"spec"`
+}
+
+// IndexedSpec is the spec of an Indexed.
+type IndexedSpec struct {
+	NodeName string `json:"nodeName"`
+	Zone     Zone   `json:"zone"`
+}
+
+// Zone is the name of a zone.
+type Zone string
+
+// IndexedList is a list of Indexed.
+type IndexedList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Indexed `json:"items"`
+}
+
+// +genclient
+
+// Plain has no index.
+type Plain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// PlainList is a list of Plain.
+type PlainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Plain `json:"items"`
+}
@@ -18,6 +18,7 @@ package args
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/spf13/pflag"
 )
@@ -25,8 +26,17 @@ import (
 type Args struct {
 	OutputFile   string
 	GoHeaderFile string
+
+	// SchemeVarPrefix is prepended to the exported scheme identifiers
+	// (SchemeBuilder, AddToScheme and Install), for packages which already
+	// declare those names for a hand-written scheme.
+	SchemeVarPrefix string
 }
 
+// schemeVarPrefixRegexp matches prefixes which keep the generated scheme
+// identifiers exported.
+var schemeVarPrefixRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+
 // New returns default arguments for the generator.
 func New() *Args {
 	return &Args{}
@@ -38,6 +48,8 @@ func (args *Args) AddFlags(fs *pflag.FlagSet) {
 		"the name of the file to be generated")
 	fs.StringVar(&args.GoHeaderFile, "go-header-file", "",
 		"the path to a file containing boilerplate header text; the string \"YEAR\" will be replaced with the current 4-digit year")
+	fs.StringVar(&args.SchemeVarPrefix, "scheme-var-prefix", "",
+		"a prefix for the generated SchemeBuilder, AddToScheme and Install variables, e.g. \"My\" for MySchemeBuilder; must start with an upper-case letter")
 }
 
 // Validate checks the given arguments.
@@ -45,6 +57,9 @@ func (args *Args) Validate() error {
 	if len(args.OutputFile) == 0 {
 		return fmt.Errorf("output file base name cannot be empty")
 	}
+	if len(args.SchemeVarPrefix) > 0 && !schemeVarPrefixRegexp.MatchString(args.SchemeVarPrefix) {
+		return fmt.Errorf("--scheme-var-prefix %q must be an exported Go identifier", args.SchemeVarPrefix)
+	}
 
 	return nil
 }
@@ -31,6 +31,10 @@ type registerExternalGenerator struct {
 	outputPackage   string
 	gv              clientgentypes.GroupVersion
 	typesToGenerate []*types.Type
+	// schemeVarPrefix is prepended to the exported scheme variables. The
+	// unexported localSchemeBuilder keeps its name, since the other
+	// generators register their functions through it.
+	schemeVarPrefix string
 	imports         namer.ImportTracker
 }
 
@@ -64,6 +68,7 @@ func (g *registerExternalGenerator) Finalize(context *generator.Context, w io.Wr
 		"groupName":           g.gv.Group,
 		"version":             g.gv.Version,
 		"types":               typesToGenerateOnlyNames,
+		"prefix":              g.schemeVarPrefix,
 		"addToGroupVersion":   context.Universe.Function(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "AddToGroupVersion"}),
 		"groupVersion":        context.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "GroupVersion"}),
 		"schemaGroupVersion":  context.Universe.Type(types.Name{Package: "k8s.io/apimachinery/pkg/runtime/schema", Name: "GroupVersion"}),
@@ -93,12 +98,12 @@ func Resource(resource string) $.schemaGroupResource|raw$ {
 }
 
 var (
-	// localSchemeBuilder and AddToScheme will stay in k8s.io/kubernetes.
-	SchemeBuilder      $.schemeBuilder|raw$
-	localSchemeBuilder = &SchemeBuilder
-    // Deprecated: use Install instead
-	AddToScheme        = localSchemeBuilder.AddToScheme
-	Install            = localSchemeBuilder.AddToScheme
+	// localSchemeBuilder and $.prefix$AddToScheme will stay in k8s.io/kubernetes.
+	$.prefix$SchemeBuilder      $.schemeBuilder|raw$
+	localSchemeBuilder = &$.prefix$SchemeBuilder
+    // Deprecated: use $.prefix$Install instead
+	$.prefix$AddToScheme        = localSchemeBuilder.AddToScheme
+	$.prefix$Install            = localSchemeBuilder.AddToScheme
 )
 
 func init() {
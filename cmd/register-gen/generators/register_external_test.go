@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	clientgentypes "k8s.io/code-generator/cmd/client-gen/types"
+	"k8s.io/gengo/v2/generator"
+	"k8s.io/gengo/v2/parser"
+)
+
+func TestSchemeVarPrefix(t *testing.T) {
+	testcases := []struct {
+		prefix string
+		want   []string
+	}{
+		{
+			prefix: "",
+			want: []string{
+				"\tSchemeBuilder      runtime.SchemeBuilder\n",
+				"\tlocalSchemeBuilder = &SchemeBuilder\n",
+				"\tAddToScheme        = localSchemeBuilder.AddToScheme\n",
+				"\tInstall            = localSchemeBuilder.AddToScheme\n",
+			},
+		},
+		{
+			prefix: "My",
+			want: []string{
+				"\tMySchemeBuilder      runtime.SchemeBuilder\n",
+				"\tlocalSchemeBuilder = &MySchemeBuilder\n",
+				"\tMyAddToScheme        = localSchemeBuilder.AddToScheme\n",
+				"\tMyInstall            = localSchemeBuilder.AddToScheme\n",
+				"\tlocalSchemeBuilder.Register(addKnownTypes)\n",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			g := &registerExternalGenerator{
+				gv:              clientgentypes.GroupVersion{Group: "example.com", Version: "v1"},
+				outputPackage:   "example.com/apis/example/v1",
+				schemeVarPrefix: tc.prefix,
+				imports:         generator.NewImportTrackerForPackage("example.com/apis/example/v1"),
+			}
+			c, err := generator.NewContext(parser.New(), g.Namers(nil), "raw")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := g.Finalize(c, &buf); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected the generated code to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -121,6 +121,7 @@ func GetTargets(context *generator.Context, args *args.Args) []generator.Target
 							gv:              gv,
 							typesToGenerate: typesToRegister,
 							outputPackage:   pkg.Path,
+							schemeVarPrefix: args.SchemeVarPrefix,
 							imports:         generator.NewImportTrackerForPackage(pkg.Path),
 						},
 					}
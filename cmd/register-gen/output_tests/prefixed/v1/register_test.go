@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPrefixedScheme(t *testing.T) {
+	gvk := SchemeGroupVersion.WithKind("PrefixedType")
+	for name, install := range map[string]func(*runtime.Scheme) error{
+		"AddToScheme":   AddToScheme,
+		"MyAddToScheme": MyAddToScheme,
+		"MyInstall":     MyInstall,
+	} {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := install(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if !scheme.Recognizes(gvk) {
+				t.Errorf("expected %v to be registered", gvk)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ package v1
 
 import (
 	context "context"
+	json "encoding/json"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,6 +53,8 @@ type ClusterTestTypeInterface interface {
 	Apply(ctx context.Context, clusterTestType *applyconfigurationexamplev1.ClusterTestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *examplev1.ClusterTestType, err error)
 	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, clusterTestType *applyconfigurationexamplev1.ClusterTestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *examplev1.ClusterTestType, err error)
+	MergePatchClusterTestType(ctx context.Context, name string, patch *examplev1.ClusterTestType, opts metav1.PatchOptions) (*examplev1.ClusterTestType, error)
+	StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *examplev1.ClusterTestType, opts metav1.PatchOptions) (*examplev1.ClusterTestType, error)
 	GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (*autoscalingv1.Scale, error)
 	UpdateScale(ctx context.Context, clusterTestTypeName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (*autoscalingv1.Scale, error)
 
@@ -77,6 +80,26 @@ func newClusterTestTypes(c *ExampleGroupV1Client) *clusterTestTypes {
 	}
 }
 
+// MergePatchClusterTestType marshals patch to JSON and applies it to the named clusterTestType as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *clusterTestTypes) MergePatchClusterTestType(ctx context.Context, name string, patch *examplev1.ClusterTestType, opts metav1.PatchOptions) (*examplev1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchClusterTestType marshals patch to JSON and applies it to the named clusterTestType as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatchClusterTestType for them instead.
+func (c *clusterTestTypes) StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *examplev1.ClusterTestType, opts metav1.PatchOptions) (*examplev1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
 // GetScale takes name of the clusterTestType, and returns the corresponding autoscalingv1.Scale object, and an error if there is any.
 func (c *clusterTestTypes) GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
 	result = &autoscalingv1.Scale{}
@@ -20,17 +20,28 @@ package v1
 
 import (
 	context "context"
+	fmt "fmt"
+	slices "slices"
+	strconv "strconv"
+	strings "strings"
+	sync "sync"
 	time "time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	watch "k8s.io/apimachinery/pkg/watch"
 	cache "k8s.io/client-go/tools/cache"
 	apisexamplev1 "k8s.io/code-generator/examples/HyphenGroup/apis/example/v1"
+	applyconfigurationexamplev1 "k8s.io/code-generator/examples/HyphenGroup/applyconfiguration/example/v1"
 	versioned "k8s.io/code-generator/examples/HyphenGroup/clientset/versioned"
 	internalinterfaces "k8s.io/code-generator/examples/HyphenGroup/informers/externalversions/internalinterfaces"
 	examplev1 "k8s.io/code-generator/examples/HyphenGroup/listers/example/v1"
+	v2 "k8s.io/klog/v2"
+	typed "sigs.k8s.io/structured-merge-diff/v6/typed"
 )
 
 // TestTypeInformer provides access to a shared informer and lister for
@@ -38,6 +49,10 @@ import (
 type TestTypeInformer interface {
 	Informer() cache.SharedIndexInformer
 	Lister() examplev1.TestTypeLister
+	// Factory returns the factory which owns the informer. It is the
+	// SharedInformerFactory of the informers package, which cannot be named
+	// here without an import cycle; use a type assertion to access it.
+	Factory() internalinterfaces.SharedInformerFactory
 }
 
 type testTypeInformer struct {
@@ -50,14 +65,30 @@ type testTypeInformer struct {
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewTestTypeInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
-	return NewTestTypeInformerWithOptions(client, namespace, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers})
+	return NewTestTypeInformerWithContext(context.TODO(), client, namespace, resyncPeriod, indexers)
+}
+
+// NewTestTypeInformerWithContext constructs a new informer for TestType type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewTestTypeInformerWithContext(ctx context.Context, client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewTestTypeInformerWithOptions(client, namespace, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, Context: ctx})
 }
 
 // NewFilteredTestTypeInformer constructs a new informer for TestType type.
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewFilteredTestTypeInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
-	return NewTestTypeInformerWithOptions(client, namespace, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions})
+	return NewFilteredTestTypeInformerWithContext(context.TODO(), client, namespace, resyncPeriod, indexers, tweakListOptions)
+}
+
+// NewFilteredTestTypeInformerWithContext constructs a new informer for TestType type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredTestTypeInformerWithContext(ctx context.Context, client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return NewTestTypeInformerWithOptions(client, namespace, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions, Context: ctx})
 }
 
 // NewTestTypeInformerWithOptions constructs a new informer for TestType type with additional options.
@@ -67,33 +98,108 @@ func NewTestTypeInformerWithOptions(client versioned.Interface, namespace string
 	gvr := schema.GroupVersionResource{Group: "example-group.hyphens.code-generator.k8s.io", Version: "v1", Resource: "testtypes"}
 	identifier := options.InformerName.WithResource(gvr)
 	tweakListOptions := options.TweakListOptions
-	return cache.NewSharedIndexInformerWithOptions(
-		cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
-			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleGroupV1().TestTypes(namespace).List(context.Background(), opts)
-			},
-			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleGroupV1().TestTypes(namespace).Watch(context.Background(), opts)
-			},
-			ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+	if options.Retweaker != nil {
+		tweakListOptions = options.Retweaker.TweakListOptions
+	}
+	// initialResourceVersion is cleared by the first list. Lists are never
+	// called concurrently by a reflector.
+	initialResourceVersion := options.InitialResourceVersion
+	initialResourceVersionMatch := options.InitialResourceVersionMatch
+	if initialResourceVersionMatch == "" {
+		initialResourceVersionMatch = metav1.ResourceVersionMatchExact
+	}
+	pageSize := options.WatchListPageSize
+	observeWatch := func(w watch.Interface, err error) (watch.Interface, error) {
+		if err == nil && options.ReconnectObserver != nil {
+			options.ReconnectObserver(gvr, time.Now())
+		}
+		return w, err
+	}
+	var lw cache.ListerWatcher = cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.ExampleGroupV1().TestTypes(namespace).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.ExampleGroupV1().TestTypes(namespace).Watch(context.Background(), opts))
+		},
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.ExampleGroupV1().TestTypes(namespace).List(ctx, opts)
+		},
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.ExampleGroupV1().TestTypes(namespace).Watch(ctx, opts))
+		},
+	}, client)
+	if len(options.NamespaceSelectors) > 0 {
+		lw = internalinterfaces.NewMultiNamespaceListerWatcher(options.NamespaceSelectors, func(namespace string, selector labels.Selector) cache.ListerWatcher {
+			tweak := func(opts *metav1.ListOptions) {
 				if tweakListOptions != nil {
-					tweakListOptions(&opts)
+					tweakListOptions(opts)
 				}
-				return client.ExampleGroupV1().TestTypes(namespace).List(ctx, opts)
-			},
-			WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
+				if selector != nil {
+					opts.LabelSelector = selector.String()
 				}
-				return client.ExampleGroupV1().TestTypes(namespace).Watch(ctx, opts)
-			},
-		}, client),
+			}
+			// Like initialResourceVersion, but cleared by the first list of the namespace.
+			nsInitialResourceVersion := options.InitialResourceVersion
+			return &cache.ListWatch{
+				ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+					if pageSize > 0 {
+						opts.Limit = pageSize
+					}
+					tweak(&opts)
+					if nsInitialResourceVersion != "" {
+						opts.ResourceVersion = nsInitialResourceVersion
+						opts.ResourceVersionMatch = initialResourceVersionMatch
+						nsInitialResourceVersion = ""
+					}
+					return client.ExampleGroupV1().TestTypes(namespace).List(ctx, opts)
+				},
+				WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+					tweak(&opts)
+					return observeWatch(client.ExampleGroupV1().TestTypes(namespace).Watch(ctx, opts))
+				},
+			}
+		})
+	}
+	if options.InitialResourceVersion != "" {
+		lw = internalinterfaces.NewListerWatcherWithoutWatchList(lw)
+	}
+	lw = internalinterfaces.NewValidatingListerWatcher(lw, options.IngestValidator)
+	lw = internalinterfaces.NewValidatingListerWatcher(lw, internalinterfaces.NewObjectFilter(options.ObjectFilter))
+	lw = internalinterfaces.NewRetweakableListerWatcher(lw, options.Retweaker)
+	lw = internalinterfaces.NewRotatingListerWatcher(lw, options.WatchRotation)
+	lw = internalinterfaces.NewLeadershipGatedListerWatcher(lw, options.LeadershipGate)
+	lw = internalinterfaces.NewContextBoundListerWatcher(lw, options.Context)
+	informer := cache.NewSharedIndexInformerWithOptions(
+		internalinterfaces.NewCacheSnapshotListerWatcher(lw, options.CacheSnapshot, options.CacheSnapshotDecoder, &apisexamplev1.TestTypeList{}),
 		&apisexamplev1.TestType{},
 		cache.SharedIndexInformerOptions{
 			ResyncPeriod: options.ResyncPeriod,
@@ -101,10 +207,23 @@ func NewTestTypeInformerWithOptions(client versioned.Interface, namespace string
 			Identifier:   identifier,
 		},
 	)
+	informer = internalinterfaces.NewCacheBackendInformer(informer, options.CacheBackend, options.Indexers, options.InitialCacheCapacity)
+	return internalinterfaces.NewKeyNormalizingInformer(informer, options.KeyNormalizer, options.Indexers)
 }
 
 func (f *testTypeInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-	return NewTestTypeInformerWithOptions(client, f.namespace, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, InformerName: f.factory.InformerName(), TweakListOptions: f.tweakListOptions})
+	options := internalinterfaces.InformerOptions{InformerName: f.factory.InformerName()}
+	if factory, ok := f.factory.(internalinterfaces.SharedInformerFactoryExtensions); ok {
+		factory.CheckInformerCreate(&apisexamplev1.TestType{})
+		options = factory.InformerOptionsFor(&apisexamplev1.TestType{})
+	}
+	options.ResyncPeriod = resyncPeriod
+	options.Indexers = cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	options.TweakListOptions = f.tweakListOptions
+	if options.Retweaker != nil {
+		options.Retweaker.Retweak(f.tweakListOptions)
+	}
+	return NewTestTypeInformerWithOptions(client, f.namespace, options)
 }
 
 func (f *testTypeInformer) Informer() cache.SharedIndexInformer {
@@ -114,3 +233,788 @@ func (f *testTypeInformer) Informer() cache.SharedIndexInformer {
 func (f *testTypeInformer) Lister() examplev1.TestTypeLister {
 	return examplev1.NewTestTypeLister(f.Informer().GetIndexer())
 }
+
+func (f *testTypeInformer) Factory() internalinterfaces.SharedInformerFactory {
+	return f.factory
+}
+
+// testTypeFactoryExtensions returns the extensions of the factory of informer, if
+// informer was obtained from a factory which implements them.
+func testTypeFactoryExtensions(informer TestTypeInformer) (internalinterfaces.SharedInformerFactoryExtensions, bool) {
+	factoryInformer, ok := informer.(*testTypeInformer)
+	if !ok {
+		return nil, false
+	}
+	factory, ok := factoryInformer.factory.(internalinterfaces.SharedInformerFactoryExtensions)
+	return factory, ok
+}
+
+// AddTestTypeEventHandler adds handler to the shared informer of informer. Panics of
+// handler are handled by the panic handler of the factory of informer, if any. The returned
+// registration reports through HasSynced when the replay of the informer's cache to handler
+// is over, independently of other handlers.
+func AddTestTypeEventHandler(informer TestTypeInformer, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.TestType{}))
+	}
+	registration, err := sharedInformer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeEventHandlerFuncs adds an event handler to the shared informer of informer
+// which invokes the given callbacks with typed TestTypes; any of them may be nil.
+// Deletions whose final state is unknown are delivered to deleteFunc with the last known state
+// of the object. Objects of other types are ignored. The handler is added through
+// AddTestTypeEventHandler.
+func AddTestTypeEventHandlerFuncs(informer TestTypeInformer, addFunc func(*apisexamplev1.TestType), updateFunc func(oldObj, newObj *apisexamplev1.TestType), deleteFunc func(*apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	handler := cache.ResourceEventHandlerFuncs{}
+	if addFunc != nil {
+		handler.AddFunc = func(obj interface{}) {
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				addFunc(item)
+			}
+		}
+	}
+	if updateFunc != nil {
+		handler.UpdateFunc = func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.TestType)
+			newItem, newOK := newObj.(*apisexamplev1.TestType)
+			if oldOK && newOK {
+				updateFunc(oldItem, newItem)
+			}
+		}
+	}
+	if deleteFunc != nil {
+		handler.DeleteFunc = func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				deleteFunc(item)
+			}
+		}
+	}
+	return AddTestTypeEventHandler(informer, handler)
+}
+
+// AddTestTypeEventHandlerWithPriority adds handler to the shared informer of informer,
+// which must be obtained from a factory. The handlers added with a priority are invoked one
+// after another in ascending priority, and in the order they were added for equal priorities;
+// other handlers are invoked independently of them. Panics of handler are handled by the
+// panic handler of the factory, if any. The objects in the cache of the informer are delivered
+// to handler before it returns.
+func AddTestTypeEventHandlerWithPriority(informer TestTypeInformer, priority int, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if !fromFactory {
+		return nil, fmt.Errorf("handler priorities require an informer obtained from a factory")
+	}
+	sharedInformer := informer.Informer()
+	handlers, err := factory.PriorityEventHandlers(sharedInformer)
+	if err != nil {
+		return nil, err
+	}
+	handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.TestType{}))
+	registration := handlers.Add(priority, handler, sharedInformer.GetStore())
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+
+// AddTestTypeResyncHandler adds an event handler to the shared informer of informer
+// which invokes fn for periodic resyncs of TestTypes only, not for changes.
+// Resyncs are recognized by the old and new objects of an update being the same
+// object. fn is never invoked if the informer has no resync period.
+func AddTestTypeResyncHandler(informer TestTypeInformer, fn func(*apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.TestType)
+			newItem, newOK := newObj.(*apisexamplev1.TestType)
+			if oldOK && newOK && oldItem == newItem {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeSpecChangeHandler adds an event handler to the shared informer of informer
+// which invokes fn with the new object of the updates of TestTypes which change
+// their metadata.generation, ignoring updates of their status or metadata only. It is not
+// invoked for adds or deletes, nor for resources whose generation is not maintained by the
+// API server.
+func AddTestTypeSpecChangeHandler(informer TestTypeInformer, fn func(*apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.TestType)
+			newItem, newOK := newObj.(*apisexamplev1.TestType)
+			if oldOK && newOK && oldItem.Generation != newItem.Generation {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeDiffHandler adds an event handler to the shared informer of informer
+// which invokes fn with the old and new states of the added, updated and deleted
+// TestTypes: oldObj is nil for adds and newObj is nil for deletes. The last known
+// state of TestTypes whose deletion the informer missed is passed as oldObj.
+// Resyncs are passed as updates with the same old and new object.
+func AddTestTypeDiffHandler(informer TestTypeInformer, fn func(oldObj, newObj *apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	diff := func(oldItem, newItem *apisexamplev1.TestType) {
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(oldItem, newItem)
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				diff(nil, item)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.TestType)
+			newItem, newOK := newObj.(*apisexamplev1.TestType)
+			if oldOK && newOK {
+				diff(oldItem, newItem)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				diff(item, nil)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// TestTypeEvent is an event of a TestType delivered to a sequenced handler.
+type TestTypeEvent struct {
+	// Type is watch.Added, watch.Modified or watch.Deleted.
+	Type watch.EventType
+	// Object is the added, updated or deleted TestType. Deleted objects
+	// may be stale if the informer missed their deletion.
+	Object *apisexamplev1.TestType
+	// OldObject is the previous state of an updated TestType, or nil.
+	OldObject *apisexamplev1.TestType
+	// IsInInitialList is true for the adds of the initial list of the informer.
+	IsInInitialList bool
+}
+
+// AddTestTypeSequencedHandler adds an event handler to the shared informer of informer,
+// which must be obtained from a factory, which invokes fn for the added, updated and deleted
+// TestTypes with a sequence number. The sequence numbers are shared by all the
+// sequenced handlers of the factory and increase monotonically in the order in which the
+// events are dispatched, so they order the events of different informers. fn may be invoked
+// concurrently for the events of different informers.
+func AddTestTypeSequencedHandler(informer TestTypeInformer, fn func(seq uint64, ev TestTypeEvent)) (cache.ResourceEventHandlerRegistration, error) {
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if !fromFactory {
+		return nil, fmt.Errorf("sequenced handlers require an informer obtained from a factory")
+	}
+	panicHandler := factory.PanicHandler(&apisexamplev1.TestType{})
+	dispatch := func(ev TestTypeEvent) {
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(factory.NextEventSequence(), ev)
+	}
+	sharedInformer := informer.Informer()
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				dispatch(TestTypeEvent{Type: watch.Added, Object: item, IsInInitialList: isInInitialList})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.TestType)
+			newItem, newOK := newObj.(*apisexamplev1.TestType)
+			if oldOK && newOK {
+				dispatch(TestTypeEvent{Type: watch.Modified, Object: newItem, OldObject: oldItem})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.TestType); ok {
+				dispatch(TestTypeEvent{Type: watch.Deleted, Object: item})
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+
+// AddTestTypeDebouncedHandler adds an event handler to the shared informer of informer
+// which invokes fn with the latest version of a TestType once it was not added or
+// updated for window. Deleting a TestType cancels its pending invocation. fn is
+// invoked in a goroutine of its own, so invocations for different TestTypes may
+// run concurrently. Invocations which are pending when the handler is removed still run.
+func AddTestTypeDebouncedHandler(informer TestTypeInformer, window time.Duration, fn func(*apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	type pendingCall struct {
+		item  *apisexamplev1.TestType
+		timer *time.Timer
+	}
+	var lock sync.Mutex
+	pending := map[string]*pendingCall{}
+	schedule := func(obj interface{}) {
+		item, ok := obj.(*apisexamplev1.TestType)
+		if !ok {
+			return
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if call, ok := pending[key]; ok {
+			call.timer.Stop()
+		}
+		call := &pendingCall{item: item}
+		call.timer = time.AfterFunc(window, func() {
+			lock.Lock()
+			// The call was superseded if an update or delete raced with the timer.
+			current := pending[key] == call
+			if current {
+				delete(pending, key)
+			}
+			lock.Unlock()
+			if current {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(call.item)
+			}
+		})
+		pending[key] = call
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: schedule,
+		UpdateFunc: func(_, newObj interface{}) {
+			schedule(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if call, ok := pending[key]; ok {
+				call.timer.Stop()
+				delete(pending, key)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeBatchHandler adds an event handler to the shared informer of informer
+// which collects the added, updated and deleted TestTypes into batches and invokes
+// fn with each batch. A batch holds the latest version of each TestType once, in the
+// order in which they first changed. Deleted TestTypes are included with their
+// last state and are not marked as deleted; fn has to look them up in the informer's lister
+// to tell them apart. A batch is flushed once it holds maxBatch TestTypes, which
+// invokes fn in the informer's notification goroutine, or maxDelay after its first change.
+// When ctx is done, the handler is removed from the shared informer, the pending batch is
+// flushed and no further changes are collected. Invocations of fn never overlap.
+func AddTestTypeBatchHandler(ctx context.Context, informer TestTypeInformer, maxBatch int, maxDelay time.Duration, fn func([]*apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	if maxBatch <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", maxBatch)
+	}
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	// flushLock serializes the invocations of fn. lock guards the batch.
+	var flushLock, lock sync.Mutex
+	var batch []*apisexamplev1.TestType
+	positions := map[string]int{}
+	var timer *time.Timer
+	flush := func() {
+		flushLock.Lock()
+		defer flushLock.Unlock()
+		lock.Lock()
+		items := batch
+		batch, positions = nil, map[string]int{}
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		lock.Unlock()
+		if len(items) > 0 {
+			defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+			fn(items)
+		}
+	}
+	collect := func(obj interface{}) {
+		if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*apisexamplev1.TestType)
+		if !ok {
+			return
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		if ctx.Err() != nil {
+			lock.Unlock()
+			return
+		}
+		if position, ok := positions[key]; ok {
+			batch[position] = item
+		} else {
+			positions[key] = len(batch)
+			batch = append(batch, item)
+		}
+		if timer == nil {
+			timer = time.AfterFunc(maxDelay, flush)
+		}
+		full := len(batch) >= maxBatch
+		lock.Unlock()
+		if full {
+			flush()
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: collect,
+		UpdateFunc: func(_, newObj interface{}) {
+			collect(newObj)
+		},
+		DeleteFunc: collect,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := sharedInformer.RemoveEventHandler(registration); err != nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to remove the TestType batch handler")
+		}
+		flush()
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeTracedHandler adds an event handler to the shared informer of informer
+// which invokes fn for added, updated and deleted TestTypes with the trace context
+// stored in their annotations. extract returns the context carried by the annotations,
+// derived from its ctx; it must not modify them. An OpenTelemetry propagator is used as
+//
+//	func(ctx context.Context, carrier map[string]string) context.Context {
+//		return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+//	}
+//
+// Deleted TestTypes are passed with their last state.
+func AddTestTypeTracedHandler(informer TestTypeInformer, extract func(ctx context.Context, carrier map[string]string) context.Context, fn func(ctx context.Context, obj *apisexamplev1.TestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	handle := func(obj interface{}) {
+		if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*apisexamplev1.TestType)
+		if !ok {
+			return
+		}
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(extract(context.Background(), item.Annotations), item)
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, newObj interface{}) {
+			handle(newObj)
+		},
+		DeleteFunc: handle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypeHandlerFromResourceVersion adds handler to the shared informer of informer,
+// resuming from a checkpoint at resourceVersion. Additions and updates of TestTypes
+// whose resource version is not newer than resourceVersion are not delivered, because they
+// were processed before the checkpoint. This skips the replay of the informer's cache to the
+// new handler as well as relisted TestTypes which did not change since. Deletions
+// are always delivered. Resource versions are compared as integers; TestTypes whose
+// resource version is not an integer are always delivered.
+func AddTestTypeHandlerFromResourceVersion(informer TestTypeInformer, resourceVersion string, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	checkpoint, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource version %q to resume from: %w", resourceVersion, err)
+	}
+	sharedInformer := informer.Informer()
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.TestType{}))
+	}
+	processed := func(obj interface{}) bool {
+		item, ok := obj.(*apisexamplev1.TestType)
+		if !ok {
+			return false
+		}
+		itemResourceVersion, err := strconv.ParseUint(item.ResourceVersion, 10, 64)
+		return err == nil && itemResourceVersion <= checkpoint
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !processed(obj) {
+				handler.OnAdd(obj, isInInitialList)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !processed(newObj) {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: handler.OnDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddTestTypePostSyncHandler adds handler to the shared informer of informer, suppressing
+// all events until the handler has synced, i.e. until the informer has synced and the replay
+// of its cache to the handler is over. handler only receives the changes of TestTypes
+// after that. synced, if not nil, is invoked once when the handler has synced, before any event
+// is delivered to handler, unless ctx is done first.
+func AddTestTypePostSyncHandler(ctx context.Context, informer TestTypeInformer, handler cache.ResourceEventHandler, synced func()) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := testTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, panicHandler)
+	}
+	var syncedOnce sync.Once
+	notifySynced := func() {
+		syncedOnce.Do(func() {
+			if synced != nil {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				synced()
+			}
+		})
+	}
+	// The events wait for the registration, which is only known once
+	// AddEventHandler returns.
+	var registration cache.ResourceEventHandlerRegistration
+	registered := make(chan struct{})
+	postSync := func() bool {
+		<-registered
+		if !registration.HasSynced() {
+			return false
+		}
+		notifySynced()
+		return true
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnAdd(obj, false)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if postSync() {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnDelete(obj)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	close(registered)
+	go func() {
+		select {
+		case <-registration.HasSyncedChecker().Done():
+			notifySynced()
+		case <-ctx.Done():
+		}
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// OnTestTypeSynced invokes fn once, in its own goroutine, as soon as the shared informer
+// of informer has synced for the first time, or right away if it already has. fn is not invoked
+// if ctx is done first. Panics of fn are handled by the panic handler of the factory of
+// informer, if any.
+func OnTestTypeSynced(ctx context.Context, informer TestTypeInformer, fn func()) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	if factory, fromFactory := testTypeFactoryExtensions(informer); fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.TestType{})
+	}
+	go func() {
+		select {
+		case <-sharedInformer.HasSyncedChecker().Done():
+		case <-ctx.Done():
+			return
+		}
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn()
+	}()
+}
+
+// TestTypeEventStream is the part of a gRPC server stream which is used to send
+// TestType events as messages of type M, like the Foo_WatchServer interface which
+// protoc-gen-go-grpc generates for a method "rpc Watch(...) returns (stream M)".
+type TestTypeEventStream[M any] interface {
+	Send(M) error
+	Context() context.Context
+}
+
+// TestTypeProtoMarshaler converts a TestType event into the message sent on a stream.
+type TestTypeProtoMarshaler[M any] func(eventType watch.EventType, obj *apisexamplev1.TestType) (M, error)
+
+// AddTestTypeStreamServer adds an event handler to the shared informer of informer which
+// marshals every TestType event with marshal and sends the result on stream. The event
+// handler is removed once the context of stream is done, so a gRPC method can serve a watch
+// by calling AddTestTypeStreamServer and then waiting for the context of its stream.
+// Events which cannot be marshaled or sent are reported to utilruntime.HandleError and skipped.
+func AddTestTypeStreamServer[M any](informer TestTypeInformer, stream TestTypeEventStream[M], marshal TestTypeProtoMarshaler[M]) (cache.ResourceEventHandlerRegistration, error) {
+	ctx := stream.Context()
+	sharedInformer := informer.Informer()
+	send := func(eventType watch.EventType, obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		item, ok := obj.(*apisexamplev1.TestType)
+		if !ok || ctx.Err() != nil {
+			return
+		}
+		message, err := marshal(eventType, item)
+		if err != nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to marshal TestType event", "eventType", eventType, "object", v2.KObj(item))
+			return
+		}
+		if err := stream.Send(message); err != nil && ctx.Err() == nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to send TestType event", "eventType", eventType, "object", v2.KObj(item))
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			send(watch.Added, obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			send(watch.Modified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			send(watch.Deleted, obj)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if factory, ok := testTypeFactoryExtensions(informer); ok {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = sharedInformer.RemoveEventHandler(registration)
+	}()
+	return registration, nil
+}
+
+// FilteredTestTypeInformer provides access to the TestTypes of a shared informer
+// which match a predicate.
+type FilteredTestTypeInformer interface {
+	// AddEventHandler adds handler to the shared informer. handler only sees matching
+	// TestTypes: an update which makes an object match is delivered as an addition,
+	// and an update which makes it stop matching as a deletion.
+	AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)
+	// RemoveEventHandler removes a handler added by AddEventHandler.
+	RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error
+	// HasSynced returns true once the shared informer has synced.
+	HasSynced() bool
+	// Lister returns a lister which only lists and gets matching TestTypes.
+	Lister() examplev1.TestTypeLister
+}
+
+// FilteredTestType returns a view of informer which only surfaces the TestTypes
+// for which pred returns true. This allows filtering on anything a label or field selector
+// cannot express, such as the status. The view shares the watch and cache of informer,
+// so pred is evaluated whenever an object is read or an event is delivered.
+func FilteredTestType(informer TestTypeInformer, pred func(*apisexamplev1.TestType) bool) FilteredTestTypeInformer {
+	return &filteredTestTypeInformer{informer: informer, pred: pred}
+}
+
+type filteredTestTypeInformer struct {
+	informer TestTypeInformer
+	pred     func(*apisexamplev1.TestType) bool
+}
+
+func (f *filteredTestTypeInformer) matches(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	item, ok := obj.(*apisexamplev1.TestType)
+	return ok && f.pred(item)
+}
+
+func (f *filteredTestTypeInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := f.informer.Informer()
+	factory, fromFactory := testTypeFactoryExtensions(f.informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.TestType{}))
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.FilteringResourceEventHandler{FilterFunc: f.matches, Handler: handler})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+func (f *filteredTestTypeInformer) RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	return f.informer.Informer().RemoveEventHandler(registration)
+}
+
+func (f *filteredTestTypeInformer) HasSynced() bool {
+	return f.informer.Informer().HasSynced()
+}
+
+func (f *filteredTestTypeInformer) Lister() examplev1.TestTypeLister {
+	return examplev1.NewTestTypeLister(internalinterfaces.NewFilteredIndexer(f.informer.Informer().GetIndexer(), f.matches))
+}
+
+// ExportTestTypeList returns the TestTypes in the cache of informer in
+// namespace, or in all namespaces for metav1.NamespaceAll, which match selector as a
+// TestTypeList with its kind and API version set, for example to write a snapshot of
+// the cache. The items are copies sorted by namespace and name, and the
+// resource version of the list is the last one synced by the informer.
+func ExportTestTypeList(informer TestTypeInformer, namespace string, selector labels.Selector) (*apisexamplev1.TestTypeList, error) {
+	var objs []*apisexamplev1.TestType
+	var err error
+	if namespace == metav1.NamespaceAll {
+		objs, err = informer.Lister().List(selector)
+	} else {
+		objs, err = informer.Lister().TestTypes(namespace).List(selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(objs, func(a, b *apisexamplev1.TestType) int {
+		if c := strings.Compare(a.ObjectMeta.Namespace, b.ObjectMeta.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ObjectMeta.Name, b.ObjectMeta.Name)
+	})
+	list := &apisexamplev1.TestTypeList{
+		TypeMeta: metav1.TypeMeta{Kind: "TestTypeList", APIVersion: "example-group.hyphens.code-generator.k8s.io/v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: informer.Informer().LastSyncResourceVersion()},
+		Items:    make([]apisexamplev1.TestType, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.DeepCopy())
+	}
+	return list, nil
+}
+
+// TestTypeToApplyConfiguration extracts the apply configuration owned by
+// fieldManager from obj, typically returned by a lister, for an
+// extract/modify-in-place/apply workflow. Only the fields which fieldManager
+// applied and still owns are set, besides the name, namespace, kind and API
+// version. The schema of obj is deduced from its value, so lists are treated
+// as atomic. obj is not modified.
+func TestTypeToApplyConfiguration(obj *apisexamplev1.TestType, fieldManager string) (*applyconfigurationexamplev1.TestTypeApplyConfiguration, error) {
+	b := &applyconfigurationexamplev1.TestTypeApplyConfiguration{}
+	if err := managedfields.ExtractInto(obj, typed.DeducedParseableType, fieldManager, b, ""); err != nil {
+		return nil, err
+	}
+	b.WithName(obj.ObjectMeta.Name)
+	b.WithNamespace(obj.ObjectMeta.Namespace)
+	b.WithKind("TestType")
+	b.WithAPIVersion("example-group.hyphens.code-generator.k8s.io/v1")
+	return b, nil
+}
@@ -20,7 +20,9 @@ package externalversions
 
 import (
 	fmt "fmt"
+	reflect "reflect"
 
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1 "k8s.io/code-generator/examples/HyphenGroup/apis/example/v1"
@@ -62,3 +64,68 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
 }
+
+// resourceForType returns the resource served by the informers for informerType.
+func resourceForType(informerType reflect.Type) (schema.GroupVersionResource, bool) {
+	switch informerType {
+	// Group=example-group.hyphens.code-generator.k8s.io, Version=v1
+	case reflect.TypeOf(&v1.ClusterTestType{}):
+		return v1.SchemeGroupVersion.WithResource("clustertesttypes"), true
+	case reflect.TypeOf(&v1.TestType{}):
+		return v1.SchemeGroupVersion.WithResource("testtypes"), true
+
+	}
+
+	return schema.GroupVersionResource{}, false
+}
+
+// isClusterScoped returns whether the objects of resource are not namespaced.
+func isClusterScoped(resource schema.GroupVersionResource) bool {
+	switch resource {
+	case v1.SchemeGroupVersion.WithResource("clustertesttypes"):
+		return true
+	}
+	return false
+}
+
+// newListForResource returns an empty list of the type which holds the objects
+// of resource.
+func newListForResource(resource schema.GroupVersionResource) (runtime.Object, bool) {
+	switch resource {
+	// Group=example-group.hyphens.code-generator.k8s.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clustertesttypes"):
+		return &v1.ClusterTestTypeList{}, true
+	case v1.SchemeGroupVersion.WithResource("testtypes"):
+		return &v1.TestTypeList{}, true
+
+	}
+
+	return nil, false
+}
+
+// InformerConstructors holds, for every resource of the factory, the function
+// which returns the shared informer of the resource from a factory. The error
+// is the one of the informer create hook, if it vetoed the informer.
+var InformerConstructors = map[schema.GroupVersionResource]func(SharedInformerFactory) (cache.SharedIndexInformer, error){
+	// Group=example-group.hyphens.code-generator.k8s.io, Version=v1
+	v1.SchemeGroupVersion.WithResource("clustertesttypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.ExampleGroup().V1().ClusterTestTypes().Informer()
+		return informer, vetoError(f, &v1.ClusterTestType{})
+	},
+	v1.SchemeGroupVersion.WithResource("testtypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.ExampleGroup().V1().TestTypes().Informer()
+		return informer, vetoError(f, &v1.TestType{})
+	},
+}
+
+// vetoError returns the error of the informer create hook of f for the
+// informer of obj's type, if it vetoed the informer.
+func vetoError(f SharedInformerFactory, obj runtime.Object) error {
+	factory, ok := f.(*sharedInformerFactory)
+	if !ok {
+		return nil
+	}
+	factory.lock.Lock()
+	defer factory.lock.Unlock()
+	return factory.vetoedInformers[reflect.TypeOf(obj)]
+}
@@ -20,9 +20,11 @@ package fake
 
 import (
 	context "context"
+	json "encoding/json"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	gentype "k8s.io/client-go/gentype"
 	testing "k8s.io/client-go/testing"
 	v1 "k8s.io/code-generator/examples/MixedCase/apis/example/v1"
@@ -55,6 +57,24 @@ func newFakeClusterTestTypes(fake *FakeExampleV1) typedexamplev1.ClusterTestType
 	}
 }
 
+// MergePatchClusterTestType marshals patch to JSON and records it as a JSON merge patch of the named clusterTestType.
+func (c *fakeClusterTestTypes) MergePatchClusterTestType(ctx context.Context, name string, patch *v1.ClusterTestType, opts metav1.PatchOptions) (*v1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchClusterTestType marshals patch to JSON and records it as a strategic merge patch of the named clusterTestType.
+func (c *fakeClusterTestTypes) StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *v1.ClusterTestType, opts metav1.PatchOptions) (*v1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
 // GetScale takes name of the clusterTestType, and returns the corresponding scale object, and an error if there is any.
 func (c *fakeClusterTestTypes) GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
 	emptyResult := &autoscalingv1.Scale{}
@@ -19,6 +19,11 @@ limitations under the License.
 package fake
 
 import (
+	context "context"
+	json "encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	gentype "k8s.io/client-go/gentype"
 	v1 "k8s.io/code-generator/examples/MixedCase/apis/example/v1"
 	examplev1 "k8s.io/code-generator/examples/MixedCase/applyconfiguration/example/v1"
@@ -47,3 +52,21 @@ func newFakeTestTypes(fake *FakeExampleV1, namespace string) typedexamplev1.Test
 		fake,
 	}
 }
+
+// MergePatchTestType marshals patch to JSON and records it as a JSON merge patch of the named testType.
+func (c *fakeTestTypes) MergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and records it as a strategic merge patch of the named testType.
+func (c *fakeTestTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
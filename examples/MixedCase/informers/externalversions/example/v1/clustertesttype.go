@@ -20,17 +20,28 @@ package v1
 
 import (
 	context "context"
+	fmt "fmt"
+	slices "slices"
+	strconv "strconv"
+	strings "strings"
+	sync "sync"
 	time "time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	watch "k8s.io/apimachinery/pkg/watch"
 	cache "k8s.io/client-go/tools/cache"
 	apisexamplev1 "k8s.io/code-generator/examples/MixedCase/apis/example/v1"
+	applyconfigurationexamplev1 "k8s.io/code-generator/examples/MixedCase/applyconfiguration/example/v1"
 	versioned "k8s.io/code-generator/examples/MixedCase/clientset/versioned"
 	internalinterfaces "k8s.io/code-generator/examples/MixedCase/informers/externalversions/internalinterfaces"
 	examplev1 "k8s.io/code-generator/examples/MixedCase/listers/example/v1"
+	v2 "k8s.io/klog/v2"
+	typed "sigs.k8s.io/structured-merge-diff/v6/typed"
 )
 
 // ClusterTestTypeInformer provides access to a shared informer and lister for
@@ -38,6 +49,10 @@ import (
 type ClusterTestTypeInformer interface {
 	Informer() cache.SharedIndexInformer
 	Lister() examplev1.ClusterTestTypeLister
+	// Factory returns the factory which owns the informer. It is the
+	// SharedInformerFactory of the informers package, which cannot be named
+	// here without an import cycle; use a type assertion to access it.
+	Factory() internalinterfaces.SharedInformerFactory
 }
 
 type clusterTestTypeInformer struct {
@@ -49,14 +64,30 @@ type clusterTestTypeInformer struct {
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewClusterTestTypeInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
-	return NewClusterTestTypeInformerWithOptions(client, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers})
+	return NewClusterTestTypeInformerWithContext(context.TODO(), client, resyncPeriod, indexers)
+}
+
+// NewClusterTestTypeInformerWithContext constructs a new informer for ClusterTestType type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterTestTypeInformerWithContext(ctx context.Context, client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewClusterTestTypeInformerWithOptions(client, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, Context: ctx})
 }
 
 // NewFilteredClusterTestTypeInformer constructs a new informer for ClusterTestType type.
 // Always prefer using an informer factory to get a shared informer instead of getting an independent
 // one. This reduces memory footprint and number of connections to the server.
 func NewFilteredClusterTestTypeInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
-	return NewClusterTestTypeInformerWithOptions(client, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions})
+	return NewFilteredClusterTestTypeInformerWithContext(context.TODO(), client, resyncPeriod, indexers, tweakListOptions)
+}
+
+// NewFilteredClusterTestTypeInformerWithContext constructs a new informer for ClusterTestType type,
+// whose lists and watches are canceled once ctx is done.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterTestTypeInformerWithContext(ctx context.Context, client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return NewClusterTestTypeInformerWithOptions(client, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: indexers, TweakListOptions: tweakListOptions, Context: ctx})
 }
 
 // NewClusterTestTypeInformerWithOptions constructs a new informer for ClusterTestType type with additional options.
@@ -66,33 +97,76 @@ func NewClusterTestTypeInformerWithOptions(client versioned.Interface, options i
 	gvr := schema.GroupVersionResource{Group: "example.crd.code-generator.k8s.io", Version: "v1", Resource: "clustertesttypes"}
 	identifier := options.InformerName.WithResource(gvr)
 	tweakListOptions := options.TweakListOptions
-	return cache.NewSharedIndexInformerWithOptions(
-		cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
-			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleV1().ClusterTestTypes().List(context.Background(), opts)
-			},
-			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleV1().ClusterTestTypes().Watch(context.Background(), opts)
-			},
-			ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleV1().ClusterTestTypes().List(ctx, opts)
-			},
-			WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
-				if tweakListOptions != nil {
-					tweakListOptions(&opts)
-				}
-				return client.ExampleV1().ClusterTestTypes().Watch(ctx, opts)
-			},
-		}, client),
+	if options.Retweaker != nil {
+		tweakListOptions = options.Retweaker.TweakListOptions
+	}
+	// initialResourceVersion is cleared by the first list. Lists are never
+	// called concurrently by a reflector.
+	initialResourceVersion := options.InitialResourceVersion
+	initialResourceVersionMatch := options.InitialResourceVersionMatch
+	if initialResourceVersionMatch == "" {
+		initialResourceVersionMatch = metav1.ResourceVersionMatchExact
+	}
+	pageSize := options.WatchListPageSize
+	observeWatch := func(w watch.Interface, err error) (watch.Interface, error) {
+		if err == nil && options.ReconnectObserver != nil {
+			options.ReconnectObserver(gvr, time.Now())
+		}
+		return w, err
+	}
+	var lw cache.ListerWatcher = cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.ExampleV1().ClusterTestTypes().List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.ExampleV1().ClusterTestTypes().Watch(context.Background(), opts))
+		},
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			if pageSize > 0 {
+				opts.Limit = pageSize
+			}
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			if initialResourceVersion != "" {
+				opts.ResourceVersion = initialResourceVersion
+				opts.ResourceVersionMatch = initialResourceVersionMatch
+				initialResourceVersion = ""
+			}
+			return client.ExampleV1().ClusterTestTypes().List(ctx, opts)
+		},
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			if tweakListOptions != nil {
+				tweakListOptions(&opts)
+			}
+			return observeWatch(client.ExampleV1().ClusterTestTypes().Watch(ctx, opts))
+		},
+	}, client)
+	if options.InitialResourceVersion != "" {
+		lw = internalinterfaces.NewListerWatcherWithoutWatchList(lw)
+	}
+	lw = internalinterfaces.NewValidatingListerWatcher(lw, options.IngestValidator)
+	lw = internalinterfaces.NewValidatingListerWatcher(lw, internalinterfaces.NewObjectFilter(options.ObjectFilter))
+	lw = internalinterfaces.NewRetweakableListerWatcher(lw, options.Retweaker)
+	lw = internalinterfaces.NewRotatingListerWatcher(lw, options.WatchRotation)
+	lw = internalinterfaces.NewLeadershipGatedListerWatcher(lw, options.LeadershipGate)
+	lw = internalinterfaces.NewContextBoundListerWatcher(lw, options.Context)
+	informer := cache.NewSharedIndexInformerWithOptions(
+		internalinterfaces.NewCacheSnapshotListerWatcher(lw, options.CacheSnapshot, options.CacheSnapshotDecoder, &apisexamplev1.ClusterTestTypeList{}),
 		&apisexamplev1.ClusterTestType{},
 		cache.SharedIndexInformerOptions{
 			ResyncPeriod: options.ResyncPeriod,
@@ -100,10 +174,23 @@ func NewClusterTestTypeInformerWithOptions(client versioned.Interface, options i
 			Identifier:   identifier,
 		},
 	)
+	informer = internalinterfaces.NewCacheBackendInformer(informer, options.CacheBackend, options.Indexers, options.InitialCacheCapacity)
+	return internalinterfaces.NewKeyNormalizingInformer(informer, options.KeyNormalizer, options.Indexers)
 }
 
 func (f *clusterTestTypeInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-	return NewClusterTestTypeInformerWithOptions(client, internalinterfaces.InformerOptions{ResyncPeriod: resyncPeriod, Indexers: cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, InformerName: f.factory.InformerName(), TweakListOptions: f.tweakListOptions})
+	options := internalinterfaces.InformerOptions{InformerName: f.factory.InformerName()}
+	if factory, ok := f.factory.(internalinterfaces.SharedInformerFactoryExtensions); ok {
+		factory.CheckInformerCreate(&apisexamplev1.ClusterTestType{})
+		options = factory.InformerOptionsFor(&apisexamplev1.ClusterTestType{})
+	}
+	options.ResyncPeriod = resyncPeriod
+	options.Indexers = cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	options.TweakListOptions = f.tweakListOptions
+	if options.Retweaker != nil {
+		options.Retweaker.Retweak(f.tweakListOptions)
+	}
+	return NewClusterTestTypeInformerWithOptions(client, options)
 }
 
 func (f *clusterTestTypeInformer) Informer() cache.SharedIndexInformer {
@@ -113,3 +200,777 @@ func (f *clusterTestTypeInformer) Informer() cache.SharedIndexInformer {
 func (f *clusterTestTypeInformer) Lister() examplev1.ClusterTestTypeLister {
 	return examplev1.NewClusterTestTypeLister(f.Informer().GetIndexer())
 }
+
+func (f *clusterTestTypeInformer) Factory() internalinterfaces.SharedInformerFactory {
+	return f.factory
+}
+
+// clusterTestTypeFactoryExtensions returns the extensions of the factory of informer, if
+// informer was obtained from a factory which implements them.
+func clusterTestTypeFactoryExtensions(informer ClusterTestTypeInformer) (internalinterfaces.SharedInformerFactoryExtensions, bool) {
+	factoryInformer, ok := informer.(*clusterTestTypeInformer)
+	if !ok {
+		return nil, false
+	}
+	factory, ok := factoryInformer.factory.(internalinterfaces.SharedInformerFactoryExtensions)
+	return factory, ok
+}
+
+// AddClusterTestTypeEventHandler adds handler to the shared informer of informer. Panics of
+// handler are handled by the panic handler of the factory of informer, if any. The returned
+// registration reports through HasSynced when the replay of the informer's cache to handler
+// is over, independently of other handlers.
+func AddClusterTestTypeEventHandler(informer ClusterTestTypeInformer, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.ClusterTestType{}))
+	}
+	registration, err := sharedInformer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeEventHandlerFuncs adds an event handler to the shared informer of informer
+// which invokes the given callbacks with typed ClusterTestTypes; any of them may be nil.
+// Deletions whose final state is unknown are delivered to deleteFunc with the last known state
+// of the object. Objects of other types are ignored. The handler is added through
+// AddClusterTestTypeEventHandler.
+func AddClusterTestTypeEventHandlerFuncs(informer ClusterTestTypeInformer, addFunc func(*apisexamplev1.ClusterTestType), updateFunc func(oldObj, newObj *apisexamplev1.ClusterTestType), deleteFunc func(*apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	handler := cache.ResourceEventHandlerFuncs{}
+	if addFunc != nil {
+		handler.AddFunc = func(obj interface{}) {
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				addFunc(item)
+			}
+		}
+	}
+	if updateFunc != nil {
+		handler.UpdateFunc = func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.ClusterTestType)
+			newItem, newOK := newObj.(*apisexamplev1.ClusterTestType)
+			if oldOK && newOK {
+				updateFunc(oldItem, newItem)
+			}
+		}
+	}
+	if deleteFunc != nil {
+		handler.DeleteFunc = func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				deleteFunc(item)
+			}
+		}
+	}
+	return AddClusterTestTypeEventHandler(informer, handler)
+}
+
+// AddClusterTestTypeEventHandlerWithPriority adds handler to the shared informer of informer,
+// which must be obtained from a factory. The handlers added with a priority are invoked one
+// after another in ascending priority, and in the order they were added for equal priorities;
+// other handlers are invoked independently of them. Panics of handler are handled by the
+// panic handler of the factory, if any. The objects in the cache of the informer are delivered
+// to handler before it returns.
+func AddClusterTestTypeEventHandlerWithPriority(informer ClusterTestTypeInformer, priority int, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if !fromFactory {
+		return nil, fmt.Errorf("handler priorities require an informer obtained from a factory")
+	}
+	sharedInformer := informer.Informer()
+	handlers, err := factory.PriorityEventHandlers(sharedInformer)
+	if err != nil {
+		return nil, err
+	}
+	handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.ClusterTestType{}))
+	registration := handlers.Add(priority, handler, sharedInformer.GetStore())
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+
+// AddClusterTestTypeResyncHandler adds an event handler to the shared informer of informer
+// which invokes fn for periodic resyncs of ClusterTestTypes only, not for changes.
+// Resyncs are recognized by the old and new objects of an update being the same
+// object. fn is never invoked if the informer has no resync period.
+func AddClusterTestTypeResyncHandler(informer ClusterTestTypeInformer, fn func(*apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.ClusterTestType)
+			newItem, newOK := newObj.(*apisexamplev1.ClusterTestType)
+			if oldOK && newOK && oldItem == newItem {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeSpecChangeHandler adds an event handler to the shared informer of informer
+// which invokes fn with the new object of the updates of ClusterTestTypes which change
+// their metadata.generation, ignoring updates of their status or metadata only. It is not
+// invoked for adds or deletes, nor for resources whose generation is not maintained by the
+// API server.
+func AddClusterTestTypeSpecChangeHandler(informer ClusterTestTypeInformer, fn func(*apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.ClusterTestType)
+			newItem, newOK := newObj.(*apisexamplev1.ClusterTestType)
+			if oldOK && newOK && oldItem.Generation != newItem.Generation {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(newItem)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeDiffHandler adds an event handler to the shared informer of informer
+// which invokes fn with the old and new states of the added, updated and deleted
+// ClusterTestTypes: oldObj is nil for adds and newObj is nil for deletes. The last known
+// state of ClusterTestTypes whose deletion the informer missed is passed as oldObj.
+// Resyncs are passed as updates with the same old and new object.
+func AddClusterTestTypeDiffHandler(informer ClusterTestTypeInformer, fn func(oldObj, newObj *apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	diff := func(oldItem, newItem *apisexamplev1.ClusterTestType) {
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(oldItem, newItem)
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				diff(nil, item)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.ClusterTestType)
+			newItem, newOK := newObj.(*apisexamplev1.ClusterTestType)
+			if oldOK && newOK {
+				diff(oldItem, newItem)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				diff(item, nil)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// ClusterTestTypeEvent is an event of a ClusterTestType delivered to a sequenced handler.
+type ClusterTestTypeEvent struct {
+	// Type is watch.Added, watch.Modified or watch.Deleted.
+	Type watch.EventType
+	// Object is the added, updated or deleted ClusterTestType. Deleted objects
+	// may be stale if the informer missed their deletion.
+	Object *apisexamplev1.ClusterTestType
+	// OldObject is the previous state of an updated ClusterTestType, or nil.
+	OldObject *apisexamplev1.ClusterTestType
+	// IsInInitialList is true for the adds of the initial list of the informer.
+	IsInInitialList bool
+}
+
+// AddClusterTestTypeSequencedHandler adds an event handler to the shared informer of informer,
+// which must be obtained from a factory, which invokes fn for the added, updated and deleted
+// ClusterTestTypes with a sequence number. The sequence numbers are shared by all the
+// sequenced handlers of the factory and increase monotonically in the order in which the
+// events are dispatched, so they order the events of different informers. fn may be invoked
+// concurrently for the events of different informers.
+func AddClusterTestTypeSequencedHandler(informer ClusterTestTypeInformer, fn func(seq uint64, ev ClusterTestTypeEvent)) (cache.ResourceEventHandlerRegistration, error) {
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if !fromFactory {
+		return nil, fmt.Errorf("sequenced handlers require an informer obtained from a factory")
+	}
+	panicHandler := factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	dispatch := func(ev ClusterTestTypeEvent) {
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(factory.NextEventSequence(), ev)
+	}
+	sharedInformer := informer.Informer()
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				dispatch(ClusterTestTypeEvent{Type: watch.Added, Object: item, IsInInitialList: isInInitialList})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldItem, oldOK := oldObj.(*apisexamplev1.ClusterTestType)
+			newItem, newOK := newObj.(*apisexamplev1.ClusterTestType)
+			if oldOK && newOK {
+				dispatch(ClusterTestTypeEvent{Type: watch.Modified, Object: newItem, OldObject: oldItem})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if item, ok := obj.(*apisexamplev1.ClusterTestType); ok {
+				dispatch(ClusterTestTypeEvent{Type: watch.Deleted, Object: item})
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	factory.TrackEventHandler(sharedInformer)
+	return registration, nil
+}
+
+// AddClusterTestTypeDebouncedHandler adds an event handler to the shared informer of informer
+// which invokes fn with the latest version of a ClusterTestType once it was not added or
+// updated for window. Deleting a ClusterTestType cancels its pending invocation. fn is
+// invoked in a goroutine of its own, so invocations for different ClusterTestTypes may
+// run concurrently. Invocations which are pending when the handler is removed still run.
+func AddClusterTestTypeDebouncedHandler(informer ClusterTestTypeInformer, window time.Duration, fn func(*apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	type pendingCall struct {
+		item  *apisexamplev1.ClusterTestType
+		timer *time.Timer
+	}
+	var lock sync.Mutex
+	pending := map[string]*pendingCall{}
+	schedule := func(obj interface{}) {
+		item, ok := obj.(*apisexamplev1.ClusterTestType)
+		if !ok {
+			return
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if call, ok := pending[key]; ok {
+			call.timer.Stop()
+		}
+		call := &pendingCall{item: item}
+		call.timer = time.AfterFunc(window, func() {
+			lock.Lock()
+			// The call was superseded if an update or delete raced with the timer.
+			current := pending[key] == call
+			if current {
+				delete(pending, key)
+			}
+			lock.Unlock()
+			if current {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				fn(call.item)
+			}
+		})
+		pending[key] = call
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: schedule,
+		UpdateFunc: func(_, newObj interface{}) {
+			schedule(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			if call, ok := pending[key]; ok {
+				call.timer.Stop()
+				delete(pending, key)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeBatchHandler adds an event handler to the shared informer of informer
+// which collects the added, updated and deleted ClusterTestTypes into batches and invokes
+// fn with each batch. A batch holds the latest version of each ClusterTestType once, in the
+// order in which they first changed. Deleted ClusterTestTypes are included with their
+// last state and are not marked as deleted; fn has to look them up in the informer's lister
+// to tell them apart. A batch is flushed once it holds maxBatch ClusterTestTypes, which
+// invokes fn in the informer's notification goroutine, or maxDelay after its first change.
+// When ctx is done, the handler is removed from the shared informer, the pending batch is
+// flushed and no further changes are collected. Invocations of fn never overlap.
+func AddClusterTestTypeBatchHandler(ctx context.Context, informer ClusterTestTypeInformer, maxBatch int, maxDelay time.Duration, fn func([]*apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	if maxBatch <= 0 {
+		return nil, fmt.Errorf("invalid batch size %d", maxBatch)
+	}
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	// flushLock serializes the invocations of fn. lock guards the batch.
+	var flushLock, lock sync.Mutex
+	var batch []*apisexamplev1.ClusterTestType
+	positions := map[string]int{}
+	var timer *time.Timer
+	flush := func() {
+		flushLock.Lock()
+		defer flushLock.Unlock()
+		lock.Lock()
+		items := batch
+		batch, positions = nil, map[string]int{}
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		lock.Unlock()
+		if len(items) > 0 {
+			defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+			fn(items)
+		}
+	}
+	collect := func(obj interface{}) {
+		if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*apisexamplev1.ClusterTestType)
+		if !ok {
+			return
+		}
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(item)
+		if err != nil {
+			return
+		}
+		lock.Lock()
+		if ctx.Err() != nil {
+			lock.Unlock()
+			return
+		}
+		if position, ok := positions[key]; ok {
+			batch[position] = item
+		} else {
+			positions[key] = len(batch)
+			batch = append(batch, item)
+		}
+		if timer == nil {
+			timer = time.AfterFunc(maxDelay, flush)
+		}
+		full := len(batch) >= maxBatch
+		lock.Unlock()
+		if full {
+			flush()
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: collect,
+		UpdateFunc: func(_, newObj interface{}) {
+			collect(newObj)
+		},
+		DeleteFunc: collect,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := sharedInformer.RemoveEventHandler(registration); err != nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to remove the ClusterTestType batch handler")
+		}
+		flush()
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeTracedHandler adds an event handler to the shared informer of informer
+// which invokes fn for added, updated and deleted ClusterTestTypes with the trace context
+// stored in their annotations. extract returns the context carried by the annotations,
+// derived from its ctx; it must not modify them. An OpenTelemetry propagator is used as
+//
+//	func(ctx context.Context, carrier map[string]string) context.Context {
+//		return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+//	}
+//
+// Deleted ClusterTestTypes are passed with their last state.
+func AddClusterTestTypeTracedHandler(informer ClusterTestTypeInformer, extract func(ctx context.Context, carrier map[string]string) context.Context, fn func(ctx context.Context, obj *apisexamplev1.ClusterTestType)) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	handle := func(obj interface{}) {
+		if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = unknown.Obj
+		}
+		item, ok := obj.(*apisexamplev1.ClusterTestType)
+		if !ok {
+			return
+		}
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn(extract(context.Background(), item.Annotations), item)
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, newObj interface{}) {
+			handle(newObj)
+		},
+		DeleteFunc: handle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypeHandlerFromResourceVersion adds handler to the shared informer of informer,
+// resuming from a checkpoint at resourceVersion. Additions and updates of ClusterTestTypes
+// whose resource version is not newer than resourceVersion are not delivered, because they
+// were processed before the checkpoint. This skips the replay of the informer's cache to the
+// new handler as well as relisted ClusterTestTypes which did not change since. Deletions
+// are always delivered. Resource versions are compared as integers; ClusterTestTypes whose
+// resource version is not an integer are always delivered.
+func AddClusterTestTypeHandlerFromResourceVersion(informer ClusterTestTypeInformer, resourceVersion string, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	checkpoint, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource version %q to resume from: %w", resourceVersion, err)
+	}
+	sharedInformer := informer.Informer()
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.ClusterTestType{}))
+	}
+	processed := func(obj interface{}) bool {
+		item, ok := obj.(*apisexamplev1.ClusterTestType)
+		if !ok {
+			return false
+		}
+		itemResourceVersion, err := strconv.ParseUint(item.ResourceVersion, 10, 64)
+		return err == nil && itemResourceVersion <= checkpoint
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !processed(obj) {
+				handler.OnAdd(obj, isInInitialList)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !processed(newObj) {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: handler.OnDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// AddClusterTestTypePostSyncHandler adds handler to the shared informer of informer, suppressing
+// all events until the handler has synced, i.e. until the informer has synced and the replay
+// of its cache to the handler is over. handler only receives the changes of ClusterTestTypes
+// after that. synced, if not nil, is invoked once when the handler has synced, before any event
+// is delivered to handler, unless ctx is done first.
+func AddClusterTestTypePostSyncHandler(ctx context.Context, informer ClusterTestTypeInformer, handler cache.ResourceEventHandler, synced func()) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	factory, fromFactory := clusterTestTypeFactoryExtensions(informer)
+	if fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, panicHandler)
+	}
+	var syncedOnce sync.Once
+	notifySynced := func() {
+		syncedOnce.Do(func() {
+			if synced != nil {
+				defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+				synced()
+			}
+		})
+	}
+	// The events wait for the registration, which is only known once
+	// AddEventHandler returns.
+	var registration cache.ResourceEventHandlerRegistration
+	registered := make(chan struct{})
+	postSync := func() bool {
+		<-registered
+		if !registration.HasSynced() {
+			return false
+		}
+		notifySynced()
+		return true
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnAdd(obj, false)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if postSync() {
+				handler.OnUpdate(oldObj, newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if postSync() {
+				handler.OnDelete(obj)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	close(registered)
+	go func() {
+		select {
+		case <-registration.HasSyncedChecker().Done():
+			notifySynced()
+		case <-ctx.Done():
+		}
+	}()
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+// OnClusterTestTypeSynced invokes fn once, in its own goroutine, as soon as the shared informer
+// of informer has synced for the first time, or right away if it already has. fn is not invoked
+// if ctx is done first. Panics of fn are handled by the panic handler of the factory of
+// informer, if any.
+func OnClusterTestTypeSynced(ctx context.Context, informer ClusterTestTypeInformer, fn func()) {
+	sharedInformer := informer.Informer()
+	var panicHandler func(recovered interface{})
+	if factory, fromFactory := clusterTestTypeFactoryExtensions(informer); fromFactory {
+		panicHandler = factory.PanicHandler(&apisexamplev1.ClusterTestType{})
+	}
+	go func() {
+		select {
+		case <-sharedInformer.HasSyncedChecker().Done():
+		case <-ctx.Done():
+			return
+		}
+		defer internalinterfaces.RecoverEventHandlerPanic(panicHandler)
+		fn()
+	}()
+}
+
+// ClusterTestTypeEventStream is the part of a gRPC server stream which is used to send
+// ClusterTestType events as messages of type M, like the Foo_WatchServer interface which
+// protoc-gen-go-grpc generates for a method "rpc Watch(...) returns (stream M)".
+type ClusterTestTypeEventStream[M any] interface {
+	Send(M) error
+	Context() context.Context
+}
+
+// ClusterTestTypeProtoMarshaler converts a ClusterTestType event into the message sent on a stream.
+type ClusterTestTypeProtoMarshaler[M any] func(eventType watch.EventType, obj *apisexamplev1.ClusterTestType) (M, error)
+
+// AddClusterTestTypeStreamServer adds an event handler to the shared informer of informer which
+// marshals every ClusterTestType event with marshal and sends the result on stream. The event
+// handler is removed once the context of stream is done, so a gRPC method can serve a watch
+// by calling AddClusterTestTypeStreamServer and then waiting for the context of its stream.
+// Events which cannot be marshaled or sent are reported to utilruntime.HandleError and skipped.
+func AddClusterTestTypeStreamServer[M any](informer ClusterTestTypeInformer, stream ClusterTestTypeEventStream[M], marshal ClusterTestTypeProtoMarshaler[M]) (cache.ResourceEventHandlerRegistration, error) {
+	ctx := stream.Context()
+	sharedInformer := informer.Informer()
+	send := func(eventType watch.EventType, obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		item, ok := obj.(*apisexamplev1.ClusterTestType)
+		if !ok || ctx.Err() != nil {
+			return
+		}
+		message, err := marshal(eventType, item)
+		if err != nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to marshal ClusterTestType event", "eventType", eventType, "object", v2.KObj(item))
+			return
+		}
+		if err := stream.Send(message); err != nil && ctx.Err() == nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Failed to send ClusterTestType event", "eventType", eventType, "object", v2.KObj(item))
+		}
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			send(watch.Added, obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			send(watch.Modified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			send(watch.Deleted, obj)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if factory, ok := clusterTestTypeFactoryExtensions(informer); ok {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = sharedInformer.RemoveEventHandler(registration)
+	}()
+	return registration, nil
+}
+
+// FilteredClusterTestTypeInformer provides access to the ClusterTestTypes of a shared informer
+// which match a predicate.
+type FilteredClusterTestTypeInformer interface {
+	// AddEventHandler adds handler to the shared informer. handler only sees matching
+	// ClusterTestTypes: an update which makes an object match is delivered as an addition,
+	// and an update which makes it stop matching as a deletion.
+	AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)
+	// RemoveEventHandler removes a handler added by AddEventHandler.
+	RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error
+	// HasSynced returns true once the shared informer has synced.
+	HasSynced() bool
+	// Lister returns a lister which only lists and gets matching ClusterTestTypes.
+	Lister() examplev1.ClusterTestTypeLister
+}
+
+// FilteredClusterTestType returns a view of informer which only surfaces the ClusterTestTypes
+// for which pred returns true. This allows filtering on anything a label or field selector
+// cannot express, such as the status. The view shares the watch and cache of informer,
+// so pred is evaluated whenever an object is read or an event is delivered.
+func FilteredClusterTestType(informer ClusterTestTypeInformer, pred func(*apisexamplev1.ClusterTestType) bool) FilteredClusterTestTypeInformer {
+	return &filteredClusterTestTypeInformer{informer: informer, pred: pred}
+}
+
+type filteredClusterTestTypeInformer struct {
+	informer ClusterTestTypeInformer
+	pred     func(*apisexamplev1.ClusterTestType) bool
+}
+
+func (f *filteredClusterTestTypeInformer) matches(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	item, ok := obj.(*apisexamplev1.ClusterTestType)
+	return ok && f.pred(item)
+}
+
+func (f *filteredClusterTestTypeInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	sharedInformer := f.informer.Informer()
+	factory, fromFactory := clusterTestTypeFactoryExtensions(f.informer)
+	if fromFactory {
+		handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(&apisexamplev1.ClusterTestType{}))
+	}
+	registration, err := sharedInformer.AddEventHandler(cache.FilteringResourceEventHandler{FilterFunc: f.matches, Handler: handler})
+	if err != nil {
+		return nil, err
+	}
+	if fromFactory {
+		factory.TrackEventHandler(sharedInformer)
+	}
+	return registration, nil
+}
+
+func (f *filteredClusterTestTypeInformer) RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	return f.informer.Informer().RemoveEventHandler(registration)
+}
+
+func (f *filteredClusterTestTypeInformer) HasSynced() bool {
+	return f.informer.Informer().HasSynced()
+}
+
+func (f *filteredClusterTestTypeInformer) Lister() examplev1.ClusterTestTypeLister {
+	return examplev1.NewClusterTestTypeLister(internalinterfaces.NewFilteredIndexer(f.informer.Informer().GetIndexer(), f.matches))
+}
+
+// ExportClusterTestTypeList returns the ClusterTestTypes in the cache of informer which match selector as a
+// ClusterTestTypeList with its kind and API version set, for example to write a snapshot of
+// the cache. The items are copies sorted by name, and the
+// resource version of the list is the last one synced by the informer.
+func ExportClusterTestTypeList(informer ClusterTestTypeInformer, selector labels.Selector) (*apisexamplev1.ClusterTestTypeList, error) {
+	objs, err := informer.Lister().List(selector)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(objs, func(a, b *apisexamplev1.ClusterTestType) int {
+		return strings.Compare(a.ObjectMeta.Name, b.ObjectMeta.Name)
+	})
+	list := &apisexamplev1.ClusterTestTypeList{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterTestTypeList", APIVersion: "example.crd.code-generator.k8s.io/v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: informer.Informer().LastSyncResourceVersion()},
+		Items:    make([]apisexamplev1.ClusterTestType, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.DeepCopy())
+	}
+	return list, nil
+}
+
+// ClusterTestTypeToApplyConfiguration extracts the apply configuration owned by
+// fieldManager from obj, typically returned by a lister, for an
+// extract/modify-in-place/apply workflow. Only the fields which fieldManager
+// applied and still owns are set, besides the name, kind and API
+// version. The schema of obj is deduced from its value, so lists are treated
+// as atomic. obj is not modified.
+func ClusterTestTypeToApplyConfiguration(obj *apisexamplev1.ClusterTestType, fieldManager string) (*applyconfigurationexamplev1.ClusterTestTypeApplyConfiguration, error) {
+	b := &applyconfigurationexamplev1.ClusterTestTypeApplyConfiguration{}
+	if err := managedfields.ExtractInto(obj, typed.DeducedParseableType, fieldManager, b, ""); err != nil {
+		return nil, err
+	}
+	b.WithName(obj.ObjectMeta.Name)
+	b.WithKind("ClusterTestType")
+	b.WithAPIVersion("example.crd.code-generator.k8s.io/v1")
+	return b, nil
+}
@@ -20,25 +20,45 @@ package externalversions
 
 import (
 	context "context"
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
 	reflect "reflect"
+	slices "slices"
+	strings "strings"
 	sync "sync"
+	atomic "sync/atomic"
 	time "time"
 
+	apiauthorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	wait "k8s.io/apimachinery/pkg/util/wait"
+	features "k8s.io/client-go/features"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	cache "k8s.io/client-go/tools/cache"
+	events "k8s.io/client-go/tools/events"
 	versioned "k8s.io/code-generator/examples/MixedCase/clientset/versioned"
 	example "k8s.io/code-generator/examples/MixedCase/informers/externalversions/example"
 	internalinterfaces "k8s.io/code-generator/examples/MixedCase/informers/externalversions/internalinterfaces"
+	v2 "k8s.io/klog/v2"
 )
 
 // SharedInformerOption defines the functional option type for SharedInformerFactory.
 type SharedInformerOption func(*sharedInformerFactory) *sharedInformerFactory
 
 type sharedInformerFactory struct {
-	client           versioned.Interface
+	client versioned.Interface
+	// options are the options the factory was created with, which
+	// CloneForNamespace applies to its clones.
+	options          []SharedInformerOption
 	namespace        string
 	tweakListOptions internalinterfaces.TweakListOptionsFunc
 	lock             sync.Mutex
@@ -47,10 +67,173 @@ type sharedInformerFactory struct {
 	transform        cache.TransformFunc
 	informerName     *cache.InformerName
 
+	// namespaceSelectors holds the label selectors of the namespaces which
+	// namespaced informers are limited to. It is nil unless
+	// WithNamespaceSelectors was used.
+	namespaceSelectors map[string]labels.Selector
+
+	// featureGateStripper strips the fields of objects which are disabled in
+	// featureGates before the objects enter the informer caches. It is nil
+	// unless WithFeatureGateTransform was used.
+	featureGates        features.Gates
+	featureGateStripper func(v1.Object, features.Gates)
+
+	// memoryBudget strips fields from objects when the estimated size of the
+	// informer caches exceeds it. It is nil unless WithSharedMemoryBudget was
+	// used.
+	memoryBudget *memoryBudget
+
+	// ingestTimes records when objects entered the informer caches, keyed by UID.
+	// It is nil unless WithIngestTimestamps was used.
+	ingestTimes map[types.UID]time.Time
+	ingestLock  sync.RWMutex
+
+	// queueCounters tracks the queue of each informer. It is nil unless
+	// WithInformerStats was used.
+	queueCounters map[reflect.Type]*informerQueueCounter
+
+	// cacheGenerations count the mutations of the cache of each informer for
+	// which CacheGeneration was called.
+	cacheGenerations map[reflect.Type]*atomic.Uint64
+
+	// keyNormalizers hold the functions normalizing the names in the keys of
+	// the caches of informers, keyed by resource. It is only written by
+	// WithKeyNormalizer.
+	keyNormalizers map[schema.GroupVersionResource]func(name string) string
+
+	// cacheCapacities hold the expected numbers of objects of informers,
+	// keyed by resource. It is only written by WithInitialCacheCapacity.
+	cacheCapacities map[schema.GroupVersionResource]int
+
+	// cacheBackend creates the indexers of the generated informers. It is nil
+	// unless WithCacheBackend was used.
+	cacheBackend internalinterfaces.CacheBackend
+
+	// leadershipGate pauses the generated informers while leadership is not
+	// held. It is nil unless WithLeadershipGate was used. It is shared with
+	// the clones of the factory, and so is leadershipGateRunner, which records
+	// the signals in it while any of them is started.
+	leadershipGate       *internalinterfaces.LeadershipGate
+	leadershipGateRunner *leadershipGateRunner
+	// leadershipGateRunning is set by the first start which starts
+	// informers, whose context keeps leadershipGateRunner running.
+	leadershipGateRunning bool
+
+	// watchRotation is the maximum lifetime of the watches of the generated
+	// informers. It is zero unless WithWatchRotation was used.
+	watchRotation time.Duration
+
+	// logger logs a summary of the informers started by each Start. It is nil
+	// unless WithLogger was used.
+	logger *v2.Logger
+
+	// watchErrorHandler replaces the default handling of watch errors if set.
+	watchErrorHandler cache.WatchErrorHandler
+
+	// reconnectObserver is called whenever the watch of a generated informer
+	// is established. It is nil unless WithReconnectObserver was used.
+	reconnectObserver func(resource schema.GroupVersionResource, at time.Time)
+
+	// eventRecorder emits events about watch failures regarding involvedObject.
+	// It is nil unless WithEventRecorder was used.
+	eventRecorder  events.EventRecorder
+	involvedObject runtime.Object
+
+	// cacheSnapshots holds the snapshots to warm informer caches from, keyed
+	// by resource. It is only written by WithCacheSnapshot.
+	cacheSnapshots map[schema.GroupVersionResource]cacheSnapshot
+
+	// initialResourceVersions holds the resource versions to pin the first
+	// list of informers to, keyed by resource. It is only written by
+	// WithInitialResourceVersion.
+	initialResourceVersions map[schema.GroupVersionResource]string
+
+	// listResourceVersion and listResourceVersionMatch constrain the first
+	// list of the informers which are not pinned by WithInitialResourceVersion.
+	// They are only written by WithListResourceVersionMatch.
+	listResourceVersion      string
+	listResourceVersionMatch v1.ResourceVersionMatch
+
+	// optionErrs holds the errors of the options which were ignored because
+	// they were invalid. They are returned by StartWithError.
+	optionErrs []error
+
+	// watchListPageSizes holds the list chunk sizes of informers, keyed by
+	// resource. It is only written by WithWatchListPageSize.
+	watchListPageSizes map[schema.GroupVersionResource]int64
+
+	// equalityFuncs holds the equality functions used to drop the updates of
+	// informers, keyed by resource. It is only written by WithEqualityFunc.
+	equalityFuncs map[schema.GroupVersionResource]EqualityFunc
+
+	// ingestValidators hold the validators of the objects ingested by
+	// informers, keyed by resource. It is only written by WithIngestValidator.
+	ingestValidators map[schema.GroupVersionResource]*internalinterfaces.IngestValidator
+
+	// objectFilter drops the objects which no informer may cache. It is nil
+	// unless WithGlobalObjectFilter was used.
+	objectFilter func(obj v1.Object) bool
+
+	// retweakers hold the list options tweaks of the generated informers,
+	// keyed by resource.
+	retweakers map[schema.GroupVersionResource]*internalinterfaces.Retweaker
+
+	// latencyHistograms hold the histograms observing the event processing
+	// latencies of informers, keyed by resource. It is only written by
+	// WithLatencyHistogram.
+	latencyHistograms map[schema.GroupVersionResource]cache.HistogramMetric
+
+	// stalenessWatchdogs hold the watchdogs of informers, keyed by resource.
+	// It is only written by WithStalenessWatchdog.
+	stalenessWatchdogs map[schema.GroupVersionResource]*stalenessWatchdog
+
+	// panicHandler handles the panics of the event handlers added by the
+	// generated handler helpers. Panics are not recovered if it is nil.
+	panicHandler func(resource schema.GroupVersionResource, recovered interface{})
+
+	// informerCreateHook is consulted before the generated informers are
+	// created. It is nil unless WithInformerCreateHook was used.
+	informerCreateHook func(resource schema.GroupVersionResource) error
+	// vetoedInformers holds the errors returned by informerCreateHook for
+	// the informers it vetoed. These informers are never started.
+	vetoedInformers map[reflect.Type]error
+
+	// rbacPrecheck reviews the access to the resources of the informers
+	// before they are started. It is nil unless WithRBACPrecheck was used.
+	rbacPrecheck authorizationv1.SelfSubjectAccessReviewsGetter
+	// precheckedInformers tracks the informers whose access was reviewed.
+	precheckedInformers map[reflect.Type]bool
+	// skippedInformers holds the reasons of the informers which are never
+	// started because their resources may not be listed or watched.
+	skippedInformers map[reflect.Type]string
+
 	informers map[reflect.Type]cache.SharedIndexInformer
+	// handlerCounts counts the event handlers which were added to informers
+	// through the generated handler helpers.
+	handlerCounts map[reflect.Type]int
+	// resyncHandlers holds the handlers added through AddResyncHandler, to
+	// which Resync and ReplayKey deliver the cached objects.
+	resyncHandlers map[reflect.Type]map[cache.ResourceEventHandlerRegistration]*resyncableHandler
+	// priorityEventHandlers holds the dispatchers of the handlers added with
+	// a priority, which are added to informers on demand.
+	priorityEventHandlers map[reflect.Type]*internalinterfaces.PriorityEventHandlers
+	// eventSequence is the last sequence number assigned to an event
+	// delivered to a sequenced handler.
+	eventSequence atomic.Uint64
+	// consumers holds the names registered by RegisterConsumer by resource.
+	consumers map[schema.GroupVersionResource][]string
 	// startedInformers is used for tracking which informers have been started.
 	// This allows Start() to be called multiple times safely.
 	startedInformers map[reflect.Type]bool
+	// deferredInformers tracks the informers which were started but wait for
+	// the informers of the previous stage of WithSyncOrder to sync.
+	deferredInformers map[reflect.Type]bool
+	// syncStages holds the stage of WithSyncOrder of each resource.
+	syncStages     map[schema.GroupVersionResource]int
+	syncStageCount int
+	// cancelFuncs cancel the contexts of the informers started by
+	// StartWithContext. They are called by Shutdown.
+	cancelFuncs []context.CancelCauseFunc
 	// wg tracks how many goroutines were started.
 	wg sync.WaitGroup
 	// shuttingDown is true when Shutdown has been called. It may still be running
@@ -84,6 +267,23 @@ func WithNamespace(namespace string) SharedInformerOption {
 	}
 }
 
+// WithNamespaceSelectors limits the namespaced informers of the
+// SharedInformerFactory to the namespaces of selectors, instead of the
+// namespace of WithNamespace. Each namespace is listed and watched with its
+// label selector, or with the label selector of WithTweakListOptions if its
+// selector is nil. Each namespace is watched from its own resource version,
+// but an error of the watch of one of them makes the informers relist all
+// of them, see NewMultiNamespaceListerWatcher in internalinterfaces.
+func WithNamespaceSelectors(selectors map[string]labels.Selector) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.namespaceSelectors = make(map[string]labels.Selector, len(selectors))
+		for namespace, selector := range selectors {
+			factory.namespaceSelectors[namespace] = selector
+		}
+		return factory
+	}
+}
+
 // WithTransform sets a transform on all informers.
 func WithTransform(transform cache.TransformFunc) SharedInformerOption {
 	return func(factory *sharedInformerFactory) *sharedInformerFactory {
@@ -92,6 +292,35 @@ func WithTransform(transform cache.TransformFunc) SharedInformerOption {
 	}
 }
 
+// WithFeatureGateTransform passes every object to stripper with gates before
+// the object enters an informer cache, after the transform of WithTransform.
+// stripper may modify the object in place, typically to clear the fields
+// guarded by feature gates which are disabled in gates. gates is consulted
+// for every object, so a changed gate applies to objects ingested later.
+func WithFeatureGateTransform(gates features.Gates, stripper func(v1.Object, features.Gates)) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.featureGates = gates
+		factory.featureGateStripper = stripper
+		return factory
+	}
+}
+
+// WithSharedMemoryBudget limits the estimated total size of the objects in
+// all informer caches of the factory to bytes, by progressively stripping
+// fields from objects before they enter a cache. An object which would exceed
+// the budget escalates the stripping by one stage at a time, which applies to
+// it and to the objects ingested after it: first managed fields are dropped,
+// then annotations. Whenever the estimated total size falls below three
+// quarters of bytes, the stripping is relaxed by one stage. Objects which are
+// already cached are not stripped until they change. The size of an object is
+// estimated by its JSON encoding; objects without a UID are not accounted for.
+func WithSharedMemoryBudget(bytes int64) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.memoryBudget = &memoryBudget{limit: bytes, sizes: make(map[types.UID]int64)}
+		return factory
+	}
+}
+
 // WithInformerName sets the InformerName for informer identity used in metrics.
 // The InformerName must be created via cache.NewInformerName() at startup,
 // which validates global uniqueness. Each informer type will register its
@@ -103,10 +332,543 @@ func WithInformerName(informerName *cache.InformerName) SharedInformerOption {
 	}
 }
 
+// WithIngestTimestamps records the time at which each object is ingested into
+// an informer cache. The timestamps are kept in a side map keyed by UID, so the
+// cached objects are never modified, even when a transform is also configured.
+// Use IngestTime to retrieve them.
+func WithIngestTimestamps() SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.ingestTimes = make(map[types.UID]time.Time)
+		return factory
+	}
+}
+
+// WithInformerStats tracks the changes queued by each informer until all of its
+// event handlers processed them, so that Stats can report a queue length. Without it, Stats only reports object counts.
+func WithInformerStats() SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.queueCounters = make(map[reflect.Type]*informerQueueCounter)
+		return factory
+	}
+}
+
+// WithWatchErrorHandler sets the handler which is called by all informers of the
+// factory whenever their watch fails, instead of cache.DefaultWatchErrorHandler.
+// Options are applied before any informer is created, so the handler is always
+// set before the informers are started.
+func WithWatchErrorHandler(handler cache.WatchErrorHandler) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.watchErrorHandler = handler
+		return factory
+	}
+}
+
+// WithReconnectObserver sets an observer which is called with the resource and
+// the time whenever the watch of a generated informer of the factory is
+// established, both initially and after every reconnection. It is called
+// synchronously by the reflector, so it must not block.
+func WithReconnectObserver(observer func(resource schema.GroupVersionResource, at time.Time)) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.reconnectObserver = observer
+		return factory
+	}
+}
+
+// WithEventRecorder emits a Warning event regarding involvedObject when the watch
+// of an informer fails repeatedly. At most one event is emitted per informer and
+// minute, so a persistently failing watch does not flood the API server.
+func WithEventRecorder(recorder events.EventRecorder, involvedObject runtime.Object) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.eventRecorder = recorder
+		factory.involvedObject = involvedObject
+		return factory
+	}
+}
+
+// WithCacheSnapshot warms the cache of the informer for resource from snapshot,
+// a list as written by SnapshotCache with the same codec. A nil codec stands
+// for JSON. The snapshot is read by the first list of the informer instead of
+// listing from the server. The informer then watches from the resource version
+// of the snapshot, and relists from the server if that resource version is too
+// old. A snapshot which cannot be decoded is reported and ignored.
+func WithCacheSnapshot(resource schema.GroupVersionResource, snapshot io.Reader, codec runtime.Codec) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.cacheSnapshots == nil {
+			factory.cacheSnapshots = make(map[schema.GroupVersionResource]cacheSnapshot)
+		}
+		factory.cacheSnapshots[resource] = cacheSnapshot{snapshot: snapshot, codec: codec}
+		return factory
+	}
+}
+
+// WithInitialResourceVersion pins the first list of the informer for resource
+// to resourceVersion, so that its cache starts from exactly that state of the
+// server, for example to reproduce what was observed at a known point in time.
+// The informer then watches from resourceVersion. The list fails if the server
+// no longer serves resourceVersion, in which case the informer relists at the
+// latest resource version like after any other failed list.
+func WithInitialResourceVersion(resource schema.GroupVersionResource, resourceVersion string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.initialResourceVersions == nil {
+			factory.initialResourceVersions = make(map[schema.GroupVersionResource]string)
+		}
+		factory.initialResourceVersions[resource] = resourceVersion
+		return factory
+	}
+}
+
+// WithListResourceVersionMatch makes the first list of each generated informer
+// request resourceVersion with match, unless WithInitialResourceVersion pins it
+// for the resource of the informer. For example, v1.ResourceVersionMatchNotOlderThan
+// with "0" lets the API server serve the list from its watch cache, which may
+// be stale, instead of from etcd. The informers then watch from the resource
+// version of their first list; later lists are not constrained. Streaming
+// lists are not used by these informers, because they would bypass the
+// constrained list. match must be Exact or NotOlderThan, resourceVersion must
+// not be empty, and Exact does not accept "0". Invalid combinations are
+// ignored and reported by StartWithError.
+func WithListResourceVersionMatch(match v1.ResourceVersionMatch, resourceVersion string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if err := validateListResourceVersionMatch(match, resourceVersion); err != nil {
+			factory.optionErrs = append(factory.optionErrs, err)
+			return factory
+		}
+		factory.listResourceVersion = resourceVersion
+		factory.listResourceVersionMatch = match
+		return factory
+	}
+}
+
+// validateListResourceVersionMatch checks match and resourceVersion like the
+// API server checks the list options.
+func validateListResourceVersionMatch(match v1.ResourceVersionMatch, resourceVersion string) error {
+	switch {
+	case match != v1.ResourceVersionMatchExact && match != v1.ResourceVersionMatchNotOlderThan:
+		return fmt.Errorf("unsupported resource version match %q", match)
+	case resourceVersion == "":
+		return fmt.Errorf("resource version match %s requires a resource version", match)
+	case match == v1.ResourceVersionMatchExact && resourceVersion == "0":
+		return fmt.Errorf("resource version match %s does not accept resource version \"0\"", match)
+	}
+	return nil
+}
+
+// WithWatchListPageSize sets the requested chunk size of the lists of the
+// informer for resource, like cache.Reflector.WatchListPageSize. Informers
+// list when streaming lists are disabled or not supported by the server;
+// streaming lists are sent as a watch and are not affected. Paginated lists
+// are always served from etcd, so this should be used carefully.
+func WithWatchListPageSize(resource schema.GroupVersionResource, pageSize int64) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.watchListPageSizes == nil {
+			factory.watchListPageSizes = make(map[schema.GroupVersionResource]int64)
+		}
+		factory.watchListPageSizes[resource] = pageSize
+		return factory
+	}
+}
+
+// WithEqualityFunc drops the updates of the informer for resource for which
+// equal returns true, so that the handlers added through the informers
+// returned by the factory only see updates which are relevant to them, for
+// example changes of the spec or labels but not of a volatile status. Resyncs
+// deliver the same object as old and new object, so they are dropped, too,
+// unless equal says otherwise.
+func WithEqualityFunc(resource schema.GroupVersionResource, equal EqualityFunc) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.equalityFuncs == nil {
+			factory.equalityFuncs = make(map[schema.GroupVersionResource]EqualityFunc)
+		}
+		factory.equalityFuncs[resource] = equal
+		return factory
+	}
+}
+
+// WithGlobalObjectFilter makes every generated informer of the
+// SharedInformerFactory drop the objects for which filter returns false, for
+// example the objects of blocked namespaces, as they are listed and watched,
+// so that they are never cached nor delivered to event handlers. An update
+// which makes an object be filtered out is delivered as its deletion. The
+// filter applies in addition to the validators of WithIngestValidator, but
+// the objects it drops are not reported as invalid.
+func WithGlobalObjectFilter(filter func(obj v1.Object) bool) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.objectFilter = filter
+		return factory
+	}
+}
+
+// IngestValidationMode says what happens to the objects rejected by the
+// validator of WithIngestValidator.
+type IngestValidationMode int
+
+const (
+	// DropInvalidObjects keeps invalid objects out of the informer's cache.
+	// An update which makes a cached object invalid removes it from the cache.
+	DropInvalidObjects IngestValidationMode = iota
+	// KeepInvalidObjects caches invalid objects like valid ones.
+	KeepInvalidObjects
+)
+
+// WithIngestValidator validates the objects which the informer for resource
+// lists and watches with validate, to catch objects which violate invariants,
+// for example because of a bug of their controller. The objects rejected by
+// validate are reported by InvalidObjects until they are valid again or
+// deleted, and are dropped or kept according to mode.
+func WithIngestValidator(resource schema.GroupVersionResource, validate func(obj runtime.Object) error, mode IngestValidationMode) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.ingestValidators == nil {
+			factory.ingestValidators = make(map[schema.GroupVersionResource]*internalinterfaces.IngestValidator)
+		}
+		factory.ingestValidators[resource] = internalinterfaces.NewIngestValidator(validate, mode == KeepInvalidObjects)
+		return factory
+	}
+}
+
+// WithPanicHandler recovers the panics of the event handlers added by the
+// generated handler helpers, such as the RegisterHandlers method of groups,
+// and passes them to handler with the resource of the informer. Without it,
+// such panics crash the process like those of any other event handler.
+func WithPanicHandler(handler func(resource schema.GroupVersionResource, recovered interface{})) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.panicHandler = handler
+		return factory
+	}
+}
+
+// WithInformerCreateHook consults hook with the resource of each generated
+// informer before the informer is created, for example to log it or to check
+// that its configuration is supported. If hook returns an error, the informer
+// is still returned, but the factory never starts it; StartWithError reports
+// the error instead. Informers created by custom InformerFor functions are not
+// passed to hook.
+func WithInformerCreateHook(hook func(resource schema.GroupVersionResource) error) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.informerCreateHook = hook
+		return factory
+	}
+}
+
+// WithRBACPrecheck reviews through authClient whether the resources of the
+// informers may be listed and watched before the informers are started.
+// Informers whose resources may not be are skipped instead of retrying
+// forbidden requests; SkippedInformers reports why. Informers whose access
+// cannot be reviewed are started. Cluster-scoped resources are reviewed in
+// all namespaces, and namespaced resources in the namespace of the factory,
+// or in each namespace of WithNamespaceSelectors if it was used.
+func WithRBACPrecheck(authClient authorizationv1.SelfSubjectAccessReviewsGetter) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.rbacPrecheck = authClient
+		return factory
+	}
+}
+
+// WithSyncOrder starts informers in stages. The informers for the resources
+// of a stage are only started once all informers of the previous stage which
+// were requested from the factory have synced. Informers for resources which
+// are not part of any stage are started right away, like those of the first
+// stage. WaitForCacheSync also waits for the informers of later stages.
+func WithSyncOrder(stages [][]schema.GroupVersionResource) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.syncStages = make(map[schema.GroupVersionResource]int)
+		for stage, resources := range stages {
+			for _, resource := range resources {
+				factory.syncStages[resource] = stage
+			}
+		}
+		factory.syncStageCount = len(stages)
+		return factory
+	}
+}
+
+// WithStalenessWatchdog calls onStale with resource whenever the informer for
+// resource delivered no event for maxStaleness, for example because its watch
+// connection silently stopped delivering changes without failing. Adds,
+// updates, deletes and resyncs all count as events; the window starts when
+// the informer is started. onStale is called again after every further window
+// without events. It is called from a goroutine of the factory and must not
+// block.
+//
+// The watchdog cannot tell a stale watch from a resource which does not
+// change, so the informer should resync more often than maxStaleness.
+func WithStalenessWatchdog(resource schema.GroupVersionResource, maxStaleness time.Duration, onStale func(schema.GroupVersionResource)) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.stalenessWatchdogs == nil {
+			factory.stalenessWatchdogs = make(map[schema.GroupVersionResource]*stalenessWatchdog)
+		}
+		factory.stalenessWatchdogs[resource] = &stalenessWatchdog{maxStaleness: maxStaleness, onStale: onStale}
+		return factory
+	}
+}
+
+// WithKeyNormalizer makes the cache of the informer for resource key objects
+// by their namespace and their name as returned by normalize, for resources
+// whose names are case-insensitive or otherwise canonicalized by the server,
+// so that equivalent names map to a single entry. The lister finds objects by
+// any of their equivalent names. Objects with distinct names on the server
+// which normalize to the same name collide: only the last added or updated
+// one is cached, and deleting one removes the entry even if others still
+// exist, until they are updated or the informer relists. Like
+// WithCacheBackend, the normalized cache mirrors the in-memory cache of
+// client-go, whose keys cannot be changed.
+func WithKeyNormalizer(resource schema.GroupVersionResource, normalize func(name string) string) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.keyNormalizers == nil {
+			factory.keyNormalizers = make(map[schema.GroupVersionResource]func(name string) string)
+		}
+		factory.keyNormalizers[resource] = normalize
+		return factory
+	}
+}
+
+// WithInitialCacheCapacity sizes the cache of the informer for resource for n
+// objects, for resources with a known large number of objects. It requires a
+// SizedCacheBackend passed to WithCacheBackend, whose indexers are filled one
+// object at a time; without one, the option is ignored and reported by
+// StartWithError. The in-memory caches of client-go are not affected, as
+// every list replaces them with maps sized for the listed objects.
+func WithInitialCacheCapacity(resource schema.GroupVersionResource, n int) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.cacheCapacities == nil {
+			factory.cacheCapacities = make(map[schema.GroupVersionResource]int)
+		}
+		factory.cacheCapacities[resource] = n
+		return factory
+	}
+}
+
+// WithCacheBackend makes the generated informers of the SharedInformerFactory
+// store their caches in indexers of backend, which are returned by their
+// GetStore and GetIndexer and used by their listers. The caches are mirrored
+// from the in-memory caches of client-go, which remain.
+func WithCacheBackend(backend internalinterfaces.CacheBackend) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.cacheBackend = backend
+		return factory
+	}
+}
+
+// WithLeadershipGate makes the generated informers of the SharedInformerFactory
+// only list and watch while leadership is held. Leadership is acquired whenever
+// a value is received from acquired, and lost whenever a value is received from
+// lost; the channels must not be closed. Start starts the informers right
+// away, but they wait for leadership to be acquired before their first list,
+// so WaitForCacheSync waits for it, too. When leadership is lost, the watches
+// of the informers are stopped and they wait for leadership to be acquired
+// again to resume watching. Their caches and event handlers are kept in the
+// meantime, because client-go informers cannot be run again once they were
+// stopped; the caches are not updated until leadership is acquired again.
+// The clones of the factory share its leadership.
+func WithLeadershipGate(acquired <-chan struct{}, lost <-chan struct{}) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.leadershipGate = internalinterfaces.NewLeadershipGate()
+		factory.leadershipGateRunner = &leadershipGateRunner{gate: factory.leadershipGate, acquired: acquired, lost: lost}
+		return factory
+	}
+}
+
+// WithWatchRotation makes the generated informers of the SharedInformerFactory
+// stop their watches once they have been open for interval and establish them
+// again, so that no watch pins the resources of the API server for longer.
+// The informers watch again from the last resource version they observed, so
+// their caches are kept and no events are replayed; they only relist if that
+// resource version is too old, or if the watch was stopped within a second
+// without delivering any event. A non-positive interval disables rotation.
+func WithWatchRotation(interval time.Duration) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.watchRotation = interval
+		return factory
+	}
+}
+
+// WithLogger makes the SharedInformerFactory log a structured summary of the
+// informers started by each Start, with their resources and resync periods
+// and the namespace and selectors they are limited to, with logger.
+func WithLogger(logger v2.Logger) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		factory.logger = &logger
+		return factory
+	}
+}
+
+// WithLatencyHistogram observes the event processing latency of the informer
+// for resource with histogram, in seconds. histogram is any Observe(float64)
+// observer, such as a prometheus.Observer. The latency of an event is
+// measured from the time at which the informer received the change until the
+// event handler it is delivered to returns; it is measured from the time of
+// delivery for resyncs and for objects without a UID. Only the handlers added
+// through the informers returned by the factory are observed.
+//
+// WithLatencyHistogram implies WithIngestTimestamps.
+func WithLatencyHistogram(resource schema.GroupVersionResource, histogram cache.HistogramMetric) SharedInformerOption {
+	return func(factory *sharedInformerFactory) *sharedInformerFactory {
+		if factory.latencyHistograms == nil {
+			factory.latencyHistograms = make(map[schema.GroupVersionResource]cache.HistogramMetric)
+		}
+		factory.latencyHistograms[resource] = histogram
+		if factory.ingestTimes == nil {
+			factory.ingestTimes = make(map[types.UID]time.Time)
+		}
+		return factory
+	}
+}
+
 func (f *sharedInformerFactory) InformerName() *cache.InformerName {
 	return f.informerName
 }
 
+// InformerOptionsFor returns the options of the factory for the informer for
+// obj's type. Its Retweaker is the one which RetweakInformer retweaks. It is
+// called by InformerFor while f.lock is held.
+func (f *sharedInformerFactory) InformerOptionsFor(obj runtime.Object) internalinterfaces.InformerOptions {
+	options := internalinterfaces.InformerOptions{
+		InformerName:       f.informerName,
+		ReconnectObserver:  f.reconnectObserver,
+		ObjectFilter:       f.objectFilter,
+		NamespaceSelectors: f.namespaceSelectors,
+		CacheBackend:       f.cacheBackend,
+		LeadershipGate:     f.leadershipGate,
+		WatchRotation:      f.watchRotation,
+	}
+	resource, ok := resourceForType(reflect.TypeOf(obj))
+	if !ok {
+		return options
+	}
+	if snapshot, ok := f.cacheSnapshots[resource]; ok {
+		options.CacheSnapshot = snapshot.snapshot
+		options.CacheSnapshotDecoder = snapshot.codec
+	}
+	options.InitialResourceVersion = f.listResourceVersion
+	options.InitialResourceVersionMatch = f.listResourceVersionMatch
+	if resourceVersion, pinned := f.initialResourceVersions[resource]; pinned {
+		options.InitialResourceVersion = resourceVersion
+		options.InitialResourceVersionMatch = v1.ResourceVersionMatchExact
+	}
+	options.WatchListPageSize = f.watchListPageSizes[resource]
+	if f.retweakers[resource] == nil {
+		f.retweakers[resource] = internalinterfaces.NewRetweaker(nil)
+	}
+	options.Retweaker = f.retweakers[resource]
+	options.IngestValidator = f.ingestValidators[resource]
+	options.InitialCacheCapacity = f.cacheCapacities[resource]
+	options.KeyNormalizer = f.keyNormalizers[resource]
+	return options
+}
+
+func (f *sharedInformerFactory) IngestTime(obj v1.Object) (time.Time, bool) {
+	f.ingestLock.RLock()
+	defer f.ingestLock.RUnlock()
+
+	ingested, ok := f.ingestTimes[obj.GetUID()]
+	return ingested, ok
+}
+
+// informerTransform returns the transform to set on a new informer. It wraps the
+// configured transform with ingest timestamp recording and queue counting when
+// those are enabled.
+func (f *sharedInformerFactory) informerTransform(counter *informerQueueCounter) cache.TransformFunc {
+	if f.featureGateStripper == nil && f.memoryBudget == nil && f.ingestTimes == nil && counter == nil {
+		return f.transform
+	}
+	transform := f.transform
+	return func(obj interface{}) (interface{}, error) {
+		if transform != nil {
+			var err error
+			if obj, err = transform(obj); err != nil {
+				return nil, err
+			}
+		}
+		if f.featureGateStripper != nil {
+			if object, ok := obj.(v1.Object); ok {
+				f.featureGateStripper(object, f.featureGates)
+			}
+		}
+		if f.memoryBudget != nil {
+			if object, ok := obj.(v1.Object); ok {
+				f.memoryBudget.admit(object)
+			}
+		}
+		if f.ingestTimes != nil {
+			if accessor, err := meta.Accessor(obj); err == nil {
+				f.ingestLock.Lock()
+				f.ingestTimes[accessor.GetUID()] = time.Now()
+				f.ingestLock.Unlock()
+			}
+		}
+		if counter != nil {
+			counter.queued(obj)
+		}
+		return obj, nil
+	}
+}
+
+// forgetIngestTime drops the ingest timestamp of a deleted object.
+func (f *sharedInformerFactory) forgetIngestTime(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	f.ingestLock.Lock()
+	defer f.ingestLock.Unlock()
+	delete(f.ingestTimes, accessor.GetUID())
+}
+
+const (
+	// watchFailureThreshold is the number of consecutive watch failures of an
+	// informer after which a Warning event is emitted.
+	watchFailureThreshold = 3
+	// watchFailureWindow is the time after which a watch failure no longer
+	// counts as consecutive with the previous one.
+	watchFailureWindow = 5 * time.Minute
+	// watchFailureEventInterval is the minimum time between two Warning events
+	// about the watch of the same informer.
+	watchFailureEventInterval = time.Minute
+)
+
+// informerWatchErrorHandler returns the watch error handler of the informer for
+// informerType. It calls the configured watch error handler or the default one,
+// and emits a Warning event once the watch failed watchFailureThreshold times
+// in a row if an event recorder is configured.
+func (f *sharedInformerFactory) informerWatchErrorHandler(informerType reflect.Type) cache.WatchErrorHandlerWithContext {
+	handler := func(ctx context.Context, r *cache.Reflector, err error) {
+		if f.watchErrorHandler != nil {
+			f.watchErrorHandler(r, err)
+			return
+		}
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	}
+	if f.eventRecorder == nil {
+		return handler
+	}
+
+	// The reflector of an informer calls its handler sequentially, so this
+	// state needs no locking.
+	var failures int
+	var lastFailure, lastEvent time.Time
+	return func(ctx context.Context, r *cache.Reflector, err error) {
+		handler(ctx, r, err)
+		if err == io.EOF {
+			// The watch was closed normally.
+			failures = 0
+			return
+		}
+		now := time.Now()
+		if now.Sub(lastFailure) > watchFailureWindow {
+			failures = 0
+		}
+		failures++
+		lastFailure = now
+		if failures < watchFailureThreshold || now.Sub(lastEvent) < watchFailureEventInterval {
+			return
+		}
+		lastEvent = now
+		f.eventRecorder.Eventf(f.involvedObject, nil, corev1.EventTypeWarning, "WatchFailed", "Watch", "Watch of %v failed %d times in a row: %v", informerType, failures, err)
+	}
+}
+
 // NewSharedInformerFactory constructs a new instance of sharedInformerFactory for all namespaces.
 func NewSharedInformerFactory(client versioned.Interface, defaultResync time.Duration) SharedInformerFactory {
 	return NewSharedInformerFactoryWithOptions(client, defaultResync)
@@ -124,51 +886,243 @@ func NewFilteredSharedInformerFactory(client versioned.Interface, defaultResync
 // NewSharedInformerFactoryWithOptions constructs a new instance of a SharedInformerFactory with additional options.
 func NewSharedInformerFactoryWithOptions(client versioned.Interface, defaultResync time.Duration, options ...SharedInformerOption) SharedInformerFactory {
 	factory := &sharedInformerFactory{
-		client:           client,
-		namespace:        v1.NamespaceAll,
-		defaultResync:    defaultResync,
-		informers:        make(map[reflect.Type]cache.SharedIndexInformer),
-		startedInformers: make(map[reflect.Type]bool),
-		customResync:     make(map[reflect.Type]time.Duration),
+		client:            client,
+		namespace:         v1.NamespaceAll,
+		defaultResync:     defaultResync,
+		informers:         make(map[reflect.Type]cache.SharedIndexInformer),
+		startedInformers:  make(map[reflect.Type]bool),
+		customResync:      make(map[reflect.Type]time.Duration),
+		handlerCounts:     make(map[reflect.Type]int),
+		vetoedInformers:   make(map[reflect.Type]error),
+		deferredInformers: make(map[reflect.Type]bool),
+		retweakers:        make(map[schema.GroupVersionResource]*internalinterfaces.Retweaker),
+		options:           append([]SharedInformerOption(nil), options...),
 	}
 
 	// Apply all options
 	for _, opt := range options {
 		factory = opt(factory)
 	}
+	if _, sized := factory.cacheBackend.(internalinterfaces.SizedCacheBackend); !sized && len(factory.cacheCapacities) > 0 {
+		factory.optionErrs = append(factory.optionErrs, errors.New("WithInitialCacheCapacity requires a SizedCacheBackend passed to WithCacheBackend"))
+		factory.cacheCapacities = nil
+	}
 
 	return factory
 }
 
+func (f *sharedInformerFactory) CloneForNamespace(namespace string) SharedInformerFactory {
+	options := append(append([]SharedInformerOption(nil), f.options...), WithNamespace(namespace))
+	clone := NewSharedInformerFactoryWithOptions(f.client, f.defaultResync, options...).(*sharedInformerFactory)
+	// The snapshots hold the objects of all namespaces, and their readers
+	// can only be consumed once. The namespace selectors would override
+	// namespace.
+	clone.cacheSnapshots = nil
+	clone.namespaceSelectors = nil
+	// The name of the parent would be released by the Shutdown of the clone,
+	// and registering the same resources twice under it disables the metrics
+	// of the clone's informers.
+	clone.informerName = nil
+	// Each signal of the leadership channels is received once, so the clone
+	// shares the gate of its parent rather than competing for them.
+	clone.leadershipGate = f.leadershipGate
+	clone.leadershipGateRunner = f.leadershipGateRunner
+	if f.informerName != nil {
+		informerName, err := cache.NewInformerName(f.informerName.Name() + "/" + namespace)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("the informers of the clone for namespace %q are not named: %w", namespace, err))
+		} else {
+			clone.informerName = informerName
+		}
+	}
+	return clone
+}
+
 func (f *sharedInformerFactory) Start(stopCh <-chan struct{}) {
 	f.StartWithContext(wait.ContextForChannel(stopCh))
 }
 
 func (f *sharedInformerFactory) StartWithContext(ctx context.Context) {
+	if err := f.StartWithError(ctx); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+func (f *sharedInformerFactory) StartWithError(ctx context.Context) error {
+	f.precheckInformers(ctx)
+
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
 	if f.shuttingDown {
-		return
+		return nil
 	}
 
-	for informerType, informer := range f.informers {
+	errs := slices.Clone(f.optionErrs)
+	for informerType, err := range f.vetoedInformers {
 		if !f.startedInformers[informerType] {
+			errs = append(errs, err)
+		}
+	}
+	slices.SortFunc(errs, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+
+	// The informers pass ctx on to their list and watch calls, so canceling
+	// it aborts the requests which are in flight. Shutdown cancels it, too.
+	ctx, cancel := context.WithCancelCause(ctx)
+	started := false
+	var summaries []informerStartSummary
+	deferred := make([][]reflect.Type, f.syncStageCount)
+	for informerType, informer := range f.informers {
+		if _, vetoed := f.vetoedInformers[informerType]; vetoed || f.skippedInformers[informerType] != "" || f.startedInformers[informerType] || f.deferredInformers[informerType] {
+			continue
+		}
+		if f.logger != nil {
+			summaries = append(summaries, f.startSummary(informerType))
+		}
+		if stage := f.syncStage(informerType); stage > 0 {
+			deferred[stage] = append(deferred[stage], informerType)
+			f.deferredInformers[informerType] = true
+		} else {
+			f.startInformer(ctx, informerType, informer)
+		}
+		started = true
+	}
+	for stage, informerTypes := range deferred {
+		if len(informerTypes) > 0 {
 			f.wg.Go(func() {
-				informer.RunWithContext(ctx)
+				f.startStage(ctx, stage, informerTypes)
 			})
-			f.startedInformers[informerType] = true
 		}
 	}
+	if !started {
+		cancel(nil)
+		return errors.Join(errs...)
+	}
+	f.cancelFuncs = append(f.cancelFuncs, cancel)
+	if f.leadershipGate != nil && !f.leadershipGateRunning {
+		f.leadershipGateRunning = true
+		f.wg.Go(func() {
+			f.leadershipGateRunner.run(ctx)
+		})
+	}
+	if f.logger != nil {
+		slices.SortFunc(summaries, func(a, b informerStartSummary) int {
+			return strings.Compare(a.Resource, b.Resource)
+		})
+		var namespaces []string
+		for namespace := range f.namespaceSelectors {
+			namespaces = append(namespaces, namespace)
+		}
+		slices.SortFunc(namespaces, strings.Compare)
+		f.logger.Info("Started informers", "count", len(summaries), "namespace", f.namespace, "namespaces", namespaces, "defaultResync", f.defaultResync, "informers", summaries)
+	}
+	return errors.Join(errs...)
 }
 
-func (f *sharedInformerFactory) Shutdown() {
-	f.lock.Lock()
-	f.shuttingDown = true
-	f.lock.Unlock()
+// informerStartSummary describes an informer in the summary logged by Start.
+type informerStartSummary struct {
+	Resource      string
+	Resync        time.Duration
+	LabelSelector string
+	FieldSelector string
+	// Deferred is true if the informer waits for the informers of a previous
+	// stage of WithSyncOrder.
+	Deferred bool
+}
 
-	// Will return immediately if there is nothing to wait for.
-	f.wg.Wait()
+// startSummary returns the summary of the informer for informerType. f.lock
+// must be held.
+func (f *sharedInformerFactory) startSummary(informerType reflect.Type) informerStartSummary {
+	summary := informerStartSummary{Resource: informerType.String(), Resync: f.defaultResync}
+	if resyncPeriod, exists := f.customResync[informerType]; exists {
+		summary.Resync = resyncPeriod
+	}
+	var opts v1.ListOptions
+	resource, ok := resourceForType(informerType)
+	if ok {
+		summary.Resource = resource.String()
+		summary.Deferred = f.syncStages[resource] > 0
+	}
+	if retweaker := f.retweakers[resource]; retweaker != nil {
+		retweaker.TweakListOptions(&opts)
+	} else if f.tweakListOptions != nil {
+		f.tweakListOptions(&opts)
+	}
+	summary.LabelSelector = opts.LabelSelector
+	summary.FieldSelector = opts.FieldSelector
+	return summary
+}
+
+// startInformer runs informer until ctx is canceled. f.lock must be held.
+func (f *sharedInformerFactory) startInformer(ctx context.Context, informerType reflect.Type, informer cache.SharedIndexInformer) {
+	f.wg.Go(func() {
+		informer.RunWithContext(ctx)
+	})
+	if resource, ok := resourceForType(informerType); ok && f.stalenessWatchdogs[resource] != nil {
+		f.wg.Go(func() {
+			f.stalenessWatchdogs[resource].run(ctx, resource)
+		})
+	}
+	f.startedInformers[informerType] = true
+}
+
+// syncStage returns the stage of WithSyncOrder of the informer for
+// informerType, or 0 if it is not part of any stage.
+func (f *sharedInformerFactory) syncStage(informerType reflect.Type) int {
+	resource, ok := resourceForType(informerType)
+	if !ok {
+		return 0
+	}
+	return f.syncStages[resource]
+}
+
+// startStage starts the deferred informers of stage once the informers of the
+// previous stages have synced.
+func (f *sharedInformerFactory) startStage(ctx context.Context, stage int, informerTypes []reflect.Type) {
+	for previous := 0; previous < stage; previous++ {
+		if !cache.WaitFor(ctx, "" /* no logging */, f.stageCheckers(previous)...) {
+			return
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.shuttingDown {
+		return
+	}
+	for _, informerType := range informerTypes {
+		delete(f.deferredInformers, informerType)
+		f.startInformer(ctx, informerType, f.informers[informerType])
+	}
+}
+
+// stageCheckers returns the checkers of the started informers of stage.
+func (f *sharedInformerFactory) stageCheckers(stage int) []cache.DoneChecker {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var checkers []cache.DoneChecker
+	for informerType, informer := range f.informers {
+		if (f.startedInformers[informerType] || f.deferredInformers[informerType]) && f.syncStage(informerType) == stage {
+			checkers = append(checkers, informer.HasSyncedChecker())
+		}
+	}
+	return checkers
+}
+
+func (f *sharedInformerFactory) Shutdown() {
+	f.lock.Lock()
+	f.shuttingDown = true
+	for _, cancel := range f.cancelFuncs {
+		cancel(errors.New("the informer factory is shutting down"))
+	}
+	f.cancelFuncs = nil
+	f.lock.Unlock()
+
+	// Will return immediately if there is nothing to wait for.
+	f.wg.Wait()
 	f.informerName.Release()
 }
 
@@ -184,7 +1138,7 @@ func (f *sharedInformerFactory) WaitForCacheSyncWithContext(ctx context.Context)
 
 		informers := map[reflect.Type]cache.SharedIndexInformer{}
 		for informerType, informer := range f.informers {
-			if f.startedInformers[informerType] {
+			if f.startedInformers[informerType] || f.deferredInformers[informerType] {
 				informers[informerType] = informer
 			}
 		}
@@ -219,6 +1173,19 @@ func (f *sharedInformerFactory) WaitForCacheSyncWithContext(ctx context.Context)
 	return res
 }
 
+func (f *sharedInformerFactory) WaitForNamedCacheSync(controllerName string, stopCh <-chan struct{}, cacheSyncs ...cache.InformerSynced) bool {
+	if len(cacheSyncs) == 0 {
+		f.lock.Lock()
+		for informerType, informer := range f.informers {
+			if f.startedInformers[informerType] || f.deferredInformers[informerType] {
+				cacheSyncs = append(cacheSyncs, informer.HasSynced)
+			}
+		}
+		f.lock.Unlock()
+	}
+	return cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSyncs...)
+}
+
 // InformerFor returns the SharedIndexInformer for obj using an internal
 // client.
 func (f *sharedInformerFactory) InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer {
@@ -237,8 +1204,42 @@ func (f *sharedInformerFactory) InformerFor(obj runtime.Object, newFunc internal
 	}
 
 	informer = newFunc(f.client, resyncPeriod)
-	if f.transform != nil {
-		informer.SetTransform(f.transform)
+	var counter *informerQueueCounter
+	if f.queueCounters != nil {
+		counter = &informerQueueCounter{}
+		f.queueCounters[informerType] = counter
+	}
+	if transform := f.informerTransform(counter); transform != nil {
+		informer.SetTransform(transform)
+	}
+	if f.ingestTimes != nil {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: f.forgetIngestTime})
+	}
+	if f.memoryBudget != nil {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: f.memoryBudget.forget})
+	}
+	if resource, ok := resourceForType(informerType); ok && f.stalenessWatchdogs[resource] != nil {
+		informer.AddEventHandler(f.stalenessWatchdogs[resource].handler())
+	}
+	if counter != nil {
+		informer = &queueCountingInformer{SharedIndexInformer: informer, counter: counter}
+		// A change also waits for this handler, so that it is not delivered
+		// before the informer processed it when no other handler was added.
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{})
+	}
+	if f.watchErrorHandler != nil || f.eventRecorder != nil {
+		// This fails if newFunc returned an informer which was already started.
+		if err := informer.SetWatchErrorHandlerWithContext(f.informerWatchErrorHandler(informerType)); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to set the watch error handler of the %v informer: %w", informerType, err))
+		}
+	}
+	if f.equalityFuncs != nil {
+		if resource, ok := resourceForType(informerType); ok && f.equalityFuncs[resource] != nil {
+			informer = &dedupingInformer{SharedIndexInformer: informer, equal: f.equalityFuncs[resource]}
+		}
+	}
+	if histogram := f.latencyHistogram(informerType); histogram != nil {
+		informer = &latencyObservingInformer{SharedIndexInformer: informer, factory: f, histogram: histogram}
 	}
 	f.informers[informerType] = informer
 
@@ -292,17 +1293,33 @@ type SharedInformerFactory interface {
 	Start(stopCh <-chan struct{})
 
 	// StartWithContext initializes all requested informers. They are handled in goroutines
-	// which run until the context gets canceled.
+	// which run until the context gets canceled or Shutdown is called. The context is
+	// passed on to the list and watch calls of the informers, so canceling it also
+	// aborts requests which are in flight.
 	// Warning: StartWithContext does not block. When run in a go-routine, it will race with a later WaitForCacheSync.
 	StartWithContext(ctx context.Context)
 
+	// RetweakInformer replaces the list options tweak of the informer for
+	// resource, including the one of WithTweakListOptions, with tweak and
+	// makes the informer relist, if it is running. The relist evicts the
+	// objects which do not match the new list options from the informer
+	// cache; handlers see them deleted. RetweakInformer fails if no informer
+	// for resource was requested, or if it was created by a custom
+	// InformerFor function.
+	RetweakInformer(resource schema.GroupVersionResource, tweak internalinterfaces.TweakListOptionsFunc) error
+
+	// StartWithError works like StartWithContext, but also returns the errors
+	// of the informers which were vetoed by the hook of WithInformerCreateHook.
+	// These informers are not started; the others are started regardless.
+	// StartWithContext passes such errors to utilruntime.HandleError.
+	StartWithError(ctx context.Context) error
+
 	// Shutdown marks a factory as shutting down. At that point no new
 	// informers can be started anymore and Start will return without
 	// doing anything.
 	//
-	// In addition, Shutdown blocks until all goroutines have terminated. For that
-	// to happen, the close channel(s) that they were started with must be closed,
-	// either before Shutdown gets called or while it is waiting.
+	// In addition, Shutdown stops all started informers and blocks until all
+	// goroutines have terminated.
 	//
 	// Shutdown may be called multiple times, even concurrently. All such calls will
 	// block until all goroutines have terminated.
@@ -318,6 +1335,12 @@ type SharedInformerFactory interface {
 	// or the context gets canceled.
 	WaitForCacheSyncWithContext(ctx context.Context) cache.SyncResult
 
+	// WaitForNamedCacheSync blocks until cacheSyncs, or all started informers'
+	// caches if none are given, were synced or the stop channel gets closed,
+	// like cache.WaitForNamedCacheSync. It logs the name of the controller
+	// when it starts waiting and when the caches failed to sync.
+	WaitForNamedCacheSync(controllerName string, stopCh <-chan struct{}, cacheSyncs ...cache.InformerSynced) bool
+
 	// ForResource gives generic access to a shared informer of the matching type.
 	ForResource(resource schema.GroupVersionResource) (GenericInformer, error)
 
@@ -325,9 +1348,1248 @@ type SharedInformerFactory interface {
 	// client.
 	InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer
 
+	// IngestTime returns the time at which obj was last ingested into an informer
+	// cache. It only reports timestamps when the factory was created with
+	// WithIngestTimestamps.
+	IngestTime(obj v1.Object) (time.Time, bool)
+
+	// Stats returns a snapshot of the informer for obj's type. It returns false
+	// if no informer was requested for that type.
+	Stats(obj runtime.Object) (InformerStats, bool)
+
+	// CacheGeneration returns the number of mutations of the cache of the
+	// informer for obj's type so far, which increases whenever an object is
+	// added, updated or deleted, but not on resyncs. Consumers can compare it
+	// to a generation they saw earlier to tell whether anything changed since.
+	// It is zero if no informer was requested for that type. The mutations
+	// are counted by an event handler which the first call for a type adds to
+	// its informer; the objects cached by then are counted once each, as they
+	// are delivered to the handler.
+	CacheGeneration(obj runtime.Object) uint64
+
+	// PendingDeltas returns the number of objects of resource whose latest
+	// change was queued by the informer but not yet processed by all of its
+	// event handlers, for example to publish it as an autoscaling metric. It is
+	// zero unless the factory was created with WithInformerStats.
+	PendingDeltas(resource schema.GroupVersionResource) int
+
+	// CloneForNamespace returns a new factory limited to namespace, which is
+	// created with the client, default resync period and options of this
+	// factory. The clone has its own informers, which it starts and stops
+	// independently of this factory. The snapshots of WithCacheSnapshot and
+	// the namespaces of WithNamespaceSelectors are not used by the clone. If
+	// this factory has an InformerName, the clone gets its own one, named
+	// after it with a "/<namespace>" suffix, which its Shutdown releases.
+	CloneForNamespace(namespace string) SharedInformerFactory
+
+	// DumpState returns a description of all informers requested from the
+	// factory, for debugging.
+	DumpState() FactoryState
+
+	// SkippedInformers returns the reasons why the informers which were skipped
+	// by the precheck of WithRBACPrecheck are not started, by resource.
+	SkippedInformers() map[schema.GroupVersionResource]string
+
+	// InformersWithHandlers returns the resources of the informers to which
+	// event handlers were added through the generated handler helpers, such as
+	// RegisterHandlers of a group, ordered by resource. Handlers which were
+	// added to an informer directly, or removed later, are not observed.
+	InformersWithHandlers() []schema.GroupVersionResource
+
+	// InvalidObjects returns the objects which are currently rejected by the
+	// validators of WithIngestValidator, ordered by resource and key.
+	InvalidObjects() []InvalidObject
+
+	// RegisterConsumer records that the consumer called name, such as a
+	// controller, uses the informer for resource. It is only metadata for
+	// DependencyGraph and does not request the informer. Registering a name
+	// again for the same resource has no effect.
+	RegisterConsumer(resource schema.GroupVersionResource, name string)
+
+	// DependencyGraph returns the names of the consumers registered for each
+	// resource, in the order in which they were registered.
+	DependencyGraph() map[schema.GroupVersionResource][]string
+
+	// AddResyncHandler adds handler to the informer for obj's type, which
+	// must have been requested from the factory, like AddEventHandler. Besides
+	// the notifications of the informer, handler gets the ones of Resync and
+	// ReplayKey, which the handlers added to the informer directly do not.
+	AddResyncHandler(obj runtime.Object, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)
+
+	// RemoveResyncHandler removes a handler added by AddResyncHandler from
+	// the informer for obj's type.
+	RemoveResyncHandler(obj runtime.Object, registration cache.ResourceEventHandlerRegistration) error
+
+	// Resync delivers every object in the cache of the synced informer for
+	// obj's type to the event handlers added through AddResyncHandler, as an
+	// update from the object to itself, like a periodic resync. The handlers
+	// are called from the calling goroutine, never concurrently with their
+	// other notifications, and Resync returns once all were called. Unlike a
+	// periodic resync, the updates are not ordered with the notifications
+	// which are queued for the handlers, so a handler may see an older
+	// version of an object after Resync.
+	Resync(obj runtime.Object) error
+
+	// ReplayKey delivers the object with key in the cache of the synced
+	// informer for obj's type to the event handlers added through
+	// AddResyncHandler like Resync, as an update from the object to itself.
+	// It fails if there is no such object.
+	ReplayKey(obj runtime.Object, key string) error
+
+	// WaitForCondition blocks until pred holds for the cache of the informer
+	// for obj's type, which must have been requested from the factory. pred
+	// is evaluated first, then again after notifications of the informer,
+	// including resyncs; notifications arriving during an evaluation are
+	// coalesced into a single new evaluation. It returns the cause of ctx
+	// being done if it is done first.
+	WaitForCondition(ctx context.Context, obj runtime.Object, pred func(store cache.Store) bool) error
+
+	// SnapshotCache writes the objects in the cache of the synced informer for
+	// resource to w, as a list encoded by codec which WithCacheSnapshot can
+	// warm the cache of another factory from. A nil codec writes JSON. The
+	// codec must know the list type of resource.
+	SnapshotCache(resource schema.GroupVersionResource, w io.Writer, codec runtime.Codec) error
+
 	Example() example.Interface
 }
 
 func (f *sharedInformerFactory) Example() example.Interface {
 	return example.New(f, f.namespace, f.tweakListOptions)
 }
+
+// InformerStats is a point-in-time snapshot of a shared informer.
+//
+// client-go does not expose the controller or the queue of a shared informer,
+// so the queue length is derived from the changes seen by the informer's
+// transform and by its event handlers.
+type InformerStats struct {
+	// QueueLength is the number of objects whose latest change was queued by
+	// the informer but not yet processed by all of its event handlers. Like the
+	// keys of a DeltaFIFO, several changes of one object count once. It is
+	// always zero unless the factory was created with WithInformerStats.
+	QueueLength int
+	// ObjectCount is the number of objects in the informer's cache.
+	ObjectCount int
+}
+
+func (f *sharedInformerFactory) Stats(obj runtime.Object) (InformerStats, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return InformerStats{}, false
+	}
+	stats := InformerStats{ObjectCount: len(informer.GetStore().ListKeys())}
+	if counter := f.queueCounters[informerType]; counter != nil {
+		stats.QueueLength = counter.length()
+	}
+	return stats, true
+}
+
+func (f *sharedInformerFactory) CacheGeneration(obj runtime.Object) uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(obj)
+	if generation := f.cacheGenerations[informerType]; generation != nil {
+		return generation.Load()
+	}
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return 0
+	}
+	generation := &atomic.Uint64{}
+	if _, err := informer.AddEventHandler(cacheGenerationHandler(generation)); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to count the cache generations of the %v informer: %w", informerType, err))
+		return 0
+	}
+	if f.cacheGenerations == nil {
+		f.cacheGenerations = make(map[reflect.Type]*atomic.Uint64)
+	}
+	f.cacheGenerations[informerType] = generation
+	return generation.Load()
+}
+
+// cacheGenerationHandler returns an event handler which increments generation
+// for the notifications of an informer, which are delivered after its cache
+// was mutated. Resyncs are recognized by the old and new objects of an update
+// being the same object and do not count.
+func cacheGenerationHandler(generation *atomic.Uint64) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			generation.Add(1)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj != newObj {
+				generation.Add(1)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			generation.Add(1)
+		},
+	}
+}
+
+func (f *sharedInformerFactory) PendingDeltas(resource schema.GroupVersionResource) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, counter := range f.queueCounters {
+		if r, ok := resourceForType(informerType); ok && r == resource {
+			return counter.length()
+		}
+	}
+	return 0
+}
+
+// informerQueueCounter tracks the objects whose latest change was queued by an
+// informer, as seen by its transform, until every event handler added to the
+// informer processed that change. Changes are told apart by resource version,
+// so a change which supersedes a queued one, including the relisted objects
+// of a Replace, replaces it rather than being counted again. Resyncs do not
+// pass through the transform and are not counted.
+type informerQueueCounter struct {
+	lock sync.Mutex
+	// handlers are the event handlers which a change is delivered to.
+	handlers map[*queueCountingHandler]struct{}
+	// registrations map the registrations of handlers to the handlers.
+	registrations map[cache.ResourceEventHandlerRegistration]*queueCountingHandler
+	// pending maps the keys of objects to their latest queued change.
+	pending map[string]*queuedChange
+}
+
+// queuedChange is a change which was not yet processed by all event handlers.
+type queuedChange struct {
+	resourceVersion string
+	waiting         map[*queueCountingHandler]struct{}
+}
+
+// changeOf returns the key and resource version of the object of a change.
+func changeOf(obj interface{}) (string, string, bool) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return "", "", false
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", "", false
+	}
+	return key, accessor.GetResourceVersion(), true
+}
+
+func (c *informerQueueCounter) queued(obj interface{}) {
+	key, resourceVersion, ok := changeOf(obj)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.handlers) == 0 {
+		return
+	}
+	if c.pending == nil {
+		c.pending = make(map[string]*queuedChange)
+	}
+	change := &queuedChange{resourceVersion: resourceVersion, waiting: make(map[*queueCountingHandler]struct{}, len(c.handlers))}
+	for handler := range c.handlers {
+		change.waiting[handler] = struct{}{}
+	}
+	c.pending[key] = change
+}
+
+// delivered records that handler processed a notification for obj. Only the
+// notification of the latest queued change of an object clears it, since the
+// notifications of the changes it superseded are delivered before it.
+func (c *informerQueueCounter) delivered(handler *queueCountingHandler, obj interface{}) {
+	key, resourceVersion, ok := changeOf(obj)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	change := c.pending[key]
+	if change == nil || change.resourceVersion != resourceVersion {
+		return
+	}
+	delete(change.waiting, handler)
+	if len(change.waiting) == 0 {
+		delete(c.pending, key)
+	}
+}
+
+// add adds a handler wrapping handler, which register adds to the informer.
+// Changes which are queued from then on wait for it.
+func (c *informerQueueCounter) add(handler cache.ResourceEventHandler, register func(cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)) (cache.ResourceEventHandlerRegistration, error) {
+	counting := &queueCountingHandler{counter: c, handler: handler}
+	c.lock.Lock()
+	if c.handlers == nil {
+		c.handlers = make(map[*queueCountingHandler]struct{})
+	}
+	c.handlers[counting] = struct{}{}
+	c.lock.Unlock()
+
+	registration, err := register(counting)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err != nil {
+		c.forget(counting)
+		return registration, err
+	}
+	if c.registrations == nil {
+		c.registrations = make(map[cache.ResourceEventHandlerRegistration]*queueCountingHandler)
+	}
+	c.registrations[registration] = counting
+	return registration, nil
+}
+
+// remove stops changes from waiting for the handler of registration.
+func (c *informerQueueCounter) remove(registration cache.ResourceEventHandlerRegistration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if counting, ok := c.registrations[registration]; ok {
+		delete(c.registrations, registration)
+		c.forget(counting)
+	}
+}
+
+// forget removes handler from the handlers and from the queued changes.
+// c.lock must be held.
+func (c *informerQueueCounter) forget(handler *queueCountingHandler) {
+	delete(c.handlers, handler)
+	for key, change := range c.pending {
+		delete(change.waiting, handler)
+		if len(change.waiting) == 0 {
+			delete(c.pending, key)
+		}
+	}
+}
+
+func (c *informerQueueCounter) length() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.pending)
+}
+
+// queueCountingInformer wraps the event handlers added to it, so that their
+// informer's queue counter knows when they processed a change.
+type queueCountingInformer struct {
+	cache.SharedIndexInformer
+	counter *informerQueueCounter
+}
+
+func (i *queueCountingInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	return i.counter.add(handler, i.SharedIndexInformer.AddEventHandler)
+}
+
+func (i *queueCountingInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	return i.counter.add(handler, func(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+		return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	})
+}
+
+func (i *queueCountingInformer) AddEventHandlerWithOptions(handler cache.ResourceEventHandler, options cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error) {
+	return i.counter.add(handler, func(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+		return i.SharedIndexInformer.AddEventHandlerWithOptions(handler, options)
+	})
+}
+
+func (i *queueCountingInformer) RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	if err := i.SharedIndexInformer.RemoveEventHandler(registration); err != nil {
+		return err
+	}
+	i.counter.remove(registration)
+	return nil
+}
+
+// queueCountingHandler marks the changes it processed as delivered to it.
+type queueCountingHandler struct {
+	counter *informerQueueCounter
+	handler cache.ResourceEventHandler
+}
+
+func (h *queueCountingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.handler.OnAdd(obj, isInInitialList)
+	h.counter.delivered(h, obj)
+}
+
+func (h *queueCountingHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.handler.OnUpdate(oldObj, newObj)
+	h.counter.delivered(h, newObj)
+}
+
+func (h *queueCountingHandler) OnDelete(obj interface{}) {
+	h.handler.OnDelete(obj)
+	h.counter.delivered(h, obj)
+}
+
+// leadershipGateRunner records the signals of the leadership channels of
+// WithLeadershipGate in the gate shared by a factory and its clones. The
+// channels are read by a single goroutine, which runs while the context of
+// at least one run call is not done.
+type leadershipGateRunner struct {
+	gate     *internalinterfaces.LeadershipGate
+	acquired <-chan struct{}
+	lost     <-chan struct{}
+
+	lock  sync.Mutex
+	users int
+	stop  func()
+}
+
+// run makes sure the signals are recorded until ctx is done.
+func (r *leadershipGateRunner) run(ctx context.Context) {
+	r.lock.Lock()
+	r.users++
+	if r.users == 1 {
+		runCtx, cancel := context.WithCancelCause(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.gate.Run(runCtx, r.acquired, r.lost)
+		}()
+		r.stop = func() {
+			cancel(nil)
+			<-done
+		}
+	}
+	r.lock.Unlock()
+
+	<-ctx.Done()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.users--
+	if r.users == 0 {
+		r.stop()
+	}
+}
+
+// FactoryState is a point-in-time description of a SharedInformerFactory.
+type FactoryState struct {
+	// Informers describes the informers of the factory, ordered by resource.
+	Informers []InformerState
+}
+
+// InformerState is a point-in-time description of a shared informer.
+type InformerState struct {
+	// Resource is the resource served by the informer. It is empty for
+	// informers of types which this factory does not know about.
+	Resource schema.GroupVersionResource
+	// Started is true if the informer was started.
+	Started bool
+	// Synced is true if the informer's cache is synced.
+	Synced bool
+	// ObjectCount is the number of objects in the informer's cache.
+	ObjectCount int
+	// LastSyncResourceVersion is the resource version observed by the last
+	// list or watch of the informer.
+	LastSyncResourceVersion string
+}
+
+// GroupSynced returns true if all informers for resources of group which
+// were requested from the factory have synced.
+func (f *sharedInformerFactory) GroupSynced(group string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, informer := range f.informers {
+		if resource, ok := resourceForType(informerType); ok && resource.Group == group && !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *sharedInformerFactory) DumpState() FactoryState {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	state := FactoryState{Informers: make([]InformerState, 0, len(f.informers))}
+	for informerType, informer := range f.informers {
+		resource, _ := resourceForType(informerType)
+		state.Informers = append(state.Informers, InformerState{
+			Resource:                resource,
+			Started:                 f.startedInformers[informerType],
+			Synced:                  informer.HasSynced(),
+			ObjectCount:             len(informer.GetStore().ListKeys()),
+			LastSyncResourceVersion: informer.LastSyncResourceVersion(),
+		})
+	}
+	slices.SortFunc(state.Informers, func(a, b InformerState) int {
+		return strings.Compare(a.Resource.String(), b.Resource.String())
+	})
+	return state
+}
+
+// precheckInformers reviews the access to the resources of the informers
+// which were not reviewed yet, if WithRBACPrecheck was used. The reviews are
+// made without holding f.lock.
+func (f *sharedInformerFactory) precheckInformers(ctx context.Context) {
+	if f.rbacPrecheck == nil {
+		return
+	}
+	f.lock.Lock()
+	resources := map[reflect.Type]schema.GroupVersionResource{}
+	for informerType := range f.informers {
+		if resource, ok := resourceForType(informerType); ok && !f.precheckedInformers[informerType] {
+			resources[informerType] = resource
+		}
+	}
+	f.lock.Unlock()
+
+	for informerType, resource := range resources {
+		reason, err := f.reviewAccess(ctx, resource)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to review the access to %v, starting its informer anyway: %w", resource, err))
+		}
+		f.lock.Lock()
+		if f.precheckedInformers == nil {
+			f.precheckedInformers = make(map[reflect.Type]bool)
+			f.skippedInformers = make(map[reflect.Type]string)
+		}
+		f.precheckedInformers[informerType] = true
+		if reason != "" {
+			f.skippedInformers[informerType] = reason
+		}
+		f.lock.Unlock()
+	}
+}
+
+// reviewAccess returns why resource may not be listed or watched in the
+// namespaces of the factory, or an empty string if it may be. Cluster-scoped
+// resources are reviewed in all namespaces, and namespaced resources in each
+// namespace of WithNamespaceSelectors if it was used.
+func (f *sharedInformerFactory) reviewAccess(ctx context.Context, resource schema.GroupVersionResource) (string, error) {
+	namespaces := []string{f.namespace}
+	selected := false
+	if isClusterScoped(resource) {
+		namespaces = []string{v1.NamespaceAll}
+	} else if f.namespaceSelectors != nil {
+		namespaces, selected = nil, true
+		for namespace := range f.namespaceSelectors {
+			namespaces = append(namespaces, namespace)
+		}
+		slices.SortFunc(namespaces, strings.Compare)
+	}
+	for _, namespace := range namespaces {
+		for _, verb := range []string{"list", "watch"} {
+			review := &apiauthorizationv1.SelfSubjectAccessReview{
+				Spec: apiauthorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &apiauthorizationv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      verb,
+						Group:     resource.Group,
+						Version:   resource.Version,
+						Resource:  resource.Resource,
+					},
+				},
+			}
+			result, err := f.rbacPrecheck.SelfSubjectAccessReviews().Create(ctx, review, v1.CreateOptions{})
+			if err != nil {
+				return "", err
+			}
+			if !result.Status.Allowed {
+				reason := verb + " is not allowed"
+				if selected {
+					reason += " in namespace " + namespace
+				}
+				if result.Status.Reason != "" {
+					reason += ": " + result.Status.Reason
+				}
+				return reason, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (f *sharedInformerFactory) SkippedInformers() map[schema.GroupVersionResource]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	skipped := make(map[schema.GroupVersionResource]string, len(f.skippedInformers))
+	for informerType, reason := range f.skippedInformers {
+		resource, _ := resourceForType(informerType)
+		skipped[resource] = reason
+	}
+	return skipped
+}
+
+// cacheSnapshot is a snapshot passed to WithCacheSnapshot.
+type cacheSnapshot struct {
+	snapshot io.Reader
+	// codec decodes snapshot. It is nil for JSON.
+	codec runtime.Codec
+}
+
+// CheckInformerCreate consults the informer create hook for obj's type and
+// records its error, if any, so that the informer is never started. It is
+// called by InformerFor while f.lock is held.
+func (f *sharedInformerFactory) CheckInformerCreate(obj runtime.Object) {
+	if f.informerCreateHook == nil {
+		return
+	}
+	informerType := reflect.TypeOf(obj)
+	resource, _ := resourceForType(informerType)
+	if err := f.informerCreateHook(resource); err != nil {
+		f.vetoedInformers[informerType] = fmt.Errorf("the %v informer was vetoed: %w", resource, err)
+	}
+}
+
+// InvalidObject is an object rejected by the validator of WithIngestValidator.
+type InvalidObject struct {
+	// Resource is the resource of the object.
+	Resource schema.GroupVersionResource
+	// Key is the namespace/name key of the object.
+	Key string
+	// Err is the error returned by the validator.
+	Err error
+}
+
+func (f *sharedInformerFactory) InvalidObjects() []InvalidObject {
+	var invalid []InvalidObject
+	for resource, validator := range f.ingestValidators {
+		for key, err := range validator.Invalid() {
+			invalid = append(invalid, InvalidObject{Resource: resource, Key: key, Err: err})
+		}
+	}
+	slices.SortFunc(invalid, func(a, b InvalidObject) int {
+		if c := strings.Compare(a.Resource.String(), b.Resource.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return invalid
+}
+
+func (f *sharedInformerFactory) RetweakInformer(resource schema.GroupVersionResource, tweak internalinterfaces.TweakListOptionsFunc) error {
+	f.lock.Lock()
+	retweaker := f.retweakers[resource]
+	f.lock.Unlock()
+	if retweaker == nil {
+		return fmt.Errorf("no informer for %v was requested from the factory", resource)
+	}
+	retweaker.Retweak(tweak)
+	return nil
+}
+
+// PanicHandler returns the function to pass the recovered panics of event
+// handlers of the informer for obj's type to, or nil if panics are not
+// recovered.
+func (f *sharedInformerFactory) PanicHandler(obj runtime.Object) func(recovered interface{}) {
+	if f.panicHandler == nil {
+		return nil
+	}
+	resource, _ := resourceForType(reflect.TypeOf(obj))
+	return func(recovered interface{}) {
+		f.panicHandler(resource, recovered)
+	}
+}
+
+func (f *sharedInformerFactory) SnapshotCache(resource schema.GroupVersionResource, w io.Writer, codec runtime.Codec) error {
+	f.lock.Lock()
+	var informer cache.SharedIndexInformer
+	for informerType, i := range f.informers {
+		if r, ok := resourceForType(informerType); ok && r == resource {
+			informer = i
+			break
+		}
+	}
+	f.lock.Unlock()
+
+	if informer == nil {
+		return fmt.Errorf("no informer was requested for %v", resource)
+	}
+	if !informer.HasSynced() {
+		return fmt.Errorf("the informer for %v has not synced", resource)
+	}
+	// The resource version is read before the objects are listed, so that a
+	// watch started from it replays any change made while they are listed.
+	resourceVersion := informer.LastSyncResourceVersion()
+	if codec != nil {
+		// Codecs encode the typed list, which they decode into again.
+		list, ok := newListForResource(resource)
+		if !ok {
+			return fmt.Errorf("no list type is known for %v", resource)
+		}
+		var items []runtime.Object
+		for _, obj := range informer.GetStore().List() {
+			items = append(items, obj.(runtime.Object))
+		}
+		if err := meta.SetList(list, items); err != nil {
+			return err
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return err
+		}
+		listMeta.SetResourceVersion(resourceVersion)
+		return codec.Encode(list, w)
+	}
+	list := &v1.List{ListMeta: v1.ListMeta{ResourceVersion: resourceVersion}}
+	for _, obj := range informer.GetStore().List() {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: data})
+	}
+	return json.NewEncoder(w).Encode(list)
+}
+
+// TrackEventHandler records that an event handler was added to informer,
+// which must have been created by InformerFor.
+func (f *sharedInformerFactory) TrackEventHandler(informer cache.SharedIndexInformer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, i := range f.informers {
+		if i == informer {
+			f.handlerCounts[informerType]++
+			return
+		}
+	}
+}
+
+// PriorityEventHandlers returns the dispatcher of the handlers of informer
+// which were added with a priority, adding it to informer first if needed.
+// informer must have been created by InformerFor.
+func (f *sharedInformerFactory) PriorityEventHandlers(informer cache.SharedIndexInformer) (*internalinterfaces.PriorityEventHandlers, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, i := range f.informers {
+		if i != informer {
+			continue
+		}
+		if handlers, exists := f.priorityEventHandlers[informerType]; exists {
+			return handlers, nil
+		}
+		handlers, err := internalinterfaces.AddPriorityEventHandlers(informer)
+		if err != nil {
+			return nil, err
+		}
+		if f.priorityEventHandlers == nil {
+			f.priorityEventHandlers = make(map[reflect.Type]*internalinterfaces.PriorityEventHandlers)
+		}
+		f.priorityEventHandlers[informerType] = handlers
+		return handlers, nil
+	}
+	return nil, errors.New("the informer was not created by the factory")
+}
+
+// NextEventSequence returns the sequence number of the next event delivered to
+// a sequenced handler of any informer of the factory. Sequence numbers start at
+// 1 and increase monotonically.
+func (f *sharedInformerFactory) NextEventSequence() uint64 {
+	return f.eventSequence.Add(1)
+}
+
+func (f *sharedInformerFactory) InformersWithHandlers() []schema.GroupVersionResource {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var resources []schema.GroupVersionResource
+	for informerType, count := range f.handlerCounts {
+		if resource, ok := resourceForType(informerType); ok && count > 0 {
+			resources = append(resources, resource)
+		}
+	}
+	slices.SortFunc(resources, func(a, b schema.GroupVersionResource) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return resources
+}
+
+func (f *sharedInformerFactory) RegisterConsumer(resource schema.GroupVersionResource, name string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.consumers == nil {
+		f.consumers = make(map[schema.GroupVersionResource][]string)
+	}
+	if !slices.Contains(f.consumers[resource], name) {
+		f.consumers[resource] = append(f.consumers[resource], name)
+	}
+}
+
+func (f *sharedInformerFactory) DependencyGraph() map[schema.GroupVersionResource][]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	graph := make(map[schema.GroupVersionResource][]string, len(f.consumers))
+	for resource, names := range f.consumers {
+		graph[resource] = append([]string(nil), names...)
+	}
+	return graph
+}
+
+// latencyHistogram returns the histogram observing the event processing
+// latency of the informer for informerType, or nil.
+func (f *sharedInformerFactory) latencyHistogram(informerType reflect.Type) cache.HistogramMetric {
+	resource, ok := resourceForType(informerType)
+	if !ok {
+		return nil
+	}
+	if histogram := f.latencyHistograms[resource]; histogram != nil {
+		return histogram
+	}
+	return nil
+}
+
+// latencyObservingInformer observes the event processing latency of the
+// handlers added to it.
+type latencyObservingInformer struct {
+	cache.SharedIndexInformer
+	factory   *sharedInformerFactory
+	histogram cache.HistogramMetric
+}
+
+func (i *latencyObservingInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandler(&latencyObservingHandler{informer: i, handler: handler})
+}
+
+func (i *latencyObservingInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(&latencyObservingHandler{informer: i, handler: handler}, resyncPeriod)
+}
+
+func (i *latencyObservingInformer) AddEventHandlerWithOptions(handler cache.ResourceEventHandler, options cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithOptions(&latencyObservingHandler{informer: i, handler: handler}, options)
+}
+
+// receiveTime returns the time at which the latest change of obj was received
+// by the informer, or the current time if it is unknown.
+func (i *latencyObservingInformer) receiveTime(obj interface{}) time.Time {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if accessor, err := meta.Accessor(obj); err == nil && accessor.GetUID() != "" {
+		if received, ok := i.factory.IngestTime(accessor); ok {
+			return received
+		}
+	}
+	return time.Now()
+}
+
+func (i *latencyObservingInformer) observe(start time.Time) {
+	i.histogram.Observe(time.Now().Sub(start).Seconds())
+}
+
+// latencyObservingHandler wraps an event handler to observe its latency.
+type latencyObservingHandler struct {
+	informer *latencyObservingInformer
+	handler  cache.ResourceEventHandler
+}
+
+func (h *latencyObservingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	start := h.informer.receiveTime(obj)
+	h.handler.OnAdd(obj, isInInitialList)
+	h.informer.observe(start)
+}
+
+func (h *latencyObservingHandler) OnUpdate(oldObj, newObj interface{}) {
+	start := time.Now()
+	// Resyncs deliver the cached object again, so its ingest time is stale.
+	oldAccessor, oldErr := meta.Accessor(oldObj)
+	newAccessor, newErr := meta.Accessor(newObj)
+	if oldErr != nil || newErr != nil || oldAccessor.GetResourceVersion() != newAccessor.GetResourceVersion() {
+		start = h.informer.receiveTime(newObj)
+	}
+	h.handler.OnUpdate(oldObj, newObj)
+	h.informer.observe(start)
+}
+
+func (h *latencyObservingHandler) OnDelete(obj interface{}) {
+	start := h.informer.receiveTime(obj)
+	h.handler.OnDelete(obj)
+	h.informer.observe(start)
+}
+
+// EqualityFunc returns true if newObj is equal to oldObj, in which case the
+// update from oldObj to newObj is not delivered to event handlers.
+type EqualityFunc func(oldObj, newObj runtime.Object) bool
+
+// dedupingInformer drops the updates which its equality function considers
+// equal before they reach the handlers added to it.
+type dedupingInformer struct {
+	cache.SharedIndexInformer
+	equal EqualityFunc
+}
+
+func (i *dedupingInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandler(&dedupingHandler{equal: i.equal, handler: handler})
+}
+
+func (i *dedupingInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(&dedupingHandler{equal: i.equal, handler: handler}, resyncPeriod)
+}
+
+func (i *dedupingInformer) AddEventHandlerWithOptions(handler cache.ResourceEventHandler, options cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error) {
+	return i.SharedIndexInformer.AddEventHandlerWithOptions(&dedupingHandler{equal: i.equal, handler: handler}, options)
+}
+
+// dedupingHandler wraps an event handler to drop equal updates.
+type dedupingHandler struct {
+	equal   EqualityFunc
+	handler cache.ResourceEventHandler
+}
+
+func (h *dedupingHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.handler.OnAdd(obj, isInInitialList)
+}
+
+func (h *dedupingHandler) OnUpdate(oldObj, newObj interface{}) {
+	oldObject, oldOK := oldObj.(runtime.Object)
+	newObject, newOK := newObj.(runtime.Object)
+	if oldOK && newOK && h.equal(oldObject, newObject) {
+		return
+	}
+	h.handler.OnUpdate(oldObj, newObj)
+}
+
+func (h *dedupingHandler) OnDelete(obj interface{}) {
+	h.handler.OnDelete(obj)
+}
+
+func (f *sharedInformerFactory) AddResyncHandler(obj runtime.Object, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return nil, fmt.Errorf("no informer for %T was requested from the factory", obj)
+	}
+	wrapped := &resyncableHandler{handler: handler}
+	registration, err := informer.AddEventHandler(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if f.resyncHandlers == nil {
+		f.resyncHandlers = make(map[reflect.Type]map[cache.ResourceEventHandlerRegistration]*resyncableHandler)
+	}
+	if f.resyncHandlers[informerType] == nil {
+		f.resyncHandlers[informerType] = make(map[cache.ResourceEventHandlerRegistration]*resyncableHandler)
+	}
+	f.resyncHandlers[informerType][registration] = wrapped
+	return registration, nil
+}
+
+func (f *sharedInformerFactory) RemoveResyncHandler(obj runtime.Object, registration cache.ResourceEventHandlerRegistration) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := reflect.TypeOf(obj)
+	informer, exists := f.informers[informerType]
+	if !exists {
+		return fmt.Errorf("no informer for %T was requested from the factory", obj)
+	}
+	delete(f.resyncHandlers[informerType], registration)
+	return informer.RemoveEventHandler(registration)
+}
+
+func (f *sharedInformerFactory) Resync(obj runtime.Object) error {
+	informer, handlers, err := f.resyncTarget(obj)
+	if err != nil {
+		return err
+	}
+	objs := informer.GetStore().List()
+	for _, handler := range handlers {
+		handler.resync(objs)
+	}
+	return nil
+}
+
+func (f *sharedInformerFactory) ReplayKey(obj runtime.Object, key string) error {
+	informer, handlers, err := f.resyncTarget(obj)
+	if err != nil {
+		return err
+	}
+	item, exists, err := informer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%q is not in the cache of the informer for %T", key, obj)
+	}
+	for _, handler := range handlers {
+		handler.resync([]interface{}{item})
+	}
+	return nil
+}
+
+// resyncTarget returns the synced informer for obj's type and the handlers
+// added to it through AddResyncHandler.
+func (f *sharedInformerFactory) resyncTarget(obj runtime.Object) (cache.SharedIndexInformer, []*resyncableHandler, error) {
+	f.lock.Lock()
+	informerType := reflect.TypeOf(obj)
+	informer, exists := f.informers[informerType]
+	handlers := make([]*resyncableHandler, 0, len(f.resyncHandlers[informerType]))
+	for _, handler := range f.resyncHandlers[informerType] {
+		handlers = append(handlers, handler)
+	}
+	f.lock.Unlock()
+
+	if !exists {
+		return nil, nil, fmt.Errorf("no informer for %T was requested from the factory", obj)
+	}
+	if !informer.HasSynced() {
+		return nil, nil, fmt.Errorf("the informer for %T has not synced", obj)
+	}
+	return informer, handlers, nil
+}
+
+// resyncableHandler serializes the notifications of an event handler with
+// the ones of Resync.
+type resyncableHandler struct {
+	lock    sync.Mutex
+	handler cache.ResourceEventHandler
+}
+
+func (h *resyncableHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnAdd(obj, isInInitialList)
+}
+
+func (h *resyncableHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnUpdate(oldObj, newObj)
+}
+
+func (h *resyncableHandler) OnDelete(obj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handler.OnDelete(obj)
+}
+
+func (h *resyncableHandler) resync(objs []interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, obj := range objs {
+		h.handler.OnUpdate(obj, obj)
+	}
+}
+
+func (f *sharedInformerFactory) WaitForCondition(ctx context.Context, obj runtime.Object, pred func(store cache.Store) bool) error {
+	f.lock.Lock()
+	informer, exists := f.informers[reflect.TypeOf(obj)]
+	f.lock.Unlock()
+	if !exists {
+		return fmt.Errorf("no informer for %T was requested from the factory", obj)
+	}
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = informer.RemoveEventHandler(registration)
+	}()
+
+	for {
+		if pred(informer.GetStore()) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-changed:
+		}
+	}
+}
+
+// memoryBudgetStages are the stages of stripping applied by a memoryBudget.
+// Each stage also applies the stages before it.
+var memoryBudgetStages = []func(v1.Object){
+	func(obj v1.Object) { obj.SetManagedFields(nil) },
+	func(obj v1.Object) { obj.SetAnnotations(nil) },
+}
+
+// memoryBudget tracks the estimated sizes of the objects in the informer
+// caches of a factory and strips fields from new objects when they exceed the
+// limit.
+type memoryBudget struct {
+	limit int64
+
+	lock  sync.Mutex
+	used  int64
+	sizes map[types.UID]int64
+	// stage is the number of memoryBudgetStages applied to new objects.
+	stage int
+}
+
+// admit strips obj according to the current stage and accounts for its size.
+// If obj does not fit in the budget, the stage is escalated and obj is
+// stripped further, until it fits or all stages are applied.
+func (b *memoryBudget) admit(obj v1.Object) {
+	b.lock.Lock()
+	stage := b.stage
+	b.lock.Unlock()
+	for applied := 0; ; {
+		for ; applied < stage; applied++ {
+			memoryBudgetStages[applied](obj)
+		}
+		if obj.GetUID() == "" {
+			return
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return
+		}
+
+		b.lock.Lock()
+		used := b.used + int64(len(data)) - b.sizes[obj.GetUID()]
+		if used > b.limit && applied < len(memoryBudgetStages) {
+			b.stage = max(b.stage, applied+1)
+			stage = b.stage
+			b.lock.Unlock()
+			continue
+		}
+		b.used = used
+		b.sizes[obj.GetUID()] = int64(len(data))
+		b.relax()
+		b.lock.Unlock()
+		return
+	}
+}
+
+// relax lowers the stage by one if the used size is below the low-water mark
+// of three quarters of the limit. b.lock must be held.
+func (b *memoryBudget) relax() {
+	if b.stage > 0 && b.used < b.limit-b.limit/4 {
+		b.stage--
+	}
+}
+
+// forget drops the size of a deleted object.
+func (b *memoryBudget) forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.used -= b.sizes[accessor.GetUID()]
+	delete(b.sizes, accessor.GetUID())
+	b.relax()
+}
+
+// stalenessWatchdog reports an informer which delivered no event for longer
+// than maxStaleness.
+type stalenessWatchdog struct {
+	maxStaleness time.Duration
+	onStale      func(schema.GroupVersionResource)
+
+	lock      sync.Mutex
+	lastEvent time.Time
+}
+
+func (w *stalenessWatchdog) observe() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lastEvent = time.Now()
+}
+
+// handler returns an event handler recording the time of every event.
+func (w *stalenessWatchdog) handler() cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { w.observe() },
+		UpdateFunc: func(_, _ interface{}) { w.observe() },
+		DeleteFunc: func(interface{}) { w.observe() },
+	}
+}
+
+// run calls onStale with resource after every window of maxStaleness without
+// events until ctx is canceled.
+func (w *stalenessWatchdog) run(ctx context.Context, resource schema.GroupVersionResource) {
+	w.observe()
+	timer := time.NewTimer(w.maxStaleness)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		w.lock.Lock()
+		now := time.Now()
+		idle := now.Sub(w.lastEvent)
+		if idle >= w.maxStaleness {
+			w.lastEvent = now
+		}
+		w.lock.Unlock()
+		if idle >= w.maxStaleness {
+			w.onStale(resource)
+			timer.Reset(w.maxStaleness)
+		} else {
+			timer.Reset(w.maxStaleness - idle)
+		}
+	}
+}
+
+// CompositeFactory drives the lifecycles of several factories as one, for
+// example the SharedInformerFactories generated for different APIs. It is a
+// Startable itself, so composites can be nested.
+type CompositeFactory struct {
+	factories []internalinterfaces.Startable
+}
+
+var _ internalinterfaces.Startable = &CompositeFactory{}
+var _ internalinterfaces.Startable = &sharedInformerFactory{}
+
+// NewCompositeFactory returns a CompositeFactory of factories.
+func NewCompositeFactory(factories ...internalinterfaces.Startable) *CompositeFactory {
+	return &CompositeFactory{factories: factories}
+}
+
+// StartWithContext starts the requested informers of all factories, in order.
+func (c *CompositeFactory) StartWithContext(ctx context.Context) {
+	for _, factory := range c.factories {
+		factory.StartWithContext(ctx)
+	}
+}
+
+// WaitForCacheSyncWithContext waits for the caches of the started informers of
+// all factories to sync and merges the results. Err is the error of the first
+// factory whose caches did not sync.
+func (c *CompositeFactory) WaitForCacheSyncWithContext(ctx context.Context) cache.SyncResult {
+	result := cache.SyncResult{Synced: map[reflect.Type]bool{}}
+	for _, factory := range c.factories {
+		factoryResult := factory.WaitForCacheSyncWithContext(ctx)
+		for informerType, synced := range factoryResult.Synced {
+			result.Synced[informerType] = synced
+		}
+		if result.Err == nil {
+			result.Err = factoryResult.Err
+		}
+	}
+	return result
+}
+
+// Shutdown shuts all factories down, in reverse order, and blocks until all
+// their goroutines have terminated.
+func (c *CompositeFactory) Shutdown() {
+	for i := len(c.factories) - 1; i >= 0; i-- {
+		c.factories[i].Shutdown()
+	}
+}
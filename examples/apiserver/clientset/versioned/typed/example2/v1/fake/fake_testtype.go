@@ -19,6 +19,11 @@ limitations under the License.
 package fake
 
 import (
+	context "context"
+	json "encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	gentype "k8s.io/client-go/gentype"
 	v1 "k8s.io/code-generator/examples/apiserver/apis/example2/v1"
 	example2v1 "k8s.io/code-generator/examples/apiserver/clientset/versioned/typed/example2/v1"
@@ -46,3 +51,21 @@ func newFakeTestTypes(fake *FakeSecondExampleV1, namespace string) example2v1.Te
 		fake,
 	}
 }
+
+// MergePatchTestType marshals patch to JSON and records it as a JSON merge patch of the named testType.
+func (c *fakeTestTypes) MergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and records it as a strategic merge patch of the named testType.
+func (c *fakeTestTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
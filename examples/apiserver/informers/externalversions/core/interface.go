@@ -19,6 +19,12 @@ limitations under the License.
 package core
 
 import (
+	fmt "fmt"
+
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+	corev1 "k8s.io/code-generator/examples/apiserver/apis/core/v1"
 	v1 "k8s.io/code-generator/examples/apiserver/informers/externalversions/core/v1"
 	internalinterfaces "k8s.io/code-generator/examples/apiserver/informers/externalversions/internalinterfaces"
 )
@@ -27,6 +33,15 @@ import (
 type Interface interface {
 	// V1 provides access to shared informers for resources in V1.
 	V1() v1.Interface
+
+	// RegisterHandlers adds each handler to the shared informer of the resource
+	// it is keyed by. If any resource is not part of this group, it returns an
+	// error without adding any handler.
+	RegisterHandlers(handlers map[schema.GroupVersionResource]cache.ResourceEventHandler) error
+	// Ready returns true once the informers of this group which were
+	// requested from the factory have all synced. It is true if none were
+	// requested.
+	Ready() bool
 }
 
 type group struct {
@@ -44,3 +59,51 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (g *group) V1() v1.Interface {
 	return v1.New(g.factory, g.namespace, g.tweakListOptions)
 }
+
+// RegisterHandlers adds each handler to the shared informer of the resource
+// it is keyed by.
+func (g *group) RegisterHandlers(handlers map[schema.GroupVersionResource]cache.ResourceEventHandler) error {
+	informers := make(map[schema.GroupVersionResource]func() cache.SharedIndexInformer, len(handlers))
+	objects := make(map[schema.GroupVersionResource]runtime.Object, len(handlers))
+	for resource := range handlers {
+		informer, obj, ok := g.informerFor(resource)
+		if !ok {
+			return fmt.Errorf("no informer found for %v", resource)
+		}
+		informers[resource] = informer
+		objects[resource] = obj
+	}
+	factory, fromFactory := g.factory.(internalinterfaces.SharedInformerFactoryExtensions)
+	for resource, handler := range handlers {
+		informer := informers[resource]()
+		if fromFactory {
+			handler = internalinterfaces.NewPanicRecoveringEventHandler(handler, factory.PanicHandler(objects[resource]))
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return fmt.Errorf("failed to add event handler for %v: %w", resource, err)
+		}
+		if fromFactory {
+			factory.TrackEventHandler(informer)
+		}
+	}
+	return nil
+}
+
+// Ready returns true once the informers of this group which were requested
+// from the factory have all synced. It is always false for factories which do
+// not implement SharedInformerFactoryExtensions.
+func (g *group) Ready() bool {
+	factory, ok := g.factory.(internalinterfaces.SharedInformerFactoryExtensions)
+	return ok && factory.GroupSynced("")
+}
+
+// informerFor returns the function returning the shared informer for
+// resource and an object of its type, or false if resource is not part of
+// this group.
+func (g *group) informerFor(resource schema.GroupVersionResource) (func() cache.SharedIndexInformer, runtime.Object, bool) {
+	switch resource {
+	case corev1.SchemeGroupVersion.WithResource("testtypes"):
+		return g.V1().TestTypes().Informer, &corev1.TestType{}, true
+	}
+	return nil, nil, false
+}
@@ -19,10 +19,23 @@ limitations under the License.
 package internalinterfaces
 
 import (
+	context "context"
+	json "encoding/json"
+	errors "errors"
+	io "io"
+	slices "slices"
+	strconv "strconv"
+	sync "sync"
 	time "time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
 	cache "k8s.io/client-go/tools/cache"
 	versioned "k8s.io/code-generator/examples/apiserver/clientset/versioned"
 )
@@ -37,9 +50,67 @@ type SharedInformerFactory interface {
 	InformerName() *cache.InformerName
 }
 
+// SharedInformerFactoryExtensions is implemented by the factories generated by
+// informer-gen in addition to SharedInformerFactory. Informers and groups check
+// for it with a type assertion, so other implementations of
+// SharedInformerFactory need not implement it. Their informers are created
+// with default options, and the event handlers added to them are neither
+// tracked nor recovered from panics.
+type SharedInformerFactoryExtensions interface {
+	// InformerOptionsFor returns the options which the factory sets on the
+	// informer for obj's type. ResyncPeriod, Indexers and TweakListOptions are
+	// left to the caller, and the Retweaker, if any, starts without a tweak.
+	// It is called when InformerFor creates that informer.
+	InformerOptionsFor(obj runtime.Object) InformerOptions
+	// CheckInformerCreate records whether the factory vetoes the informer for
+	// obj's type. It is called when InformerFor creates that informer.
+	CheckInformerCreate(obj runtime.Object)
+	// PanicHandler returns the function to pass the recovered panics of the
+	// event handlers of the informer for obj's type to, or nil.
+	PanicHandler(obj runtime.Object) func(recovered interface{})
+	// TrackEventHandler records that an event handler was added to informer.
+	TrackEventHandler(informer cache.SharedIndexInformer)
+	// PriorityEventHandlers returns the handlers with a priority of informer.
+	PriorityEventHandlers(informer cache.SharedIndexInformer) (*PriorityEventHandlers, error)
+	// NextEventSequence returns the next sequence number of the sequenced
+	// handlers of the factory.
+	NextEventSequence() uint64
+	// GroupSynced returns true once the informers of group which were
+	// requested from the factory have all synced.
+	GroupSynced(group string) bool
+}
+
 // TweakListOptionsFunc is a function that transforms a v1.ListOptions.
 type TweakListOptionsFunc func(*v1.ListOptions)
 
+// WithLabelSelector returns a TweakListOptionsFunc which calls tweak, if it is
+// not nil, and then ANDs selector into the label selector it left.
+func WithLabelSelector(tweak TweakListOptionsFunc, selector string) TweakListOptionsFunc {
+	return func(options *v1.ListOptions) {
+		if tweak != nil {
+			tweak(options)
+		}
+		options.LabelSelector = AndLabelSelectors(options.LabelSelector, selector)
+	}
+}
+
+// AndLabelSelectors returns the label selector matching the objects which are
+// matched by all of selectors. Empty selectors, which match everything, are
+// skipped.
+func AndLabelSelectors(selectors ...string) string {
+	var and string
+	for _, selector := range selectors {
+		switch {
+		case selector == "":
+		case and == "":
+			and = selector
+		default:
+			and += "," + selector
+		}
+	}
+	return and
+}
+
 // InformerOptions holds the options for creating an informer.
 type InformerOptions struct {
 	// ResyncPeriod is the resync period for this informer.
@@ -56,4 +127,1716 @@ type InformerOptions struct {
 
 	// TweakListOptions is an optional function to modify the list options.
 	TweakListOptions TweakListOptionsFunc
+
+	// CacheSnapshot, if set, is decoded by the first list of the informer
+	// instead of listing from the server. It must hold a list with a resource
+	// version, like the lists written by SnapshotCache, encoded as JSON or in
+	// the format of CacheSnapshotDecoder.
+	// The informer then watches from that resource version, and relists from
+	// the server if it is too old. Streaming lists are not used if
+	// CacheSnapshot is set, because they would bypass the snapshot.
+	CacheSnapshot io.Reader
+
+	// CacheSnapshotDecoder, if set, decodes CacheSnapshot instead of a JSON
+	// decoder.
+	CacheSnapshotDecoder runtime.Decoder
+
+	// InitialResourceVersion, if set, is the resource version which the first
+	// list served by the server must match as InitialResourceVersionMatch
+	// says. The informer then watches from the resource version of that list.
+	// Later lists are not pinned, so the informer relists at the latest
+	// resource version if the watch cannot be continued. Streaming lists are
+	// not used if InitialResourceVersion is set, because they would bypass the
+	// pinned list.
+	InitialResourceVersion string
+
+	// InitialResourceVersionMatch is how the first list must match
+	// InitialResourceVersion. It defaults to v1.ResourceVersionMatchExact.
+	InitialResourceVersionMatch v1.ResourceVersionMatch
+
+	// WatchListPageSize, if positive, is the requested chunk size of the
+	// lists of the informer, like cache.Reflector.WatchListPageSize. It has
+	// no effect on streaming lists, which the server sends as a watch. If it
+	// is zero, client-go's default paging applies.
+	WatchListPageSize int64
+
+	// ReconnectObserver, if set, is called with the resource and the time
+	// whenever the watch of the informer is established.
+	ReconnectObserver func(resource schema.GroupVersionResource, at time.Time)
+
+	// Retweaker, if set, replaces TweakListOptions. The list options of the
+	// informer are tweaked by its current tweak, and replacing the tweak
+	// makes the informer relist. Use it with NewRetweakableListerWatcher.
+	Retweaker *Retweaker
+
+	// IngestValidator, if set, validates the objects listed and watched by
+	// the informer. Use it with NewValidatingListerWatcher.
+	IngestValidator *IngestValidator
+
+	// ObjectFilter, if set, drops the objects for which it returns false, so
+	// that they are never cached by the informer. Use it with
+	// NewObjectFilter and NewValidatingListerWatcher.
+	ObjectFilter func(obj v1.Object) bool
+
+	// NamespaceSelectors, if set, limits namespaced informers to its
+	// namespaces, each listed and watched with its label selector. A nil
+	// selector keeps the label selector of TweakListOptions. Use it with
+	// NewMultiNamespaceListerWatcher.
+	NamespaceSelectors map[string]labels.Selector
+
+	// CacheBackend, if set, creates the indexer which is returned as the
+	// store and indexer of the informer. Use it with NewCacheBackendInformer.
+	CacheBackend CacheBackend
+
+	// InitialCacheCapacity, if positive, is the expected number of objects of
+	// the informer, for which the indexer of CacheBackend is sized if it is a
+	// SizedCacheBackend. Use it with NewCacheBackendInformer.
+	InitialCacheCapacity int
+
+	// LeadershipGate, if set, pauses the lists and watches of the informer
+	// while leadership is not held. Use it with
+	// NewLeadershipGatedListerWatcher.
+	LeadershipGate *LeadershipGate
+
+	// KeyNormalizer, if set, normalizes the names in the keys of the store
+	// and indexer of the informer. Use it with NewKeyNormalizingInformer.
+	KeyNormalizer func(name string) string
+
+	// WatchRotation, if positive, is the maximum lifetime of the watches of
+	// the informer, after which they are stopped and reestablished. Use it
+	// with NewRotatingListerWatcher.
+	WatchRotation time.Duration
+
+	// Context, if set, bounds the lists and watches of the informer, which
+	// are canceled once it is done. Use it with NewContextBoundListerWatcher.
+	Context context.Context
+}
+
+// NewListerWatcherWithoutWatchList returns a ListerWatcher which delegates
+// to lw, but whose initial list is never replaced by a streaming list.
+func NewListerWatcherWithoutWatchList(lw cache.ListerWatcher) cache.ListerWatcher {
+	return listerWatcherWithoutWatchList{cache.ToListerWatcherWithContext(lw)}
+}
+
+type listerWatcherWithoutWatchList struct {
+	cache.ListerWatcherWithContext
+}
+
+func (lw listerWatcherWithoutWatchList) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw listerWatcherWithoutWatchList) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+// IsWatchListSemanticsUnSupported returns true, so that the reflector calls
+// List instead of starting with a streaming list.
+func (lw listerWatcherWithoutWatchList) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+// NewCacheSnapshotListerWatcher returns lw if snapshot is nil. Otherwise it
+// returns a ListerWatcher whose first list decodes snapshot into list instead
+// of listing from lw. snapshot is decoded by decoder, or as JSON if decoder
+// is nil. If snapshot cannot be decoded, the error is reported and the first
+// list is served by lw.
+func NewCacheSnapshotListerWatcher(lw cache.ListerWatcher, snapshot io.Reader, decoder runtime.Decoder, list runtime.Object) cache.ListerWatcher {
+	if snapshot == nil {
+		return lw
+	}
+	return &cacheSnapshotListerWatcher{
+		ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw),
+		snapshot:                 snapshot,
+		decoder:                  decoder,
+		list:                     list,
+	}
+}
+
+type cacheSnapshotListerWatcher struct {
+	cache.ListerWatcherWithContext
+
+	// snapshot is cleared by the first list. Lists are never called
+	// concurrently by a reflector.
+	snapshot io.Reader
+	decoder  runtime.Decoder
+	list     runtime.Object
+}
+
+func (lw *cacheSnapshotListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *cacheSnapshotListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *cacheSnapshotListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	if snapshot := lw.snapshot; snapshot != nil {
+		lw.snapshot = nil
+		if err := lw.decode(snapshot); err != nil {
+			utilruntime.HandleErrorWithContext(ctx, err, "Ignoring cache snapshot")
+		} else {
+			return lw.list, nil
+		}
+	}
+	return lw.ListerWatcherWithContext.ListWithContext(ctx, options)
+}
+
+func (lw *cacheSnapshotListerWatcher) decode(snapshot io.Reader) error {
+	if lw.decoder == nil {
+		if err := json.NewDecoder(snapshot).Decode(lw.list); err != nil {
+			return err
+		}
+	} else {
+		data, err := io.ReadAll(snapshot)
+		if err != nil {
+			return err
+		}
+		list, _, err := lw.decoder.Decode(data, nil, lw.list)
+		if err != nil {
+			return err
+		}
+		lw.list = list
+	}
+	listMeta, err := meta.ListAccessor(lw.list)
+	if err != nil {
+		return err
+	}
+	if listMeta.GetResourceVersion() == "" {
+		return errors.New("cache snapshot has no resource version")
+	}
+	return nil
+}
+
+// IsWatchListSemanticsUnSupported returns true, so that the reflector calls
+// List instead of starting with a streaming list.
+func (lw *cacheSnapshotListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+// RecoverEventHandlerPanic passes a panic of an event handler to panicHandler
+// if it is not nil. Otherwise the panic continues. It must be deferred
+// directly by the event handler.
+func RecoverEventHandlerPanic(panicHandler func(recovered interface{})) {
+	if panicHandler == nil {
+		return
+	}
+	if recovered := recover(); recovered != nil {
+		panicHandler(recovered)
+	}
+}
+
+// NewPanicRecoveringEventHandler returns handler if panicHandler is nil.
+// Otherwise it returns an event handler which passes the panics of handler
+// to panicHandler.
+func NewPanicRecoveringEventHandler(handler cache.ResourceEventHandler, panicHandler func(recovered interface{})) cache.ResourceEventHandler {
+	if panicHandler == nil {
+		return handler
+	}
+	return &panicRecoveringEventHandler{handler: handler, panicHandler: panicHandler}
+}
+
+type panicRecoveringEventHandler struct {
+	handler      cache.ResourceEventHandler
+	panicHandler func(recovered interface{})
+}
+
+func (h *panicRecoveringEventHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	defer RecoverEventHandlerPanic(h.panicHandler)
+	h.handler.OnAdd(obj, isInInitialList)
+}
+
+func (h *panicRecoveringEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	defer RecoverEventHandlerPanic(h.panicHandler)
+	h.handler.OnUpdate(oldObj, newObj)
+}
+
+func (h *panicRecoveringEventHandler) OnDelete(obj interface{}) {
+	defer RecoverEventHandlerPanic(h.panicHandler)
+	h.handler.OnDelete(obj)
+}
+
+// PriorityEventHandlers is an event handler of a shared informer which
+// invokes handlers in ascending priority, and in the order they were added
+// for equal priorities. Handlers must not add handlers to the
+// PriorityEventHandlers invoking them.
+type PriorityEventHandlers struct {
+	lock         sync.Mutex
+	handlers     []*priorityEventHandler
+	registration cache.ResourceEventHandlerRegistration
+}
+
+type priorityEventHandler struct {
+	priority int
+	handler  cache.ResourceEventHandler
+	// replayed holds the resource versions of the objects which were in the
+	// cache when handler was added, by key, until the next notification for
+	// them. Pending adds of those versions were already delivered to handler.
+	replayed map[string]string
+}
+
+// AddPriorityEventHandlers adds a PriorityEventHandlers without handlers to
+// informer.
+func AddPriorityEventHandlers(informer cache.SharedIndexInformer) (*PriorityEventHandlers, error) {
+	h := &PriorityEventHandlers{}
+	registration, err := informer.AddEventHandler(h)
+	if err != nil {
+		return nil, err
+	}
+	h.registration = registration
+	return h, nil
+}
+
+// Add adds handler with priority. The objects in store, the store of the
+// informer, are delivered to handler as adds of the initial list first. It
+// returns the registration of h with the informer.
+func (h *PriorityEventHandlers) Add(priority int, handler cache.ResourceEventHandler, store cache.Store) cache.ResourceEventHandlerRegistration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	added := &priorityEventHandler{priority: priority, handler: handler}
+	for _, obj := range store.List() {
+		if key, resourceVersion, ok := priorityEventHandlerKey(obj); ok {
+			if added.replayed == nil {
+				added.replayed = make(map[string]string)
+			}
+			added.replayed[key] = resourceVersion
+		}
+		handler.OnAdd(obj, true)
+	}
+	i := slices.IndexFunc(h.handlers, func(other *priorityEventHandler) bool {
+		return other.priority > priority
+	})
+	if i < 0 {
+		i = len(h.handlers)
+	}
+	h.handlers = slices.Insert(h.handlers, i, added)
+	return h.registration
+}
+
+func (h *PriorityEventHandlers) OnAdd(obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, handler := range h.handlers {
+		if !handler.replayedAdd(obj) {
+			handler.handler.OnAdd(obj, isInInitialList)
+		}
+	}
+}
+
+func (h *PriorityEventHandlers) OnUpdate(oldObj, newObj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, handler := range h.handlers {
+		handler.replayedAdd(newObj)
+		handler.handler.OnUpdate(oldObj, newObj)
+	}
+}
+
+func (h *PriorityEventHandlers) OnDelete(obj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, handler := range h.handlers {
+		handler.replayedAdd(obj)
+		handler.handler.OnDelete(obj)
+	}
+}
+
+// replayedAdd forgets the replayed version of obj, and returns true if obj is
+// that version, whose add was delivered to the handler when it was added.
+func (h *priorityEventHandler) replayedAdd(obj interface{}) bool {
+	if h.replayed == nil {
+		return false
+	}
+	key, resourceVersion, ok := priorityEventHandlerKey(obj)
+	if !ok {
+		return false
+	}
+	replayedVersion, found := h.replayed[key]
+	if !found {
+		return false
+	}
+	delete(h.replayed, key)
+	if len(h.replayed) == 0 {
+		h.replayed = nil
+	}
+	return replayedVersion == resourceVersion
+}
+
+func priorityEventHandlerKey(obj interface{}) (key, resourceVersion string, ok bool) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return "", "", false
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		resourceVersion = accessor.GetResourceVersion()
+	}
+	return key, resourceVersion, true
+}
+
+// Retweaker holds the list options tweak of an informer, which can be
+// replaced while the informer runs.
+type Retweaker struct {
+	lock  sync.Mutex
+	tweak TweakListOptionsFunc
+	// generation is incremented by every Retweak.
+	generation uint64
+	watches    map[*retweakableWatch]struct{}
+}
+
+// NewRetweaker returns a Retweaker whose tweak is initially tweak, which may
+// be nil.
+func NewRetweaker(tweak TweakListOptionsFunc) *Retweaker {
+	return &Retweaker{tweak: tweak, watches: map[*retweakableWatch]struct{}{}}
+}
+
+// TweakListOptions applies the current tweak to options.
+func (r *Retweaker) TweakListOptions(options *v1.ListOptions) {
+	r.lock.Lock()
+	tweak := r.tweak
+	r.lock.Unlock()
+	if tweak != nil {
+		tweak(options)
+	}
+}
+
+// Retweak replaces the tweak and ends the watches of the ListerWatchers
+// returned by NewRetweakableListerWatcher for r with an expired error. Their
+// reflectors then relist with the new tweak, which evicts the objects that no
+// longer match from the informer caches.
+func (r *Retweaker) Retweak(tweak TweakListOptionsFunc) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.tweak = tweak
+	r.generation++
+	for w := range r.watches {
+		w.expire()
+		delete(r.watches, w)
+	}
+}
+
+func (r *Retweaker) currentGeneration() uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.generation
+}
+
+// track returns a watch which delegates to w until r is retweaked. The watch
+// expires immediately if r was retweaked since generation.
+func (r *Retweaker) track(w watch.Interface, generation uint64) watch.Interface {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	rw := &retweakableWatch{
+		Interface: w,
+		retweaker: r,
+		result:    make(chan watch.Event),
+		expired:   make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	if generation != r.generation {
+		rw.expire()
+	} else {
+		r.watches[rw] = struct{}{}
+	}
+	go rw.run()
+	return rw
+}
+
+func (r *Retweaker) untrack(w *retweakableWatch) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.watches, w)
+}
+
+// NewRetweakableListerWatcher returns lw if r is nil. Otherwise it returns a
+// ListerWatcher which delegates to lw, whose list options must be tweaked by
+// r, and whose watches end with an expired error when r is retweaked.
+func NewRetweakableListerWatcher(lw cache.ListerWatcher, r *Retweaker) cache.ListerWatcher {
+	if r == nil {
+		return lw
+	}
+	return &retweakableListerWatcher{ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw), lw: lw, retweaker: r}
+}
+
+type retweakableListerWatcher struct {
+	cache.ListerWatcherWithContext
+	lw        cache.ListerWatcher
+	retweaker *Retweaker
+
+	// generation is the generation of the retweaker at the start of the last
+	// list. Lists and watches are never called concurrently by a reflector.
+	generation uint64
+}
+
+func (lw *retweakableListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *retweakableListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *retweakableListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	lw.generation = lw.retweaker.currentGeneration()
+	return lw.ListerWatcherWithContext.ListWithContext(ctx, options)
+}
+
+func (lw *retweakableListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	if options.SendInitialEvents != nil && *options.SendInitialEvents {
+		// A streaming list replaces the list.
+		lw.generation = lw.retweaker.currentGeneration()
+	}
+	w, err := lw.ListerWatcherWithContext.WatchWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	// A watch continuing a list which raced with Retweak expires at once.
+	return lw.retweaker.track(w, lw.generation), nil
+}
+
+// IsWatchListSemanticsUnSupported forwards to the wrapped ListerWatcher.
+func (lw *retweakableListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	unsupported, ok := lw.lw.(interface{ IsWatchListSemanticsUnSupported() bool })
+	return ok && unsupported.IsWatchListSemanticsUnSupported()
+}
+
+// retweakableWatch forwards the events of a watch until its Retweaker is
+// retweaked, and then sends an expired error.
+type retweakableWatch struct {
+	watch.Interface
+	retweaker *Retweaker
+	result    chan watch.Event
+
+	expired    chan struct{}
+	expireOnce sync.Once
+	stopped    chan struct{}
+	stopOnce   sync.Once
+}
+
+func (w *retweakableWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *retweakableWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		w.Interface.Stop()
+		w.retweaker.untrack(w)
+	})
+}
+
+func (w *retweakableWatch) expire() {
+	w.expireOnce.Do(func() {
+		close(w.expired)
+	})
+}
+
+func (w *retweakableWatch) run() {
+	defer close(w.result)
+	for {
+		select {
+		case <-w.expired:
+			w.Interface.Stop()
+			status := apierrors.NewResourceExpired("the list options of the informer were changed").ErrStatus
+			select {
+			case w.result <- watch.Event{Type: watch.Error, Object: &status}:
+			case <-w.stopped:
+			}
+			return
+		default:
+		}
+		select {
+		case <-w.expired:
+		case <-w.stopped:
+			return
+		case event, ok := <-w.Interface.ResultChan():
+			if !ok {
+				return
+			}
+			select {
+			case w.result <- event:
+			case <-w.expired:
+			case <-w.stopped:
+				return
+			}
+		}
+	}
+}
+
+// NewFilteredIndexer returns a read-only view of indexer which only contains
+// the objects for which match returns true. Its key and index functions are
+// the ones of indexer.
+func NewFilteredIndexer(indexer cache.Indexer, match func(obj interface{}) bool) cache.Indexer {
+	return &filteredIndexer{Indexer: indexer, match: match}
+}
+
+type filteredIndexer struct {
+	cache.Indexer
+	match func(obj interface{}) bool
+}
+
+func (i *filteredIndexer) filter(objs []interface{}) []interface{} {
+	var matching []interface{}
+	for _, obj := range objs {
+		if i.match(obj) {
+			matching = append(matching, obj)
+		}
+	}
+	return matching
+}
+
+func (i *filteredIndexer) filterKeys(keys []string) []string {
+	var matching []string
+	for _, key := range keys {
+		if _, exists, _ := i.GetByKey(key); exists {
+			matching = append(matching, key)
+		}
+	}
+	return matching
+}
+
+func (i *filteredIndexer) List() []interface{} {
+	return i.filter(i.Indexer.List())
+}
+
+func (i *filteredIndexer) ListKeys() []string {
+	return i.filterKeys(i.Indexer.ListKeys())
+}
+
+func (i *filteredIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	item, exists, err := i.Indexer.Get(obj)
+	if err != nil || !exists || !i.match(item) {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+func (i *filteredIndexer) GetByKey(key string) (interface{}, bool, error) {
+	item, exists, err := i.Indexer.GetByKey(key)
+	if err != nil || !exists || !i.match(item) {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+func (i *filteredIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	objs, err := i.Indexer.Index(indexName, obj)
+	if err != nil {
+		return nil, err
+	}
+	return i.filter(objs), nil
+}
+
+func (i *filteredIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	keys, err := i.Indexer.IndexKeys(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	return i.filterKeys(keys), nil
+}
+
+func (i *filteredIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	objs, err := i.Indexer.ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	return i.filter(objs), nil
+}
+
+// NewCoResourceListerWatcher returns a ListerWatcher which lists and watches
+// both lw and co, whose objects are paired by key, and which presents the
+// objects of lw merged with their pairs of co by merge. The objects of co are
+// only presented merged into their pairs. An event of either resource
+// replaces the fields owned by that resource, so the last writer wins for
+// each set of fields. The resource versions of both resources must be
+// comparable, as when both are served from the same storage.
+func NewCoResourceListerWatcher(lw, co cache.ListerWatcher, merge func(obj, coObj runtime.Object) runtime.Object) cache.ListerWatcher {
+	return &coResourceListerWatcher{
+		lw:     cache.ToListerWatcherWithContext(lw),
+		co:     cache.ToListerWatcherWithContext(co),
+		merge:  merge,
+		objs:   map[string]runtime.Object{},
+		coObjs: map[string]runtime.Object{},
+	}
+}
+
+type coResourceListerWatcher struct {
+	lw    cache.ListerWatcherWithContext
+	co    cache.ListerWatcherWithContext
+	merge func(obj, coObj runtime.Object) runtime.Object
+
+	// lock guards objs and coObjs, the last unmerged objects of both
+	// resources by key, which a list hands over to the watches continuing it.
+	lock   sync.Mutex
+	objs   map[string]runtime.Object
+	coObjs map[string]runtime.Object
+}
+
+func (lw *coResourceListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *coResourceListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *coResourceListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	list, err := lw.lw.ListWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	// Every page of lw is merged with the whole of co.
+	coOptions := options
+	coOptions.Limit = 0
+	coOptions.Continue = ""
+	coList, err := lw.co.ListWithContext(ctx, coOptions)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	coItems, err := meta.ExtractList(coList)
+	if err != nil {
+		return nil, err
+	}
+
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	if options.Continue == "" {
+		lw.objs = map[string]runtime.Object{}
+	}
+	lw.coObjs = map[string]runtime.Object{}
+	for _, coItem := range coItems {
+		if key, err := cache.MetaNamespaceKeyFunc(coItem); err == nil {
+			lw.coObjs[key] = coItem
+		}
+	}
+	merged := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		key, err := cache.MetaNamespaceKeyFunc(item)
+		if err != nil {
+			return nil, err
+		}
+		lw.objs[key] = item
+		if coItem, ok := lw.coObjs[key]; ok {
+			item = lw.merge(item, coItem)
+		}
+		merged = append(merged, item)
+	}
+	if err := meta.SetList(list, merged); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (lw *coResourceListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	w, err := lw.lw.WatchWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	// The bookmarks of lw suffice.
+	coOptions := options
+	coOptions.AllowWatchBookmarks = false
+	coW, err := lw.co.WatchWithContext(ctx, coOptions)
+	if err != nil {
+		w.Stop()
+		return nil, err
+	}
+	cw := &coResourceWatch{lw: lw, w: w, coW: coW, result: make(chan watch.Event), stopped: make(chan struct{})}
+	go cw.run()
+	return cw, nil
+}
+
+// IsWatchListSemanticsUnSupported returns true, as streaming lists cannot be
+// merged with co.
+func (lw *coResourceListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+// handle returns the event to deliver for an event of lw.
+func (lw *coResourceListerWatcher) handle(event watch.Event) watch.Event {
+	if event.Type != watch.Added && event.Type != watch.Modified && event.Type != watch.Deleted {
+		return event
+	}
+	key, err := cache.MetaNamespaceKeyFunc(event.Object)
+	if err != nil {
+		return event
+	}
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	if event.Type == watch.Deleted {
+		delete(lw.objs, key)
+		delete(lw.coObjs, key)
+		return event
+	}
+	lw.objs[key] = event.Object
+	if coObj, ok := lw.coObjs[key]; ok {
+		event.Object = lw.merge(event.Object, coObj)
+	}
+	return event
+}
+
+// handleCo returns the event to deliver for an event of co, if any. Changes
+// of co are delivered as modifications of their pairs, with the resource
+// version of the change.
+func (lw *coResourceListerWatcher) handleCo(event watch.Event) (watch.Event, bool) {
+	if event.Type == watch.Error {
+		return event, true
+	}
+	if event.Type != watch.Added && event.Type != watch.Modified && event.Type != watch.Deleted {
+		return event, false
+	}
+	key, err := cache.MetaNamespaceKeyFunc(event.Object)
+	if err != nil {
+		return event, false
+	}
+	coAccessor, err := meta.Accessor(event.Object)
+	if err != nil {
+		return event, false
+	}
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	var merged runtime.Object
+	obj, ok := lw.objs[key]
+	if event.Type == watch.Deleted {
+		delete(lw.coObjs, key)
+		if ok {
+			merged = obj.DeepCopyObject()
+		}
+	} else {
+		lw.coObjs[key] = event.Object
+		if ok {
+			merged = lw.merge(obj, event.Object)
+		}
+	}
+	if merged == nil {
+		return event, false
+	}
+	accessor, err := meta.Accessor(merged)
+	if err != nil {
+		return event, false
+	}
+	accessor.SetResourceVersion(coAccessor.GetResourceVersion())
+	return watch.Event{Type: watch.Modified, Object: merged}, true
+}
+
+// coResourceWatch delivers the events of the watches of both resources of a
+// coResourceListerWatcher until either ends.
+type coResourceWatch struct {
+	lw     *coResourceListerWatcher
+	w      watch.Interface
+	coW    watch.Interface
+	result chan watch.Event
+
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *coResourceWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *coResourceWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		w.w.Stop()
+		w.coW.Stop()
+	})
+}
+
+func (w *coResourceWatch) run() {
+	defer close(w.result)
+	defer w.Stop()
+	for {
+		var event watch.Event
+		deliver := true
+		select {
+		case <-w.stopped:
+			return
+		case e, ok := <-w.w.ResultChan():
+			if !ok {
+				return
+			}
+			event = w.lw.handle(e)
+		case e, ok := <-w.coW.ResultChan():
+			if !ok {
+				return
+			}
+			event, deliver = w.lw.handleCo(e)
+		}
+		if !deliver {
+			continue
+		}
+		select {
+		case w.result <- event:
+		case <-w.stopped:
+			return
+		}
+	}
+}
+
+// IngestValidator validates the objects ingested by an informer and records
+// the errors of the objects which are invalid.
+type IngestValidator struct {
+	validate    func(obj runtime.Object) error
+	keepInvalid bool
+
+	lock    sync.Mutex
+	invalid map[string]error
+}
+
+// NewIngestValidator returns an IngestValidator validating objects with
+// validate. Invalid objects are dropped from the informer's cache unless
+// keepInvalid is true.
+func NewIngestValidator(validate func(obj runtime.Object) error, keepInvalid bool) *IngestValidator {
+	return &IngestValidator{validate: validate, keepInvalid: keepInvalid, invalid: map[string]error{}}
+}
+
+// NewObjectFilter returns nil if filter is nil. Otherwise it returns an
+// IngestValidator which drops the objects for which filter returns false, as
+// well as the objects without object metadata.
+func NewObjectFilter(filter func(obj v1.Object) bool) *IngestValidator {
+	if filter == nil {
+		return nil
+	}
+	return NewIngestValidator(func(obj runtime.Object) error {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		if !filter(accessor) {
+			return errors.New("filtered out")
+		}
+		return nil
+	}, false)
+}
+
+// Invalid returns the errors of the objects which are currently invalid, by
+// key.
+func (v *IngestValidator) Invalid() map[string]error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	invalid := make(map[string]error, len(v.invalid))
+	for key, err := range v.invalid {
+		invalid[key] = err
+	}
+	return invalid
+}
+
+// check validates obj and records the outcome. It returns whether obj is
+// kept, and whether the previous version of obj was dropped.
+func (v *IngestValidator) check(obj runtime.Object) (keep, wasDropped bool) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return true, false
+	}
+	err = v.validate(obj)
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	_, wasInvalid := v.invalid[key]
+	if err == nil {
+		delete(v.invalid, key)
+	} else {
+		v.invalid[key] = err
+	}
+	return err == nil || v.keepInvalid, wasInvalid && !v.keepInvalid
+}
+
+// forget forgets the deleted obj. It returns whether obj was dropped.
+func (v *IngestValidator) forget(obj runtime.Object) (wasDropped bool) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return false
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	_, wasInvalid := v.invalid[key]
+	delete(v.invalid, key)
+	return wasInvalid && !v.keepInvalid
+}
+
+// NewValidatingListerWatcher returns lw if v is nil. Otherwise it returns a
+// ListerWatcher which delegates to lw and validates the listed and watched
+// objects with v. Invalid objects which are dropped are removed from lists
+// and are not delivered by watches. An update which makes an object invalid
+// is delivered as its deletion.
+func NewValidatingListerWatcher(lw cache.ListerWatcher, v *IngestValidator) cache.ListerWatcher {
+	if v == nil {
+		return lw
+	}
+	return &validatingListerWatcher{ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw), validator: v}
+}
+
+type validatingListerWatcher struct {
+	cache.ListerWatcherWithContext
+	validator *IngestValidator
+}
+
+func (lw *validatingListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *validatingListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *validatingListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	list, err := lw.ListerWatcherWithContext.ListWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	if options.Continue == "" {
+		// A new list replaces the objects recorded by earlier lists.
+		lw.validator.lock.Lock()
+		lw.validator.invalid = map[string]error{}
+		lw.validator.lock.Unlock()
+	}
+	valid := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		if keep, _ := lw.validator.check(item); keep {
+			valid = append(valid, item)
+		}
+	}
+	if len(valid) == len(items) {
+		return list, nil
+	}
+	if err := meta.SetList(list, valid); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (lw *validatingListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	w, err := lw.ListerWatcherWithContext.WatchWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	vw := &validatingWatch{Interface: w, validator: lw.validator, result: make(chan watch.Event), stopped: make(chan struct{})}
+	go vw.run()
+	return vw, nil
+}
+
+// IsWatchListSemanticsUnSupported returns true, as streaming lists would not
+// replace the recorded objects like lists do.
+func (lw *validatingListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+// validatingWatch forwards the events of a watch which pass its validator.
+type validatingWatch struct {
+	watch.Interface
+	validator *IngestValidator
+	result    chan watch.Event
+
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *validatingWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *validatingWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		w.Interface.Stop()
+	})
+}
+
+func (w *validatingWatch) run() {
+	defer close(w.result)
+	for {
+		var event watch.Event
+		select {
+		case <-w.stopped:
+			return
+		case e, ok := <-w.Interface.ResultChan():
+			if !ok {
+				return
+			}
+			event = e
+		}
+		switch event.Type {
+		case watch.Added, watch.Modified:
+			keep, wasDropped := w.validator.check(event.Object)
+			if !keep {
+				if wasDropped || event.Type == watch.Added {
+					continue
+				}
+				// The valid previous version is removed from the cache.
+				event.Type = watch.Deleted
+			}
+		case watch.Deleted:
+			if w.validator.forget(event.Object) {
+				continue
+			}
+		}
+		select {
+		case w.result <- event:
+		case <-w.stopped:
+			return
+		}
+	}
+}
+
+// NewMultiNamespaceListerWatcher returns a ListerWatcher which lists and watches
+// each namespace of selectors through the ListerWatcher returned by newLW for
+// it, and presents them as one. newLW must set the label selector to the one
+// passed to it, unless that is nil.
+//
+// A list follows the continue tokens of each namespace, and is given the
+// smallest resource version of the lists of the namespaces. The watch of each
+// namespace starts from the resource version of its list, and resumes from the
+// last event which was delivered for it. A namespace which was not listed,
+// for example because the informer started from a cache snapshot, is watched
+// from the resource version passed to the watch. When the watch of one
+// namespace ends, the watches of all of them end and are resumed, without a
+// relist. An error of the watch of one namespace, such as an expired resource
+// version, makes the informer relist every namespace, which costs one list
+// per namespace.
+func NewMultiNamespaceListerWatcher(selectors map[string]labels.Selector, newLW func(namespace string, selector labels.Selector) cache.ListerWatcher) cache.ListerWatcher {
+	lw := &multiNamespaceListerWatcher{}
+	for namespace, selector := range selectors {
+		lw.lws = append(lw.lws, cache.ToListerWatcherWithContext(newLW(namespace, selector)))
+	}
+	lw.resourceVersions = make([]string, len(lw.lws))
+	return lw
+}
+
+type multiNamespaceListerWatcher struct {
+	lws []cache.ListerWatcherWithContext
+
+	// lock guards resourceVersions, the resource version to resume the watch
+	// of each namespace of lws from. It is empty for a namespace which was
+	// neither listed nor watched from a resource version.
+	lock             sync.Mutex
+	resourceVersions []string
+}
+
+func (lw *multiNamespaceListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *multiNamespaceListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *multiNamespaceListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	var list runtime.Object
+	var items []runtime.Object
+	var resourceVersion string
+	var minVersion uint64
+	resourceVersions := make([]string, len(lw.lws))
+	for i, nsLW := range lw.lws {
+		nsOptions := options
+		nsOptions.Continue = ""
+		for {
+			nsList, err := nsLW.ListWithContext(ctx, nsOptions)
+			if err != nil {
+				return nil, err
+			}
+			nsItems, err := meta.ExtractList(nsList)
+			if err != nil {
+				return nil, err
+			}
+			listMeta, err := meta.ListAccessor(nsList)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, nsItems...)
+			// The pages of a list share the resource version of its first page.
+			if nsOptions.Continue == "" {
+				resourceVersions[i] = listMeta.GetResourceVersion()
+				if list == nil {
+					list, resourceVersion = nsList, listMeta.GetResourceVersion()
+				}
+				if version, err := strconv.ParseUint(listMeta.GetResourceVersion(), 10, 64); err == nil && (minVersion == 0 || version < minVersion) {
+					minVersion, resourceVersion = version, listMeta.GetResourceVersion()
+				}
+			}
+			if listMeta.GetContinue() == "" {
+				break
+			}
+			nsOptions.Continue = listMeta.GetContinue()
+			nsOptions.ResourceVersion = ""
+			nsOptions.ResourceVersionMatch = ""
+		}
+	}
+	if list == nil {
+		return nil, errors.New("no namespace to list")
+	}
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, err
+	}
+	listMeta.SetResourceVersion(resourceVersion)
+	listMeta.SetContinue("")
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	lw.resourceVersions = resourceVersions
+	return list, nil
+}
+
+func (lw *multiNamespaceListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	mw := &multiNamespaceWatch{lw: lw, result: make(chan watch.Event), stopped: make(chan struct{})}
+	var tracked []bool
+	for i, nsLW := range lw.lws {
+		nsOptions := options
+		if resourceVersion := lw.resourceVersion(i); resourceVersion != "" {
+			nsOptions.ResourceVersion = resourceVersion
+		}
+		w, err := nsLW.WatchWithContext(ctx, nsOptions)
+		if err != nil {
+			mw.Stop()
+			return nil, err
+		}
+		mw.watches = append(mw.watches, w)
+		// A watch from no resource version starts with synthetic events in
+		// no particular order, so it cannot be resumed from them.
+		tracked = append(tracked, nsOptions.ResourceVersion != "" && nsOptions.ResourceVersion != "0")
+	}
+	mw.pending = len(mw.watches)
+	for i, w := range mw.watches {
+		go mw.forward(i, w, tracked[i])
+	}
+	return mw, nil
+}
+
+// IsWatchListSemanticsUnSupported returns true, as streaming lists of several
+// namespaces cannot be merged.
+func (lw *multiNamespaceListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
+
+// resourceVersion returns the resource version to resume the watch of the
+// namespace of lws[i] from.
+func (lw *multiNamespaceListerWatcher) resourceVersion(i int) string {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	return lw.resourceVersions[i]
+}
+
+// observe records the resource version of event, which was delivered for
+// the namespace of lws[i].
+func (lw *multiNamespaceListerWatcher) observe(i int, event watch.Event) {
+	if event.Type == watch.Error {
+		return
+	}
+	accessor, err := meta.Accessor(event.Object)
+	if err != nil || accessor.GetResourceVersion() == "" {
+		return
+	}
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	lw.resourceVersions[i] = accessor.GetResourceVersion()
+}
+
+// multiNamespaceWatch delivers the events of the watches of several namespaces
+// until one of them ends.
+type multiNamespaceWatch struct {
+	lw      *multiNamespaceListerWatcher
+	watches []watch.Interface
+	result  chan watch.Event
+
+	// lock guards pending, the number of forward calls which did not return.
+	lock    sync.Mutex
+	pending int
+
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *multiNamespaceWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *multiNamespaceWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		for _, nsW := range w.watches {
+			nsW.Stop()
+		}
+	})
+}
+
+// forward delivers the events of nsW, the watch of the namespace of
+// w.lw.lws[i], and records their resource versions if tracked is set. The
+// last forward call to return closes the result channel.
+func (w *multiNamespaceWatch) forward(i int, nsW watch.Interface, tracked bool) {
+	defer func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.pending--
+		if w.pending == 0 {
+			close(w.result)
+		}
+	}()
+	for {
+		select {
+		case <-w.stopped:
+			return
+		case event, ok := <-nsW.ResultChan():
+			if !ok {
+				// The reflector resumes the watches of all namespaces.
+				w.Stop()
+				return
+			}
+			// A bookmark of one namespace says nothing about the others.
+			if event.Type == watch.Bookmark {
+				if tracked {
+					w.lw.observe(i, event)
+				}
+				continue
+			}
+			select {
+			case w.result <- event:
+				if tracked {
+					w.lw.observe(i, event)
+				}
+			case <-w.stopped:
+				return
+			}
+		}
+	}
+}
+
+// CacheBackend creates the indexers backing the caches of informers, for
+// example to store them on disk.
+type CacheBackend interface {
+	// NewIndexer returns an empty indexer with indexers, which keys objects
+	// with cache.MetaNamespaceKeyFunc.
+	NewIndexer(indexers cache.Indexers) cache.Indexer
+}
+
+// SizedCacheBackend is a CacheBackend which can pre-size its indexers for the
+// expected number of objects.
+type SizedCacheBackend interface {
+	CacheBackend
+	// NewIndexerWithCapacity is like NewIndexer, but the returned indexer is
+	// sized for capacity objects.
+	NewIndexerWithCapacity(indexers cache.Indexers, capacity int) cache.Indexer
+}
+
+// NewCacheBackendInformer returns informer if backend is nil. Otherwise it
+// returns an informer whose store and indexer are an indexer of backend with
+// indexers, which an event handler of informer keeps up to date. client-go
+// keeps the cache of informer in memory regardless, to compute the
+// notifications of its handlers, so the indexer of backend mirrors it. The
+// returned informer has synced once the indexer of backend has. If capacity
+// is positive and backend is a SizedCacheBackend, its indexer is sized for
+// capacity objects.
+func NewCacheBackendInformer(informer cache.SharedIndexInformer, backend CacheBackend, indexers cache.Indexers, capacity int) cache.SharedIndexInformer {
+	if backend == nil {
+		return informer
+	}
+	if sized, ok := backend.(SizedCacheBackend); ok && capacity > 0 {
+		return newMirroringInformer(informer, sized.NewIndexerWithCapacity(indexers, capacity))
+	}
+	return newMirroringInformer(informer, backend.NewIndexer(indexers))
+}
+
+// NewKeyNormalizingInformer returns informer if normalize is nil. Otherwise
+// it returns an informer whose store and indexer key objects by their
+// namespace and their name as returned by normalize, which an event handler
+// of informer keeps up to date like NewCacheBackendInformer. The keys passed
+// to GetByKey are normalized, too, so listers find objects by any of their
+// equivalent names. Objects whose names normalize to the same name share a
+// single entry: the last added or updated one is cached, and deleting any of
+// them removes the entry, even if others still exist on the server, until
+// they are updated or the informer relists.
+func NewKeyNormalizingInformer(informer cache.SharedIndexInformer, normalize func(name string) string, indexers cache.Indexers) cache.SharedIndexInformer {
+	if normalize == nil {
+		return informer
+	}
+	normalizeKey := func(key string) string {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return key
+		}
+		if namespace == "" {
+			return normalize(name)
+		}
+		return namespace + "/" + normalize(name)
+	}
+	indexer := cache.NewIndexer(func(obj interface{}) (string, error) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return "", err
+		}
+		return normalizeKey(key), nil
+	}, indexers)
+	return newMirroringInformer(informer, &keyNormalizingIndexer{Indexer: indexer, normalizeKey: normalizeKey})
+}
+
+// keyNormalizingIndexer normalizes the keys passed to GetByKey.
+type keyNormalizingIndexer struct {
+	cache.Indexer
+	normalizeKey func(key string) string
+}
+
+func (i *keyNormalizingIndexer) GetByKey(key string) (interface{}, bool, error) {
+	return i.Indexer.GetByKey(i.normalizeKey(key))
+}
+
+// newMirroringInformer returns an informer whose store and indexer are
+// indexer, which an event handler of informer keeps up to date. It has synced
+// once indexer has.
+func newMirroringInformer(informer cache.SharedIndexInformer, indexer cache.Indexer) cache.SharedIndexInformer {
+	registration, err := informer.AddEventHandler(&mirroringHandler{indexer: indexer})
+	if err != nil {
+		utilruntime.HandleError(err)
+		return informer
+	}
+	return &mirroringInformer{SharedIndexInformer: informer, indexer: indexer, registration: registration}
+}
+
+type mirroringInformer struct {
+	cache.SharedIndexInformer
+	indexer      cache.Indexer
+	registration cache.ResourceEventHandlerRegistration
+}
+
+func (i *mirroringInformer) GetStore() cache.Store {
+	return i.indexer
+}
+
+func (i *mirroringInformer) GetIndexer() cache.Indexer {
+	return i.indexer
+}
+
+func (i *mirroringInformer) AddIndexers(indexers cache.Indexers) error {
+	return i.indexer.AddIndexers(indexers)
+}
+
+func (i *mirroringInformer) HasSynced() bool {
+	return i.registration.HasSynced()
+}
+
+func (i *mirroringInformer) HasSyncedChecker() cache.DoneChecker {
+	return i.registration.HasSyncedChecker()
+}
+
+// mirroringHandler applies the notifications of an informer to indexer.
+type mirroringHandler struct {
+	indexer cache.Indexer
+}
+
+func (h *mirroringHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	if err := h.indexer.Add(obj); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+func (h *mirroringHandler) OnUpdate(oldObj, newObj interface{}) {
+	if err := h.indexer.Update(newObj); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+func (h *mirroringHandler) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if err := h.indexer.Delete(obj); err != nil {
+		utilruntime.HandleError(err)
+	}
+}
+
+// LeadershipGate tracks whether leadership is held, so that informers only
+// list and watch while it is.
+type LeadershipGate struct {
+	lock sync.Mutex
+	// acquired is closed while leadership is held, and lost while it is not.
+	acquired chan struct{}
+	lost     chan struct{}
+}
+
+// NewLeadershipGate returns a LeadershipGate which does not hold leadership.
+func NewLeadershipGate() *LeadershipGate {
+	lost := make(chan struct{})
+	close(lost)
+	return &LeadershipGate{acquired: make(chan struct{}), lost: lost}
+}
+
+// Run records the acquisitions of leadership received from acquired and its
+// losses received from lost until ctx is done.
+func (g *LeadershipGate) Run(ctx context.Context, acquired, lost <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-acquired:
+			g.set(true)
+		case <-lost:
+			g.set(false)
+		}
+	}
+}
+
+// Leading returns whether leadership is held.
+func (g *LeadershipGate) Leading() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.leading()
+}
+
+// leading returns whether leadership is held. g.lock must be held.
+func (g *LeadershipGate) leading() bool {
+	select {
+	case <-g.acquired:
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *LeadershipGate) set(leading bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	switch {
+	case leading == g.leading():
+	case leading:
+		close(g.acquired)
+		g.lost = make(chan struct{})
+	default:
+		close(g.lost)
+		g.acquired = make(chan struct{})
+	}
+}
+
+// wait waits until leadership is held and returns the channel which is
+// closed when it is lost, or the cause of ctx if ctx is done first.
+func (g *LeadershipGate) wait(ctx context.Context) (<-chan struct{}, error) {
+	for {
+		g.lock.Lock()
+		acquired, lost, leading := g.acquired, g.lost, g.leading()
+		g.lock.Unlock()
+		if leading {
+			return lost, nil
+		}
+		select {
+		case <-acquired:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
+
+// NewLeadershipGatedListerWatcher returns lw if gate is nil. Otherwise it
+// returns a ListerWatcher which delegates to lw, whose lists and watches wait
+// until gate holds leadership, and whose watches are stopped when it is
+// lost. The reflector of an informer then waits for leadership to be
+// acquired again to resume watching, so its cache is kept but not updated
+// while leadership is not held.
+func NewLeadershipGatedListerWatcher(lw cache.ListerWatcher, gate *LeadershipGate) cache.ListerWatcher {
+	if gate == nil {
+		return lw
+	}
+	return &leadershipGatedListerWatcher{ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw), lw: lw, gate: gate}
+}
+
+type leadershipGatedListerWatcher struct {
+	cache.ListerWatcherWithContext
+	lw   cache.ListerWatcher
+	gate *LeadershipGate
+}
+
+func (lw *leadershipGatedListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *leadershipGatedListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *leadershipGatedListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	if _, err := lw.gate.wait(ctx); err != nil {
+		return nil, err
+	}
+	return lw.ListerWatcherWithContext.ListWithContext(ctx, options)
+}
+
+func (lw *leadershipGatedListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	lost, err := lw.gate.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := lw.ListerWatcherWithContext.WatchWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	gw := &leadershipGatedWatch{Interface: w, stopped: make(chan struct{})}
+	go func() {
+		select {
+		case <-lost:
+			w.Stop()
+		case <-gw.stopped:
+		}
+	}()
+	return gw, nil
+}
+
+// IsWatchListSemanticsUnSupported forwards to the wrapped ListerWatcher.
+func (lw *leadershipGatedListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	unsupported, ok := lw.lw.(interface{ IsWatchListSemanticsUnSupported() bool })
+	return ok && unsupported.IsWatchListSemanticsUnSupported()
+}
+
+// leadershipGatedWatch is a watch which is stopped when leadership is lost.
+type leadershipGatedWatch struct {
+	watch.Interface
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *leadershipGatedWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+		w.Interface.Stop()
+	})
+}
+
+// NewRotatingListerWatcher returns lw if interval is not positive. Otherwise
+// it returns a ListerWatcher which delegates to lw, and whose watches are
+// stopped once they have been open for interval. The reflector of an informer
+// then watches again from the last resource version it observed, so its cache
+// is kept; it relists first if the watch was stopped within a second without
+// delivering any event, which client-go considers a failure, or if the
+// resource version is too old.
+func NewRotatingListerWatcher(lw cache.ListerWatcher, interval time.Duration) cache.ListerWatcher {
+	if interval <= 0 {
+		return lw
+	}
+	return &rotatingListerWatcher{ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw), lw: lw, interval: interval}
+}
+
+type rotatingListerWatcher struct {
+	cache.ListerWatcherWithContext
+	lw       cache.ListerWatcher
+	interval time.Duration
+}
+
+func (lw *rotatingListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(context.Background(), options)
+}
+
+func (lw *rotatingListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(context.Background(), options)
+}
+
+func (lw *rotatingListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	w, err := lw.ListerWatcherWithContext.WatchWithContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return &rotatingWatch{Interface: w, timer: time.AfterFunc(lw.interval, w.Stop)}, nil
+}
+
+// IsWatchListSemanticsUnSupported forwards to the wrapped ListerWatcher.
+func (lw *rotatingListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	unsupported, ok := lw.lw.(interface{ IsWatchListSemanticsUnSupported() bool })
+	return ok && unsupported.IsWatchListSemanticsUnSupported()
+}
+
+// rotatingWatch is a watch which is stopped by timer.
+type rotatingWatch struct {
+	watch.Interface
+	timer *time.Timer
+}
+
+func (w *rotatingWatch) Stop() {
+	w.timer.Stop()
+	w.Interface.Stop()
+}
+
+// Startable is the lifecycle of a SharedInformerFactory. It only refers to
+// types of the standard library and client-go, so the factories generated for
+// any API implement the Startable of every generated informers package, and
+// can be driven together by a CompositeFactory.
+type Startable interface {
+	StartWithContext(ctx context.Context)
+	WaitForCacheSyncWithContext(ctx context.Context) cache.SyncResult
+	Shutdown()
+}
+
+// NewContextBoundListerWatcher returns lw if ctx is nil or never done.
+// Otherwise it returns a ListerWatcher which delegates to lw, and whose lists
+// and watches are canceled once ctx is done, with its cause. Lists and
+// watches called without a context get ctx.
+func NewContextBoundListerWatcher(lw cache.ListerWatcher, ctx context.Context) cache.ListerWatcher {
+	if ctx == nil || ctx.Done() == nil {
+		return lw
+	}
+	return &contextBoundListerWatcher{ListerWatcherWithContext: cache.ToListerWatcherWithContext(lw), lw: lw, ctx: ctx}
+}
+
+type contextBoundListerWatcher struct {
+	cache.ListerWatcherWithContext
+	lw  cache.ListerWatcher
+	ctx context.Context
+}
+
+// bind returns a context which is canceled once ctx or lw.ctx is done, and
+// the function releasing it.
+func (lw *contextBoundListerWatcher) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	bound, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(lw.ctx, func() {
+		cancel(context.Cause(lw.ctx))
+	})
+	return bound, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+func (lw *contextBoundListerWatcher) List(options v1.ListOptions) (runtime.Object, error) {
+	return lw.ListWithContext(lw.ctx, options)
+}
+
+func (lw *contextBoundListerWatcher) Watch(options v1.ListOptions) (watch.Interface, error) {
+	return lw.WatchWithContext(lw.ctx, options)
+}
+
+func (lw *contextBoundListerWatcher) ListWithContext(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+	ctx, cancel := lw.bind(ctx)
+	defer cancel()
+	return lw.ListerWatcherWithContext.ListWithContext(ctx, options)
+}
+
+func (lw *contextBoundListerWatcher) WatchWithContext(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+	ctx, cancel := lw.bind(ctx)
+	w, err := lw.ListerWatcherWithContext.WatchWithContext(ctx, options)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &contextBoundWatch{Interface: w, cancel: cancel}, nil
+}
+
+// IsWatchListSemanticsUnSupported forwards to the wrapped ListerWatcher.
+func (lw *contextBoundListerWatcher) IsWatchListSemanticsUnSupported() bool {
+	unsupported, ok := lw.lw.(interface{ IsWatchListSemanticsUnSupported() bool })
+	return ok && unsupported.IsWatchListSemanticsUnSupported()
+}
+
+// contextBoundWatch is a watch which releases the context bound to it once
+// it is stopped.
+type contextBoundWatch struct {
+	watch.Interface
+	cancel context.CancelFunc
+}
+
+func (w *contextBoundWatch) Stop() {
+	w.Interface.Stop()
+	w.cancel()
 }
@@ -19,7 +19,12 @@ limitations under the License.
 package v1
 
 import (
+	context "context"
+
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	listers "k8s.io/client-go/listers"
 	cache "k8s.io/client-go/tools/cache"
 	examplev1 "k8s.io/code-generator/examples/apiserver/apis/example/v1"
@@ -31,6 +36,20 @@ type TestTypeLister interface {
 	// List lists all TestTypes in the indexer.
 	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*examplev1.TestType, err error)
+	// ListChan sends all TestTypes in the indexer matching selector on the returned channel,
+	// which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.TestType
+	// GetByKeys retrieves the TestTypes with the given indexer keys, which are
+	// of the form namespace/name. It returns the TestTypes which were found in
+	// the order of keys, and the keys which were not.
+	// Objects returned here must be treated as read-only.
+	GetByKeys(keys []string) (found []*examplev1.TestType, missing []string, err error)
+	// OwnerTestType retrieves the TestType which owns obj according to the owner
+	// references of obj, from the namespace of obj. It returns a NotFound error if obj
+	// has no owner reference to a TestType in the indexer.
+	// Objects returned here must be treated as read-only.
+	OwnerTestType(obj metav1.Object) (*examplev1.TestType, error)
 	// TestTypes returns an object that can list and get TestTypes.
 	TestTypes(namespace string) TestTypeNamespaceLister
 	TestTypeListerExpansion
@@ -39,11 +58,85 @@ type TestTypeLister interface {
 // testTypeLister implements the TestTypeLister interface.
 type testTypeLister struct {
 	listers.ResourceIndexer[*examplev1.TestType]
+	indexer cache.Indexer
 }
 
 // NewTestTypeLister returns a new TestTypeLister.
 func NewTestTypeLister(indexer cache.Indexer) TestTypeLister {
-	return &testTypeLister{listers.New[*examplev1.TestType](indexer, examplev1.Resource("testtype"))}
+	return &testTypeLister{listers.New[*examplev1.TestType](indexer, examplev1.Resource("testtype")), indexer}
+}
+
+// ListChan sends all TestTypes in the indexer matching selector on the returned channel.
+func (s *testTypeLister) ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.TestType {
+	return listChan(ctx, selector, s.List)
+}
+
+// GetByKeys retrieves the TestTypes with the given indexer keys, and returns the keys which were not found.
+func (s *testTypeLister) GetByKeys(keys []string) (found []*examplev1.TestType, missing []string, err error) {
+	for _, key := range keys {
+		obj, exists, err := s.indexer.GetByKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			missing = append(missing, key)
+			continue
+		}
+		found = append(found, obj.(*examplev1.TestType))
+	}
+	return found, missing, nil
+}
+
+// NewTestTypeListerWithSelectorCache returns a TestTypeLister which memoizes
+// the results of List per selector. Memoized results are invalidated whenever
+// informer delivers a change notification, so they may lag behind the indexer
+// by as long as the informer takes to notify its event handlers.
+func NewTestTypeListerWithSelectorCache(informer cache.SharedIndexInformer) (TestTypeLister, error) {
+	memo, err := newSelectorCache[*examplev1.TestType](informer)
+	if err != nil {
+		return nil, err
+	}
+	lister := &testTypeLister{listers.New[*examplev1.TestType](informer.GetIndexer(), examplev1.Resource("testtype")), informer.GetIndexer()}
+	return &testTypeCachingLister{testTypeLister: lister, cache: memo}, nil
+}
+
+// testTypeCachingLister implements the TestTypeLister interface
+// with memoized List results.
+type testTypeCachingLister struct {
+	*testTypeLister
+	cache *selectorCache[*examplev1.TestType]
+}
+
+// List lists all TestTypes in the indexer, reusing the memoized result for selector if possible.
+func (s *testTypeCachingLister) List(selector labels.Selector) ([]*examplev1.TestType, error) {
+	return s.cache.list("", selector, s.testTypeLister.List)
+}
+
+// OwnerTestType retrieves the TestType which owns obj. Owner references match if
+// their kind is TestType, their API version is of the group of TestTypes and
+// their UID is the one of the cached TestType. Any version of the group matches,
+// because the cache holds the same object whichever version it was read in.
+func (s *testTypeLister) OwnerTestType(obj metav1.Object) (*examplev1.TestType, error) {
+	resource := examplev1.Resource("testtype")
+	name := ""
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != resource.Group || ref.Kind != "TestType" {
+			continue
+		}
+		name = ref.Name
+		owner, err := s.TestTypes(obj.GetNamespace()).Get(ref.Name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if owner.UID == ref.UID {
+			return owner, nil
+		}
+	}
+	return nil, errors.NewNotFound(resource, name)
 }
 
 // TestTypes returns an object that can list and get TestTypes.
@@ -51,12 +144,25 @@ func (s *testTypeLister) TestTypes(namespace string) TestTypeNamespaceLister {
 	return testTypeNamespaceLister{listers.NewNamespaced[*examplev1.TestType](s.ResourceIndexer, namespace)}
 }
 
+// TestTypes returns an object that can list and get TestTypes, reusing memoized List results.
+func (s *testTypeCachingLister) TestTypes(namespace string) TestTypeNamespaceLister {
+	return testTypeCachingNamespaceLister{
+		testTypeNamespaceLister: testTypeNamespaceLister{listers.NewNamespaced[*examplev1.TestType](s.ResourceIndexer, namespace)},
+		namespace:               namespace,
+		cache:                   s.cache,
+	}
+}
+
 // TestTypeNamespaceLister helps list and get TestTypes.
 // All objects returned here must be treated as read-only.
 type TestTypeNamespaceLister interface {
 	// List lists all TestTypes in the indexer for a given namespace.
 	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*examplev1.TestType, err error)
+	// ListChan sends all TestTypes in the indexer for a given namespace matching selector
+	// on the returned channel, which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.TestType
 	// Get retrieves the TestType from the indexer for a given namespace and name.
 	// Objects returned here must be treated as read-only.
 	Get(name string) (*examplev1.TestType, error)
@@ -68,3 +174,21 @@ type TestTypeNamespaceLister interface {
 type testTypeNamespaceLister struct {
 	listers.ResourceIndexer[*examplev1.TestType]
 }
+
+// ListChan sends all TestTypes in the indexer for the namespace matching selector on the returned channel.
+func (s testTypeNamespaceLister) ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.TestType {
+	return listChan(ctx, selector, s.List)
+}
+
+// testTypeCachingNamespaceLister implements the TestTypeNamespaceLister
+// interface with memoized List results.
+type testTypeCachingNamespaceLister struct {
+	testTypeNamespaceLister
+	namespace string
+	cache     *selectorCache[*examplev1.TestType]
+}
+
+// List lists all TestTypes in the indexer for the namespace, reusing the memoized result for selector if possible.
+func (s testTypeCachingNamespaceLister) List(selector labels.Selector) ([]*examplev1.TestType, error) {
+	return s.cache.list(s.namespace, selector, s.testTypeNamespaceLister.List)
+}
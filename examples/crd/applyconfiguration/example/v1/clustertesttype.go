@@ -20,6 +20,7 @@ package v1
 
 import (
 	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
 	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
@@ -185,6 +186,21 @@ func (b *ClusterTestTypeApplyConfiguration) WithOwnerReferences(values ...*metav
 	return b
 }
 
+// WithControllerReference adds an owner reference to the given owner, whose group, version and kind
+// are given by gvk, to the OwnerReferences field in the declarative configuration and returns the
+// receiver, so that objects can be build by chaining "With" function invocations. Like
+// metav1.NewControllerRef, the reference marks the owner as the managing controller and blocks
+// the owner's foreground deletion until this object is deleted.
+func (b *ClusterTestTypeApplyConfiguration) WithControllerReference(owner apismetav1.Object, gvk schema.GroupVersionKind) *ClusterTestTypeApplyConfiguration {
+	return b.WithOwnerReferences(metav1.OwnerReference().
+		WithAPIVersion(gvk.GroupVersion().String()).
+		WithKind(gvk.Kind).
+		WithName(owner.GetName()).
+		WithUID(owner.GetUID()).
+		WithController(true).
+		WithBlockOwnerDeletion(true))
+}
+
 // WithFinalizers adds the given value to the Finalizers field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Finalizers field.
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	examplev1 "k8s.io/code-generator/examples/crd/apis/example/v1"
+)
+
+func TestWithControllerReference(t *testing.T) {
+	owner := &examplev1.ClusterTestType{
+		ObjectMeta: metav1.ObjectMeta{Name: "owner", UID: "owner-uid"},
+	}
+	gvk := examplev1.SchemeGroupVersion.WithKind("ClusterTestType")
+
+	got := TestType("child", "ns").
+		WithOwnerReferences(applymetav1.OwnerReference().WithName("other")).
+		WithControllerReference(owner, gvk)
+
+	want := []applymetav1.OwnerReferenceApplyConfiguration{
+		*applymetav1.OwnerReference().WithName("other"),
+		{
+			APIVersion:         ptrTo("example.crd.code-generator.k8s.io/v1"),
+			Kind:               ptrTo("ClusterTestType"),
+			Name:               ptrTo("owner"),
+			UID:                ptrTo(types.UID("owner-uid")),
+			Controller:         ptrTo(true),
+			BlockOwnerDeletion: ptrTo(true),
+		},
+	}
+	if !reflect.DeepEqual(want, got.OwnerReferences) {
+		t.Errorf("expected owner references %+v, got %+v", want, got.OwnerReferences)
+	}
+	if got.GetName() == nil || *got.GetName() != "child" {
+		t.Errorf("expected the name to be preserved, got %v", got.GetName())
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
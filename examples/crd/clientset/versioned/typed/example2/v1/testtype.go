@@ -20,6 +20,7 @@ package v1
 
 import (
 	context "context"
+	json "encoding/json"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -51,6 +52,8 @@ type TestTypeInterface interface {
 	Apply(ctx context.Context, testType *applyconfigurationexample2v1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *example2v1.TestType, err error)
 	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, testType *applyconfigurationexample2v1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *example2v1.TestType, err error)
+	MergePatchTestType(ctx context.Context, name string, patch *example2v1.TestType, opts metav1.PatchOptions) (*example2v1.TestType, error)
+	StrategicMergePatchTestType(ctx context.Context, name string, patch *example2v1.TestType, opts metav1.PatchOptions) (*example2v1.TestType, error)
 	TestTypeExpansion
 }
 
@@ -72,3 +75,23 @@ func newTestTypes(c *SecondExampleV1Client, namespace string) *testTypes {
 		),
 	}
 }
+
+// MergePatchTestType marshals patch to JSON and applies it to the named testType as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *testTypes) MergePatchTestType(ctx context.Context, name string, patch *example2v1.TestType, opts metav1.PatchOptions) (*example2v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and applies it to the named testType as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatchTestType for them instead.
+func (c *testTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *example2v1.TestType, opts metav1.PatchOptions) (*example2v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
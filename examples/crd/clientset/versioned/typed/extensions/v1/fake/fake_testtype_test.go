@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+	extensionsv1 "k8s.io/code-generator/examples/crd/apis/extensions/v1"
+	applyextensionsv1 "k8s.io/code-generator/examples/crd/applyconfiguration/extensions/v1"
+	"k8s.io/code-generator/examples/crd/clientset/versioned/fake"
+)
+
+// TestApplyStatus verifies that ApplyStatus sends an apply patch of the
+// status for the status subresource.
+func TestApplyStatus(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var gotAction clienttesting.PatchAction
+	client.PrependReactor("patch", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		gotAction = action.(clienttesting.PatchAction)
+		obj := &extensionsv1.TestType{}
+		if err := json.Unmarshal(gotAction.GetPatch(), obj); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	})
+
+	applyConfig := applyextensionsv1.TestType("foo", "ns").WithStatus(applyextensionsv1.TestTypeStatus().WithBlah("applied"))
+	result, err := client.ExtensionsExampleV1().TestTypes("ns").ApplyStatus(context.Background(), applyConfig, metav1.ApplyOptions{FieldManager: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "foo" || result.Status.Blah != "applied" {
+		t.Errorf("result: got %s with status %q, want foo with status %q", result.Name, result.Status.Blah, "applied")
+	}
+	if gotAction == nil {
+		t.Fatal("expected a patch action")
+	}
+	if got := gotAction.GetSubresource(); got != "status" {
+		t.Errorf("subresource: got %q, want %q", got, "status")
+	}
+	if got := gotAction.GetPatchType(); got != types.ApplyPatchType {
+		t.Errorf("patch type: got %q, want %q", got, types.ApplyPatchType)
+	}
+}
+
+// TestApplySubresource verifies that the apply method of a
+// +genclient:method tag sends an apply patch for its subresource.
+func TestApplySubresource(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var gotAction clienttesting.PatchAction
+	client.PrependReactor("patch", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		gotAction = action.(clienttesting.PatchAction)
+		return true, &extensionsv1.TestSubresource{Name: "applied"}, nil
+	})
+
+	result, err := client.ExtensionsExampleV1().TestTypes("ns").ApplySubresource(context.Background(), "foo", applyextensionsv1.TestSubresource().WithName("applied"), metav1.ApplyOptions{FieldManager: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "applied" {
+		t.Errorf("result name: got %q, want %q", result.Name, "applied")
+	}
+	if gotAction == nil {
+		t.Fatal("expected a patch action")
+	}
+	if got := gotAction.GetSubresource(); got != "subresource" {
+		t.Errorf("subresource: got %q, want %q", got, "subresource")
+	}
+	if got := gotAction.GetPatchType(); got != types.ApplyPatchType {
+		t.Errorf("patch type: got %q, want %q", got, types.ApplyPatchType)
+	}
+	applied := &extensionsv1.TestSubresource{}
+	if err := json.Unmarshal(gotAction.GetPatch(), applied); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied.Name != "applied" {
+		t.Errorf("patch data: got name %q, want %q", applied.Name, "applied")
+	}
+}
@@ -20,6 +20,7 @@ package v1
 
 import (
 	context "context"
+	json "encoding/json"
 	fmt "fmt"
 	time "time"
 
@@ -54,6 +55,8 @@ type TestTypeInterface interface {
 	Apply(ctx context.Context, testType *applyconfigurationextensionsv1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *extensionsv1.TestType, err error)
 	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, testType *applyconfigurationextensionsv1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *extensionsv1.TestType, err error)
+	MergePatchTestType(ctx context.Context, name string, patch *extensionsv1.TestType, opts metav1.PatchOptions) (*extensionsv1.TestType, error)
+	StrategicMergePatchTestType(ctx context.Context, name string, patch *extensionsv1.TestType, opts metav1.PatchOptions) (*extensionsv1.TestType, error)
 	GetExtended(ctx context.Context, name string, opts metav1.GetOptions) (*extensionsv1.TestType, error)
 	ListExtended(ctx context.Context, opts metav1.ListOptions) (*extensionsv1.TestTypeList, error)
 	CreateExtended(ctx context.Context, testType *extensionsv1.TestType, opts metav1.CreateOptions) (*extensionsv1.TestType, error)
@@ -87,6 +90,26 @@ func newTestTypes(c *ExtensionsExampleV1Client, namespace string) *testTypes {
 	}
 }
 
+// MergePatchTestType marshals patch to JSON and applies it to the named testType as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *testTypes) MergePatchTestType(ctx context.Context, name string, patch *extensionsv1.TestType, opts metav1.PatchOptions) (*extensionsv1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and applies it to the named testType as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatchTestType for them instead.
+func (c *testTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *extensionsv1.TestType, opts metav1.PatchOptions) (*extensionsv1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
 // GetExtended takes name of the testType, and returns the corresponding testType object, and an error if there is any.
 func (c *testTypes) GetExtended(ctx context.Context, name string, options metav1.GetOptions) (result *extensionsv1.TestType, err error) {
 	result = &extensionsv1.TestType{}
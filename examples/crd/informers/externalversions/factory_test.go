@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	examplev1 "k8s.io/code-generator/examples/crd/apis/example/v1"
+	example2v1 "k8s.io/code-generator/examples/crd/apis/example2/v1"
+	"k8s.io/code-generator/examples/crd/clientset/versioned/fake"
+)
+
+// TestNoResync verifies that informers of types tagged +genclient:noResync
+// are not resynced, even if the factory has a resync period.
+func TestNoResync(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&examplev1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&example2v1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+	)
+	factory := NewSharedInformerFactory(client, time.Second)
+
+	countResyncs := func(informer cache.SharedIndexInformer) *atomic.Int32 {
+		var resyncs atomic.Int32
+		if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if oldObj == newObj {
+					resyncs.Add(1)
+				}
+			},
+		}); err != nil {
+			t.Fatalf("failed to add handler: %v", err)
+		}
+		return &resyncs
+	}
+	resynced := countResyncs(factory.Example().V1().TestTypes().Informer())
+	notResynced := countResyncs(factory.SecondExample().V1().TestTypes().Informer())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return resynced.Load() > 1, nil
+	})
+	if err != nil {
+		t.Fatalf("the informer without +genclient:noResync was not resynced: %v", err)
+	}
+	if got := notResynced.Load(); got != 0 {
+		t.Errorf("expected no resyncs of the informer with +genclient:noResync, got %d", got)
+	}
+}
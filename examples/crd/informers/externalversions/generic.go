@@ -20,7 +20,9 @@ package externalversions
 
 import (
 	fmt "fmt"
+	reflect "reflect"
 
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1 "k8s.io/code-generator/examples/crd/apis/conflicting/v1"
@@ -77,3 +79,110 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
 }
+
+// resourceForType returns the resource served by the informers for informerType.
+func resourceForType(informerType reflect.Type) (schema.GroupVersionResource, bool) {
+	switch informerType {
+	// Group=conflicting.test.crd.code-generator.k8s.io, Version=v1
+	case reflect.TypeOf(&v1.TestType{}):
+		return v1.SchemeGroupVersion.WithResource("testtypes"), true
+
+		// Group=example.crd.code-generator.k8s.io, Version=v1
+	case reflect.TypeOf(&examplev1.ClusterTestType{}):
+		return examplev1.SchemeGroupVersion.WithResource("clustertesttypes"), true
+	case reflect.TypeOf(&examplev1.TestType{}):
+		return examplev1.SchemeGroupVersion.WithResource("testtypes"), true
+
+		// Group=example.test.crd.code-generator.k8s.io, Version=v1
+	case reflect.TypeOf(&example2v1.TestType{}):
+		return example2v1.SchemeGroupVersion.WithResource("testtypes"), true
+
+		// Group=extensions.test.crd.code-generator.k8s.io, Version=v1
+	case reflect.TypeOf(&extensionsv1.TestType{}):
+		return extensionsv1.SchemeGroupVersion.WithResource("testtypes"), true
+
+	}
+
+	return schema.GroupVersionResource{}, false
+}
+
+// isClusterScoped returns whether the objects of resource are not namespaced.
+func isClusterScoped(resource schema.GroupVersionResource) bool {
+	switch resource {
+	case examplev1.SchemeGroupVersion.WithResource("clustertesttypes"):
+		return true
+	}
+	return false
+}
+
+// newListForResource returns an empty list of the type which holds the objects
+// of resource.
+func newListForResource(resource schema.GroupVersionResource) (runtime.Object, bool) {
+	switch resource {
+	// Group=conflicting.test.crd.code-generator.k8s.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("testtypes"):
+		return &v1.TestTypeList{}, true
+
+		// Group=example.crd.code-generator.k8s.io, Version=v1
+	case examplev1.SchemeGroupVersion.WithResource("clustertesttypes"):
+		return &examplev1.ClusterTestTypeList{}, true
+	case examplev1.SchemeGroupVersion.WithResource("testtypes"):
+		return &examplev1.TestTypeList{}, true
+
+		// Group=example.test.crd.code-generator.k8s.io, Version=v1
+	case example2v1.SchemeGroupVersion.WithResource("testtypes"):
+		return &example2v1.TestTypeList{}, true
+
+		// Group=extensions.test.crd.code-generator.k8s.io, Version=v1
+	case extensionsv1.SchemeGroupVersion.WithResource("testtypes"):
+		return &extensionsv1.TestTypeList{}, true
+
+	}
+
+	return nil, false
+}
+
+// InformerConstructors holds, for every resource of the factory, the function
+// which returns the shared informer of the resource from a factory. The error
+// is the one of the informer create hook, if it vetoed the informer.
+var InformerConstructors = map[schema.GroupVersionResource]func(SharedInformerFactory) (cache.SharedIndexInformer, error){
+	// Group=conflicting.test.crd.code-generator.k8s.io, Version=v1
+	v1.SchemeGroupVersion.WithResource("testtypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.ConflictingExample().V1().TestTypes().Informer()
+		return informer, vetoError(f, &v1.TestType{})
+	},
+
+	// Group=example.crd.code-generator.k8s.io, Version=v1
+	examplev1.SchemeGroupVersion.WithResource("clustertesttypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.Example().V1().ClusterTestTypes().Informer()
+		return informer, vetoError(f, &examplev1.ClusterTestType{})
+	},
+	examplev1.SchemeGroupVersion.WithResource("testtypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.Example().V1().TestTypes().Informer()
+		return informer, vetoError(f, &examplev1.TestType{})
+	},
+
+	// Group=example.test.crd.code-generator.k8s.io, Version=v1
+	example2v1.SchemeGroupVersion.WithResource("testtypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.SecondExample().V1().TestTypes().Informer()
+		return informer, vetoError(f, &example2v1.TestType{})
+	},
+
+	// Group=extensions.test.crd.code-generator.k8s.io, Version=v1
+	extensionsv1.SchemeGroupVersion.WithResource("testtypes"): func(f SharedInformerFactory) (cache.SharedIndexInformer, error) {
+		informer := f.ExtensionsExample().V1().TestTypes().Informer()
+		return informer, vetoError(f, &extensionsv1.TestType{})
+	},
+}
+
+// vetoError returns the error of the informer create hook of f for the
+// informer of obj's type, if it vetoed the informer.
+func vetoError(f SharedInformerFactory, obj runtime.Object) error {
+	factory, ok := f.(*sharedInformerFactory)
+	if !ok {
+		return nil
+	}
+	factory.lock.Lock()
+	defer factory.lock.Unlock()
+	return factory.vetoedInformers[reflect.TypeOf(obj)]
+}
@@ -19,7 +19,12 @@ limitations under the License.
 package v1
 
 import (
+	context "context"
+
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	listers "k8s.io/client-go/listers"
 	cache "k8s.io/client-go/tools/cache"
 	examplev1 "k8s.io/code-generator/examples/crd/apis/example/v1"
@@ -31,6 +36,20 @@ type ClusterTestTypeLister interface {
 	// List lists all ClusterTestTypes in the indexer.
 	// Objects returned here must be treated as read-only.
 	List(selector labels.Selector) (ret []*examplev1.ClusterTestType, err error)
+	// ListChan sends all ClusterTestTypes in the indexer matching selector on the returned channel,
+	// which is closed when all were received or ctx is done.
+	// Objects returned here must be treated as read-only.
+	ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.ClusterTestType
+	// GetByKeys retrieves the ClusterTestTypes with the given indexer keys, which are
+	// their names. It returns the ClusterTestTypes which were found in the order of
+	// keys, and the keys which were not.
+	// Objects returned here must be treated as read-only.
+	GetByKeys(keys []string) (found []*examplev1.ClusterTestType, missing []string, err error)
+	// OwnerClusterTestType retrieves the ClusterTestType which owns obj according to the owner
+	// references of obj. It returns a NotFound error if obj has no owner reference to a
+	// ClusterTestType in the indexer.
+	// Objects returned here must be treated as read-only.
+	OwnerClusterTestType(obj metav1.Object) (*examplev1.ClusterTestType, error)
 	// Get retrieves the ClusterTestType from the index for a given name.
 	// Objects returned here must be treated as read-only.
 	Get(name string) (*examplev1.ClusterTestType, error)
@@ -40,9 +59,83 @@ type ClusterTestTypeLister interface {
 // clusterTestTypeLister implements the ClusterTestTypeLister interface.
 type clusterTestTypeLister struct {
 	listers.ResourceIndexer[*examplev1.ClusterTestType]
+	indexer cache.Indexer
 }
 
 // NewClusterTestTypeLister returns a new ClusterTestTypeLister.
 func NewClusterTestTypeLister(indexer cache.Indexer) ClusterTestTypeLister {
-	return &clusterTestTypeLister{listers.New[*examplev1.ClusterTestType](indexer, examplev1.Resource("clustertesttype"))}
+	return &clusterTestTypeLister{listers.New[*examplev1.ClusterTestType](indexer, examplev1.Resource("clustertesttype")), indexer}
+}
+
+// ListChan sends all ClusterTestTypes in the indexer matching selector on the returned channel.
+func (s *clusterTestTypeLister) ListChan(ctx context.Context, selector labels.Selector) <-chan *examplev1.ClusterTestType {
+	return listChan(ctx, selector, s.List)
+}
+
+// GetByKeys retrieves the ClusterTestTypes with the given indexer keys, and returns the keys which were not found.
+func (s *clusterTestTypeLister) GetByKeys(keys []string) (found []*examplev1.ClusterTestType, missing []string, err error) {
+	for _, key := range keys {
+		obj, exists, err := s.indexer.GetByKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			missing = append(missing, key)
+			continue
+		}
+		found = append(found, obj.(*examplev1.ClusterTestType))
+	}
+	return found, missing, nil
+}
+
+// NewClusterTestTypeListerWithSelectorCache returns a ClusterTestTypeLister which memoizes
+// the results of List per selector. Memoized results are invalidated whenever
+// informer delivers a change notification, so they may lag behind the indexer
+// by as long as the informer takes to notify its event handlers.
+func NewClusterTestTypeListerWithSelectorCache(informer cache.SharedIndexInformer) (ClusterTestTypeLister, error) {
+	memo, err := newSelectorCache[*examplev1.ClusterTestType](informer)
+	if err != nil {
+		return nil, err
+	}
+	lister := &clusterTestTypeLister{listers.New[*examplev1.ClusterTestType](informer.GetIndexer(), examplev1.Resource("clustertesttype")), informer.GetIndexer()}
+	return &clusterTestTypeCachingLister{clusterTestTypeLister: lister, cache: memo}, nil
+}
+
+// clusterTestTypeCachingLister implements the ClusterTestTypeLister interface
+// with memoized List results.
+type clusterTestTypeCachingLister struct {
+	*clusterTestTypeLister
+	cache *selectorCache[*examplev1.ClusterTestType]
+}
+
+// List lists all ClusterTestTypes in the indexer, reusing the memoized result for selector if possible.
+func (s *clusterTestTypeCachingLister) List(selector labels.Selector) ([]*examplev1.ClusterTestType, error) {
+	return s.cache.list("", selector, s.clusterTestTypeLister.List)
+}
+
+// OwnerClusterTestType retrieves the ClusterTestType which owns obj. Owner references match if
+// their kind is ClusterTestType, their API version is of the group of ClusterTestTypes and
+// their UID is the one of the cached ClusterTestType. Any version of the group matches,
+// because the cache holds the same object whichever version it was read in.
+func (s *clusterTestTypeLister) OwnerClusterTestType(obj metav1.Object) (*examplev1.ClusterTestType, error) {
+	resource := examplev1.Resource("clustertesttype")
+	name := ""
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil || gv.Group != resource.Group || ref.Kind != "ClusterTestType" {
+			continue
+		}
+		name = ref.Name
+		owner, err := s.Get(ref.Name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if owner.UID == ref.UID {
+			return owner, nil
+		}
+	}
+	return nil, errors.NewNotFound(resource, name)
 }
@@ -28,6 +28,7 @@ THIS_PKG="k8s.io/code-generator/examples"
 
 kube::codegen::gen_helpers \
     --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+    --with-roundtrip-tests \
     "${SCRIPT_ROOT}"
 
 kube::codegen::gen_register \
@@ -71,6 +72,15 @@ kube::codegen::gen_client \
     --output-dir "${SCRIPT_ROOT}/single" \
     --output-pkg "${THIS_PKG}/single" \
     --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
+    --tenant-label "example.com/tenant" \
+    --with-lister-type-meta \
+    --with-dynamic-informers \
+    --with-metadata-informers \
+    --with-informer-controllers \
+    --with-prometheus-collector \
+    --with-create-or-update \
+    --with-update-with-retry \
+    --with-server-side-applier \
     --one-input-api "api" \
     "${SCRIPT_ROOT}/single"
 
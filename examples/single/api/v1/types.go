@@ -19,6 +19,8 @@ package v1
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // +genclient
+// +genclient:testListWatch=k8s.io/code-generator/examples/single/fixtures.NewTestTypeListWatch
+// +lister:index=Status.Blah
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TestType is a top-level type. A client is created for it.
@@ -73,3 +75,32 @@ type ClusterTestType struct {
 type ClusterTestTypeStatus struct {
 	Blah string `json:"blah"`
 }
+
+// +genclient
+// +genclient:coResource=splitstatustypestatuses
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SplitStatusType is a top-level type whose status is watched through a
+// separate resource.
+type SplitStatusType struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +optional
+	Status SplitStatusTypeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SplitStatusTypeList is a top-level list type.
+type SplitStatusTypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SplitStatusType `json:"items"`
+}
+
+type SplitStatusTypeStatus struct {
+	Blah string `json:"blah"`
+}
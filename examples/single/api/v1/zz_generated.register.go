@@ -64,6 +64,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ClusterTestType{},
 		&ClusterTestTypeList{},
+		&SplitStatusType{},
+		&SplitStatusTypeList{},
 		&TestType{},
 		&TestTypeList{},
 	)
@@ -36,6 +36,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.ClusterTestTypeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterTestTypeStatus"):
 		return &apiv1.ClusterTestTypeStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SplitStatusType"):
+		return &apiv1.SplitStatusTypeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SplitStatusTypeStatus"):
+		return &apiv1.SplitStatusTypeStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TestType"):
 		return &apiv1.TestTypeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TestTypeStatus"):
@@ -19,17 +19,25 @@ limitations under the License.
 package v1
 
 import (
+	context "context"
 	http "net/http"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rest "k8s.io/client-go/rest"
 	apiv1 "k8s.io/code-generator/examples/single/api/v1"
+	applyconfigurationapiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
 	scheme "k8s.io/code-generator/examples/single/clientset/versioned/scheme"
 )
 
 type ExampleV1Interface interface {
 	RESTClient() rest.Interface
 	ClusterTestTypesGetter
+	SplitStatusTypesGetter
 	TestTypesGetter
+
+	// Applier returns a ExampleV1Applier which applies with fieldManager and, if force is set,
+	// takes ownership of conflicting fields.
+	Applier(fieldManager string, force bool) ExampleV1Applier
 }
 
 // ExampleV1Client is used to interact with features provided by the example.crd.code-generator.k8s.io group.
@@ -41,6 +49,10 @@ func (c *ExampleV1Client) ClusterTestTypes() ClusterTestTypeInterface {
 	return newClusterTestTypes(c)
 }
 
+func (c *ExampleV1Client) SplitStatusTypes(namespace string) SplitStatusTypeInterface {
+	return newSplitStatusTypes(c, namespace)
+}
+
 func (c *ExampleV1Client) TestTypes(namespace string) TestTypeInterface {
 	return newTestTypes(c, namespace)
 }
@@ -104,3 +116,53 @@ func (c *ExampleV1Client) RESTClient() rest.Interface {
 	}
 	return c.restClient
 }
+
+// ExampleV1Applier applies apply configurations of the example.crd.code-generator.k8s.io group with preset
+// apply options.
+type ExampleV1Applier interface {
+	ApplyClusterTestType(ctx context.Context, clusterTestType *applyconfigurationapiv1.ClusterTestTypeApplyConfiguration) (*apiv1.ClusterTestType, error)
+	ApplySplitStatusType(ctx context.Context, splitStatusType *applyconfigurationapiv1.SplitStatusTypeApplyConfiguration) (*apiv1.SplitStatusType, error)
+	ApplyTestType(ctx context.Context, testType *applyconfigurationapiv1.TestTypeApplyConfiguration) (*apiv1.TestType, error)
+}
+
+// exampleV1Applier implements ExampleV1Applier
+type exampleV1Applier struct {
+	client ExampleV1Interface
+	opts   metav1.ApplyOptions
+}
+
+// NewExampleV1Applier returns a ExampleV1Applier which applies through client with
+// fieldManager and, if force is set, takes ownership of conflicting fields.
+func NewExampleV1Applier(client ExampleV1Interface, fieldManager string, force bool) ExampleV1Applier {
+	return &exampleV1Applier{
+		client: client,
+		opts:   metav1.ApplyOptions{FieldManager: fieldManager, Force: force},
+	}
+}
+
+func (c *ExampleV1Client) Applier(fieldManager string, force bool) ExampleV1Applier {
+	return NewExampleV1Applier(c, fieldManager, force)
+}
+
+// ApplyClusterTestType applies clusterTestType with the preset apply options.
+func (a *exampleV1Applier) ApplyClusterTestType(ctx context.Context, clusterTestType *applyconfigurationapiv1.ClusterTestTypeApplyConfiguration) (*apiv1.ClusterTestType, error) {
+	return a.client.ClusterTestTypes().Apply(ctx, clusterTestType, a.opts)
+}
+
+// ApplySplitStatusType applies splitStatusType in its namespace with the preset apply options.
+func (a *exampleV1Applier) ApplySplitStatusType(ctx context.Context, splitStatusType *applyconfigurationapiv1.SplitStatusTypeApplyConfiguration) (*apiv1.SplitStatusType, error) {
+	var namespace string
+	if splitStatusType != nil && splitStatusType.GetNamespace() != nil {
+		namespace = *splitStatusType.GetNamespace()
+	}
+	return a.client.SplitStatusTypes(namespace).Apply(ctx, splitStatusType, a.opts)
+}
+
+// ApplyTestType applies testType in its namespace with the preset apply options.
+func (a *exampleV1Applier) ApplyTestType(ctx context.Context, testType *applyconfigurationapiv1.TestTypeApplyConfiguration) (*apiv1.TestType, error) {
+	var namespace string
+	if testType != nil && testType.GetNamespace() != nil {
+		namespace = *testType.GetNamespace()
+	}
+	return a.client.TestTypes(namespace).Apply(ctx, testType, a.opts)
+}
@@ -20,12 +20,15 @@ package v1
 
 import (
 	context "context"
+	json "encoding/json"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	gentype "k8s.io/client-go/gentype"
+	retry "k8s.io/client-go/util/retry"
 	apiv1 "k8s.io/code-generator/examples/single/api/v1"
 	applyconfigurationapiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
 	scheme "k8s.io/code-generator/examples/single/clientset/versioned/scheme"
@@ -52,6 +55,11 @@ type ClusterTestTypeInterface interface {
 	Apply(ctx context.Context, clusterTestType *applyconfigurationapiv1.ClusterTestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *apiv1.ClusterTestType, err error)
 	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, clusterTestType *applyconfigurationapiv1.ClusterTestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *apiv1.ClusterTestType, err error)
+	MergePatchClusterTestType(ctx context.Context, name string, patch *apiv1.ClusterTestType, opts metav1.PatchOptions) (*apiv1.ClusterTestType, error)
+	StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *apiv1.ClusterTestType, opts metav1.PatchOptions) (*apiv1.ClusterTestType, error)
+	CreateOrUpdateClusterTestType(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error)
+	UpdateWithRetry(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error)
+	UpdateStatusWithRetry(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error)
 	GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (*autoscalingv1.Scale, error)
 	UpdateScale(ctx context.Context, clusterTestTypeName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (*autoscalingv1.Scale, error)
 
@@ -77,6 +85,92 @@ func newClusterTestTypes(c *ExampleV1Client) *clusterTestTypes {
 	}
 }
 
+// MergePatchClusterTestType marshals patch to JSON and applies it to the named clusterTestType as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *clusterTestTypes) MergePatchClusterTestType(ctx context.Context, name string, patch *apiv1.ClusterTestType, opts metav1.PatchOptions) (*apiv1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchClusterTestType marshals patch to JSON and applies it to the named clusterTestType as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatchClusterTestType for them instead.
+func (c *clusterTestTypes) StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *apiv1.ClusterTestType, opts metav1.PatchOptions) (*apiv1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
+// CreateOrUpdateClusterTestType gets the clusterTestType named like obj, applies mutate to it and updates it.
+// If the clusterTestType does not exist, mutate is applied to a copy of obj which is then created.
+// The whole sequence is retried on conflicts and when a concurrent create wins the race.
+func (c *clusterTestTypes) CreateOrUpdateClusterTestType(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error) {
+	var result *apiv1.ClusterTestType
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, metav1.CreateOptions{DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateWithRetry gets the clusterTestType named like obj, applies mutate to it and updates it.
+// On conflicts the clusterTestType is fetched again and mutate is applied to the fresh copy.
+func (c *clusterTestTypes) UpdateWithRetry(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error) {
+	var result *apiv1.ClusterTestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateStatusWithRetry gets the clusterTestType named like obj, applies mutate to it and updates its status.
+// On conflicts the clusterTestType is fetched again and mutate is applied to the fresh copy.
+func (c *clusterTestTypes) UpdateStatusWithRetry(ctx context.Context, obj *apiv1.ClusterTestType, mutate func(*apiv1.ClusterTestType), opts metav1.UpdateOptions) (*apiv1.ClusterTestType, error) {
+	var result *apiv1.ClusterTestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetScale takes name of the clusterTestType, and returns the corresponding autoscalingv1.Scale object, and an error if there is any.
 func (c *clusterTestTypes) GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
 	result = &autoscalingv1.Scale{}
@@ -32,6 +32,10 @@ func (c *FakeExampleV1) ClusterTestTypes() v1.ClusterTestTypeInterface {
 	return newFakeClusterTestTypes(c)
 }
 
+func (c *FakeExampleV1) SplitStatusTypes(namespace string) v1.SplitStatusTypeInterface {
+	return newFakeSplitStatusTypes(c, namespace)
+}
+
 func (c *FakeExampleV1) TestTypes(namespace string) v1.TestTypeInterface {
 	return newFakeTestTypes(c, namespace)
 }
@@ -42,3 +46,9 @@ func (c *FakeExampleV1) RESTClient() rest.Interface {
 	var ret *rest.RESTClient
 	return ret
 }
+
+// Applier returns the real applier of the group on top of the fake client, so that
+// the applied configurations are recorded with the preset apply options.
+func (c *FakeExampleV1) Applier(fieldManager string, force bool) v1.ExampleV1Applier {
+	return v1.NewExampleV1Applier(c, fieldManager, force)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
+	applyconfigurationapiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+)
+
+// TestApplier verifies that the applier sends apply patches with its preset
+// field manager and force to the namespace of the configuration.
+func TestApplier(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("patch", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	ctx := context.Background()
+
+	applier := client.ExampleV1().Applier("test-manager", true)
+	if _, err := applier.ApplyTestType(ctx, applyconfigurationapiv1.TestType("foo", "ns")); err != nil {
+		t.Fatalf("failed to apply test type: %v", err)
+	}
+	if _, err := applier.ApplyClusterTestType(ctx, applyconfigurationapiv1.ClusterTestType("bar")); err != nil {
+		t.Fatalf("failed to apply cluster test type: %v", err)
+	}
+
+	actions := client.Actions()
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %v", actions)
+	}
+	wantNamespaces := []string{"ns", ""}
+	for i, action := range actions {
+		patch, ok := action.(clienttesting.PatchActionImpl)
+		if !ok {
+			t.Fatalf("expected a patch action, got %#v", action)
+		}
+		if patch.GetPatchType() != types.ApplyPatchType {
+			t.Errorf("expected patch type %q, got %q", types.ApplyPatchType, patch.GetPatchType())
+		}
+		if patch.GetNamespace() != wantNamespaces[i] {
+			t.Errorf("expected namespace %q, got %q", wantNamespaces[i], patch.GetNamespace())
+		}
+		if got := patch.PatchOptions; got.FieldManager != "test-manager" || got.Force == nil || !*got.Force {
+			t.Errorf("expected field manager test-manager with force, got %+v", got)
+		}
+	}
+	if actions[0].GetResource() != singleapiv1.SchemeGroupVersion.WithResource("testtypes") {
+		t.Errorf("expected the first apply for testtypes, got %v", actions[0].GetResource())
+	}
+}
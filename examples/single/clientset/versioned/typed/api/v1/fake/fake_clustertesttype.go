@@ -20,11 +20,15 @@ package fake
 
 import (
 	context "context"
+	json "encoding/json"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	gentype "k8s.io/client-go/gentype"
 	testing "k8s.io/client-go/testing"
+	retry "k8s.io/client-go/util/retry"
 	v1 "k8s.io/code-generator/examples/single/api/v1"
 	apiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
 	typedapiv1 "k8s.io/code-generator/examples/single/clientset/versioned/typed/api/v1"
@@ -55,6 +59,89 @@ func newFakeClusterTestTypes(fake *FakeExampleV1) typedapiv1.ClusterTestTypeInte
 	}
 }
 
+// MergePatchClusterTestType marshals patch to JSON and records it as a JSON merge patch of the named clusterTestType.
+func (c *fakeClusterTestTypes) MergePatchClusterTestType(ctx context.Context, name string, patch *v1.ClusterTestType, opts metav1.PatchOptions) (*v1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchClusterTestType marshals patch to JSON and records it as a strategic merge patch of the named clusterTestType.
+func (c *fakeClusterTestTypes) StrategicMergePatchClusterTestType(ctx context.Context, name string, patch *v1.ClusterTestType, opts metav1.PatchOptions) (*v1.ClusterTestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
+// CreateOrUpdateClusterTestType gets the clusterTestType named like obj, applies mutate to it and records an update.
+// If the clusterTestType does not exist, mutate is applied to a copy of obj which is then recorded as created.
+func (c *fakeClusterTestTypes) CreateOrUpdateClusterTestType(ctx context.Context, obj *v1.ClusterTestType, mutate func(*v1.ClusterTestType), opts metav1.UpdateOptions) (*v1.ClusterTestType, error) {
+	var result *v1.ClusterTestType
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, metav1.CreateOptions{DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateWithRetry gets the clusterTestType named like obj, applies mutate to it and records an update.
+// On conflicts the clusterTestType is fetched again and mutate is applied to the fresh copy.
+func (c *fakeClusterTestTypes) UpdateWithRetry(ctx context.Context, obj *v1.ClusterTestType, mutate func(*v1.ClusterTestType), opts metav1.UpdateOptions) (*v1.ClusterTestType, error) {
+	var result *v1.ClusterTestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateStatusWithRetry gets the clusterTestType named like obj, applies mutate to it and records a status update.
+// On conflicts the clusterTestType is fetched again and mutate is applied to the fresh copy.
+func (c *fakeClusterTestTypes) UpdateStatusWithRetry(ctx context.Context, obj *v1.ClusterTestType, mutate func(*v1.ClusterTestType), opts metav1.UpdateOptions) (*v1.ClusterTestType, error) {
+	var result *v1.ClusterTestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetScale takes name of the clusterTestType, and returns the corresponding scale object, and an error if there is any.
 func (c *fakeClusterTestTypes) GetScale(ctx context.Context, clusterTestTypeName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
 	emptyResult := &autoscalingv1.Scale{}
@@ -19,7 +19,14 @@ limitations under the License.
 package fake
 
 import (
+	context "context"
+	json "encoding/json"
+
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
 	gentype "k8s.io/client-go/gentype"
+	retry "k8s.io/client-go/util/retry"
 	v1 "k8s.io/code-generator/examples/single/api/v1"
 	apiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
 	typedapiv1 "k8s.io/code-generator/examples/single/clientset/versioned/typed/api/v1"
@@ -47,3 +54,86 @@ func newFakeTestTypes(fake *FakeExampleV1, namespace string) typedapiv1.TestType
 		fake,
 	}
 }
+
+// MergePatchTestType marshals patch to JSON and records it as a JSON merge patch of the named testType.
+func (c *fakeTestTypes) MergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and records it as a strategic merge patch of the named testType.
+func (c *fakeTestTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *v1.TestType, opts metav1.PatchOptions) (*v1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
+// CreateOrUpdateTestType gets the testType named like obj, applies mutate to it and records an update.
+// If the testType does not exist, mutate is applied to a copy of obj which is then recorded as created.
+func (c *fakeTestTypes) CreateOrUpdateTestType(ctx context.Context, obj *v1.TestType, mutate func(*v1.TestType), opts metav1.UpdateOptions) (*v1.TestType, error) {
+	var result *v1.TestType
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, metav1.CreateOptions{DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateWithRetry gets the testType named like obj, applies mutate to it and records an update.
+// On conflicts the testType is fetched again and mutate is applied to the fresh copy.
+func (c *fakeTestTypes) UpdateWithRetry(ctx context.Context, obj *v1.TestType, mutate func(*v1.TestType), opts metav1.UpdateOptions) (*v1.TestType, error) {
+	var result *v1.TestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateStatusWithRetry gets the testType named like obj, applies mutate to it and records a status update.
+// On conflicts the testType is fetched again and mutate is applied to the fresh copy.
+func (c *fakeTestTypes) UpdateStatusWithRetry(ctx context.Context, obj *v1.TestType, mutate func(*v1.TestType), opts metav1.UpdateOptions) (*v1.TestType, error) {
+	var result *v1.TestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
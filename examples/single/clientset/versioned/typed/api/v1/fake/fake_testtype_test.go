@@ -0,0 +1,256 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+)
+
+// TestTypedPatch verifies that the typed patch helpers send the marshaled
+// object with the matching patch type.
+func TestTypedPatch(t *testing.T) {
+	patch := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}}}
+	wantData, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("failed to marshal patch: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		patch         func(ctx context.Context, client *fake.Clientset) (*singleapiv1.TestType, error)
+		wantPatchType types.PatchType
+	}{
+		{
+			name: "merge patch",
+			patch: func(ctx context.Context, client *fake.Clientset) (*singleapiv1.TestType, error) {
+				return client.ExampleV1().TestTypes("ns").MergePatchTestType(ctx, "foo", patch, metav1.PatchOptions{})
+			},
+			wantPatchType: types.MergePatchType,
+		},
+		{
+			name: "strategic merge patch",
+			patch: func(ctx context.Context, client *fake.Clientset) (*singleapiv1.TestType, error) {
+				return client.ExampleV1().TestTypes("ns").StrategicMergePatchTestType(ctx, "foo", patch, metav1.PatchOptions{})
+			},
+			wantPatchType: types.StrategicMergePatchType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			var gotPatchType types.PatchType
+			var gotData []byte
+			client.PrependReactor("patch", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				patchAction := action.(clienttesting.PatchAction)
+				gotPatchType = patchAction.GetPatchType()
+				gotData = patchAction.GetPatch()
+				return true, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: patchAction.GetName()}}, nil
+			})
+
+			result, err := tt.patch(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Name != "foo" {
+				t.Errorf("unexpected result name: got %q, want %q", result.Name, "foo")
+			}
+			if gotPatchType != tt.wantPatchType {
+				t.Errorf("patch type: got %q, want %q", gotPatchType, tt.wantPatchType)
+			}
+			if string(gotData) != string(wantData) {
+				t.Errorf("patch data: got %s, want %s", gotData, wantData)
+			}
+		})
+	}
+}
+
+// TestCreateOrUpdate verifies that CreateOrUpdate creates missing objects,
+// updates existing ones and retries the update on conflicts.
+func TestCreateOrUpdate(t *testing.T) {
+	existing := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", Labels: map[string]string{"old": "true"}}}
+	setLabel := func(obj *singleapiv1.TestType) {
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		obj.Labels["mutated"] = "true"
+	}
+
+	tests := []struct {
+		name         string
+		objects      []runtime.Object
+		conflicts    int
+		wantVerbs    []string
+		wantOldLabel bool
+	}{
+		{
+			name:      "create",
+			wantVerbs: []string{"get", "create"},
+		},
+		{
+			name:         "update",
+			objects:      []runtime.Object{existing},
+			wantVerbs:    []string{"get", "update"},
+			wantOldLabel: true,
+		},
+		{
+			name:         "conflict retry",
+			objects:      []runtime.Object{existing},
+			conflicts:    1,
+			wantVerbs:    []string{"get", "update", "get", "update"},
+			wantOldLabel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tt.objects...)
+			conflicts := tt.conflicts
+			client.PrependReactor("update", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if conflicts == 0 {
+					return false, nil, nil
+				}
+				conflicts--
+				return true, nil, apierrors.NewConflict(singleapiv1.Resource("testtypes"), "foo", nil)
+			})
+
+			obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}
+			result, err := client.ExampleV1().TestTypes("ns").CreateOrUpdateTestType(context.Background(), obj, setLabel, metav1.UpdateOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if obj.Labels != nil {
+				t.Errorf("obj was mutated: %v", obj.Labels)
+			}
+			if result.Labels["mutated"] != "true" {
+				t.Errorf("result was not mutated: %v", result.Labels)
+			}
+			if got := result.Labels["old"] == "true"; got != tt.wantOldLabel {
+				t.Errorf("result has the labels of the existing object: got %v, want %v", got, tt.wantOldLabel)
+			}
+
+			stored, err := client.ExampleV1().TestTypes("ns").Get(context.Background(), "foo", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stored.Labels["mutated"] != "true" {
+				t.Errorf("stored object was not mutated: %v", stored.Labels)
+			}
+
+			var verbs []string
+			for _, action := range client.Actions() {
+				verbs = append(verbs, action.GetVerb())
+			}
+			// The final Get above is not part of CreateOrUpdate.
+			verbs = verbs[:len(verbs)-1]
+			if !reflect.DeepEqual(verbs, tt.wantVerbs) {
+				t.Errorf("actions: got %v, want %v", verbs, tt.wantVerbs)
+			}
+		})
+	}
+}
+
+// TestUpdateWithRetry verifies that UpdateWithRetry and UpdateStatusWithRetry
+// retry on conflicts and apply the mutation to the freshly fetched object.
+func TestUpdateWithRetry(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          func(ctx context.Context, client *fake.Clientset, obj *singleapiv1.TestType, mutate func(*singleapiv1.TestType)) (*singleapiv1.TestType, error)
+		wantSubresource string
+	}{
+		{
+			name: "update",
+			update: func(ctx context.Context, client *fake.Clientset, obj *singleapiv1.TestType, mutate func(*singleapiv1.TestType)) (*singleapiv1.TestType, error) {
+				return client.ExampleV1().TestTypes("ns").UpdateWithRetry(ctx, obj, mutate, metav1.UpdateOptions{})
+			},
+		},
+		{
+			name: "update status",
+			update: func(ctx context.Context, client *fake.Clientset, obj *singleapiv1.TestType, mutate func(*singleapiv1.TestType)) (*singleapiv1.TestType, error) {
+				return client.ExampleV1().TestTypes("ns").UpdateStatusWithRetry(ctx, obj, mutate, metav1.UpdateOptions{})
+			},
+			wantSubresource: "status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}, Status: singleapiv1.TestTypeStatus{Blah: "stale"}}
+			client := fake.NewSimpleClientset(existing)
+			conflicts := 1
+			client.PrependReactor("update", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if conflicts == 0 {
+					return false, nil, nil
+				}
+				conflicts--
+				// Simulate a concurrent writer which wins the race.
+				fresh := existing.DeepCopy()
+				fresh.Status.Blah = "fresh"
+				if err := client.Tracker().Update(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), fresh, "ns"); err != nil {
+					t.Fatalf("failed to update tracker: %v", err)
+				}
+				return true, nil, apierrors.NewConflict(singleapiv1.Resource("testtypes"), "foo", nil)
+			})
+
+			var seen []string
+			mutate := func(obj *singleapiv1.TestType) {
+				seen = append(seen, obj.Status.Blah)
+				if obj.Labels == nil {
+					obj.Labels = map[string]string{}
+				}
+				obj.Labels["mutated"] = "true"
+			}
+
+			obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}
+			result, err := tt.update(context.Background(), client, obj, mutate)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if obj.Labels != nil {
+				t.Errorf("obj was mutated: %v", obj.Labels)
+			}
+			if want := []string{"stale", "fresh"}; !reflect.DeepEqual(seen, want) {
+				t.Errorf("objects passed to mutate: got %v, want %v", seen, want)
+			}
+			if result.Labels["mutated"] != "true" || result.Status.Blah != "fresh" {
+				t.Errorf("unexpected result: %v", result)
+			}
+
+			var verbs []string
+			for _, action := range client.Actions() {
+				verbs = append(verbs, action.GetVerb())
+				if action.GetVerb() == "update" && action.GetSubresource() != tt.wantSubresource {
+					t.Errorf("update subresource: got %q, want %q", action.GetSubresource(), tt.wantSubresource)
+				}
+			}
+			if want := []string{"get", "update", "get", "update"}; !reflect.DeepEqual(verbs, want) {
+				t.Errorf("actions: got %v, want %v", verbs, want)
+			}
+		})
+	}
+}
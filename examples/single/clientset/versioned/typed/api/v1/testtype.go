@@ -20,11 +20,14 @@ package v1
 
 import (
 	context "context"
+	json "encoding/json"
 
+	errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	gentype "k8s.io/client-go/gentype"
+	retry "k8s.io/client-go/util/retry"
 	apiv1 "k8s.io/code-generator/examples/single/api/v1"
 	applyconfigurationapiv1 "k8s.io/code-generator/examples/single/applyconfiguration/api/v1"
 	scheme "k8s.io/code-generator/examples/single/clientset/versioned/scheme"
@@ -51,6 +54,11 @@ type TestTypeInterface interface {
 	Apply(ctx context.Context, testType *applyconfigurationapiv1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *apiv1.TestType, err error)
 	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
 	ApplyStatus(ctx context.Context, testType *applyconfigurationapiv1.TestTypeApplyConfiguration, opts metav1.ApplyOptions) (result *apiv1.TestType, err error)
+	MergePatchTestType(ctx context.Context, name string, patch *apiv1.TestType, opts metav1.PatchOptions) (*apiv1.TestType, error)
+	StrategicMergePatchTestType(ctx context.Context, name string, patch *apiv1.TestType, opts metav1.PatchOptions) (*apiv1.TestType, error)
+	CreateOrUpdateTestType(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error)
+	UpdateWithRetry(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error)
+	UpdateStatusWithRetry(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error)
 	TestTypeExpansion
 }
 
@@ -72,3 +80,89 @@ func newTestTypes(c *ExampleV1Client, namespace string) *testTypes {
 		),
 	}
 }
+
+// MergePatchTestType marshals patch to JSON and applies it to the named testType as a JSON merge patch.
+// Fields of patch which are not omitted when empty are always part of the patch.
+func (c *testTypes) MergePatchTestType(ctx context.Context, name string, patch *apiv1.TestType, opts metav1.PatchOptions) (*apiv1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, opts)
+}
+
+// StrategicMergePatchTestType marshals patch to JSON and applies it to the named testType as a strategic merge patch.
+// Custom resources do not support strategic merge patches; use MergePatchTestType for them instead.
+func (c *testTypes) StrategicMergePatchTestType(ctx context.Context, name string, patch *apiv1.TestType, opts metav1.PatchOptions) (*apiv1.TestType, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.StrategicMergePatchType, data, opts)
+}
+
+// CreateOrUpdateTestType gets the testType named like obj, applies mutate to it and updates it.
+// If the testType does not exist, mutate is applied to a copy of obj which is then created.
+// The whole sequence is retried on conflicts and when a concurrent create wins the race.
+func (c *testTypes) CreateOrUpdateTestType(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error) {
+	var result *apiv1.TestType
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+	}, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			created := obj.DeepCopy()
+			mutate(created)
+			result, err = c.Create(ctx, created, metav1.CreateOptions{DryRun: opts.DryRun, FieldManager: opts.FieldManager, FieldValidation: opts.FieldValidation})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateWithRetry gets the testType named like obj, applies mutate to it and updates it.
+// On conflicts the testType is fetched again and mutate is applied to the fresh copy.
+func (c *testTypes) UpdateWithRetry(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error) {
+	var result *apiv1.TestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.Update(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateStatusWithRetry gets the testType named like obj, applies mutate to it and updates its status.
+// On conflicts the testType is fetched again and mutate is applied to the fresh copy.
+func (c *testTypes) UpdateStatusWithRetry(ctx context.Context, obj *apiv1.TestType, mutate func(*apiv1.TestType), opts metav1.UpdateOptions) (*apiv1.TestType, error) {
+	var result *apiv1.TestType
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.Get(ctx, obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutate(existing)
+		result, err = c.UpdateStatus(ctx, existing, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicinformers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
+	dynamicapiv1 "k8s.io/code-generator/examples/single/dynamicinformers/api/v1"
+)
+
+func newUnstructuredTestType(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.crd.code-generator.k8s.io/v1")
+	obj.SetKind("TestType")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// TestDynamicInformer verifies that informers obtained from the dynamic
+// factory list and watch their resource through the dynamic client and that
+// their listers return unstructured objects.
+func TestDynamicInformer(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{dynamicapiv1.TestTypeResource: "TestTypeList"},
+		newUnstructuredTestType("ns", "foo"),
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes()
+	added := make(chan string, 1)
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if name := obj.(*unstructured.Unstructured).GetName(); name == "bar" {
+				added <- name
+			}
+		},
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.Start(ctx.Done())
+	for resource, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			t.Fatalf("failed to sync %v", resource)
+		}
+	}
+
+	obj, err := informer.Lister().Namespace("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get foo: %v", err)
+	}
+	if obj.GetKind() != "TestType" || obj.GetNamespace() != "ns" {
+		t.Errorf("unexpected object %v", obj.Object)
+	}
+
+	if _, err := client.Resource(dynamicapiv1.TestTypeResource).Namespace("ns").Create(ctx, newUnstructuredTestType("ns", "bar"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	<-added
+	objs, err := informer.Lister().List(labels.Everything())
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(objs) != 2 {
+		t.Errorf("expected 2 objects, got %v", objs)
+	}
+}
+
+// TestDecodeWithScheme verifies that Decode converts the unstructured objects
+// produced by the informers into the types registered in the scheme passed
+// with WithScheme, applying the defaults of that scheme.
+func TestDecodeWithScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := singleapiv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add types to scheme: %v", err)
+	}
+	scheme.AddTypeDefaultingFunc(&singleapiv1.TestType{}, func(obj interface{}) {
+		if testType := obj.(*singleapiv1.TestType); testType.Status.Blah == "" {
+			testType.Status.Blah = "defaulted"
+		}
+	})
+	withStatus := newUnstructuredTestType("ns", "foo")
+	if err := unstructured.SetNestedField(withStatus.Object, "set", "status", "blah"); err != nil {
+		t.Fatalf("failed to set status: %v", err)
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{dynamicapiv1.TestTypeResource: "TestTypeList"},
+		withStatus,
+		newUnstructuredTestType("ns", "bar"),
+	)
+
+	if _, err := NewSharedInformerFactory(client, 0).Decode(dynamicapiv1.TestTypeKind, withStatus); err == nil {
+		t.Errorf("expected decoding without a scheme to fail")
+	}
+
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithNamespace("ns"), WithScheme(scheme))
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.Start(ctx.Done())
+	for resource, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			t.Fatalf("failed to sync %v", resource)
+		}
+	}
+
+	for name, want := range map[string]string{"foo": "set", "bar": "defaulted"} {
+		obj, err := informer.Lister().Namespace("ns").Get(name)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", name, err)
+		}
+		decoded, err := factory.Decode(dynamicapiv1.TestTypeKind, obj)
+		if err != nil {
+			t.Fatalf("failed to decode %s: %v", name, err)
+		}
+		testType, ok := decoded.(*singleapiv1.TestType)
+		if !ok {
+			t.Fatalf("expected a *TestType, got %T", decoded)
+		}
+		if testType.Name != name || testType.Namespace != "ns" || testType.Status.Blah != want {
+			t.Errorf("unexpected %s: %+v", name, testType)
+		}
+		if gvk := testType.GroupVersionKind(); gvk != dynamicapiv1.TestTypeKind {
+			t.Errorf("expected kind %v, got %v", dynamicapiv1.TestTypeKind, gvk)
+		}
+	}
+}
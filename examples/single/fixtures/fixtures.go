@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures holds the recorded objects which the informers of the
+// single example list and watch instead of using their client in builds with
+// the testlistwatch build tag, as configured by +genclient:testListWatch.
+package fixtures
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	v1 "k8s.io/code-generator/examples/single/api/v1"
+)
+
+// RecordedTestTypes are the TestTypes listed by NewTestTypeListWatch.
+var RecordedTestTypes = []v1.TestType{
+	{ObjectMeta: metav1.ObjectMeta{Name: "recorded", Namespace: "fixtures", ResourceVersion: "1"}},
+}
+
+// NewTestTypeListWatch returns a ListerWatcher which lists RecordedTestTypes
+// at resource version 1, whatever the list options, and whose watches deliver
+// no events.
+func NewTestTypeListWatch() cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			list := &v1.TestTypeList{ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+			for _, item := range RecordedTestTypes {
+				list.Items = append(list.Items, *item.DeepCopy())
+			}
+			return list, nil
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+}
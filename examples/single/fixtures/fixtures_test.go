@@ -0,0 +1,74 @@
+//go:build testlistwatch
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	v1 "k8s.io/code-generator/examples/single/api/v1"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+	"k8s.io/code-generator/examples/single/informers/externalversions"
+)
+
+// TestFixtureListWatch verifies that, in builds with the testlistwatch build
+// tag, the TestType informers list the recorded fixtures instead of using
+// their client, while the other informers still use it.
+func TestFixtureListWatch(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "ns"}},
+		&v1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "live"}},
+	)
+	factory := externalversions.NewSharedInformerFactory(client, 0)
+	testTypes := factory.Example().V1().TestTypes()
+	clusterTestTypes := factory.Example().V1().ClusterTestTypes()
+	testTypes.Informer()
+	clusterTestTypes.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	objs, err := testTypes.Lister().List(labels.Everything())
+	if err != nil {
+		t.Fatalf("failed to list TestTypes: %v", err)
+	}
+	var keys []string
+	for _, obj := range objs {
+		keys = append(keys, obj.Namespace+"/"+obj.Name)
+	}
+	if want := []string{"fixtures/recorded"}; !slices.Equal(keys, want) {
+		t.Errorf("expected the TestTypes %v of the fixture, got %v", want, keys)
+	}
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "testtypes" {
+			t.Errorf("unexpected %s of TestTypes through the client", action.GetVerb())
+		}
+	}
+	if _, err := clusterTestTypes.Lister().Get("live"); err != nil {
+		t.Errorf("expected ClusterTestTypes to be listed through the client: %v", err)
+	}
+}
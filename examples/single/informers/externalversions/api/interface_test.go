@@ -0,0 +1,170 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
+	"k8s.io/code-generator/examples/single/clientset/versioned"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+	"k8s.io/code-generator/examples/single/informers/externalversions"
+	"k8s.io/code-generator/examples/single/informers/externalversions/internalinterfaces"
+)
+
+func TestRegisterHandlers(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+	)
+	factory := externalversions.NewSharedInformerFactory(client, 0)
+
+	added := make(chan string, 2)
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			added <- obj.(metav1.Object).GetName()
+		},
+	}
+	err := factory.Example().RegisterHandlers(map[schema.GroupVersionResource]cache.ResourceEventHandler{
+		singleapiv1.SchemeGroupVersion.WithResource("testtypes"):        handler,
+		singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"): handler,
+	})
+	if err != nil {
+		t.Fatalf("failed to register handlers: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+
+	got := map[string]bool{}
+	for len(got) < 2 {
+		select {
+		case name := <-added:
+			got[name] = true
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("handlers were not invoked for all objects, got %v", got)
+		}
+	}
+	if !got["foo"] || !got["bar"] {
+		t.Errorf("expected handlers to be invoked for foo and bar, got %v", got)
+	}
+}
+
+func TestRegisterHandlersUnknownResource(t *testing.T) {
+	factory := externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+
+	err := factory.Example().RegisterHandlers(map[schema.GroupVersionResource]cache.ResourceEventHandler{
+		singleapiv1.SchemeGroupVersion.WithResource("testtypes"):  cache.ResourceEventHandlerFuncs{},
+		singleapiv1.SchemeGroupVersion.WithResource("othertypes"): cache.ResourceEventHandlerFuncs{},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for an unknown resource")
+	}
+	if state := factory.DumpState(); len(state.Informers) != 0 {
+		t.Errorf("expected no informers to be requested, got %+v", state.Informers)
+	}
+}
+
+func TestRegisterHandlersPanicHandler(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	type recoveredPanic struct {
+		resource  schema.GroupVersionResource
+		recovered interface{}
+	}
+	recovered := make(chan recoveredPanic, 1)
+	factory := externalversions.NewSharedInformerFactoryWithOptions(client, 0, externalversions.WithPanicHandler(func(resource schema.GroupVersionResource, r interface{}) {
+		recovered <- recoveredPanic{resource: resource, recovered: r}
+	}))
+
+	err := factory.Example().RegisterHandlers(map[schema.GroupVersionResource]cache.ResourceEventHandler{
+		singleapiv1.SchemeGroupVersion.WithResource("testtypes"): cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				panic("boom " + obj.(metav1.Object).GetName())
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to register handlers: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+
+	select {
+	case got := <-recovered:
+		want := recoveredPanic{resource: singleapiv1.SchemeGroupVersion.WithResource("testtypes"), recovered: "boom foo"}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the panic handler was not invoked")
+	}
+}
+
+func TestReady(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := externalversions.NewSharedInformerFactory(client, 0)
+
+	factory.Example().V1().TestTypes().Informer()
+	// The cluster test types informer does not sync until release is closed.
+	release := make(chan struct{})
+	factory.InformerFor(&singleapiv1.ClusterTestType{}, func(versioned.Interface, time.Duration) cache.SharedIndexInformer {
+		lw := &cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				<-release
+				return &singleapiv1.ClusterTestTypeList{}, nil
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}
+		return cache.NewSharedIndexInformer(internalinterfaces.NewListerWatcherWithoutWatchList(lw), &singleapiv1.ClusterTestType{}, 0, cache.Indexers{})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+
+	informer := factory.Example().V1().TestTypes().Informer()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		t.Fatalf("test types informer did not sync")
+	}
+	if factory.Example().Ready() {
+		t.Errorf("expected the group not to be ready while the cluster test types informer is pending")
+	}
+
+	close(release)
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return factory.Example().Ready(), nil
+	})
+	if err != nil {
+		t.Errorf("expected the group to become ready: %v", err)
+	}
+}
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// ClusterTestTypes returns a ClusterTestTypeInformer.
 	ClusterTestTypes() ClusterTestTypeInformer
+	// SplitStatusTypes returns a SplitStatusTypeInformer.
+	SplitStatusTypes() SplitStatusTypeInformer
 	// TestTypes returns a TestTypeInformer.
 	TestTypes() TestTypeInformer
 }
@@ -46,6 +48,11 @@ func (v *version) ClusterTestTypes() ClusterTestTypeInformer {
 	return &clusterTestTypeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// SplitStatusTypes returns a SplitStatusTypeInformer.
+func (v *version) SplitStatusTypes() SplitStatusTypeInformer {
+	return &splitStatusTypeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TestTypes returns a TestTypeInformer.
 func (v *version) TestTypes() TestTypeInformer {
 	return &testTypeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
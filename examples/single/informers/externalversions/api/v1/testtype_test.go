@@ -0,0 +1,830 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	apiv1 "k8s.io/code-generator/examples/single/api/v1"
+	"k8s.io/code-generator/examples/single/clientset/versioned"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+	internalinterfaces "k8s.io/code-generator/examples/single/informers/externalversions/internalinterfaces"
+	listersapiv1 "k8s.io/code-generator/examples/single/listers/api/v1"
+)
+
+// TestEventHandlerRegistrations verifies that the registrations of handlers
+// which were added at different times sync independently.
+func TestEventHandlerRegistrations(t *testing.T) {
+	client := fake.NewSimpleClientset(&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	informer := fakeTestTypeInformer{NewTestTypeInformer(client, metav1.NamespaceAll, 0, cache.Indexers{})}
+	first, err := AddTestTypeEventHandler(informer, cache.ResourceEventHandlerFuncs{})
+	if err != nil {
+		t.Fatalf("failed to add the first handler: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go informer.Informer().RunWithContext(ctx)
+	if !cache.WaitForCacheSync(ctx.Done(), first.HasSynced) {
+		t.Fatalf("the first handler did not sync")
+	}
+
+	// The replay to the second handler blocks until it is released.
+	release := make(chan struct{})
+	second, err := AddTestTypeEventHandler(informer, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { <-release },
+	})
+	if err != nil {
+		t.Fatalf("failed to add the second handler: %v", err)
+	}
+	if !first.HasSynced() || second.HasSynced() {
+		t.Errorf("expected only the first handler to be synced, got %v and %v", first.HasSynced(), second.HasSynced())
+	}
+	close(release)
+	if !cache.WaitForCacheSync(ctx.Done(), second.HasSynced) {
+		t.Fatalf("the second handler did not sync")
+	}
+}
+
+// TestResyncHandler verifies that a resync handler only fires for resyncs.
+func TestResyncHandler(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var resynced []string
+	if _, err := AddTestTypeResyncHandler(fakeTestTypeInformer{informer}, func(obj *apiv1.TestType) {
+		resynced = append(resynced, obj.Name)
+	}); err != nil {
+		t.Fatalf("failed to add resync handler: %v", err)
+	}
+
+	foo := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: "1"}}
+	fooUpdated := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: "2"}}
+	bar := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns", ResourceVersion: "1"}}
+
+	// Live changes.
+	informer.handler.OnAdd(foo, false)
+	informer.handler.OnUpdate(foo, fooUpdated)
+	informer.handler.OnDelete(fooUpdated)
+	// A relist returning an unchanged copy of bar is not a resync either.
+	informer.handler.OnUpdate(bar, bar.DeepCopy())
+	// Resync of bar.
+	informer.handler.OnUpdate(bar, bar)
+
+	if want := []string{"bar"}; !slices.Equal(resynced, want) {
+		t.Errorf("resync handler invoked for %v, want %v", resynced, want)
+	}
+}
+
+// TestSpecChangeHandler verifies that a spec change handler only fires for
+// updates changing the generation.
+func TestSpecChangeHandler(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var changed []string
+	if _, err := AddTestTypeSpecChangeHandler(fakeTestTypeInformer{informer}, func(obj *apiv1.TestType) {
+		changed = append(changed, obj.ResourceVersion)
+	}); err != nil {
+		t.Fatalf("failed to add spec change handler: %v", err)
+	}
+
+	foo := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: "1", Generation: 1}}
+	statusUpdated := foo.DeepCopy()
+	statusUpdated.ResourceVersion = "2"
+	statusUpdated.Status.Blah = "ready"
+	specUpdated := statusUpdated.DeepCopy()
+	specUpdated.ResourceVersion = "3"
+	specUpdated.Generation = 2
+
+	informer.handler.OnAdd(foo, false)
+	informer.handler.OnUpdate(foo, statusUpdated)
+	informer.handler.OnUpdate(statusUpdated, specUpdated)
+	informer.handler.OnUpdate(specUpdated, specUpdated)
+	informer.handler.OnDelete(specUpdated)
+
+	if want := []string{"3"}; !slices.Equal(changed, want) {
+		t.Errorf("spec change handler invoked for resource versions %v, want %v", changed, want)
+	}
+}
+
+// TestDebouncedHandler verifies that a debounced handler fires once with the
+// latest object after updates settle, and not at all for deleted objects.
+func TestDebouncedHandler(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	const window = 100 * time.Millisecond
+	fired := make(chan *apiv1.TestType, 10)
+	if _, err := AddTestTypeDebouncedHandler(fakeTestTypeInformer{informer}, window, func(obj *apiv1.TestType) {
+		fired <- obj
+	}); err != nil {
+		t.Fatalf("failed to add debounced handler: %v", err)
+	}
+
+	start := time.Now()
+	var foo *apiv1.TestType
+	for i := 1; i <= 5; i++ {
+		updated := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: strconv.Itoa(i)}}
+		if foo == nil {
+			informer.handler.OnAdd(updated, false)
+		} else {
+			informer.handler.OnUpdate(foo, updated)
+		}
+		foo = updated
+		time.Sleep(window / 10)
+	}
+	// Changes of bar are never delivered because bar is deleted.
+	bar := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns", ResourceVersion: "1"}}
+	informer.handler.OnAdd(bar, false)
+	informer.handler.OnDelete(bar)
+
+	select {
+	case got := <-fired:
+		if got != foo {
+			t.Errorf("handler invoked with resource version %s, want the latest %s", got.ResourceVersion, foo.ResourceVersion)
+		}
+		if elapsed := time.Since(start); elapsed < window {
+			t.Errorf("handler invoked after %v, before the window of %v", elapsed, window)
+		}
+	case <-time.After(10 * window):
+		t.Fatalf("handler was not invoked")
+	}
+	select {
+	case got := <-fired:
+		t.Errorf("handler invoked again for %s/%s", got.Name, got.ResourceVersion)
+	case <-time.After(2 * window):
+	}
+}
+
+// TestBatchHandler verifies that a batch handler flushes batches of distinct
+// objects when they are full, after their delay and when its context is done,
+// after which it is removed and collects nothing.
+func TestBatchHandler(t *testing.T) {
+	newObj := func(name, resourceVersion string) *apiv1.TestType {
+		return &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", ResourceVersion: resourceVersion}}
+	}
+	batches := make(chan []string, 10)
+	fn := func(items []*apiv1.TestType) {
+		var batch []string
+		for _, item := range items {
+			batch = append(batch, item.Name+"@"+item.ResourceVersion)
+		}
+		batches <- batch
+	}
+	expectBatch := func(want ...string) {
+		t.Helper()
+		select {
+		case got := <-batches:
+			if !slices.Equal(got, want) {
+				t.Errorf("got batch %v, want %v", got, want)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("batch %v was not flushed", want)
+		}
+	}
+
+	t.Run("size and shutdown", func(t *testing.T) {
+		informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		registration, err := AddTestTypeBatchHandler(ctx, fakeTestTypeInformer{informer}, 2, time.Hour, fn)
+		if err != nil {
+			t.Fatalf("failed to add handler: %v", err)
+		}
+		informer.handler.OnAdd(newObj("foo", "1"), false)
+		informer.handler.OnUpdate(newObj("foo", "1"), newObj("foo", "2"))
+		informer.handler.OnAdd(newObj("bar", "3"), false)
+		expectBatch("foo@2", "bar@3")
+
+		informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/baz", Obj: newObj("baz", "4")})
+		select {
+		case batch := <-batches:
+			t.Fatalf("unexpected batch %v before the shutdown", batch)
+		default:
+		}
+		cancel()
+		expectBatch("baz@4")
+		if want := []cache.ResourceEventHandlerRegistration{registration}; !slices.Equal(informer.removed, want) {
+			t.Errorf("removed registrations %v, want %v", informer.removed, want)
+		}
+
+		// Changes delivered after the shutdown are not collected.
+		informer.handler.OnAdd(newObj("qux", "5"), false)
+		informer.handler.OnAdd(newObj("quux", "6"), false)
+		select {
+		case batch := <-batches:
+			t.Fatalf("unexpected batch %v after the shutdown", batch)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+		if _, err := AddTestTypeBatchHandler(context.Background(), fakeTestTypeInformer{informer}, 10, 100*time.Millisecond, fn); err != nil {
+			t.Fatalf("failed to add handler: %v", err)
+		}
+		informer.handler.OnAdd(newObj("foo", "1"), false)
+		informer.handler.OnAdd(newObj("bar", "2"), false)
+		expectBatch("foo@1", "bar@2")
+	})
+}
+
+// spanIDKey is the context key of the span ID extracted by extractTraceParent.
+type spanIDKey struct{}
+
+// extractTraceParent extracts the parent span ID of a W3C traceparent header,
+// like a trace context propagator.
+func extractTraceParent(ctx context.Context, carrier map[string]string) context.Context {
+	parts := strings.Split(carrier["traceparent"], "-")
+	if len(parts) != 4 {
+		return ctx
+	}
+	return context.WithValue(ctx, spanIDKey{}, parts[2])
+}
+
+// TestTracedHandler verifies that a traced handler passes the trace context
+// of the annotations of objects.
+func TestTracedHandler(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var spans []string
+	if _, err := AddTestTypeTracedHandler(fakeTestTypeInformer{informer}, extractTraceParent, func(ctx context.Context, obj *apiv1.TestType) {
+		spanID, _ := ctx.Value(spanIDKey{}).(string)
+		spans = append(spans, obj.Name+" "+spanID)
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	traced := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "traced", Namespace: "ns", Annotations: map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}}}
+	untraced := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "untraced", Namespace: "ns"}}
+	informer.handler.OnAdd(traced, false)
+	informer.handler.OnUpdate(untraced, untraced)
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/traced", Obj: traced})
+
+	if want := []string{"traced 00f067aa0ba902b7", "untraced ", "traced 00f067aa0ba902b7"}; !slices.Equal(spans, want) {
+		t.Errorf("handler received %q, want %q", spans, want)
+	}
+}
+
+// TestDiffHandler verifies that a diff handler gets no old object for adds,
+// both objects for updates and no new object for deletes, including those of
+// tombstones.
+func TestDiffHandler(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var diffs []string
+	name := func(obj *apiv1.TestType) string {
+		if obj == nil {
+			return "nil"
+		}
+		return obj.Name + "@" + obj.ResourceVersion
+	}
+	if _, err := AddTestTypeDiffHandler(fakeTestTypeInformer{informer}, func(oldObj, newObj *apiv1.TestType) {
+		diffs = append(diffs, name(oldObj)+" -> "+name(newObj))
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	newObj := func(name, resourceVersion string) *apiv1.TestType {
+		return &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", ResourceVersion: resourceVersion}}
+	}
+	informer.handler.OnAdd(newObj("foo", "1"), false)
+	informer.handler.OnUpdate(newObj("foo", "1"), newObj("foo", "2"))
+	informer.handler.OnDelete(newObj("foo", "2"))
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/bar", Obj: newObj("bar", "3")})
+
+	if want := []string{"nil -> foo@1", "foo@1 -> foo@2", "foo@2 -> nil", "bar@3 -> nil"}; !slices.Equal(diffs, want) {
+		t.Errorf("handler received %q, want %q", diffs, want)
+	}
+}
+
+// TestEventHandlerFuncs verifies that the typed callbacks receive the objects
+// of the events, including the last known state of tombstones, and that nil
+// callbacks are skipped.
+func TestEventHandlerFuncs(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var events []string
+	if _, err := AddTestTypeEventHandlerFuncs(fakeTestTypeInformer{informer},
+		func(obj *apiv1.TestType) {
+			events = append(events, "add "+obj.Name+"@"+obj.ResourceVersion)
+		},
+		func(oldObj, newObj *apiv1.TestType) {
+			events = append(events, "update "+oldObj.Name+"@"+oldObj.ResourceVersion+" -> "+newObj.ResourceVersion)
+		},
+		func(obj *apiv1.TestType) {
+			events = append(events, "delete "+obj.Name+"@"+obj.ResourceVersion)
+		},
+	); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	newObj := func(name, resourceVersion string) *apiv1.TestType {
+		return &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", ResourceVersion: resourceVersion}}
+	}
+	informer.handler.OnAdd(newObj("foo", "1"), false)
+	informer.handler.OnUpdate(newObj("foo", "1"), newObj("foo", "2"))
+	informer.handler.OnDelete(newObj("foo", "2"))
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/bar", Obj: newObj("bar", "3")})
+	// Objects of other types are ignored.
+	informer.handler.OnAdd(&metav1.PartialObjectMetadata{}, false)
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/baz", Obj: &metav1.PartialObjectMetadata{}})
+
+	if want := []string{"add foo@1", "update foo@1 -> 2", "delete foo@2", "delete bar@3"}; !slices.Equal(events, want) {
+		t.Errorf("handler received %q, want %q", events, want)
+	}
+
+	if _, err := AddTestTypeEventHandlerFuncs(fakeTestTypeInformer{informer}, nil, nil, nil); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	informer.handler.OnAdd(newObj("foo", "4"), false)
+	informer.handler.OnUpdate(newObj("foo", "4"), newObj("foo", "5"))
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/foo", Obj: newObj("foo", "5")})
+}
+
+// TestHandlerFromResourceVersion verifies that a handler resumed from a
+// resource version skips the objects which are not newer than it.
+func TestHandlerFromResourceVersion(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})}
+	var events []string
+	if _, err := AddTestTypeHandlerFromResourceVersion(fakeTestTypeInformer{informer}, "10", cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { events = append(events, "add "+obj.(*apiv1.TestType).Name) },
+		UpdateFunc: func(_, obj interface{}) { events = append(events, "update "+obj.(*apiv1.TestType).Name) },
+		DeleteFunc: func(obj interface{}) { events = append(events, "delete "+obj.(*apiv1.TestType).Name) },
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	older := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "older", Namespace: "ns", ResourceVersion: "9"}}
+	checkpoint := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "checkpoint", Namespace: "ns", ResourceVersion: "10"}}
+	newer := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "newer", Namespace: "ns", ResourceVersion: "11"}}
+	for _, obj := range []*apiv1.TestType{older, checkpoint, newer} {
+		informer.handler.OnAdd(obj, true)
+	}
+	// A relist of the unchanged object at the checkpoint is not delivered,
+	// but a later update of the older object is.
+	informer.handler.OnUpdate(checkpoint, checkpoint.DeepCopy())
+	olderUpdated := older.DeepCopy()
+	olderUpdated.ResourceVersion = "12"
+	informer.handler.OnUpdate(older, olderUpdated)
+	informer.handler.OnDelete(checkpoint)
+
+	if want := []string{"add newer", "update older", "delete checkpoint"}; !slices.Equal(events, want) {
+		t.Errorf("handler received %v, want %v", events, want)
+	}
+
+	if _, err := AddTestTypeHandlerFromResourceVersion(fakeTestTypeInformer{informer}, "not-a-number", cache.ResourceEventHandlerFuncs{}); err == nil {
+		t.Errorf("expected an error for an invalid resource version")
+	}
+}
+
+// TestStreamServer verifies that a stream server sends the marshaled events
+// and removes its event handler once the stream's context is canceled.
+func TestStreamServer(t *testing.T) {
+	informer := &removalTrackingInformer{
+		handlerTrackingInformer: &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{})},
+		removed:                 make(chan cache.ResourceEventHandlerRegistration, 1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &fakeEventStream{ctx: ctx}
+	registration, err := AddTestTypeStreamServer(fakeTestTypeInformer{informer}, stream, func(eventType watch.EventType, obj *apiv1.TestType) (string, error) {
+		return string(eventType) + " " + obj.Name, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to add stream server: %v", err)
+	}
+
+	foo := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: "1"}}
+	fooUpdated := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: "2"}}
+	informer.handler.OnAdd(foo, false)
+	informer.handler.OnUpdate(foo, fooUpdated)
+	informer.handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/foo", Obj: fooUpdated})
+	if want := []string{"ADDED foo", "MODIFIED foo", "DELETED foo"}; !slices.Equal(stream.sent, want) {
+		t.Errorf("sent %v, want %v", stream.sent, want)
+	}
+
+	cancel()
+	select {
+	case removed := <-informer.removed:
+		if removed != registration {
+			t.Errorf("removed registration %v, want %v", removed, registration)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("event handler was not removed after the stream was canceled")
+	}
+	informer.handler.OnAdd(foo, false)
+	if len(stream.sent) != 3 {
+		t.Errorf("sent %v after the stream was canceled", stream.sent[3:])
+	}
+}
+
+// TestFilteredView verifies that a filtered view hides the objects which do
+// not match its predicate from both its lister and its event handlers.
+func TestFilteredView(t *testing.T) {
+	informer := &handlerTrackingInformer{SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})}
+	filtered := FilteredTestType(fakeTestTypeInformer{informer}, func(obj *apiv1.TestType) bool {
+		return obj.Status.Blah == "ready"
+	})
+	var events []string
+	if _, err := filtered.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { events = append(events, "add "+obj.(*apiv1.TestType).Name) },
+		UpdateFunc: func(_, obj interface{}) { events = append(events, "update "+obj.(*apiv1.TestType).Name) },
+		DeleteFunc: func(obj interface{}) { events = append(events, "delete "+obj.(*apiv1.TestType).Name) },
+	}); err != nil {
+		t.Fatalf("failed to add event handler: %v", err)
+	}
+
+	ready := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "ns", ResourceVersion: "1"}, Status: apiv1.TestTypeStatus{Blah: "ready"}}
+	pending := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "ns", ResourceVersion: "1"}, Status: apiv1.TestTypeStatus{Blah: "pending"}}
+	for _, obj := range []*apiv1.TestType{ready, pending} {
+		if err := informer.GetIndexer().Add(obj); err != nil {
+			t.Fatalf("failed to add %s: %v", obj.Name, err)
+		}
+		informer.handler.OnAdd(obj, false)
+	}
+	// pending becomes ready and ready stops being ready.
+	pendingReady := pending.DeepCopy()
+	pendingReady.ResourceVersion, pendingReady.Status.Blah = "2", "ready"
+	informer.handler.OnUpdate(pending, pendingReady)
+	readyPending := ready.DeepCopy()
+	readyPending.ResourceVersion, readyPending.Status.Blah = "2", "pending"
+	informer.handler.OnUpdate(ready, readyPending)
+
+	if want := []string{"add ready", "add pending", "delete ready"}; !slices.Equal(events, want) {
+		t.Errorf("handler received %v, want %v", events, want)
+	}
+
+	lister := filtered.Lister()
+	listed, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Name != ready.Name {
+		t.Errorf("expected only ready to be listed, got %v", listed)
+	}
+	if namespaced, err := lister.TestTypes("ns").List(labels.Everything()); err != nil || len(namespaced) != 1 {
+		t.Errorf("expected only ready to be listed in ns, got %v, %v", namespaced, err)
+	}
+	if _, err := lister.TestTypes("ns").Get("pending"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected pending to be hidden, got %v", err)
+	}
+	if _, missing, err := lister.GetByKeys([]string{"ns/ready", "ns/pending"}); err != nil || !slices.Equal(missing, []string{"ns/pending"}) {
+		t.Errorf("expected ns/pending to be missing, got %v, %v", missing, err)
+	}
+}
+
+// TestPostSyncHandler verifies that a post-sync handler is not called for the
+// objects replayed before the sync, but only notified of the sync, and that it
+// receives the changes after the sync.
+func TestPostSyncHandler(t *testing.T) {
+	foo := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}
+	client := fake.NewSimpleClientset(foo)
+	watcher := watch.NewFake()
+	watchStarted := make(chan struct{})
+	var once sync.Once
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (handled bool, w watch.Interface, err error) {
+		once.Do(func() {
+			handled, w = true, watcher
+			close(watchStarted)
+		})
+		return handled, w, nil
+	})
+	informer := NewTestTypeInformer(client, metav1.NamespaceAll, 0, cache.Indexers{})
+	var lock sync.Mutex
+	var events []string
+	record := func(event string) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, event)
+	}
+	synced := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := AddTestTypePostSyncHandler(ctx, fakeTestTypeInformer{informer}, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { record("add " + obj.(*apiv1.TestType).Name) },
+		UpdateFunc: func(_, obj interface{}) { record("update " + obj.(*apiv1.TestType).Name) },
+		DeleteFunc: func(obj interface{}) { record("delete " + obj.(*apiv1.TestType).Name) },
+	}, func() {
+		record("synced")
+		close(synced)
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	go informer.RunWithContext(ctx)
+
+	for failure, done := range map[string]chan struct{}{"handler was not notified of the sync": synced, "watch was not started": watchStarted} {
+		select {
+		case <-done:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatal(failure)
+		}
+	}
+	watcher.Add(&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}})
+	watcher.Delete(foo)
+
+	want := []string{"synced", "add bar", "delete foo"}
+	var got []string
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		got = slices.Clone(events)
+		return len(got) >= len(want), nil
+	})
+	if err != nil || !slices.Equal(got, want) {
+		t.Errorf("handler received %v, want %v", got, want)
+	}
+}
+
+// fakeEventStream records the messages sent on it.
+type fakeEventStream struct {
+	ctx  context.Context
+	sent []string
+}
+
+func (s *fakeEventStream) Send(message string) error {
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func (s *fakeEventStream) Context() context.Context {
+	return s.ctx
+}
+
+// removalTrackingInformer reports the event handler registrations which are
+// removed.
+type removalTrackingInformer struct {
+	*handlerTrackingInformer
+	removed chan cache.ResourceEventHandlerRegistration
+}
+
+func (i *removalTrackingInformer) RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	i.removed <- registration
+	return i.handlerTrackingInformer.RemoveEventHandler(registration)
+}
+
+type fakeTestTypeInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+func (f fakeTestTypeInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+func (f fakeTestTypeInformer) Lister() listersapiv1.TestTypeLister {
+	return listersapiv1.NewTestTypeLister(f.informer.GetIndexer())
+}
+
+func (f fakeTestTypeInformer) Factory() internalinterfaces.SharedInformerFactory {
+	return nil
+}
+
+// minimalFactory implements only SharedInformerFactory, like the factories
+// and fakes which are not generated by informer-gen.
+type minimalFactory struct {
+	client    versioned.Interface
+	informers map[reflect.Type]cache.SharedIndexInformer
+}
+
+func (f *minimalFactory) Start(stopCh <-chan struct{}) {
+	for _, informer := range f.informers {
+		go informer.Run(stopCh)
+	}
+}
+
+func (f *minimalFactory) InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer {
+	informerType := reflect.TypeOf(obj)
+	if informer, ok := f.informers[informerType]; ok {
+		return informer
+	}
+	informer := newFunc(f.client, 0)
+	f.informers[informerType] = informer
+	return informer
+}
+
+func (f *minimalFactory) InformerName() *cache.InformerName {
+	return nil
+}
+
+// TestMinimalFactory verifies that the informers of a factory which does not
+// implement SharedInformerFactoryExtensions are created with the list options
+// tweak of their group and deliver events to their handlers.
+func TestMinimalFactory(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", Labels: map[string]string{"app": "a"}}},
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	factory := &minimalFactory{client: client, informers: map[reflect.Type]cache.SharedIndexInformer{}}
+	informer := New(factory, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.LabelSelector = "app=a"
+	}).TestTypes()
+	added := make(chan string, 2)
+	registration, err := AddTestTypeEventHandler(informer, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { added <- obj.(*apiv1.TestType).Name },
+	})
+	if err != nil {
+		t.Fatalf("failed to add the handler: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, registration.HasSynced) {
+		t.Fatal("the handler did not sync")
+	}
+	if name := <-added; name != "foo" {
+		t.Errorf("expected foo to be added, got %q", name)
+	}
+	if keys := informer.Informer().GetStore().ListKeys(); !slices.Equal(keys, []string{"ns/foo"}) {
+		t.Errorf("expected only ns/foo to be cached, got %v", keys)
+	}
+}
+
+// handlerTrackingInformer records the last event handler which was added.
+type handlerTrackingInformer struct {
+	cache.SharedIndexInformer
+	handler cache.ResourceEventHandler
+	removed []cache.ResourceEventHandlerRegistration
+}
+
+func (i *handlerTrackingInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	i.handler = handler
+	return i.SharedIndexInformer.AddEventHandler(handler)
+}
+
+func (i *handlerTrackingInformer) RemoveEventHandler(registration cache.ResourceEventHandlerRegistration) error {
+	i.removed = append(i.removed, registration)
+	return i.SharedIndexInformer.RemoveEventHandler(registration)
+}
+
+// TestTestTypeToApplyConfiguration verifies that the apply configuration
+// extracted from a cached object holds the fields owned by the field manager.
+func TestTestTypeToApplyConfiguration(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&apiv1.TestType{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "ns",
+			Labels:      map[string]string{"applied": "true", "updated": "true"},
+			Annotations: map[string]string{"updated": "true"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "controller",
+				Operation:  metav1.ManagedFieldsOperationApply,
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:applied":{}}},"f:status":{"f:blah":{}}}`)},
+			}, {
+				Manager:    "other",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:updated":{}},"f:labels":{"f:updated":{}}}}`)},
+			}},
+		},
+		Status: apiv1.TestTypeStatus{Blah: "ready"},
+	}); err != nil {
+		t.Fatalf("failed to add foo: %v", err)
+	}
+	obj, err := listersapiv1.NewTestTypeLister(indexer).TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get foo: %v", err)
+	}
+
+	applyConfiguration, err := TestTypeToApplyConfiguration(obj, "controller")
+	if err != nil {
+		t.Fatalf("failed to extract the apply configuration: %v", err)
+	}
+	if *applyConfiguration.Name != "foo" || *applyConfiguration.Namespace != "ns" {
+		t.Errorf("unexpected name %s/%s", *applyConfiguration.Namespace, *applyConfiguration.Name)
+	}
+	if *applyConfiguration.Kind != "TestType" || *applyConfiguration.APIVersion != "example.crd.code-generator.k8s.io/v1" {
+		t.Errorf("unexpected kind %s and API version %s", *applyConfiguration.Kind, *applyConfiguration.APIVersion)
+	}
+	if want := map[string]string{"applied": "true"}; !maps.Equal(applyConfiguration.Labels, want) {
+		t.Errorf("expected labels %v, got %v", want, applyConfiguration.Labels)
+	}
+	if applyConfiguration.Annotations != nil {
+		t.Errorf("expected no annotations, got %v", applyConfiguration.Annotations)
+	}
+	if applyConfiguration.Status == nil || applyConfiguration.Status.Blah == nil || *applyConfiguration.Status.Blah != "ready" {
+		t.Errorf("expected the status to be extracted, got %v", applyConfiguration.Status)
+	}
+
+	if applyConfiguration, err := TestTypeToApplyConfiguration(obj, "unknown"); err != nil || applyConfiguration.Labels != nil || applyConfiguration.Status != nil {
+		t.Errorf("expected only the name and type of foo for an unknown field manager, got %v, %v", applyConfiguration, err)
+	}
+}
+
+// TestExportTestTypeList verifies that the exported list holds copies of the
+// matching cached objects, sorted, with the kind and API version of the list.
+func TestExportTestTypeList(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns2", Labels: map[string]string{"app": "a"}}},
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns1", Labels: map[string]string{"app": "a"}}},
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns1", Labels: map[string]string{"app": "a"}}},
+		&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "ns1", Labels: map[string]string{"app": "b"}}},
+	)
+	informer := fakeTestTypeInformer{NewTestTypeInformer(client, metav1.NamespaceAll, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go informer.Informer().RunWithContext(ctx)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		t.Fatalf("failed to sync the cache")
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "a"})
+	for _, tc := range []struct {
+		namespace string
+		want      []string
+	}{
+		{metav1.NamespaceAll, []string{"ns1/bar", "ns1/foo", "ns2/foo"}},
+		{"ns1", []string{"ns1/bar", "ns1/foo"}},
+	} {
+		list, err := ExportTestTypeList(informer, tc.namespace, selector)
+		if err != nil {
+			t.Fatalf("failed to export the list of %q: %v", tc.namespace, err)
+		}
+		if list.Kind != "TestTypeList" || list.APIVersion != "example.crd.code-generator.k8s.io/v1" {
+			t.Errorf("unexpected type meta %+v", list.TypeMeta)
+		}
+		var keys []string
+		for _, item := range list.Items {
+			keys = append(keys, item.Namespace+"/"+item.Name)
+		}
+		if !slices.Equal(keys, tc.want) {
+			t.Errorf("expected items %v in %q, got %v", tc.want, tc.namespace, keys)
+		}
+		list.Items[0].Labels["app"] = "modified"
+		if cached, err := informer.Lister().TestTypes(list.Items[0].Namespace).Get(list.Items[0].Name); err != nil || cached.Labels["app"] != "a" {
+			t.Errorf("expected the items to be copies of the cached objects, got %v, %v", cached, err)
+		}
+	}
+}
+
+// TestTestTypeController verifies that the controller reconciles the keys of
+// existing and added objects, and that keys whose reconciliation failed are
+// reconciled again.
+func TestTestTypeController(t *testing.T) {
+	client := fake.NewSimpleClientset(&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	informer := fakeTestTypeInformer{NewTestTypeInformer(client, metav1.NamespaceAll, 0, cache.Indexers{})}
+	reconciled := make(chan string, 10)
+	var failed sync.Once
+	controller := NewTestTypeController(informer, func(key string) error {
+		reconciled <- key
+		if key == "ns/bar" {
+			var err error
+			failed.Do(func() { err = apierrors.NewConflict(apiv1.Resource("testtypes"), "bar", nil) })
+			return err
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go informer.Informer().RunWithContext(ctx)
+	done := make(chan error)
+	go func() { done <- controller.Run(ctx, 2) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error from Run: %v", err)
+		}
+	}()
+
+	if key := <-reconciled; key != "ns/foo" {
+		t.Fatalf("expected ns/foo to be reconciled, got %q", key)
+	}
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	for i := range 2 {
+		select {
+		case key := <-reconciled:
+			if key != "ns/bar" {
+				t.Fatalf("expected ns/bar to be reconciled, got %q", key)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("timed out waiting for reconciliation %d of ns/bar", i+1)
+		}
+	}
+}
@@ -17,13 +17,53 @@ limitations under the License.
 package externalversions
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/cbor"
+	serializerjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/features"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	crdexamplev1 "k8s.io/code-generator/examples/crd/apis/example/v1"
+	crdfake "k8s.io/code-generator/examples/crd/clientset/versioned/fake"
+	crdinformers "k8s.io/code-generator/examples/crd/informers/externalversions"
 	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
 	"k8s.io/code-generator/examples/single/clientset/versioned"
+	"k8s.io/code-generator/examples/single/clientset/versioned/fake"
+	informersapiv1 "k8s.io/code-generator/examples/single/informers/externalversions/api/v1"
+	"k8s.io/code-generator/examples/single/informers/externalversions/internalinterfaces"
+	"k8s.io/klog/v2/ktesting"
 )
 
 // TestTransforms verified that transform calls are applied as expected.
@@ -109,3 +149,3245 @@ func (s *transformTrackingInformer) SetTransform(handler cache.TransformFunc) er
 	s.lastTransform = handler
 	return s.SharedIndexInformer.SetTransform(handler)
 }
+
+// TestIngestTimestamps verifies that ingest timestamps are recorded without
+// touching the cached objects.
+func TestIngestTimestamps(t *testing.T) {
+	obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", UID: "foo-uid"}}
+	client := fake.NewSimpleClientset(obj)
+
+	before := time.Now()
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithIngestTimestamps())
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	cached, err := informer.Lister().TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get cached object: %v", err)
+	}
+	if len(cached.Annotations) != 0 {
+		t.Errorf("cached object was modified: %v", cached.Annotations)
+	}
+	ingested, ok := factory.IngestTime(cached)
+	if !ok {
+		t.Fatalf("no ingest time recorded for %s", cached.Name)
+	}
+	if ingested.Before(before) || ingested.After(time.Now()) {
+		t.Errorf("ingest time %v outside of expected range", ingested)
+	}
+
+	unknown := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: "bar-uid"}}
+	if _, ok := factory.IngestTime(unknown); ok {
+		t.Errorf("unexpected ingest time for %s", unknown.Name)
+	}
+}
+
+// TestInformerStats verifies that stats reflect queued and cached objects.
+func TestInformerStats(t *testing.T) {
+	factory := NewSharedInformerFactoryWithOptions(nil, 0, WithInformerStats())
+	if _, ok := factory.Stats(&singleapiv1.TestType{}); ok {
+		t.Fatalf("unexpected stats for an informer which was not requested")
+	}
+
+	var wrapper *transformTrackingInformer
+	factory.InformerFor(&singleapiv1.TestType{}, func(_ versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		inner := cache.NewSharedIndexInformer(nil, &singleapiv1.TestType{}, resyncPeriod, cache.Indexers{})
+		wrapper = &transformTrackingInformer{SharedIndexInformer: inner}
+		return wrapper
+	})
+
+	// Simulate the informer queueing two objects, one of which is already cached.
+	for _, name := range []string{"foo", "bar"} {
+		obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+		if _, err := wrapper.lastTransform(obj); err != nil {
+			t.Fatalf("failed to invoke transform: %v", err)
+		}
+	}
+	if err := wrapper.GetStore().Add(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+
+	stats, ok := factory.Stats(&singleapiv1.TestType{})
+	if !ok {
+		t.Fatalf("no stats for requested informer")
+	}
+	if want := (InformerStats{QueueLength: 2, ObjectCount: 1}); stats != want {
+		t.Errorf("stats: got %+v, want %+v", stats, want)
+	}
+}
+
+// TestInformerStatsDrained verifies that the queue length drops to zero once
+// all objects were delivered.
+func TestInformerStatsDrained(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithInformerStats())
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		stats, _ := factory.Stats(&singleapiv1.TestType{})
+		return stats == InformerStats{QueueLength: 0, ObjectCount: 2}, nil
+	})
+	if err != nil {
+		stats, _ := factory.Stats(&singleapiv1.TestType{})
+		t.Errorf("queue was not drained, stats: %+v", stats)
+	}
+}
+
+// TestPendingDeltas verifies that changes are reported as pending until every
+// event handler processed them, and that a change superseding a pending one
+// is not counted again.
+func TestPendingDeltas(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	events := watch.NewFake()
+	watchStarted := make(chan struct{})
+	var once sync.Once
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (handled bool, w watch.Interface, err error) {
+		once.Do(func() {
+			handled, w = true, events
+			close(watchStarted)
+		})
+		return handled, w, nil
+	})
+
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithInformerStats())
+	informer := factory.Example().V1().TestTypes().Informer()
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	if got := factory.PendingDeltas(resource); got != 0 {
+		t.Fatalf("expected no pending deltas before the informer is started, got %d", got)
+	}
+
+	// The informer keeps processing changes while one of its handlers is
+	// blocked, and a handler which returns at once does not clear them.
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	var processed atomic.Int32
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{}); err != nil {
+		t.Fatalf("failed to add the event handler: %v", err)
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) {
+			<-release
+			processed.Add(1)
+		},
+		UpdateFunc: func(interface{}, interface{}) {
+			<-release
+			processed.Add(1)
+		},
+	}); err != nil {
+		t.Fatalf("failed to add the event handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	defer unblock()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	<-watchStarted
+	for i := 0; i < 5; i++ {
+		events.Add(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "obj-" + strconv.Itoa(i), Namespace: "ns", ResourceVersion: strconv.Itoa(i + 1)}})
+	}
+	events.Modify(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "obj-0", Namespace: "ns", ResourceVersion: "6"}})
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		// The informer processes changes in order, so the cache holds the
+		// update once it processed all of them.
+		obj, exists, err := informer.GetStore().GetByKey("ns/obj-0")
+		if err != nil || !exists {
+			return false, err
+		}
+		return obj.(*singleapiv1.TestType).ResourceVersion == "6", nil
+	})
+	if err != nil {
+		t.Fatalf("the informer did not process the changes: %v", err)
+	}
+	if got := factory.PendingDeltas(resource); got != 5 {
+		t.Fatalf("expected 5 pending deltas while a handler is blocked, got %d", got)
+	}
+
+	unblock()
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return factory.PendingDeltas(resource) == 0, nil
+	})
+	if err != nil {
+		t.Errorf("expected no pending deltas once processed, got %d", factory.PendingDeltas(resource))
+	}
+	if got := processed.Load(); got != 6 {
+		t.Errorf("expected the handler to process 6 changes, got %d", got)
+	}
+	if got := factory.PendingDeltas(schema.GroupVersionResource{Resource: "unknown"}); got != 0 {
+		t.Errorf("expected no pending deltas for an unknown resource, got %d", got)
+	}
+}
+
+// TestPendingDeltasController verifies that the changes delivered to a
+// controller stop being pending once their keys are queued, even while their
+// reconciliation is blocked.
+func TestPendingDeltasController(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithInformerStats())
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	release := make(chan struct{})
+	reconciling := make(chan string, 3)
+	controller := informersapiv1.NewTestTypeController(informer, func(key string) error {
+		reconciling <- key
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	done := make(chan error)
+	go func() { done <- controller.Run(ctx, 1) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error from Run: %v", err)
+		}
+	}()
+	defer close(release)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "obj-" + strconv.Itoa(i), Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create obj-%d: %v", i, err)
+		}
+	}
+	select {
+	case <-reconciling:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for a reconciliation")
+	}
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return len(informer.Informer().GetStore().ListKeys()) == 3 && factory.PendingDeltas(resource) == 0, nil
+	})
+	if err != nil {
+		t.Errorf("expected no pending deltas once the keys were queued, got %d", factory.PendingDeltas(resource))
+	}
+	if len(reconciling) != 0 {
+		t.Errorf("expected a single reconciliation while it is blocked, got %d more", len(reconciling))
+	}
+}
+
+// TestStalenessWatchdog verifies that the watchdog reports an informer whose
+// watch stays open but delivers nothing once the window elapsed.
+func TestStalenessWatchdog(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	stalled := watch.NewFake()
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (bool, watch.Interface, error) {
+		return true, stalled, nil
+	})
+
+	const maxStaleness = 100 * time.Millisecond
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	stale := make(chan schema.GroupVersionResource, 10)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithStalenessWatchdog(resource, maxStaleness, func(resource schema.GroupVersionResource) {
+		stale <- resource
+	}))
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	started := time.Now()
+	factory.StartWithContext(ctx)
+
+	select {
+	case got := <-stale:
+		if got != resource {
+			t.Errorf("expected the watchdog to report %v, got %v", resource, got)
+		}
+		if elapsed := time.Since(started); elapsed < maxStaleness {
+			t.Errorf("expected the watchdog to fire after %v, fired after %v", maxStaleness, elapsed)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the watchdog did not fire for a stalled informer")
+	}
+}
+
+// TestCloneForNamespace verifies that a clone is limited to its namespace,
+// keeps the options of its parent and is started independently.
+func TestCloneForNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns1", Labels: map[string]string{"app": "foo"}}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns2", Labels: map[string]string{"app": "foo"}}},
+	)
+	parent := NewSharedInformerFactoryWithOptions(client, 0,
+		WithTweakListOptions(func(options *metav1.ListOptions) { options.LabelSelector = "app=foo" }),
+		WithTransform(func(obj interface{}) (interface{}, error) {
+			obj.(*singleapiv1.TestType).Annotations = map[string]string{"transformed": "true"}
+			return obj, nil
+		}),
+	)
+	parentInformer := parent.Example().V1().TestTypes().Informer()
+	clone := parent.CloneForNamespace("ns1")
+	lister := clone.Example().V1().TestTypes().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer parent.Shutdown()
+	defer cancel()
+	clone.StartWithContext(ctx)
+	if err := clone.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	objs, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(objs) != 1 || objs[0].Namespace != "ns1" {
+		t.Fatalf("expected only the object of ns1, got %v", objs)
+	}
+	if objs[0].Annotations["transformed"] != "true" {
+		t.Errorf("expected the transform of the parent to be applied, got %v", objs[0].Annotations)
+	}
+	for _, action := range client.Actions() {
+		if list, ok := action.(clienttesting.ListActionImpl); ok {
+			if list.GetNamespace() != "ns1" || list.ListRestrictions.Labels.String() != "app=foo" {
+				t.Errorf("expected a list of ns1 with the tweaked selector, got %v", list)
+			}
+		}
+	}
+	if parentInformer.HasSynced() {
+		t.Errorf("expected the parent informer not to be started with the clone")
+	}
+
+	clone.Shutdown()
+	parent.StartWithContext(ctx)
+	if err := parent.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync the parent after the clone was shut down: %v", err)
+	}
+	if got := len(parentInformer.GetStore().ListKeys()); got != 2 {
+		t.Errorf("expected the parent to cache both namespaces, got %d objects", got)
+	}
+}
+
+// TestCloneForNamespaceInformerName verifies that a clone registers its
+// informers under a name of its own, and that shutting it down leaves the name
+// of its parent registered.
+func TestCloneForNamespaceInformerName(t *testing.T) {
+	informerName, err := cache.NewInformerName("clone-parent")
+	if err != nil {
+		t.Fatalf("failed to create the informer name: %v", err)
+	}
+	defer informerName.Release()
+	parent := NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, WithInformerName(informerName))
+	parent.Example().V1().TestTypes().Informer()
+
+	clone := parent.CloneForNamespace("ns1")
+	clone.Example().V1().TestTypes().Informer()
+	cloneName := clone.InformerName()
+	if cloneName == nil || cloneName == informerName {
+		t.Fatalf("expected the clone to have an informer name of its own, got %v", cloneName)
+	}
+	if got, want := cloneName.Name(), "clone-parent/ns1"; got != want {
+		t.Errorf("clone informer name: got %q, want %q", got, want)
+	}
+
+	clone.Shutdown()
+	if _, err := cache.NewInformerName("clone-parent"); err == nil {
+		t.Errorf("expected the name of the parent to remain registered after the clone was shut down")
+	}
+	other := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "others"}
+	if !informerName.WithResource(other).Reserved() {
+		t.Errorf("expected the name of the parent to remain reserved after the clone was shut down")
+	}
+	if released, err := cache.NewInformerName("clone-parent/ns1"); err != nil {
+		t.Errorf("expected the name of the clone to be released by its shutdown: %v", err)
+	} else {
+		released.Release()
+	}
+}
+
+// TestNamespaceSelectors verifies that each namespace is listed and watched
+// with its own label selector.
+func TestNamespaceSelectors(t *testing.T) {
+	var objects []runtime.Object
+	for _, namespace := range []string{"ns1", "ns2", "ns3", "other"} {
+		for _, tenant := range []string{"a", "b", "c"} {
+			objects = append(objects, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: tenant, Namespace: namespace, Labels: map[string]string{"tenant": tenant}}})
+		}
+	}
+	client := fake.NewSimpleClientset(objects...)
+	var lists atomic.Int32
+	client.PrependReactor("list", "testtypes", func(clienttesting.Action) (bool, runtime.Object, error) {
+		lists.Add(1)
+		return false, nil, nil
+	})
+	watches := make(chan *watch.FakeWatcher, 10)
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		watches <- w
+		return true, w, nil
+	})
+
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithTweakListOptions(func(options *metav1.ListOptions) { options.LabelSelector = "tenant=c" }),
+		WithNamespaceSelectors(map[string]labels.Selector{
+			"ns1": labels.SelectorFromSet(labels.Set{"tenant": "a"}),
+			"ns2": labels.SelectorFromSet(labels.Set{"tenant": "b"}),
+			"ns3": nil,
+		}),
+	)
+	informer := factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	keys := informer.GetStore().ListKeys()
+	slices.Sort(keys)
+	if want := []string{"ns1/a", "ns2/b", "ns3/c"}; !slices.Equal(keys, want) {
+		t.Errorf("cached %v, want %v", keys, want)
+	}
+	if got := lists.Load(); got != 3 {
+		t.Errorf("expected one list per namespace, got %d", got)
+	}
+
+	for range 3 {
+		select {
+		case <-watches:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("expected a watch per namespace")
+		}
+	}
+}
+
+// namespaceWatch is a watch of a namespace started by a reflector.
+type namespaceWatch struct {
+	namespace       string
+	resourceVersion string
+	watcher         *watch.FakeWatcher
+}
+
+// TestNamespaceSelectorsResourceVersions verifies that each namespace of
+// WithNamespaceSelectors is listed with the page size and the pinned resource
+// version of the informer, and that the watch of each namespace resumes from
+// its own resource version without a relist.
+func TestNamespaceSelectorsResourceVersions(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var lock sync.Mutex
+	var lists []metav1.ListOptions
+	client.PrependReactor("list", "testtypes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		options := action.(clienttesting.ListActionImpl).ListOptions
+		lock.Lock()
+		lists = append(lists, options)
+		lock.Unlock()
+		// Each namespace is served in two pages of one object each.
+		namespace := action.GetNamespace()
+		if options.Continue == "" {
+			return true, &singleapiv1.TestTypeList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "10", Continue: "page2"},
+				Items:    []singleapiv1.TestType{{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: namespace}}},
+			}, nil
+		}
+		return true, &singleapiv1.TestTypeList{
+			ListMeta: metav1.ListMeta{ResourceVersion: "10"},
+			Items:    []singleapiv1.TestType{{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: namespace}}},
+		}, nil
+	})
+	watches := make(chan namespaceWatch, 10)
+	client.PrependWatchReactor("testtypes", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		watches <- namespaceWatch{
+			namespace:       action.GetNamespace(),
+			resourceVersion: action.(clienttesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion,
+			watcher:         w,
+		}
+		return true, w, nil
+	})
+
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithNamespaceSelectors(map[string]labels.Selector{"ns1": nil, "ns2": nil}),
+		WithWatchListPageSize(resource, 1),
+		WithInitialResourceVersion(resource, "10"),
+	)
+	informer := factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	keys := informer.GetStore().ListKeys()
+	slices.Sort(keys)
+	if want := []string{"ns1/a", "ns1/b", "ns2/a", "ns2/b"}; !slices.Equal(keys, want) {
+		t.Errorf("cached %v, want %v", keys, want)
+	}
+	lock.Lock()
+	for _, options := range lists {
+		if options.Limit != 1 {
+			t.Errorf("expected every page to request 1 item, got %+v", options)
+		}
+		first := options.Continue == ""
+		if first && (options.ResourceVersion != "10" || options.ResourceVersionMatch != metav1.ResourceVersionMatchExact) {
+			t.Errorf("expected the first page to be pinned to resource version 10, got %+v", options)
+		}
+		if !first && (options.Continue != "page2" || options.ResourceVersion != "") {
+			t.Errorf("expected the second page to continue the first one, got %+v", options)
+		}
+	}
+	if len(lists) != 4 {
+		t.Errorf("expected two pages per namespace, got %d lists", len(lists))
+	}
+	lock.Unlock()
+
+	nextWatches := func() map[string]namespaceWatch {
+		started := make(map[string]namespaceWatch)
+		for range 2 {
+			select {
+			case w := <-watches:
+				started[w.namespace] = w
+			case <-time.After(wait.ForeverTestTimeout):
+				t.Fatalf("expected a watch per namespace")
+			}
+		}
+		return started
+	}
+	started := nextWatches()
+	for namespace, w := range started {
+		if w.resourceVersion != "10" {
+			t.Errorf("expected the watch of %s to start from resource version 10, got %q", namespace, w.resourceVersion)
+		}
+	}
+
+	// The namespaces see changes at different resource versions, and the
+	// end of the watch of one of them ends both.
+	updated := make(chan string, 2)
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) { updated <- obj.(*singleapiv1.TestType).Namespace },
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	started["ns1"].watcher.Modify(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1", ResourceVersion: "15"}})
+	started["ns2"].watcher.Modify(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns2", ResourceVersion: "12"}})
+	for range 2 {
+		select {
+		case <-updated:
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("expected an update per namespace")
+		}
+	}
+	started["ns1"].watcher.Stop()
+
+	resumed := nextWatches()
+	for namespace, want := range map[string]string{"ns1": "15", "ns2": "12"} {
+		if got := resumed[namespace].resourceVersion; got != want {
+			t.Errorf("expected the watch of %s to resume from resource version %s, got %q", namespace, want, got)
+		}
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(lists) != 4 {
+		t.Errorf("expected the watches to resume without a relist, got %d lists", len(lists))
+	}
+}
+
+// TestNamespaceSelectorsCacheSnapshot verifies that an informer limited to
+// the namespaces of WithNamespaceSelectors starts from its cache snapshot and
+// watches each namespace from the resource version of the snapshot.
+func TestNamespaceSelectorsCacheSnapshot(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var lists atomic.Int32
+	client.PrependReactor("list", "testtypes", func(clienttesting.Action) (bool, runtime.Object, error) {
+		lists.Add(1)
+		return false, nil, nil
+	})
+	watches := make(chan namespaceWatch, 10)
+	client.PrependWatchReactor("testtypes", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		watches <- namespaceWatch{
+			namespace:       action.GetNamespace(),
+			resourceVersion: action.(clienttesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion,
+			watcher:         w,
+		}
+		return true, w, nil
+	})
+
+	snapshot := strings.NewReader(`{"metadata":{"resourceVersion":"7"},"items":[{"metadata":{"name":"a","namespace":"ns1"}}]}`)
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithNamespaceSelectors(map[string]labels.Selector{"ns1": nil, "ns2": nil}),
+		WithCacheSnapshot(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), snapshot, nil),
+	)
+	informer := factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if keys := informer.GetStore().ListKeys(); !slices.Equal(keys, []string{"ns1/a"}) {
+		t.Errorf("expected the cache to be warmed from the snapshot, got %v", keys)
+	}
+
+	for range 2 {
+		select {
+		case w := <-watches:
+			if w.resourceVersion != "7" {
+				t.Errorf("expected the watch of %s to start from the snapshot, got resource version %q", w.namespace, w.resourceVersion)
+			}
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("expected a watch per namespace")
+		}
+	}
+	if got := lists.Load(); got != 0 {
+		t.Errorf("expected no list, got %d", got)
+	}
+}
+
+// TestResync verifies that Resync delivers every cached object to the
+// handlers added through AddResyncHandler as an update to itself, and not to
+// the handlers added to the informer directly.
+func TestResync(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	if err := factory.Resync(&singleapiv1.TestType{}); err == nil {
+		t.Errorf("expected an error for an informer which was not requested")
+	}
+
+	var lock sync.Mutex
+	var resynced []string
+	if _, err := factory.AddResyncHandler(&singleapiv1.TestType{}, cache.ResourceEventHandlerFuncs{}); err == nil {
+		t.Errorf("expected an error adding a handler to an informer which was not requested")
+	}
+	informer := factory.Example().V1().TestTypes().Informer()
+	if _, err := factory.AddResyncHandler(&singleapiv1.TestType{}, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj != newObj {
+				t.Errorf("expected an update of an object to itself, got %v and %v", oldObj, newObj)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			resynced = append(resynced, newObj.(*singleapiv1.TestType).Name)
+		},
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	removed, err := factory.AddResyncHandler(&singleapiv1.TestType{}, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			t.Errorf("unexpected update of a removed handler: %v", newObj)
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	if err := factory.RemoveResyncHandler(&singleapiv1.TestType{}, removed); err != nil {
+		t.Fatalf("failed to remove handler: %v", err)
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			t.Errorf("unexpected update of a handler added to the informer directly: %v", newObj)
+		},
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	if err := factory.Resync(&singleapiv1.TestType{}); err == nil {
+		t.Errorf("expected an error for an informer which has not synced")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	if err := factory.Resync(&singleapiv1.TestType{}); err != nil {
+		t.Fatalf("failed to resync: %v", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	slices.Sort(resynced)
+	if want := []string{"bar", "foo"}; !slices.Equal(resynced, want) {
+		t.Errorf("resynced %v, want %v", resynced, want)
+	}
+}
+
+// TestReplayKey verifies that ReplayKey delivers the cached object with a key
+// to the resync handlers of the informer as an update of the object to itself.
+func TestReplayKey(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	if err := factory.ReplayKey(&singleapiv1.TestType{}, "ns/foo"); err == nil {
+		t.Errorf("expected an error for an informer which was not requested")
+	}
+
+	var lock sync.Mutex
+	var replayed []interface{}
+	informer := factory.Example().V1().TestTypes().Informer()
+	for range 2 {
+		if _, err := factory.AddResyncHandler(&singleapiv1.TestType{}, cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if oldObj != newObj {
+					t.Errorf("expected an update of an object to itself, got %v and %v", oldObj, newObj)
+				}
+				lock.Lock()
+				defer lock.Unlock()
+				replayed = append(replayed, newObj)
+			},
+		}); err != nil {
+			t.Fatalf("failed to add handler: %v", err)
+		}
+	}
+	if err := factory.ReplayKey(&singleapiv1.TestType{}, "ns/foo"); err == nil {
+		t.Errorf("expected an error for an informer which has not synced")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	if err := factory.ReplayKey(&singleapiv1.TestType{}, "ns/foo"); err != nil {
+		t.Fatalf("failed to replay ns/foo: %v", err)
+	}
+	if err := factory.ReplayKey(&singleapiv1.TestType{}, "ns/baz"); err == nil {
+		t.Errorf("expected an error for a key which is not cached")
+	}
+	cached, _, err := informer.GetStore().GetByKey("ns/foo")
+	if err != nil {
+		t.Fatalf("failed to get foo: %v", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(replayed) != 2 || replayed[0] != cached || replayed[1] != cached {
+		t.Errorf("expected both handlers to get the cached foo, got %v", replayed)
+	}
+}
+
+// TestEventHandlerPriorities verifies that the handlers added with a priority
+// are invoked in ascending priority, in the order they were added for equal
+// priorities, and that handlers added late get the cached objects first.
+func TestEventHandlerPriorities(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes()
+
+	var lock sync.Mutex
+	var invocations []string
+	events := make(chan struct{}, 100)
+	handler := func(name string) cache.ResourceEventHandler {
+		record := func(obj interface{}) {
+			lock.Lock()
+			defer lock.Unlock()
+			invocations = append(invocations, name+":"+obj.(*singleapiv1.TestType).Name)
+			events <- struct{}{}
+		}
+		return cache.ResourceEventHandlerFuncs{
+			AddFunc:    record,
+			UpdateFunc: func(_, newObj interface{}) { record(newObj) },
+		}
+	}
+	takeInvocations := func(n int) []string {
+		for range n {
+			<-events
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		taken := invocations
+		invocations = nil
+		return taken
+	}
+	for _, h := range []struct {
+		name     string
+		priority int
+	}{{"a", 10}, {"b", 0}, {"c", 10}, {"d", -5}} {
+		if _, err := informersapiv1.AddTestTypeEventHandlerWithPriority(informer, h.priority, handler(h.name)); err != nil {
+			t.Fatalf("failed to add handler %s: %v", h.name, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if got, want := takeInvocations(4), []string{"d:foo", "b:foo", "a:foo", "c:foo"}; !slices.Equal(got, want) {
+		t.Errorf("got invocations %v, want %v", got, want)
+	}
+
+	// A handler added late gets the cached objects before it is returned.
+	if _, err := informersapiv1.AddTestTypeEventHandlerWithPriority(informer, 1, handler("e")); err != nil {
+		t.Fatalf("failed to add handler e: %v", err)
+	}
+	if got, want := takeInvocations(1), []string{"e:foo"}; !slices.Equal(got, want) {
+		t.Errorf("got invocations %v, want %v", got, want)
+	}
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	if got, want := takeInvocations(5), []string{"d:bar", "b:bar", "e:bar", "a:bar", "c:bar"}; !slices.Equal(got, want) {
+		t.Errorf("got invocations %v, want %v", got, want)
+	}
+
+	if _, err := informersapiv1.AddTestTypeEventHandlerWithPriority(struct {
+		informersapiv1.TestTypeInformer
+	}{}, 0, handler("f")); err == nil {
+		t.Errorf("expected an error for an informer which was not obtained from a factory")
+	}
+}
+
+// TestWaitForCondition verifies that WaitForCondition returns once objects
+// are added until its predicate holds, and the cause of the context being
+// done otherwise.
+func TestWaitForCondition(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	factory := NewSharedInformerFactory(client, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	if err := factory.WaitForCondition(ctx, &singleapiv1.TestType{}, func(cache.Store) bool { return true }); err == nil {
+		t.Errorf("expected an error for an informer which was not requested")
+	}
+
+	factory.Example().V1().TestTypes().Informer()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	waited := make(chan error, 1)
+	go func() {
+		waited <- factory.WaitForCondition(ctx, &singleapiv1.TestType{}, func(store cache.Store) bool {
+			return len(store.List()) >= 3
+		})
+	}()
+	for i := range 3 {
+		select {
+		case err := <-waited:
+			t.Fatalf("the wait returned with %d objects: %v", i, err)
+		default:
+		}
+		obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo" + strconv.Itoa(i), Namespace: "ns"}}
+		if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create %s: %v", obj.Name, err)
+		}
+	}
+	if err := <-waited; err != nil {
+		t.Errorf("the wait failed: %v", err)
+	}
+
+	timeoutCtx, timeoutCancel := context.WithTimeoutCause(ctx, 10*time.Millisecond, errors.New("gave up"))
+	defer timeoutCancel()
+	err := factory.WaitForCondition(timeoutCtx, &singleapiv1.TestType{}, func(store cache.Store) bool {
+		return len(store.List()) > 3
+	})
+	if err == nil || err.Error() != "gave up" {
+		t.Errorf("expected the cause of the timeout, got %v", err)
+	}
+}
+
+// TestStartSummary verifies that Start logs a summary of the informers it
+// started with the logger of WithLogger.
+func TestStartSummary(t *testing.T) {
+	logger := ktesting.NewLogger(ktesting.NopTL{}, ktesting.NewConfig(ktesting.BufferLogs(true)))
+	client := fake.NewSimpleClientset()
+	factory := NewSharedInformerFactoryWithOptions(client, time.Minute,
+		WithLogger(logger),
+		WithNamespace("ns"),
+		WithCustomResyncConfig(map[metav1.Object]time.Duration{&singleapiv1.ClusterTestType{}: time.Hour}),
+		WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = "app=foo"
+		}),
+	)
+	factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	// Nothing is left to start, so nothing is logged.
+	factory.StartWithContext(ctx)
+
+	entries := logger.GetSink().(ktesting.Underlier).GetBuffer().Data()
+	if len(entries) != 1 {
+		t.Fatalf("expected a single summary, got %v", entries)
+	}
+	if entries[0].Message != "Started informers" {
+		t.Errorf("unexpected message %q", entries[0].Message)
+	}
+	fields := map[string]interface{}{}
+	for i := 0; i+1 < len(entries[0].ParameterKVList); i += 2 {
+		fields[entries[0].ParameterKVList[i].(string)] = entries[0].ParameterKVList[i+1]
+	}
+	if fields["count"] != 2 || fields["namespace"] != "ns" || fields["defaultResync"] != time.Minute {
+		t.Errorf("unexpected summary fields %v", fields)
+	}
+	want := []informerStartSummary{
+		{Resource: singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes").String(), Resync: time.Hour, LabelSelector: "app=foo"},
+		{Resource: singleapiv1.SchemeGroupVersion.WithResource("testtypes").String(), Resync: time.Minute, LabelSelector: "app=foo"},
+	}
+	if got := fields["informers"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got informers %+v, want %+v", got, want)
+	}
+}
+
+// TestKeyNormalizer verifies that objects whose names normalize to the same
+// name share a single cache entry, which is found by any of their names.
+func TestKeyNormalizer(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "Foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithKeyNormalizer(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), strings.ToLower))
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	keys := informer.Informer().GetStore().ListKeys()
+	slices.Sort(keys)
+	if want := []string{"ns/bar", "ns/foo"}; !slices.Equal(keys, want) {
+		t.Errorf("expected keys %v, got %v", want, keys)
+	}
+	for _, name := range []string{"foo", "Foo", "FOO"} {
+		if obj, err := informer.Lister().TestTypes("ns").Get(name); err != nil || !strings.EqualFold(obj.Name, "foo") {
+			t.Errorf("failed to get %s: %v, %v", name, obj, err)
+		}
+	}
+}
+
+// countingCacheBackend is a CacheBackend which records the indexers it
+// created.
+type countingCacheBackend struct {
+	lock     sync.Mutex
+	indexers []cache.Indexer
+}
+
+func (b *countingCacheBackend) NewIndexer(indexers cache.Indexers) cache.Indexer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	b.indexers = append(b.indexers, indexer)
+	return indexer
+}
+
+// TestCacheBackend verifies that the informers of a factory created with
+// WithCacheBackend store their caches in indexers of the backend and that
+// their listers read from them.
+func TestCacheBackend(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	backend := &countingCacheBackend{}
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithCacheBackend(backend))
+	informer := factory.Example().V1().TestTypes()
+	sharedInformer := informer.Informer()
+	if len(backend.indexers) != 1 {
+		t.Fatalf("expected 1 indexer of the backend, got %d", len(backend.indexers))
+	}
+	indexer := backend.indexers[0]
+	if sharedInformer.GetIndexer() != indexer || sharedInformer.GetStore() != indexer {
+		t.Errorf("the informer does not use the indexer of the backend")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if keys := indexer.ListKeys(); !reflect.DeepEqual(keys, []string{"ns/foo"}) {
+		t.Errorf("expected the indexer of the backend to hold ns/foo, got %v", keys)
+	}
+	if _, err := informer.Lister().TestTypes("ns").Get("foo"); err != nil {
+		t.Errorf("failed to get foo: %v", err)
+	}
+
+	if err := client.ExampleV1().TestTypes("ns").Delete(ctx, "foo", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete foo: %v", err)
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return len(indexer.ListKeys()) == 0, nil
+	}); err != nil {
+		t.Errorf("foo was not deleted from the indexer of the backend: %v", err)
+	}
+}
+
+// sizedCacheBackend is a SizedCacheBackend which records the capacities of the
+// indexers it created.
+type sizedCacheBackend struct {
+	countingCacheBackend
+	capacities []int
+}
+
+func (b *sizedCacheBackend) NewIndexerWithCapacity(indexers cache.Indexers, capacity int) cache.Indexer {
+	b.lock.Lock()
+	b.capacities = append(b.capacities, capacity)
+	b.lock.Unlock()
+	return b.NewIndexer(indexers)
+}
+
+// TestInitialCacheCapacity verifies that only the informers of resources
+// passed to WithInitialCacheCapacity get indexers sized by the backend, and
+// that their caches behave as without a capacity.
+func TestInitialCacheCapacity(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	backend := &sizedCacheBackend{}
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithCacheBackend(backend),
+		WithInitialCacheCapacity(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), 100),
+	)
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+	if !reflect.DeepEqual(backend.capacities, []int{100}) || len(backend.indexers) != 2 {
+		t.Fatalf("expected a single indexer sized for 100 objects out of 2, got capacities %v for %d indexers", backend.capacities, len(backend.indexers))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if keys := backend.indexers[0].ListKeys(); !reflect.DeepEqual(keys, []string{"ns/foo"}) {
+		t.Errorf("expected the sized indexer to hold ns/foo, got %v", keys)
+	}
+	if _, err := informer.Lister().TestTypes("ns").Get("foo"); err != nil {
+		t.Errorf("failed to get foo: %v", err)
+	}
+
+	for _, backend := range []*countingCacheBackend{nil, {}} {
+		options := []SharedInformerOption{WithInitialCacheCapacity(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), 100)}
+		if backend != nil {
+			options = append(options, WithCacheBackend(backend))
+		}
+		factory := NewSharedInformerFactoryWithOptions(client, 0, options...)
+		if err := factory.StartWithError(ctx); err == nil {
+			t.Errorf("expected an error for a capacity without a SizedCacheBackend, backend %v", backend)
+		}
+		factory.Shutdown()
+	}
+}
+
+// mapCacheBackend is a SizedCacheBackend whose indexers hold their objects
+// in a map sized for the capacity passed to NewIndexerWithCapacity. They do
+// not index.
+type mapCacheBackend struct{}
+
+func (mapCacheBackend) NewIndexer(indexers cache.Indexers) cache.Indexer {
+	return mapCacheBackend{}.NewIndexerWithCapacity(indexers, 0)
+}
+
+func (mapCacheBackend) NewIndexerWithCapacity(indexers cache.Indexers, capacity int) cache.Indexer {
+	return &mapIndexer{Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers), items: make(map[string]interface{}, capacity)}
+}
+
+// mapIndexer stores objects in items. The methods of the embedded Indexer
+// are only used for the indexes, which stay empty.
+type mapIndexer struct {
+	cache.Indexer
+	lock  sync.RWMutex
+	items map[string]interface{}
+}
+
+func (i *mapIndexer) Add(obj interface{}) error {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return err
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.items[key] = obj
+	return nil
+}
+
+func (i *mapIndexer) Update(obj interface{}) error {
+	return i.Add(obj)
+}
+
+func (i *mapIndexer) Delete(obj interface{}) error {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return err
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.items, key)
+	return nil
+}
+
+func (i *mapIndexer) List() []interface{} {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return slices.Collect(maps.Values(i.items))
+}
+
+func (i *mapIndexer) ListKeys() []string {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return slices.Collect(maps.Keys(i.items))
+}
+
+func (i *mapIndexer) Get(obj interface{}) (interface{}, bool, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return i.GetByKey(key)
+}
+
+func (i *mapIndexer) GetByKey(key string) (interface{}, bool, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	obj, exists := i.items[key]
+	return obj, exists, nil
+}
+
+// BenchmarkInitialCacheCapacity measures the initial sync of an informer of
+// many objects whose cache backend is sized by WithInitialCacheCapacity, or
+// grows while the informer fills it.
+func BenchmarkInitialCacheCapacity(b *testing.B) {
+	const n = 10000
+	objects := make([]runtime.Object, 0, n)
+	for i := range n {
+		objects = append(objects, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("obj-%d", i), Namespace: "ns"}})
+	}
+	client := fake.NewSimpleClientset(objects...)
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	for _, capacity := range []int{0, n} {
+		b.Run(fmt.Sprintf("capacity=%d", capacity), func(b *testing.B) {
+			options := []SharedInformerOption{WithCacheBackend(mapCacheBackend{})}
+			if capacity > 0 {
+				options = append(options, WithInitialCacheCapacity(resource, capacity))
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				factory := NewSharedInformerFactoryWithOptions(client, 0, options...)
+				factory.Example().V1().TestTypes().Informer()
+				ctx, cancel := context.WithCancel(context.Background())
+				if err := factory.StartWithError(ctx); err != nil {
+					b.Fatalf("failed to start: %v", err)
+				}
+				if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+					b.Fatalf("failed to sync caches: %v", err)
+				}
+				cancel()
+				factory.Shutdown()
+			}
+		})
+	}
+}
+
+// TestSequencedHandlers verifies that the sequenced handlers of the informers
+// of a factory get increasing sequence numbers shared across informers.
+func TestSequencedHandlers(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactory(client, 0)
+	var lock sync.Mutex
+	sequences := map[string][]uint64{}
+	events := make(chan struct{}, 10)
+	record := func(name string, seq uint64) {
+		lock.Lock()
+		defer lock.Unlock()
+		sequences[name] = append(sequences[name], seq)
+		events <- struct{}{}
+	}
+	if _, err := informersapiv1.AddTestTypeSequencedHandler(factory.Example().V1().TestTypes(), func(seq uint64, ev informersapiv1.TestTypeEvent) {
+		record(string(ev.Type)+" "+ev.Object.Name, seq)
+	}); err != nil {
+		t.Fatalf("failed to add sequenced handler: %v", err)
+	}
+	if _, err := informersapiv1.AddClusterTestTypeSequencedHandler(factory.Example().V1().ClusterTestTypes(), func(seq uint64, ev informersapiv1.ClusterTestTypeEvent) {
+		record(string(ev.Type)+" "+ev.Object.Name, seq)
+	}); err != nil {
+		t.Fatalf("failed to add sequenced handler: %v", err)
+	}
+	if _, err := informersapiv1.AddTestTypeSequencedHandler(struct {
+		informersapiv1.TestTypeInformer
+	}{}, func(uint64, informersapiv1.TestTypeEvent) {}); err == nil {
+		t.Errorf("expected an error for an informer which was not obtained from a factory")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	<-events
+	if _, err := client.ExampleV1().ClusterTestTypes().Create(ctx, &singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "bar"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	<-events
+	if err := client.ExampleV1().TestTypes("ns").Delete(ctx, "foo", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete foo: %v", err)
+	}
+	<-events
+	if err := client.ExampleV1().ClusterTestTypes().Delete(ctx, "bar", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete bar: %v", err)
+	}
+	<-events
+
+	lock.Lock()
+	defer lock.Unlock()
+	want := map[string][]uint64{"ADDED foo": {1}, "ADDED bar": {2}, "DELETED foo": {3}, "DELETED bar": {4}}
+	if !reflect.DeepEqual(sequences, want) {
+		t.Errorf("expected sequence numbers %v, got %v", want, sequences)
+	}
+}
+
+// TestLeadershipGate verifies that the informers of a factory created with
+// WithLeadershipGate, and those of its clones, only list and watch while
+// leadership is held.
+func TestLeadershipGate(t *testing.T) {
+	t.Run("factory", func(t *testing.T) { testLeadershipGate(t, false) })
+	t.Run("clone", func(t *testing.T) { testLeadershipGate(t, true) })
+}
+
+// testLeadershipGate acquires, loses and re-acquires leadership. With clone,
+// the informer under test belongs to a clone of the factory, which is started
+// along with its parent, so both compete for the same channels.
+func testLeadershipGate(t *testing.T, clone bool) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	acquired, lost := make(chan struct{}), make(chan struct{})
+	parent := NewSharedInformerFactoryWithOptions(client, 0, WithLeadershipGate(acquired, lost))
+	factory, namespace := parent, ""
+	if clone {
+		parent.Example().V1().TestTypes().Informer()
+		factory, namespace = parent.CloneForNamespace("ns"), "ns"
+	}
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	countActions := func(verb string) int {
+		count := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == verb && action.GetResource().Resource == "testtypes" && action.GetNamespace() == namespace {
+				count++
+			}
+		}
+		return count
+	}
+	waitForObject := func(name string) {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+			_, err := informer.Lister().TestTypes("ns").Get(name)
+			return err == nil, nil
+		}); err != nil {
+			t.Fatalf("%s was not cached: %v", name, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer parent.Shutdown()
+	defer factory.Shutdown()
+	defer cancel()
+	parent.StartWithContext(ctx)
+	factory.StartWithContext(ctx)
+	time.Sleep(100 * time.Millisecond)
+	if informer.Informer().HasSynced() || countActions("list")+countActions("watch") != 0 {
+		t.Fatalf("the informer listed or watched before leadership was acquired: %v", client.Actions())
+	}
+
+	acquired <- struct{}{}
+	for _, f := range []SharedInformerFactory{parent, factory} {
+		if err := f.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+			t.Fatalf("failed to sync caches: %v", err)
+		}
+	}
+	waitForObject("foo")
+	if countActions("watch") != 1 {
+		t.Fatalf("expected 1 watch after leadership was acquired, got %v", client.Actions())
+	}
+
+	leading := func(f SharedInformerFactory) bool {
+		return f.(internalinterfaces.SharedInformerFactoryExtensions).InformerOptionsFor(&singleapiv1.TestType{}).LeadershipGate.Leading()
+	}
+	lost <- struct{}{}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return !leading(factory) && !leading(parent), nil
+	}); err != nil {
+		t.Fatalf("leadership was not lost: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := informer.Lister().TestTypes("ns").Get("bar"); err == nil {
+		t.Errorf("bar was cached after leadership was lost")
+	}
+	if _, err := informer.Lister().TestTypes("ns").Get("foo"); err != nil {
+		t.Errorf("foo was not kept in the cache after leadership was lost: %v", err)
+	}
+	if countActions("watch") != 1 {
+		t.Errorf("expected no new watch while leadership is lost, got %v", client.Actions())
+	}
+
+	acquired <- struct{}{}
+	// The fake client does not replay the events missed by a watch, so foo is
+	// updated until the resumed watch delivers an update.
+	updates := 0
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+		if cached, err := informer.Lister().TestTypes("ns").Get("foo"); err == nil && cached.Labels["updates"] != "" {
+			return true, nil
+		}
+		updates++
+		foo := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", Labels: map[string]string{"updates": strconv.Itoa(updates)}}}
+		_, err := client.ExampleV1().TestTypes("ns").Update(ctx, foo, metav1.UpdateOptions{})
+		return false, err
+	}); err != nil {
+		t.Fatalf("the watch did not resume after leadership was acquired again: %v", err)
+	}
+	if countActions("watch") != 2 {
+		t.Errorf("expected 2 watches after leadership was acquired again, got %v", client.Actions())
+	}
+}
+
+// TestOnSynced verifies that the synced hooks registered before and after
+// the informer synced are each invoked exactly once.
+func TestOnSynced(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+
+	var before, after sync.WaitGroup
+	var beforeCalls, afterCalls atomic.Int32
+	before.Add(1)
+	informersapiv1.OnTestTypeSynced(ctx, informer, func() {
+		if !informer.Informer().HasSynced() {
+			t.Errorf("the hook registered before sync was invoked before the informer synced")
+		}
+		beforeCalls.Add(1)
+		before.Done()
+	})
+	factory.StartWithContext(ctx)
+	before.Wait()
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	after.Add(1)
+	informersapiv1.OnTestTypeSynced(ctx, informer, func() {
+		afterCalls.Add(1)
+		after.Done()
+	})
+	after.Wait()
+
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if beforeCalls.Load() != 1 || afterCalls.Load() != 1 {
+		t.Errorf("expected each hook to be invoked once, got %d and %d invocations", beforeCalls.Load(), afterCalls.Load())
+	}
+}
+
+// TestCacheGeneration verifies that the cache generation of an informer
+// counts the objects cached when it is first asked for, increases on adds,
+// updates and deletes, and is stable in between.
+func TestCacheGeneration(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactory(client, 0)
+	if generation := factory.CacheGeneration(&singleapiv1.TestType{}); generation != 0 {
+		t.Errorf("expected generation 0 before the informer was requested, got %d", generation)
+	}
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	// The generations are only counted once they are asked for, so that
+	// informers whose generations are never read do not pay for a handler.
+	if counted := factory.(*sharedInformerFactory).cacheGenerations; len(counted) != 0 {
+		t.Errorf("expected no generation to be counted before CacheGeneration was called, got %v", counted)
+	}
+	waitForGeneration := func(generation uint64) {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+			return factory.CacheGeneration(&singleapiv1.TestType{}) == generation, nil
+		}); err != nil {
+			t.Fatalf("expected generation %d, got %d", generation, factory.CacheGeneration(&singleapiv1.TestType{}))
+		}
+		time.Sleep(50 * time.Millisecond)
+		if current := factory.CacheGeneration(&singleapiv1.TestType{}); current != generation {
+			t.Fatalf("expected generation %d to be stable, got %d", generation, current)
+		}
+	}
+	waitForGeneration(1)
+
+	bar := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, bar, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	waitForGeneration(2)
+	bar.Labels = map[string]string{"updated": "true"}
+	if _, err := client.ExampleV1().TestTypes("ns").Update(ctx, bar, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update bar: %v", err)
+	}
+	waitForGeneration(3)
+	if err := client.ExampleV1().TestTypes("ns").Delete(ctx, "bar", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete bar: %v", err)
+	}
+	waitForGeneration(4)
+}
+
+// TestCompositeFactory verifies that a composite of the factories generated
+// for different APIs starts them, waits for all their caches to sync and
+// shuts them down.
+func TestCompositeFactory(t *testing.T) {
+	singleClient := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	crdClient := crdfake.NewSimpleClientset(&crdexamplev1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}})
+	singleFactory := NewSharedInformerFactory(singleClient, 0)
+	crdFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	singleInformer := singleFactory.Example().V1().TestTypes()
+	crdInformer := crdFactory.Example().V1().TestTypes()
+	singleInformer.Informer()
+	crdInformer.Informer()
+	composite := NewCompositeFactory(singleFactory, crdFactory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	composite.StartWithContext(ctx)
+	result := composite.WaitForCacheSyncWithContext(ctx)
+	if err := result.AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if want := map[reflect.Type]bool{reflect.TypeOf(&singleapiv1.TestType{}): true, reflect.TypeOf(&crdexamplev1.TestType{}): true}; !reflect.DeepEqual(result.Synced, want) {
+		t.Errorf("expected %v to be synced, got %v", want, result.Synced)
+	}
+	if _, err := singleInformer.Lister().TestTypes("ns").Get("foo"); err != nil {
+		t.Errorf("failed to get foo: %v", err)
+	}
+	if _, err := crdInformer.Lister().TestTypes("ns").Get("bar"); err != nil {
+		t.Errorf("failed to get bar: %v", err)
+	}
+
+	composite.Shutdown()
+	if !singleInformer.Informer().IsStopped() || !crdInformer.Informer().IsStopped() {
+		t.Errorf("expected the informers of all factories to be stopped")
+	}
+}
+
+// TestWatchRotation verifies that the watches of informers are reestablished
+// after the rotation interval from the last resource version, without a
+// relist, and that the cache is kept across rotations.
+func TestWatchRotation(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithWatchRotation(1100*time.Millisecond))
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	countActions := func(verb string) int {
+		count := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == verb && action.GetResource().Resource == "testtypes" {
+				count++
+			}
+		}
+		return count
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return countActions("watch") >= 2, nil
+	}); err != nil {
+		t.Fatalf("the watch was not reestablished: %v", client.Actions())
+	}
+	if lists := countActions("list"); lists != 1 {
+		t.Errorf("expected no relist on rotation, got %d lists", lists)
+	}
+	if _, err := informer.Lister().TestTypes("ns").Get("foo"); err != nil {
+		t.Errorf("foo was not kept in the cache across the rotation: %v", err)
+	}
+
+	if _, err := client.ExampleV1().TestTypes("ns").Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		_, err := informer.Lister().TestTypes("ns").Get("bar")
+		return err == nil, nil
+	}); err != nil {
+		t.Errorf("bar was not delivered by the reestablished watch: %v", err)
+	}
+}
+
+type watchErrorHandlerTrackingInformer struct {
+	cache.SharedIndexInformer
+	lastHandler cache.WatchErrorHandlerWithContext
+}
+
+func (s *watchErrorHandlerTrackingInformer) SetWatchErrorHandlerWithContext(handler cache.WatchErrorHandlerWithContext) error {
+	s.lastHandler = handler
+	return s.SharedIndexInformer.SetWatchErrorHandlerWithContext(handler)
+}
+
+// TestEventRecorder verifies that a single Warning event is emitted after
+// repeated watch failures.
+func TestEventRecorder(t *testing.T) {
+	newInformer := func(factory SharedInformerFactory) *watchErrorHandlerTrackingInformer {
+		var wrapper *watchErrorHandlerTrackingInformer
+		factory.InformerFor(&singleapiv1.TestType{}, func(_ versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			inner := cache.NewSharedIndexInformer(nil, &singleapiv1.TestType{}, resyncPeriod, cache.Indexers{})
+			wrapper = &watchErrorHandlerTrackingInformer{SharedIndexInformer: inner}
+			return wrapper
+		})
+		return wrapper
+	}
+
+	if informer := newInformer(NewSharedInformerFactoryWithOptions(nil, 0)); informer.lastHandler != nil {
+		t.Fatalf("unexpected watch error handler without an event recorder")
+	}
+
+	recorder := events.NewFakeRecorder(10)
+	involved := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "controller", Namespace: "ns"}}
+	informer := newInformer(NewSharedInformerFactoryWithOptions(nil, 0, WithEventRecorder(recorder, involved)))
+	if informer.lastHandler == nil {
+		t.Fatalf("no watch error handler set")
+	}
+
+	ctx := context.Background()
+	reflector := cache.NewReflector(&cache.ListWatch{}, &singleapiv1.TestType{}, cache.NewStore(cache.MetaNamespaceKeyFunc), 0)
+	for i := 1; i <= 5; i++ {
+		informer.lastHandler(ctx, reflector, errors.New("connection refused"))
+		if i < 3 && len(recorder.Events) != 0 {
+			t.Fatalf("unexpected event after %d failures: %s", i, <-recorder.Events)
+		}
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("got %d events, want exactly 1", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning WatchFailed ") {
+		t.Errorf("unexpected event: %s", event)
+	}
+}
+
+// TestDumpState verifies that the dump reflects the informers of the factory.
+func TestDumpState(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "baz"}},
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	testTypes := factory.Example().V1().TestTypes().Informer()
+
+	want := FactoryState{Informers: []InformerState{
+		{Resource: singleapiv1.SchemeGroupVersion.WithResource("testtypes")},
+	}}
+	if got := factory.DumpState(); !reflect.DeepEqual(got, want) {
+		t.Errorf("state before start: got %+v, want %+v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	clusterTestTypes := factory.Example().V1().ClusterTestTypes().Informer()
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	want = FactoryState{Informers: []InformerState{
+		{
+			Resource:                singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"),
+			ObjectCount:             0,
+			LastSyncResourceVersion: clusterTestTypes.LastSyncResourceVersion(),
+		},
+		{
+			Resource:                singleapiv1.SchemeGroupVersion.WithResource("testtypes"),
+			Started:                 true,
+			Synced:                  true,
+			ObjectCount:             2,
+			LastSyncResourceVersion: testTypes.LastSyncResourceVersion(),
+		},
+	}}
+	if got := factory.DumpState(); !reflect.DeepEqual(got, want) {
+		t.Errorf("state after sync: got %+v, want %+v", got, want)
+	}
+}
+
+// TestRelistDetectsDeletes verifies that an object deleted while the watch
+// was disconnected is reported as deleted by the relist. This requires the
+// DeltaFIFO of the informer to know the objects of the informer's own indexer.
+func TestRelistDetectsDeletes(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+	)
+	// The first watch is controlled by the test, later ones are served by the tracker.
+	disconnected := watch.NewFake()
+	watchStarted := make(chan struct{})
+	var once sync.Once
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (handled bool, w watch.Interface, err error) {
+		once.Do(func() {
+			handled, w = true, disconnected
+			close(watchStarted)
+		})
+		return handled, w, nil
+	})
+
+	factory := NewSharedInformerFactoryWithOptions(client, 0)
+	deleted := make(chan interface{}, 1)
+	informer := factory.Example().V1().TestTypes().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) { deleted <- obj },
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	select {
+	case <-watchStarted:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the informer did not start watching")
+	}
+
+	// The watch never sees the deletion of bar; expiring it forces a relist.
+	if err := client.Tracker().Delete(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), "ns", "bar"); err != nil {
+		t.Fatalf("failed to delete bar: %v", err)
+	}
+	disconnected.Error(&apierrors.NewResourceExpired("watch expired").ErrStatus)
+
+	select {
+	case obj := <-deleted:
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok || tombstone.Key != "ns/bar" {
+			t.Errorf("expected a tombstone for ns/bar, got %#v", obj)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the deletion of bar was not detected by the relist")
+	}
+	if _, err := factory.Example().V1().TestTypes().Lister().TestTypes("ns").Get("bar"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected bar to be removed from the cache, got %v", err)
+	}
+}
+
+// TestReconnectObserver verifies that the reconnect observer is called for
+// the initial watch and again when the watch is reestablished after a drop.
+func TestReconnectObserver(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	dropped := watch.NewFake()
+	var once sync.Once
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (handled bool, w watch.Interface, err error) {
+		once.Do(func() { handled, w = true, dropped })
+		return handled, w, nil
+	})
+
+	type observation struct {
+		resource schema.GroupVersionResource
+		at       time.Time
+	}
+	observations := make(chan observation, 10)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithReconnectObserver(func(resource schema.GroupVersionResource, at time.Time) {
+		observations <- observation{resource: resource, at: at}
+	}))
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	start := time.Now()
+	factory.StartWithContext(ctx)
+
+	next := func() observation {
+		t.Helper()
+		select {
+		case o := <-observations:
+			return o
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("the reconnect observer was not called")
+			return observation{}
+		}
+	}
+	initial := next()
+	dropped.Stop()
+	reconnect := next()
+
+	want := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	for _, o := range []observation{initial, reconnect} {
+		if o.resource != want {
+			t.Errorf("observed resource %v, want %v", o.resource, want)
+		}
+	}
+	if initial.at.Before(start) || reconnect.at.Before(initial.at) {
+		t.Errorf("observed times out of order: start %v, initial %v, reconnect %v", start, initial.at, reconnect.at)
+	}
+	select {
+	case o := <-observations:
+		t.Errorf("unexpected observation %+v", o)
+	default:
+	}
+}
+
+// TestWatchErrorHandler verifies that the configured watch error handler is
+// invoked when a watch fails.
+func TestWatchErrorHandler(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (bool, watch.Interface, error) {
+		return true, nil, errors.New("simulated watch error")
+	})
+
+	watchErrors := make(chan error, 10)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		select {
+		case watchErrors <- err:
+		default:
+		}
+	}))
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+
+	select {
+	case err := <-watchErrors:
+		if !strings.Contains(err.Error(), "simulated watch error") {
+			t.Errorf("unexpected watch error: %v", err)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("watch error handler was not invoked")
+	}
+}
+
+// TestCacheSnapshot verifies that a snapshot written by SnapshotCache warms
+// the cache of another factory without listing, and that the informer
+// watches from the resource version of the snapshot.
+func TestCacheSnapshot(t *testing.T) {
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	objects := func() []runtime.Object {
+		return []runtime.Object{
+			&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+			&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+		}
+	}
+
+	// The objects of the snapshot have the same resource versions in client,
+	// so only baz is newer than the snapshot.
+	client := fake.NewSimpleClientset(objects()...)
+	if _, err := client.ExampleV1().TestTypes("ns").Create(context.Background(), &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "ns"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create object: %v", err)
+	}
+	var lists atomic.Int32
+	client.PrependReactor("list", "testtypes", func(clienttesting.Action) (bool, runtime.Object, error) {
+		lists.Add(1)
+		return false, nil, nil
+	})
+
+	var snapshot bytes.Buffer
+	source := NewSharedInformerFactory(fake.NewSimpleClientset(objects()...), 0)
+	source.Example().V1().TestTypes().Informer()
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithCacheSnapshot(resource, &snapshot, nil))
+	lister := factory.Example().V1().TestTypes().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer source.Shutdown()
+	defer factory.Shutdown()
+	defer cancel()
+
+	if err := source.SnapshotCache(resource, &snapshot, nil); err == nil {
+		t.Errorf("expected an error for an informer which has not synced")
+	}
+	source.StartWithContext(ctx)
+	if err := source.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if err := source.SnapshotCache(resource, &snapshot, nil); err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if got := lists.Load(); got != 0 {
+		t.Errorf("expected no list calls, got %d", got)
+	}
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		objs, err := lister.List(labels.Everything())
+		return len(objs) == 3, err
+	})
+	if err != nil {
+		t.Errorf("cache was not warmed and updated by the watch: %v", err)
+	}
+}
+
+// TestCacheSnapshotInvalid verifies that an invalid snapshot is ignored.
+func TestCacheSnapshotInvalid(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	snapshot := strings.NewReader(`{"items":[]}`)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithCacheSnapshot(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), snapshot, nil))
+	lister := factory.Example().V1().TestTypes().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	objs, err := lister.List(labels.Everything())
+	if err != nil || len(objs) != 1 {
+		t.Errorf("expected the cache to be listed from the server, got %d objects, error %v", len(objs), err)
+	}
+}
+
+// TestCacheSnapshotCodec verifies that snapshots round-trip through codecs
+// other than JSON. The example types have no protobuf marshalling, so CBOR
+// stands in for a binary format.
+func TestCacheSnapshotCodec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := singleapiv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	gv := singleapiv1.SchemeGroupVersion
+	resource := gv.WithResource("testtypes")
+
+	for name, s := range map[string]runtime.Serializer{
+		"cbor": cbor.NewSerializer(scheme, scheme),
+		"yaml": serializerjson.NewYAMLSerializer(serializerjson.DefaultMetaFactory, scheme, scheme),
+	} {
+		t.Run(name, func(t *testing.T) {
+			codec := codecs.CodecForVersions(s, s, gv, gv)
+			source := NewSharedInformerFactory(fake.NewSimpleClientset(
+				&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", Labels: map[string]string{"app": "foo"}}, Status: singleapiv1.TestTypeStatus{Blah: "ready"}},
+				&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+			), 0)
+			sourceInformer := source.Example().V1().TestTypes().Informer()
+
+			client := fake.NewSimpleClientset()
+			var lists atomic.Int32
+			client.PrependReactor("list", "testtypes", func(clienttesting.Action) (bool, runtime.Object, error) {
+				lists.Add(1)
+				return false, nil, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer source.Shutdown()
+			defer cancel()
+			source.StartWithContext(ctx)
+			if err := source.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+				t.Fatalf("failed to sync caches: %v", err)
+			}
+			var snapshot bytes.Buffer
+			if err := source.SnapshotCache(resource, &snapshot, codec); err != nil {
+				t.Fatalf("failed to snapshot cache: %v", err)
+			}
+
+			factory := NewSharedInformerFactoryWithOptions(client, 0, WithCacheSnapshot(resource, &snapshot, codec))
+			informer := factory.Example().V1().TestTypes().Informer()
+			defer factory.Shutdown()
+			factory.StartWithContext(ctx)
+			if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+				t.Fatalf("failed to sync caches: %v", err)
+			}
+			if got := lists.Load(); got != 0 {
+				t.Errorf("expected no list calls, got %d", got)
+			}
+
+			want := sourceInformer.GetStore().List()
+			if got := informer.GetStore().ListKeys(); len(got) != len(want) {
+				t.Fatalf("expected %d objects, got %v", len(want), got)
+			}
+			for _, obj := range want {
+				expected := obj.(*singleapiv1.TestType)
+				got, exists, err := informer.GetStore().Get(expected)
+				if err != nil || !exists {
+					t.Fatalf("%s was not restored: %v", expected.Name, err)
+				}
+				if !reflect.DeepEqual(got, expected) {
+					t.Errorf("%s: got %+v, want %+v", expected.Name, got, expected)
+				}
+			}
+		})
+	}
+}
+
+// TestInitialResourceVersion verifies that the first list of an informer is
+// pinned to the configured resource version.
+func TestInitialResourceVersion(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithInitialResourceVersion(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), "5"))
+	factory.Example().V1().TestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	for _, action := range client.Actions() {
+		list, ok := action.(clienttesting.ListActionImpl)
+		if !ok {
+			continue
+		}
+		if list.ListOptions.ResourceVersion != "5" || list.ListOptions.ResourceVersionMatch != metav1.ResourceVersionMatchExact {
+			t.Errorf("expected the first list to be pinned to resource version 5, got %+v", list.ListOptions)
+		}
+		return
+	}
+	t.Errorf("no list action found")
+}
+
+// TestListResourceVersionMatch verifies that the first lists of informers use
+// the configured resource version match unless their resource version is
+// pinned, and that invalid combinations are reported.
+func TestListResourceVersionMatch(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithListResourceVersionMatch(metav1.ResourceVersionMatchNotOlderThan, "0"),
+		WithInitialResourceVersion(singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"), "5"),
+	)
+	factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	if err := factory.StartWithError(ctx); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	want := map[string]metav1.ListOptions{
+		"testtypes":        {ResourceVersion: "0", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan},
+		"clustertesttypes": {ResourceVersion: "5", ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+	}
+	for _, action := range client.Actions() {
+		list, ok := action.(clienttesting.ListActionImpl)
+		if !ok {
+			continue
+		}
+		resource := list.GetResource().Resource
+		expected, first := want[resource]
+		if !first {
+			continue
+		}
+		delete(want, resource)
+		if list.ListOptions.ResourceVersion != expected.ResourceVersion || list.ListOptions.ResourceVersionMatch != expected.ResourceVersionMatch {
+			t.Errorf("%s: expected the first list to request resource version %q with match %s, got %+v", resource, expected.ResourceVersion, expected.ResourceVersionMatch, list.ListOptions)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("no list actions found for %v", want)
+	}
+
+	for _, tc := range []struct {
+		match           metav1.ResourceVersionMatch
+		resourceVersion string
+	}{
+		{metav1.ResourceVersionMatchExact, "0"},
+		{metav1.ResourceVersionMatchNotOlderThan, ""},
+		{"Newest", "1"},
+	} {
+		factory := NewSharedInformerFactoryWithOptions(client, 0, WithListResourceVersionMatch(tc.match, tc.resourceVersion))
+		if err := factory.StartWithError(ctx); err == nil {
+			t.Errorf("expected an error for match %q with resource version %q", tc.match, tc.resourceVersion)
+		}
+		factory.Shutdown()
+	}
+}
+
+// TestWatchListPageSize verifies that the lists of an informer request the
+// configured page size.
+func TestWatchListPageSize(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithWatchListPageSize(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), 42))
+	factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	want := map[string]int64{"testtypes": 42, "clustertesttypes": 500}
+	for _, action := range client.Actions() {
+		list, ok := action.(clienttesting.ListActionImpl)
+		if !ok {
+			continue
+		}
+		resource := list.GetResource().Resource
+		if limit, ok := want[resource]; ok && list.ListOptions.Limit != limit {
+			t.Errorf("expected the list of %s to request %d items, got %d", resource, limit, list.ListOptions.Limit)
+		}
+		delete(want, resource)
+	}
+	if len(want) != 0 {
+		t.Errorf("no list action found for %v", want)
+	}
+}
+
+// TestInformerFactory verifies that the factory of a typed informer provides
+// the listers of other resources.
+func TestInformerFactory(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "foo"}})
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes()
+
+	owner, ok := informer.Factory().(SharedInformerFactory)
+	if !ok {
+		t.Fatalf("expected a SharedInformerFactory, got %T", informer.Factory())
+	}
+	lister := owner.Example().V1().ClusterTestTypes().Lister()
+	if owner.Example().V1().ClusterTestTypes().Informer() != factory.Example().V1().ClusterTestTypes().Informer() {
+		t.Errorf("expected the factory of the informer to share its informers")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if _, err := lister.Get("foo"); err != nil {
+		t.Errorf("failed to get object from the lister: %v", err)
+	}
+}
+
+// TestInformersWithHandlers verifies that only informers to which handlers
+// were added through the generated helpers are reported.
+func TestInformersWithHandlers(t *testing.T) {
+	factory := NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	factory.Example().V1().ClusterTestTypes().Informer()
+	if got := factory.InformersWithHandlers(); len(got) != 0 {
+		t.Errorf("expected no informers with handlers, got %v", got)
+	}
+
+	if _, err := informersapiv1.AddTestTypeResyncHandler(factory.Example().V1().TestTypes(), func(*singleapiv1.TestType) {}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	want := []schema.GroupVersionResource{singleapiv1.SchemeGroupVersion.WithResource("testtypes")}
+	if got := factory.InformersWithHandlers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// testHistogram records the observations of WithLatencyHistogram.
+type testHistogram struct {
+	lock         sync.Mutex
+	observations []float64
+}
+
+func (h *testHistogram) Observe(value float64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.observations = append(h.observations, value)
+}
+
+func (h *testHistogram) get() []float64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return slices.Clone(h.observations)
+}
+
+// TestLatencyHistogram verifies that the latency of event handlers is observed
+// with the histogram of the informer's resource, and only for that resource.
+func TestLatencyHistogram(t *testing.T) {
+	obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", UID: "foo-uid"}}
+	client := fake.NewSimpleClientset(obj, &singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: "bar-uid"}})
+
+	histogram := &testHistogram{}
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithLatencyHistogram(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), histogram))
+	const handlerDelay = 10 * time.Millisecond
+	slowHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { time.Sleep(handlerDelay) },
+		UpdateFunc: func(_, _ interface{}) { time.Sleep(handlerDelay) },
+	}
+	if _, err := factory.Example().V1().TestTypes().Informer().AddEventHandler(slowHandler); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+	clusterTestTypes := factory.Example().V1().ClusterTestTypes().Informer()
+	if _, err := clusterTestTypes.AddEventHandler(slowHandler); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	updated := obj.DeepCopy()
+	updated.Labels = map[string]string{"foo": "bar"}
+	if _, err := client.ExampleV1().TestTypes("ns").Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update object: %v", err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return len(histogram.get()) >= 2, nil
+	})
+	if err != nil {
+		t.Fatalf("expected 2 observations, got %v", histogram.get())
+	}
+	// The add of the cluster-scoped object would have been observed by now.
+	if observations := histogram.get(); len(observations) != 2 {
+		t.Errorf("expected only the events of testtypes to be observed, got %v", observations)
+	}
+	for _, observation := range histogram.get() {
+		if observation < handlerDelay.Seconds() {
+			t.Errorf("observation %v is shorter than the handler", observation)
+		}
+	}
+}
+
+// TestLatencyObserverVec verifies that the latency of event handlers is
+// observed with the observer of a prometheus.ObserverVec for the informer's
+// resource, unless WithLatencyHistogram sets a histogram for it.
+func TestLatencyObserverVec(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", UID: "foo-uid"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: "bar-uid"}},
+	)
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "informer_event_latency_seconds", Help: "Event processing latency."}, []string{"group", "version", "resource"})
+	histogram := &testHistogram{}
+	factory := NewSharedInformerFactoryWithOptions(client, 0,
+		WithLatencyObserverVec(vec),
+		WithLatencyHistogram(singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"), histogram),
+	)
+	for _, informer := range []cache.SharedIndexInformer{factory.Example().V1().TestTypes().Informer(), factory.Example().V1().ClusterTestTypes().Informer()} {
+		if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{}); err != nil {
+			t.Fatalf("failed to add handler: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	observed := func() uint64 {
+		metric := &dto.Metric{}
+		if err := vec.WithLabelValues(resource.Group, resource.Version, resource.Resource).(prometheus.Histogram).Write(metric); err != nil {
+			t.Fatalf("failed to read the histogram: %v", err)
+		}
+		return metric.GetHistogram().GetSampleCount()
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return observed() == 1 && len(histogram.get()) == 1, nil
+	}); err != nil {
+		t.Errorf("expected one observation each, got %d for testtypes and %v for clustertesttypes", observed(), histogram.get())
+	}
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(vec)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to scrape: %v", err)
+	}
+	if len(families) != 1 || len(families[0].GetMetric()) != 1 {
+		t.Errorf("expected only testtypes to be observed by the vector, got %v", families)
+	}
+}
+
+// testGates is a features.Gates whose gates can be toggled concurrently.
+type testGates struct {
+	enabled sync.Map
+}
+
+func (g *testGates) Enabled(key features.Feature) bool {
+	enabled, _ := g.enabled.Load(key)
+	return enabled == true
+}
+
+func TestFeatureGateTransform(t *testing.T) {
+	const gatedFeature features.Feature = "GatedBlah"
+	obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}, Status: singleapiv1.TestTypeStatus{Blah: "gated"}}
+	client := fake.NewSimpleClientset(obj)
+
+	gates := &testGates{}
+	gates.enabled.Store(gatedFeature, true)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithFeatureGateTransform(gates, func(obj metav1.Object, gates features.Gates) {
+		if testType, ok := obj.(*singleapiv1.TestType); ok && !gates.Enabled(gatedFeature) {
+			testType.Status.Blah = ""
+		}
+	}))
+	lister := factory.Example().V1().TestTypes().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	cached, err := lister.TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get object: %v", err)
+	}
+	if cached.Status.Blah != "gated" {
+		t.Errorf("expected the gated field to be kept while the gate is on, got %q", cached.Status.Blah)
+	}
+
+	gates.enabled.Store(gatedFeature, false)
+	updated := obj.DeepCopy()
+	updated.Labels = map[string]string{"foo": "bar"}
+	if _, err := client.ExampleV1().TestTypes("ns").Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update object: %v", err)
+	}
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		cached, err := lister.TestTypes("ns").Get("foo")
+		return err == nil && cached.Labels["foo"] == "bar", nil
+	})
+	if err != nil {
+		t.Fatalf("the update was not observed: %v", err)
+	}
+	cached, err = lister.TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get object: %v", err)
+	}
+	if cached.Status.Blah != "" {
+		t.Errorf("expected the gated field to be stripped while the gate is off, got %q", cached.Status.Blah)
+	}
+}
+
+func TestSharedMemoryBudget(t *testing.T) {
+	obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{
+		Name:          "foo",
+		Namespace:     "ns",
+		UID:           "foo-uid",
+		Annotations:   map[string]string{"foo": "bar"},
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "test", Operation: metav1.ManagedFieldsOperationApply}},
+	}}
+	client := fake.NewSimpleClientset(obj)
+	// No object fits in the budget, so the first one is already fully stripped.
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithSharedMemoryBudget(1))
+	lister := factory.Example().V1().TestTypes().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	cached, err := lister.TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get object: %v", err)
+	}
+	if len(cached.ManagedFields) != 0 || len(cached.Annotations) != 0 {
+		t.Errorf("expected managed fields and annotations to be stripped, got %+v", cached.ObjectMeta)
+	}
+}
+
+// TestMemoryBudgetStages verifies that the stripping of a memoryBudget is
+// escalated for the object which would exceed the limit, and relaxed again
+// once objects are deleted.
+func TestMemoryBudgetStages(t *testing.T) {
+	newObj := func(name string) *singleapiv1.TestType {
+		return &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{
+			Name:          name,
+			Namespace:     "ns",
+			UID:           types.UID(name),
+			Annotations:   map[string]string{"note": strings.Repeat("a", 200)},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: strings.Repeat("m", 200)}},
+		}}
+	}
+	size := func(obj *singleapiv1.TestType, stage int) int64 {
+		obj = obj.DeepCopy()
+		for _, strip := range memoryBudgetStages[:stage] {
+			strip(obj)
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		return int64(len(data))
+	}
+	full, withoutManagedFields, stripped := size(newObj("a"), 0), size(newObj("a"), 1), size(newObj("a"), 2)
+	// a is kept whole, b loses its managed fields and c its annotations too.
+	budget := &memoryBudget{limit: full + withoutManagedFields + stripped, sizes: make(map[types.UID]int64)}
+	objs := map[string]*singleapiv1.TestType{}
+	admit := func(name string, wantStage int) {
+		t.Helper()
+		objs[name] = newObj(name)
+		budget.admit(objs[name])
+		if budget.stage != wantStage {
+			t.Errorf("after admitting %s: got stage %d, want %d", name, budget.stage, wantStage)
+		}
+	}
+	forget := func(name string, wantStage int) {
+		t.Helper()
+		budget.forget(objs[name])
+		if budget.stage != wantStage {
+			t.Errorf("after forgetting %s: got stage %d, want %d", name, budget.stage, wantStage)
+		}
+	}
+	hasFields := func(name string, managedFields, annotations bool) {
+		t.Helper()
+		obj := objs[name]
+		if got := len(obj.ManagedFields) != 0; got != managedFields {
+			t.Errorf("%s has managed fields: got %v, want %v", name, got, managedFields)
+		}
+		if got := len(obj.Annotations) != 0; got != annotations {
+			t.Errorf("%s has annotations: got %v, want %v", name, got, annotations)
+		}
+	}
+
+	admit("a", 0)
+	hasFields("a", true, true)
+	admit("b", 1)
+	hasFields("b", false, true)
+	admit("c", 2)
+	hasFields("c", false, false)
+	if budget.used > budget.limit {
+		t.Errorf("used %d bytes over the limit of %d", budget.used, budget.limit)
+	}
+
+	forget("a", 1)
+	forget("b", 0)
+	admit("d", 0)
+	hasFields("d", true, true)
+}
+
+func TestEqualityFunc(t *testing.T) {
+	obj := &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}
+	client := fake.NewSimpleClientset(obj)
+	// TestType has no spec, so only label changes are relevant.
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithEqualityFunc(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), func(oldObj, newObj runtime.Object) bool {
+		return reflect.DeepEqual(oldObj.(*singleapiv1.TestType).Labels, newObj.(*singleapiv1.TestType).Labels)
+	}))
+	updates := make(chan *singleapiv1.TestType, 10)
+	if _, err := factory.Example().V1().TestTypes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, newObj interface{}) { updates <- newObj.(*singleapiv1.TestType) },
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+
+	statusChange := obj.DeepCopy()
+	statusChange.Status.Blah = "volatile"
+	if _, err := client.ExampleV1().TestTypes("ns").Update(ctx, statusChange, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update object: %v", err)
+	}
+	labelChange := statusChange.DeepCopy()
+	labelChange.Labels = map[string]string{"foo": "bar"}
+	if _, err := client.ExampleV1().TestTypes("ns").Update(ctx, labelChange, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update object: %v", err)
+	}
+
+	// Updates are delivered in order, so the status change was dropped if the
+	// label change is delivered first.
+	select {
+	case got := <-updates:
+		if got.Labels["foo"] != "bar" {
+			t.Errorf("expected only the label change to be delivered, got %+v", got)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the label change was not delivered")
+	}
+	select {
+	case got := <-updates:
+		t.Errorf("unexpected update %+v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRetweakInformer(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "a1", Namespace: "ns", Labels: map[string]string{"app": "a"}}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "a2", Namespace: "ns", Labels: map[string]string{"app": "a"}}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "b1", Namespace: "ns", Labels: map[string]string{"app": "b"}}},
+	)
+	selectApp := func(app string) internalinterfaces.TweakListOptionsFunc {
+		return func(options *metav1.ListOptions) {
+			options.LabelSelector = "app=" + app
+		}
+	}
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithTweakListOptions(selectApp("a")))
+	lister := factory.Example().V1().TestTypes().Lister()
+	cachedNames := func() []string {
+		items, err := lister.List(labels.Everything())
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		var names []string
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if got, want := cachedNames(), []string{"a1", "a2"}; !slices.Equal(got, want) {
+		t.Fatalf("cached %v, want %v", got, want)
+	}
+
+	if err := factory.RetweakInformer(singleapiv1.SchemeGroupVersion.WithResource("testtypes"), selectApp("b")); err != nil {
+		t.Fatalf("failed to retweak the informer: %v", err)
+	}
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return slices.Equal(cachedNames(), []string{"b1"}), nil
+	})
+	if err != nil {
+		t.Errorf("expected the cache to hold only b1 after the relist, got %v", cachedNames())
+	}
+
+	if err := factory.RetweakInformer(singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"), selectApp("b")); err == nil {
+		t.Errorf("expected an error for an informer which was not requested")
+	}
+}
+
+func TestSyncOrder(t *testing.T) {
+	testTypes := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	clusterTestTypes := singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes")
+	factory := NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, WithSyncOrder([][]schema.GroupVersionResource{{testTypes}, {clusterTestTypes}}))
+
+	// The test types informer of the first stage does not sync until release
+	// is closed.
+	release := make(chan struct{})
+	stage1 := factory.InformerFor(&singleapiv1.TestType{}, func(versioned.Interface, time.Duration) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(internalinterfaces.NewListerWatcherWithoutWatchList(&cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				<-release
+				return &singleapiv1.TestTypeList{}, nil
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}), &singleapiv1.TestType{}, 0, cache.Indexers{})
+	})
+	stage2Listed := make(chan bool, 1)
+	factory.InformerFor(&singleapiv1.ClusterTestType{}, func(versioned.Interface, time.Duration) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(internalinterfaces.NewListerWatcherWithoutWatchList(&cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				stage2Listed <- stage1.HasSynced()
+				return &singleapiv1.ClusterTestTypeList{}, nil
+			},
+			WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}), &singleapiv1.ClusterTestType{}, 0, cache.Indexers{})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+
+	select {
+	case <-stage2Listed:
+		t.Fatalf("the second stage was started before the first stage synced")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case stage1Synced := <-stage2Listed:
+		if !stage1Synced {
+			t.Errorf("the second stage was started before the first stage synced")
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the second stage was not started after the first stage synced")
+	}
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+}
+
+func TestInformerCreateHook(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	vetoed := singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes")
+	var resources []schema.GroupVersionResource
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithInformerCreateHook(func(resource schema.GroupVersionResource) error {
+		resources = append(resources, resource)
+		if resource == vetoed {
+			return errors.New("not supported")
+		}
+		return nil
+	}))
+	testTypes := factory.Example().V1().TestTypes().Informer()
+	clusterTestTypes := factory.Example().V1().ClusterTestTypes().Informer()
+	want := []schema.GroupVersionResource{singleapiv1.SchemeGroupVersion.WithResource("testtypes"), vetoed}
+	if !reflect.DeepEqual(resources, want) {
+		t.Errorf("expected the hook to be consulted for %v, got %v", want, resources)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	err := factory.StartWithError(ctx)
+	if err == nil || !strings.Contains(err.Error(), "not supported") || !strings.Contains(err.Error(), vetoed.String()) {
+		t.Fatalf("expected the veto of %v to be reported, got %v", vetoed, err)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), testTypes.HasSynced) {
+		t.Fatalf("the informer which was not vetoed did not sync")
+	}
+	if clusterTestTypes.HasSynced() {
+		t.Errorf("the vetoed informer was started")
+	}
+	for _, informer := range factory.DumpState().Informers {
+		if wantStarted := informer.Resource != vetoed; informer.Started != wantStarted {
+			t.Errorf("expected the %v informer to be started: %v, got %v", informer.Resource, wantStarted, informer.Started)
+		}
+	}
+}
+
+// TestStartWithContextAbortsList verifies that a list which is in flight is
+// aborted when the context of StartWithContext is canceled or when the
+// factory is shut down.
+func TestStartWithContextAbortsList(t *testing.T) {
+	tests := map[string]func(cancel context.CancelFunc, factory SharedInformerFactory){
+		"cancel": func(cancel context.CancelFunc, _ SharedInformerFactory) { cancel() },
+		"shutdown": func(_ context.CancelFunc, factory SharedInformerFactory) {
+			go factory.Shutdown()
+		},
+	}
+	for name, stop := range tests {
+		t.Run(name, func(t *testing.T) {
+			listStarted := make(chan struct{}, 1)
+			listAborted := make(chan struct{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("watch") == "true" {
+					// Make the reflector fall back from a streaming list to a list.
+					http.Error(w, "watch not supported", http.StatusInternalServerError)
+					return
+				}
+				listStarted <- struct{}{}
+				<-r.Context().Done()
+				listAborted <- struct{}{}
+			}))
+			defer server.Close()
+
+			client, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			factory := NewSharedInformerFactory(client, 0)
+			factory.Example().V1().TestTypes().Informer()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer factory.Shutdown()
+			defer cancel()
+			factory.StartWithContext(ctx)
+
+			select {
+			case <-listStarted:
+			case <-time.After(wait.ForeverTestTimeout):
+				t.Fatalf("list was not started")
+			}
+			stop(cancel, factory)
+			select {
+			case <-listAborted:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("list was not aborted")
+			}
+		})
+	}
+}
+
+// TestCoResourceInformer verifies that the informer of a type tagged
+// +genclient:coResource merges the updates of the type and of its status,
+// which are watched through separate resources, into one cached object.
+func TestCoResourceInformer(t *testing.T) {
+	newObj := func(resourceVersion, blah string, labels map[string]string) singleapiv1.SplitStatusType {
+		return singleapiv1.SplitStatusType{
+			TypeMeta:   metav1.TypeMeta{APIVersion: singleapiv1.SchemeGroupVersion.String(), Kind: "SplitStatusType"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", ResourceVersion: resourceVersion, Labels: labels},
+			Status:     singleapiv1.SplitStatusTypeStatus{Blah: blah},
+		}
+	}
+	lists := map[string]singleapiv1.SplitStatusType{
+		"splitstatustypes":        newObj("1", "", nil),
+		"splitstatustypestatuses": newObj("1", "old", nil),
+	}
+	events := map[string]chan watch.Event{
+		"splitstatustypes":        make(chan watch.Event, 1),
+		"splitstatustypestatuses": make(chan watch.Event, 1),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		obj, ok := lists[resource]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		if r.URL.Query().Get("watch") != "true" {
+			list := singleapiv1.SplitStatusTypeList{
+				TypeMeta: metav1.TypeMeta{APIVersion: singleapiv1.SchemeGroupVersion.String(), Kind: "SplitStatusTypeList"},
+				ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+				Items:    []singleapiv1.SplitStatusType{obj},
+			}
+			_ = encoder.Encode(list)
+			return
+		}
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event := <-events[resource]:
+				_ = encoder.Encode(metav1.WatchEvent{Type: string(event.Type), Object: runtime.RawExtension{Object: event.Object}})
+				w.(http.Flusher).Flush()
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().SplitStatusTypes()
+	informer.Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	waitFor := func(want singleapiv1.SplitStatusType) {
+		t.Helper()
+		var got *singleapiv1.SplitStatusType
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+			got, _ = informer.Lister().SplitStatusTypes("ns").Get("foo")
+			return got != nil && got.ResourceVersion == want.ResourceVersion, nil
+		})
+		if err != nil {
+			t.Fatalf("expected resource version %s, got %#v", want.ResourceVersion, got)
+		}
+		if !reflect.DeepEqual(got.Labels, want.Labels) || got.Status != want.Status {
+			t.Errorf("expected labels %v and status %+v, got labels %v and status %+v", want.Labels, want.Status, got.Labels, got.Status)
+		}
+	}
+	waitFor(newObj("1", "old", nil))
+
+	// The main resource owns everything but the status.
+	updated := newObj("2", "stale", map[string]string{"foo": "bar"})
+	events["splitstatustypes"] <- watch.Event{Type: watch.Modified, Object: &updated}
+	waitFor(newObj("2", "old", map[string]string{"foo": "bar"}))
+
+	// The co-resource owns the status.
+	status := newObj("3", "new", nil)
+	events["splitstatustypestatuses"] <- watch.Event{Type: watch.Modified, Object: &status}
+	waitFor(newObj("3", "new", map[string]string{"foo": "bar"}))
+}
+
+// TestMetricsCollector verifies that a scrape of the collector reports the
+// number of objects in the cache of each informer as a gauge per resource.
+func TestMetricsCollector(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "baz"}},
+	)
+	factory := NewSharedInformerFactory(client, 0)
+	testTypes := factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(factory.MetricsCollector())
+	scrape := func() map[schema.GroupVersionResource]float64 {
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("failed to scrape: %v", err)
+		}
+		sizes := map[schema.GroupVersionResource]float64{}
+		for _, family := range families {
+			if family.GetName() != CacheSizeMetricName {
+				t.Errorf("unexpected metric %s", family.GetName())
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				var resource schema.GroupVersionResource
+				for _, label := range metric.GetLabel() {
+					switch label.GetName() {
+					case "group":
+						resource.Group = label.GetValue()
+					case "version":
+						resource.Version = label.GetValue()
+					case "resource":
+						resource.Resource = label.GetValue()
+					}
+				}
+				sizes[resource] = metric.GetGauge().GetValue()
+			}
+		}
+		return sizes
+	}
+	want := map[schema.GroupVersionResource]float64{
+		singleapiv1.SchemeGroupVersion.WithResource("testtypes"):        2,
+		singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"): 1,
+	}
+	if got := scrape(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected sizes %v, got %v", want, got)
+	}
+
+	// Sizes are read from the stores when scraped.
+	if err := testTypes.GetStore().Delete(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}); err != nil {
+		t.Fatalf("failed to delete from the store: %v", err)
+	}
+	want[singleapiv1.SchemeGroupVersion.WithResource("testtypes")] = 1
+	if got := scrape(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected sizes %v, got %v", want, got)
+	}
+}
+
+// TestRBACPrecheck verifies that informers whose resources may not be watched
+// are skipped with a reason, and that the others are started.
+func TestRBACPrecheck(t *testing.T) {
+	authClient := kubernetesfake.NewSimpleClientset()
+	authClient.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		if attributes.Resource == "clustertesttypes" && attributes.Verb == "watch" {
+			review.Status = authorizationv1.SubjectAccessReviewStatus{Reason: "no RBAC policy matched"}
+		} else {
+			review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: true}
+		}
+		return true, review, nil
+	})
+
+	factory := NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, WithRBACPrecheck(authClient.AuthorizationV1()))
+	factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	if err := factory.StartWithError(ctx); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	want := map[schema.GroupVersionResource]string{
+		singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes"): "watch is not allowed: no RBAC policy matched",
+	}
+	if got := factory.SkippedInformers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected skipped informers %v, got %v", want, got)
+	}
+	for _, informer := range factory.DumpState().Informers {
+		if wantStarted := informer.Resource.Resource != "clustertesttypes"; informer.Started != wantStarted {
+			t.Errorf("expected the %v informer to be started: %v, got %v", informer.Resource, wantStarted, informer.Started)
+		}
+	}
+}
+
+// TestRBACPrecheckNamespaces verifies that cluster-scoped resources are
+// reviewed in all namespaces, and namespaced resources in the namespace of
+// the factory or in each namespace of WithNamespaceSelectors.
+func TestRBACPrecheckNamespaces(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options []SharedInformerOption
+		want    []string
+		skipped map[schema.GroupVersionResource]string
+	}{{
+		name:    "namespace",
+		options: []SharedInformerOption{WithNamespace("ns")},
+		want:    []string{"clustertesttypes list ", "clustertesttypes watch ", "testtypes list ns", "testtypes watch ns"},
+		skipped: map[schema.GroupVersionResource]string{},
+	}, {
+		name:    "namespace selectors",
+		options: []SharedInformerOption{WithNamespaceSelectors(map[string]labels.Selector{"a": nil, "b": nil})},
+		want:    []string{"clustertesttypes list ", "clustertesttypes watch ", "testtypes list a", "testtypes list b", "testtypes watch a"},
+		skipped: map[schema.GroupVersionResource]string{
+			singleapiv1.SchemeGroupVersion.WithResource("testtypes"): "list is not allowed in namespace b",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var reviewed []string
+			authClient := kubernetesfake.NewSimpleClientset()
+			authClient.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attributes := review.Spec.ResourceAttributes
+				lock.Lock()
+				reviewed = append(reviewed, attributes.Resource+" "+attributes.Verb+" "+attributes.Namespace)
+				lock.Unlock()
+				review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: attributes.Namespace != "b"}
+				return true, review, nil
+			})
+
+			factory := NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, append(tc.options, WithRBACPrecheck(authClient.AuthorizationV1()))...)
+			factory.Example().V1().TestTypes().Informer()
+			factory.Example().V1().ClusterTestTypes().Informer()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer factory.Shutdown()
+			defer cancel()
+			if err := factory.StartWithError(ctx); err != nil {
+				t.Fatalf("failed to start: %v", err)
+			}
+
+			lock.Lock()
+			slices.Sort(reviewed)
+			if !slices.Equal(reviewed, tc.want) {
+				t.Errorf("expected reviews %v, got %v", tc.want, reviewed)
+			}
+			lock.Unlock()
+			if got := factory.SkippedInformers(); !reflect.DeepEqual(got, tc.skipped) {
+				t.Errorf("expected skipped informers %v, got %v", tc.skipped, got)
+			}
+		})
+	}
+}
+
+// TestDependencyGraph verifies that the dependency graph reflects the
+// registered consumers of each resource.
+func TestDependencyGraph(t *testing.T) {
+	factory := NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	testTypes := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	clusterTestTypes := singleapiv1.SchemeGroupVersion.WithResource("clustertesttypes")
+	factory.RegisterConsumer(testTypes, "foo-controller")
+	factory.RegisterConsumer(clusterTestTypes, "foo-controller")
+	factory.RegisterConsumer(testTypes, "bar-controller")
+	factory.RegisterConsumer(testTypes, "foo-controller")
+
+	want := map[schema.GroupVersionResource][]string{
+		testTypes:        {"foo-controller", "bar-controller"},
+		clusterTestTypes: {"foo-controller"},
+	}
+	graph := factory.DependencyGraph()
+	if !reflect.DeepEqual(graph, want) {
+		t.Errorf("expected graph %v, got %v", want, graph)
+	}
+	// The graph is a copy.
+	graph[testTypes][0] = "changed"
+	if got := factory.DependencyGraph(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected graph %v after changing a copy, got %v", want, got)
+	}
+}
+
+// TestGlobalObjectFilter verifies that the objects dropped by the filter of
+// WithGlobalObjectFilter never appear in the listers of any informer, whether
+// they are listed or watched.
+func TestGlobalObjectFilter(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+		&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "blocked"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		&singleapiv1.ClusterTestType{ObjectMeta: metav1.ObjectMeta{Name: "blocked"}},
+	)
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithGlobalObjectFilter(func(obj metav1.Object) bool {
+		return obj.GetNamespace() != "blocked" && obj.GetName() != "blocked"
+	}))
+	testTypes := factory.Example().V1().TestTypes()
+	clusterTestTypes := factory.Example().V1().ClusterTestTypes()
+	testTypes.Informer()
+	clusterTestTypes.Informer()
+	cachedNames := func() []string {
+		var names []string
+		objs, _ := testTypes.Lister().List(labels.Everything())
+		for _, obj := range objs {
+			names = append(names, obj.Namespace+"/"+obj.Name)
+		}
+		clusterObjs, _ := clusterTestTypes.Lister().List(labels.Everything())
+		for _, obj := range clusterObjs {
+			names = append(names, obj.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync caches: %v", err)
+	}
+	if names := cachedNames(); !reflect.DeepEqual(names, []string{"foo", "ns/foo"}) {
+		t.Errorf("expected only the listed objects passing the filter to be cached, got %v", names)
+	}
+
+	for _, namespace := range []string{"blocked", "ns"} {
+		if _, err := client.ExampleV1().TestTypes(namespace).Create(ctx, &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: namespace}}, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create %s/bar: %v", namespace, err)
+		}
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		_, err := testTypes.Lister().TestTypes("ns").Get("bar")
+		return err == nil, nil
+	}); err != nil {
+		t.Fatalf("ns/bar was not cached: %v", err)
+	}
+	if names := cachedNames(); !reflect.DeepEqual(names, []string{"foo", "ns/bar", "ns/foo"}) {
+		t.Errorf("expected only the watched objects passing the filter to be cached, got %v", names)
+	}
+}
+
+// TestIngestValidator verifies that objects rejected by the ingest validator
+// are recorded and not cached.
+func TestIngestValidator(t *testing.T) {
+	newObj := func(name string, valid bool) *singleapiv1.TestType {
+		return &singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{"valid": strconv.FormatBool(valid)}}}
+	}
+	client := fake.NewSimpleClientset(newObj("foo", true), newObj("bar", false))
+	watcher := watch.NewFake()
+	watchStarted := make(chan struct{})
+	var once sync.Once
+	client.PrependWatchReactor("testtypes", func(clienttesting.Action) (handled bool, w watch.Interface, err error) {
+		once.Do(func() {
+			handled, w = true, watcher
+			close(watchStarted)
+		})
+		return handled, w, nil
+	})
+	errInvalid := errors.New("not valid")
+	resource := singleapiv1.SchemeGroupVersion.WithResource("testtypes")
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithIngestValidator(resource, func(obj runtime.Object) error {
+		if obj.(*singleapiv1.TestType).Labels["valid"] != "true" {
+			return errInvalid
+		}
+		return nil
+	}, DropInvalidObjects))
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.StartWithContext(ctx)
+	if err := factory.WaitForCacheSyncWithContext(ctx).AsError(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	cachedNames := func() []string {
+		var names []string
+		for _, obj := range informer.Informer().GetStore().List() {
+			names = append(names, obj.(*singleapiv1.TestType).Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	if got, want := cachedNames(), []string{"foo"}; !slices.Equal(got, want) {
+		t.Errorf("expected cached objects %v, got %v", want, got)
+	}
+	if got, want := factory.InvalidObjects(), []InvalidObject{{Resource: resource, Key: "ns/bar", Err: errInvalid}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected invalid objects %v, got %v", want, got)
+	}
+
+	// An update which makes foo invalid removes it from the cache, and one
+	// which makes bar valid adds it.
+	select {
+	case <-watchStarted:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("watch was not started")
+	}
+	watcher.Modify(newObj("foo", false))
+	watcher.Modify(newObj("bar", true))
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return slices.Equal(cachedNames(), []string{"bar"}), nil
+	})
+	if err != nil {
+		t.Errorf("expected cached objects [bar], got %v", cachedNames())
+	}
+	if got, want := factory.InvalidObjects(), []InvalidObject{{Resource: resource, Key: "ns/foo", Err: errInvalid}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected invalid objects %v, got %v", want, got)
+	}
+}
+
+func TestWithLabelSelector(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tweak internalinterfaces.TweakListOptionsFunc
+		want  string
+	}{
+		"no tweak": {want: "app=foo"},
+		"tweak without selector": {
+			tweak: func(opts *metav1.ListOptions) { opts.FieldSelector = "metadata.name=a" },
+			want:  "app=foo",
+		},
+		"tweak with selector": {
+			tweak: func(opts *metav1.ListOptions) { opts.LabelSelector = "tier!=cache" },
+			want:  "tier!=cache,app=foo",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var opts metav1.ListOptions
+			internalinterfaces.WithLabelSelector(tc.tweak, "app=foo")(&opts)
+			if opts.LabelSelector != tc.want {
+				t.Errorf("got label selector %q, want %q", opts.LabelSelector, tc.want)
+			}
+		})
+	}
+	if got := internalinterfaces.AndLabelSelectors("", "a=b", "", "c"); got != "a=b,c" {
+		t.Errorf("got %q, want %q", got, "a=b,c")
+	}
+}
+
+func TestWaitForNamedCacheSync(t *testing.T) {
+	client := fake.NewSimpleClientset(&singleapiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}})
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes().Informer()
+	factory.Example().V1().ClusterTestTypes().Informer()
+	stopCh := make(chan struct{})
+	defer factory.Shutdown()
+	defer close(stopCh)
+
+	factory.Start(stopCh)
+	if !factory.WaitForNamedCacheSync("test", stopCh, informer.HasSynced) {
+		t.Fatal("failed to sync the TestType informer")
+	}
+	if !informer.HasSynced() {
+		t.Error("the TestType informer has not synced")
+	}
+	if !factory.WaitForNamedCacheSync("test", stopCh) {
+		t.Fatal("failed to sync the started informers")
+	}
+	if !factory.Example().V1().ClusterTestTypes().Informer().HasSynced() {
+		t.Error("the ClusterTestType informer has not synced")
+	}
+
+	closed := make(chan struct{})
+	close(closed)
+	unsynced := func() bool { return false }
+	if factory.WaitForNamedCacheSync("test", closed, unsynced) {
+		t.Error("expected a closed stop channel to fail the sync")
+	}
+}
+
+// TestInformerWithContextAbortsList verifies that a list which is in flight is
+// aborted when the context of an informer constructed with
+// NewFiltered<Type>InformerWithContext is canceled, although the informer
+// keeps running.
+func TestInformerWithContextAbortsList(t *testing.T) {
+	listStarted := make(chan struct{}, 1)
+	listAborted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") == "true" {
+			// Make the reflector fall back from a streaming list to a list.
+			http.Error(w, "watch not supported", http.StatusInternalServerError)
+			return
+		}
+		select {
+		case listStarted <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done()
+		select {
+		case listAborted <- struct{}{}:
+		default:
+		}
+	}))
+	defer server.Close()
+
+	client, err := versioned.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informer := informersapiv1.NewFilteredTestTypeInformerWithContext(ctx, client, "ns", 0, cache.Indexers{}, nil)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	select {
+	case <-listStarted:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("list was not started")
+	}
+	cancel()
+	select {
+	case <-listAborted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("list was not aborted")
+	}
+}
+
+// TestContextBoundListerWatcher verifies that the lists and watches of a
+// ListerWatcher bound to a context are canceled with the cause of that
+// context, and that the context of a watch is released once it is stopped.
+func TestContextBoundListerWatcher(t *testing.T) {
+	if lw := internalinterfaces.NewContextBoundListerWatcher(&cache.ListWatch{}, context.TODO()); lw == nil {
+		t.Fatal("expected the ListerWatcher")
+	} else if _, ok := lw.(*cache.ListWatch); !ok {
+		t.Errorf("expected a context which is never done to leave the ListerWatcher alone, got %T", lw)
+	}
+
+	var watchCtx context.Context
+	inner := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			<-ctx.Done()
+			return nil, context.Cause(ctx)
+		},
+		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			watchCtx = ctx
+			return watch.NewFake(), nil
+		},
+	}
+	bound, cancel := context.WithCancelCause(context.Background())
+	lw := internalinterfaces.NewContextBoundListerWatcher(inner, bound)
+
+	w, err := lw.Watch(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to watch: %v", err)
+	}
+	w.Stop()
+	if watchCtx.Err() == nil {
+		t.Error("expected the context of the watch to be released once it was stopped")
+	}
+
+	cause := errors.New("bound context canceled")
+	go cancel(cause)
+	if _, err := lw.(cache.ListerWatcherWithContext).ListWithContext(context.Background(), metav1.ListOptions{}); !errors.Is(err, cause) {
+		t.Errorf("expected the list to be canceled with %v, got %v", cause, err)
+	}
+}
+
+// TestCustomResyncConfig verifies that the informers of a group get the
+// resync period which WithCustomResyncConfig sets for their type, or the
+// default resync period of the factory.
+func TestCustomResyncConfig(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	factory := NewSharedInformerFactoryWithOptions(client, time.Minute,
+		WithCustomResyncConfig(map[metav1.Object]time.Duration{&singleapiv1.TestType{}: time.Hour}),
+	)
+	resyncPeriods := map[string]time.Duration{}
+	for name, obj := range map[string]runtime.Object{"TestType": &singleapiv1.TestType{}, "ClusterTestType": &singleapiv1.ClusterTestType{}} {
+		factory.InformerFor(obj, func(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+			resyncPeriods[name] = resyncPeriod
+			return cache.NewSharedIndexInformer(&cache.ListWatch{}, obj, resyncPeriod, cache.Indexers{})
+		})
+	}
+	if want := map[string]time.Duration{"TestType": time.Hour, "ClusterTestType": time.Minute}; !reflect.DeepEqual(resyncPeriods, want) {
+		t.Errorf("got resync periods %v, want %v", resyncPeriods, want)
+	}
+}
+
+// TestInformerConstructors verifies that InformerConstructors holds every
+// resource of the factory, and returns the informers of ForResource and the
+// errors of the informer create hook.
+func TestInformerConstructors(t *testing.T) {
+	vetoed := errors.New("vetoed")
+	factory := NewSharedInformerFactoryWithOptions(fake.NewSimpleClientset(), 0, WithInformerCreateHook(func(resource schema.GroupVersionResource) error {
+		if resource.Resource == "splitstatustypes" {
+			return vetoed
+		}
+		return nil
+	}))
+
+	want := map[cache.SharedIndexInformer]bool{}
+	group := reflect.ValueOf(factory.Example().V1())
+	for i := 0; i < group.NumMethod(); i++ {
+		informer := group.Method(i).Call(nil)[0].MethodByName("Informer").Call(nil)[0].Interface().(cache.SharedIndexInformer)
+		want[informer] = true
+	}
+
+	got := map[cache.SharedIndexInformer]bool{}
+	for resource, constructor := range InformerConstructors {
+		informer, err := constructor(factory)
+		if resource.Resource == "splitstatustypes" {
+			if !errors.Is(err, vetoed) {
+				t.Errorf("expected the veto of %v, got %v", resource, err)
+			}
+		} else if err != nil {
+			t.Errorf("unexpected error for %v: %v", resource, err)
+		}
+		generic, err := factory.ForResource(resource)
+		if err != nil {
+			t.Fatalf("no informer for %v: %v", resource, err)
+		}
+		if generic.Informer() != informer {
+			t.Errorf("expected the informer of ForResource for %v", resource)
+		}
+		got[informer] = true
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected InformerConstructors to hold the %d resources of the group, got %d", len(want), len(got))
+	}
+}
@@ -22,6 +22,14 @@ package v1
 // ClusterTestTypeLister.
 type ClusterTestTypeListerExpansion interface{}
 
+// SplitStatusTypeListerExpansion allows custom methods to be added to
+// SplitStatusTypeLister.
+type SplitStatusTypeListerExpansion interface{}
+
+// SplitStatusTypeNamespaceListerExpansion allows custom methods to be added to
+// SplitStatusTypeNamespaceLister.
+type SplitStatusTypeNamespaceListerExpansion interface{}
+
 // TestTypeListerExpansion allows custom methods to be added to
 // TestTypeLister.
 type TestTypeListerExpansion interface{}
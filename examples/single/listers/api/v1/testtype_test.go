@@ -0,0 +1,386 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	apiv1 "k8s.io/code-generator/examples/single/api/v1"
+)
+
+// TestSelectorCache verifies that memoized List results avoid rescanning the
+// indexer until the informer reports a change.
+func TestSelectorCache(t *testing.T) {
+	indexer := &countingIndexer{Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})}
+	informer := &handlerTrackingInformer{
+		SharedIndexInformer: cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{}),
+		indexer:             indexer,
+	}
+	lister, err := NewTestTypeListerWithSelectorCache(informer)
+	if err != nil {
+		t.Fatalf("failed to create lister: %v", err)
+	}
+
+	foo := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns", Labels: map[string]string{"app": "foo"}}}
+	if err := indexer.Add(foo); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+	selector := labels.SelectorFromSet(labels.Set{"app": "foo"})
+
+	for range 3 {
+		if items, err := lister.List(selector); err != nil || len(items) != 1 {
+			t.Fatalf("unexpected List result: %v, %v", items, err)
+		}
+		if items, err := lister.TestTypes("ns").List(selector); err != nil || len(items) != 1 {
+			t.Fatalf("unexpected namespaced List result: %v, %v", items, err)
+		}
+	}
+	if indexer.scans != 2 {
+		t.Errorf("expected one scan per list, got %d scans", indexer.scans)
+	}
+
+	bar := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns", Labels: map[string]string{"app": "foo"}}}
+	if err := indexer.Add(bar); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+	informer.handler.OnAdd(bar, false)
+
+	if items, err := lister.List(selector); err != nil || len(items) != 2 {
+		t.Fatalf("unexpected List result after change: %v, %v", items, err)
+	}
+	if indexer.scans != 3 {
+		t.Errorf("expected a change to invalidate the memoized result, got %d scans", indexer.scans)
+	}
+}
+
+// TestListByTenant verifies that tenant filtering is served by the tenant
+// index and only returns objects of the tenant matching the selector.
+func TestListByTenant(t *testing.T) {
+	indexers := TenantIndexers()
+	indexers[cache.NamespaceIndex] = cache.MetaNamespaceIndexFunc
+	indexer := &countingIndexer{Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)}
+	lister := NewTestTypeLister(indexer)
+
+	for _, obj := range []*apiv1.TestType{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1", Labels: map[string]string{TenantLabel: "blue", "app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns2", Labels: map[string]string{TenantLabel: "blue", "app": "db"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns1", Labels: map[string]string{TenantLabel: "red", "app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "ns1", Labels: map[string]string{"app": "web"}}},
+	} {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("failed to add object: %v", err)
+		}
+	}
+
+	tests := []struct {
+		tenant   string
+		selector labels.Selector
+		want     []string
+	}{
+		{tenant: "blue", selector: labels.Everything(), want: []string{"a", "b"}},
+		{tenant: "blue", selector: labels.SelectorFromSet(labels.Set{"app": "web"}), want: []string{"a"}},
+		{tenant: "red", selector: labels.Everything(), want: []string{"c"}},
+		{tenant: "green", selector: labels.Everything(), want: nil},
+	}
+	for _, tt := range tests {
+		items, err := lister.ListByTenant(tt.tenant, tt.selector)
+		if err != nil {
+			t.Fatalf("ListByTenant(%q, %q) failed: %v", tt.tenant, tt.selector, err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Name)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ListByTenant(%q, %q): got %v, want %v", tt.tenant, tt.selector, got, tt.want)
+		}
+	}
+	if indexer.scans != 0 {
+		t.Errorf("expected no scans, got %d", indexer.scans)
+	}
+	if indexer.lookups[TenantIndex] != len(tests) {
+		t.Errorf("expected one tenant index lookup per list, got %d", indexer.lookups[TenantIndex])
+	}
+
+	if _, err := NewTestTypeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})).ListByTenant("blue", labels.Everything()); err == nil {
+		t.Errorf("expected an error without the tenant index")
+	}
+}
+
+// countingIndexer counts the full scans and index lookups of an indexer.
+type countingIndexer struct {
+	cache.Indexer
+	scans   int
+	lookups map[string]int
+}
+
+func (i *countingIndexer) List() []interface{} {
+	i.scans++
+	return i.Indexer.List()
+}
+
+func (i *countingIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	i.scans++
+	return i.Indexer.Index(indexName, obj)
+}
+
+func (i *countingIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	if i.lookups == nil {
+		i.lookups = map[string]int{}
+	}
+	i.lookups[indexName]++
+	return i.Indexer.ByIndex(indexName, indexedValue)
+}
+
+// handlerTrackingInformer serves a custom indexer and records the last event
+// handler which was added.
+type handlerTrackingInformer struct {
+	cache.SharedIndexInformer
+	indexer cache.Indexer
+	handler cache.ResourceEventHandler
+}
+
+func (i *handlerTrackingInformer) GetIndexer() cache.Indexer {
+	return i.indexer
+}
+
+func (i *handlerTrackingInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	i.handler = handler
+	return i.SharedIndexInformer.AddEventHandler(handler)
+}
+
+// TestListByIndex verifies that the +lister:index tag of TestType lists
+// objects through the index of its indexers, and only through it.
+func TestListByIndex(t *testing.T) {
+	informer := cache.NewSharedIndexInformer(nil, &apiv1.TestType{}, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := informer.AddIndexers(TestTypeIndexers()); err != nil {
+		t.Fatalf("failed to add indexers: %v", err)
+	}
+	indexer := &countingIndexer{Indexer: informer.GetIndexer()}
+	lister := NewTestTypeLister(indexer)
+
+	for _, obj := range []*apiv1.TestType{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1"}, Status: apiv1.TestTypeStatus{Blah: "ready"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns2"}, Status: apiv1.TestTypeStatus{Blah: "ready"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns1"}, Status: apiv1.TestTypeStatus{Blah: "failed"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "ns1"}},
+	} {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("failed to add object: %v", err)
+		}
+	}
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "ready", want: []string{"a", "b"}},
+		{value: "failed", want: []string{"c"}},
+		{value: "", want: nil},
+	}
+	for _, tt := range tests {
+		items, err := lister.ListByStatusBlah(tt.value)
+		if err != nil {
+			t.Fatalf("ListByStatusBlah(%q) failed: %v", tt.value, err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Name)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ListByStatusBlah(%q): got %v, want %v", tt.value, got, tt.want)
+		}
+	}
+	if indexer.scans != 0 {
+		t.Errorf("expected no scans, got %d", indexer.scans)
+	}
+	if indexer.lookups[TestTypeStatusBlahIndex] != len(tests) {
+		t.Errorf("expected one index lookup per list, got %d", indexer.lookups[TestTypeStatusBlahIndex])
+	}
+
+	if _, err := NewTestTypeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})).ListByStatusBlah("ready"); err == nil {
+		t.Errorf("expected an error without the index")
+	}
+}
+
+// TestListChan verifies that ListChan streams the matching objects and that
+// the producer stops once the context of a partial consumer is canceled.
+func TestListChan(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := range 5 {
+		obj := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("foo-%d", i), Namespace: "ns", Labels: map[string]string{"app": "foo"}}}
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("failed to add object: %v", err)
+		}
+	}
+	if err := indexer.Add(&apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}}); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+	lister := NewTestTypeLister(indexer)
+	selector := labels.SelectorFromSet(labels.Set{"app": "foo"})
+
+	var names []string
+	for item := range lister.TestTypes("ns").ListChan(context.Background(), selector) {
+		names = append(names, item.Name)
+	}
+	if len(names) != 5 {
+		t.Errorf("expected 5 objects, got %v", names)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := lister.ListChan(ctx, selector)
+	if _, ok := <-ch; !ok {
+		t.Fatalf("channel closed before the first object")
+	}
+	cancel()
+	// The producer may already be sending the next object, but no more.
+	received := 0
+	for range ch {
+		received++
+	}
+	if received > 1 {
+		t.Errorf("expected the producer to stop after cancellation, received %d more objects", received)
+	}
+}
+
+// TestGetByKeys verifies that GetByKeys partitions keys into the objects which
+// are in the indexer and the keys which are not.
+func TestGetByKeys(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range []*apiv1.TestType{
+		{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns2"}},
+	} {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("failed to add object: %v", err)
+		}
+	}
+	lister := NewTestTypeLister(indexer)
+
+	found, missing, err := lister.GetByKeys([]string{"ns2/bar", "ns1/missing", "ns1/foo", "ns2/foo"})
+	if err != nil {
+		t.Fatalf("GetByKeys failed: %v", err)
+	}
+	var names []string
+	for _, item := range found {
+		names = append(names, item.Namespace+"/"+item.Name)
+	}
+	if want := []string{"ns2/bar", "ns1/foo"}; !slices.Equal(names, want) {
+		t.Errorf("found %v, want %v", names, want)
+	}
+	if want := []string{"ns1/missing", "ns2/foo"}; !slices.Equal(missing, want) {
+		t.Errorf("missing %v, want %v", missing, want)
+	}
+}
+
+func TestOwnerTestType(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	owner := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "ns", UID: "owner-uid"}}
+	if err := indexer.Add(owner); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+	lister := NewTestTypeLister(indexer)
+	ownerRef := func(apiVersion, kind string, uid types.UID) metav1.OwnerReference {
+		return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: "owner", UID: uid}
+	}
+	apiVersion := apiv1.SchemeGroupVersion.String()
+
+	owned := &metav1.ObjectMeta{Name: "owned", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{
+		ownerRef("v1", "ConfigMap", "other-uid"),
+		ownerRef(apiVersion, "TestType", "owner-uid"),
+	}}
+	got, err := lister.OwnerTestType(owned)
+	if err != nil {
+		t.Fatalf("failed to resolve the owner: %v", err)
+	}
+	// The lister returns a copy of the cached owner with its type meta set.
+	if got.UID != owner.UID {
+		t.Errorf("resolved %v, want %v", got, owner)
+	}
+
+	for name, obj := range map[string]*metav1.ObjectMeta{
+		"no owner":           {Name: "orphan", Namespace: "ns"},
+		"other kind":         {Name: "owned", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ownerRef(apiVersion, "ClusterTestType", "owner-uid")}},
+		"stale uid":          {Name: "owned", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ownerRef(apiVersion, "TestType", "old-uid")}},
+		"other namespace":    {Name: "owned", Namespace: "other", OwnerReferences: []metav1.OwnerReference{ownerRef(apiVersion, "TestType", "owner-uid")}},
+		"other api group":    {Name: "owned", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ownerRef("example.com/v1", "TestType", "owner-uid")}},
+		"invalid apiVersion": {Name: "owned", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{ownerRef("a/b/c", "TestType", "owner-uid")}},
+	} {
+		if _, err := lister.OwnerTestType(obj); !apierrors.IsNotFound(err) {
+			t.Errorf("%s: expected a NotFound error, got %v", name, err)
+		}
+	}
+}
+
+// TestTypeMeta verifies that the lister returns objects with their API version
+// and kind set, without setting them on the cached objects.
+func TestTypeMeta(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	cached := &apiv1.TestType{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}}
+	if err := indexer.Add(cached); err != nil {
+		t.Fatalf("failed to add object: %v", err)
+	}
+	lister := NewTestTypeLister(indexer)
+	want := apiv1.SchemeGroupVersion.WithKind("TestType")
+
+	var got []*apiv1.TestType
+	items, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	got = append(got, items...)
+	items, err = lister.TestTypes("ns").List(labels.Everything())
+	if err != nil {
+		t.Fatalf("namespaced List failed: %v", err)
+	}
+	got = append(got, items...)
+	item, err := lister.TestTypes("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	got = append(got, item)
+	items, _, err = lister.GetByKeys([]string{"ns/foo"})
+	if err != nil {
+		t.Fatalf("GetByKeys failed: %v", err)
+	}
+	got = append(got, items...)
+
+	if len(got) != 4 {
+		t.Fatalf("expected 4 objects, got %d", len(got))
+	}
+	for i, obj := range got {
+		if gvk := obj.GetObjectKind().GroupVersionKind(); gvk != want {
+			t.Errorf("object %d: got %v, want %v", i, gvk, want)
+		}
+		if obj == cached {
+			t.Errorf("object %d is the cached object", i)
+		}
+	}
+	if cached.APIVersion != "" || cached.Kind != "" {
+		t.Errorf("the cached object was modified: %+v", cached.TypeMeta)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadatainformers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/tools/cache"
+	singleapiv1 "k8s.io/code-generator/examples/single/api/v1"
+	metadataapiv1 "k8s.io/code-generator/examples/single/metadatainformers/api/v1"
+)
+
+func newTestTypeMetadata(namespace, name string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "example.crd.code-generator.k8s.io/v1", Kind: "TestType"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"app": name}},
+	}
+}
+
+// TestMetadataInformer verifies that informers obtained from the metadata
+// factory list and watch their resource through the metadata client and that
+// their listers return the metadata of objects.
+func TestMetadataInformer(t *testing.T) {
+	scheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatalf("failed to add meta to scheme: %v", err)
+	}
+	client := metadatafake.NewSimpleMetadataClient(scheme, newTestTypeMetadata("ns", "foo"))
+	factory := NewSharedInformerFactory(client, 0)
+	informer := factory.Example().V1().TestTypes()
+	added := make(chan string, 1)
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if name := obj.(*metav1.PartialObjectMetadata).GetName(); name == "bar" {
+				added <- name
+			}
+		},
+	}); err != nil {
+		t.Fatalf("failed to add handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.Start(ctx.Done())
+	for resource, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			t.Fatalf("failed to sync %v", resource)
+		}
+	}
+
+	var obj metav1.Object
+	obj, err := informer.Lister().Namespace("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get foo: %v", err)
+	}
+	if obj.GetNamespace() != "ns" || obj.GetLabels()["app"] != "foo" {
+		t.Errorf("unexpected object %v", obj)
+	}
+
+	if _, err := client.Resource(metadataapiv1.TestTypeResource).Namespace("ns").(metadatafake.MetadataClient).CreateFake(newTestTypeMetadata("ns", "bar"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create bar: %v", err)
+	}
+	<-added
+	objs, err := informer.Lister().List(labels.SelectorFromSet(labels.Set{"app": "bar"}))
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(objs) != 1 || objs[0].GetName() != "bar" {
+		t.Errorf("expected bar, got %v", objs)
+	}
+}
+
+// TestDecodeWithScheme verifies that Decode converts the metadata produced by
+// the informers into the types registered in the scheme passed with
+// WithScheme, and that only their metadata is set.
+func TestDecodeWithScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := singleapiv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add types to scheme: %v", err)
+	}
+	scheme.AddTypeDefaultingFunc(&singleapiv1.TestType{}, func(obj interface{}) {
+		obj.(*singleapiv1.TestType).Status.Blah = "defaulted"
+	})
+	clientScheme := metadatafake.NewTestScheme()
+	if err := metav1.AddMetaToScheme(clientScheme); err != nil {
+		t.Fatalf("failed to add meta to scheme: %v", err)
+	}
+	client := metadatafake.NewSimpleMetadataClient(clientScheme, newTestTypeMetadata("ns", "foo"))
+	factory := NewSharedInformerFactoryWithOptions(client, 0, WithScheme(scheme))
+	informer := factory.Example().V1().TestTypes()
+	informer.Informer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.Start(ctx.Done())
+	for resource, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			t.Fatalf("failed to sync %v", resource)
+		}
+	}
+
+	obj, err := informer.Lister().Namespace("ns").Get("foo")
+	if err != nil {
+		t.Fatalf("failed to get foo: %v", err)
+	}
+	// The metadata client reports its own kind, Decode must use the one passed.
+	obj.SetGroupVersionKind(metav1.SchemeGroupVersion.WithKind("PartialObjectMetadata"))
+	decoded, err := factory.Decode(metadataapiv1.TestTypeKind, obj)
+	if err != nil {
+		t.Fatalf("failed to decode foo: %v", err)
+	}
+	testType, ok := decoded.(*singleapiv1.TestType)
+	if !ok {
+		t.Fatalf("expected a *TestType, got %T", decoded)
+	}
+	if testType.Name != "foo" || testType.Labels["app"] != "foo" || testType.Status.Blah != "defaulted" {
+		t.Errorf("unexpected foo: %+v", testType)
+	}
+	if gvk := testType.GroupVersionKind(); gvk != metadataapiv1.TestTypeKind {
+		t.Errorf("expected kind %v, got %v", metadataapiv1.TestTypeKind, gvk)
+	}
+}
@@ -99,11 +99,16 @@ function kube::codegen::internal::grep() {
 #     An optional list (this flag may be specified multiple times) of "extra"
 #     directories to consider during conversion generation.
 #
+#   --with-roundtrip-tests
+#     Enables generation of zz_generated.conversion_test.go files, which run
+#     the converted kinds through apimachinery's round-trip fuzzer.
+#
 function kube::codegen::gen_helpers() {
     local in_dir=""
     local boilerplate="${KUBE_CODEGEN_ROOT}/hack/boilerplate.go.txt"
     local v="${KUBE_VERBOSE:-0}"
     local extra_peers=()
+    local roundtrip_tests="false"
 
     while [ "$#" -gt 0 ]; do
         case "$1" in
@@ -115,6 +120,10 @@ function kube::codegen::gen_helpers() {
                 extra_peers+=("$2")
                 shift 2
                 ;;
+            "--with-roundtrip-tests")
+                roundtrip_tests="true"
+                shift
+                ;;
             *)
                 if [[ "$1" =~ ^-- ]]; then
                     echo "unknown argument: $1" >&2
@@ -273,7 +282,7 @@ function kube::codegen::gen_helpers() {
         kube::codegen::internal::findz \
             "${in_dir}" \
             -type f \
-            -name zz_generated.conversion.go \
+            \( -name zz_generated.conversion.go -o -name zz_generated.conversion_test.go \) \
             | xargs -0 rm -f
 
         local extra_peer_args=()
@@ -284,6 +293,7 @@ function kube::codegen::gen_helpers() {
             -v "${v}" \
             --output-file zz_generated.conversion.go \
             --go-header-file "${boilerplate}" \
+            --generate-roundtrip-tests="${roundtrip_tests}" \
             "${extra_peer_args[@]:+"${extra_peer_args[@]}"}" \
             "${input_pkgs[@]}"
     fi
@@ -504,7 +514,8 @@ function kube::codegen::gen_openapi() {
 #     "<clientset>/versioned" directory.
 #
 #   --with-applyconfig
-#     Enables generation of applyconfiguration files.
+#     Enables generation of applyconfiguration files.  With --with-watch, the
+#     informers also get <Type>ToApplyConfiguration helpers.
 #
 #   --applyconfig-name <string = "applyconfiguration">
 #     An optional override for the leaf name of the generated "applyconfiguration" directory.
@@ -522,12 +533,52 @@ function kube::codegen::gen_openapi() {
 #   --informers-name <string = "informers">
 #     An optional override for the leaf name of the generated "informers" directory.
 #
+#   --with-dynamic-informers
+#     Enables generation of informers backed by a dynamic client, which produce
+#     unstructured objects, in a "dynamicinformers" directory.  Requires
+#     --with-watch.
+#
+#   --with-metadata-informers
+#     Enables generation of informers backed by a metadata client, which only
+#     produce the metadata of objects, in a "metadatainformers" directory.
+#     Requires --with-watch.
+#
+#   --with-informer-controllers
+#     Enables generation of a <Type>Controller scaffold next to every informer,
+#     which reconciles the keys of objects through a rate-limited work queue.
+#     Requires --with-watch.
+#
+#   --with-prometheus-collector
+#     Enables generation of a MetricsCollector for the informer factories, a
+#     prometheus.Collector of the cache sizes of their informers.  The
+#     generated code then depends on github.com/prometheus/client_golang.
+#     Requires --with-watch.
+#
 #   --plural-exceptions <string = "">
 #     An optional list of comma separated plural exception definitions in Type:PluralizedType form.
 #
+#   --tenant-label <string = "">
+#     An optional label which assigns objects to tenants.  If set, listers get
+#     a ListByTenant method backed by an index on that label.
+#
+#   --with-lister-type-meta
+#     Enables generation of listers which set the API version and kind on the
+#     objects they return.  The cached objects are copied, not modified.
+#
 #   --prefers-protobuf
 #     Enables generation of clientsets that use protobuf for API requests.
 #
+#   --with-create-or-update
+#     Enables generation of CreateOrUpdate<Type> helpers in the typed clients.
+#
+#   --with-update-with-retry
+#     Enables generation of UpdateWithRetry and UpdateStatusWithRetry helpers,
+#     which retry a mutation on conflicts, in the typed clients.
+#
+#   --with-server-side-applier
+#     Enables generation of an Applier with preset apply options in the group
+#     clients.  Requires --with-applyconfig.
+#
 function kube::codegen::gen_client() {
     local in_dir=""
     local one_input_api=""
@@ -542,10 +593,19 @@ function kube::codegen::gen_client() {
     local watchable="false"
     local listers_subdir="listers"
     local informers_subdir="informers"
+    local dynamic_informers="false"
+    local metadata_informers="false"
+    local informer_controllers="false"
+    local prometheus_collector="false"
     local boilerplate="${KUBE_CODEGEN_ROOT}/hack/boilerplate.go.txt"
     local plural_exceptions=""
+    local tenant_label=""
+    local lister_type_meta="false"
     local v="${KUBE_VERBOSE:-0}"
     local prefers_protobuf="false"
+    local create_or_update="false"
+    local update_with_retry="false"
+    local server_side_applier="false"
 
     while [ "$#" -gt 0 ]; do
         case "$1" in
@@ -601,14 +661,50 @@ function kube::codegen::gen_client() {
                 informers_subdir="$2"
                 shift 2
                 ;;
+            "--with-dynamic-informers")
+                dynamic_informers="true"
+                shift
+                ;;
+            "--with-metadata-informers")
+                metadata_informers="true"
+                shift
+                ;;
+            "--with-informer-controllers")
+                informer_controllers="true"
+                shift
+                ;;
+            "--with-prometheus-collector")
+                prometheus_collector="true"
+                shift
+                ;;
             "--plural-exceptions")
                 plural_exceptions="$2"
                 shift 2
                 ;;
+            "--tenant-label")
+                tenant_label="$2"
+                shift 2
+                ;;
+            "--with-lister-type-meta")
+                lister_type_meta="true"
+                shift
+                ;;
             "--prefers-protobuf")
                 prefers_protobuf="true"
                 shift
                 ;;
+            "--with-create-or-update")
+                create_or_update="true"
+                shift
+                ;;
+            "--with-update-with-retry")
+                update_with_retry="true"
+                shift
+                ;;
+            "--with-server-side-applier")
+                server_side_applier="true"
+                shift
+                ;;
             *)
                 if [[ "$1" =~ ^-- ]]; then
                     echo "unknown argument: $1" >&2
@@ -724,6 +820,9 @@ function kube::codegen::gen_client() {
         --input-base "$(cd "${in_dir}" && pwd -P)" `# must be absolute path or Go import path"` \
         --plural-exceptions "${plural_exceptions}" \
         --prefers-protobuf="${prefers_protobuf}" \
+        --create-or-update="${create_or_update}" \
+        --update-with-retry="${update_with_retry}" \
+        --server-side-applier="${server_side_applier}" \
         "${inputs[@]}"
 
     if [ "${watchable}" == "true" ]; then
@@ -742,6 +841,8 @@ function kube::codegen::gen_client() {
             --output-dir "${out_dir}/${listers_subdir}" \
             --output-pkg "${out_pkg}/${listers_subdir}" \
             --plural-exceptions "${plural_exceptions}" \
+            --tenant-label "${tenant_label}" \
+            --populate-type-meta="${lister_type_meta}" \
             "${input_pkgs[@]}"
 
         echo "Generating informer code for ${#input_pkgs[@]} targets"
@@ -760,8 +861,51 @@ function kube::codegen::gen_client() {
             --output-pkg "${out_pkg}/${informers_subdir}" \
             --versioned-clientset-package "${out_pkg}/${clientset_subdir}/${clientset_versioned_name}" \
             --listers-package "${out_pkg}/${listers_subdir}" \
+            --apply-configuration-package "${applyconfig_pkg}" \
+            --controllers="${informer_controllers}" \
+            --prometheus-collector="${prometheus_collector}" \
             --plural-exceptions "${plural_exceptions}" \
             "${input_pkgs[@]}"
+
+        if [ "${dynamic_informers}" == "true" ]; then
+            echo "Generating dynamic informer code for ${#input_pkgs[@]} targets"
+
+            ( kube::codegen::internal::grep -l --null \
+                -e '^// Code generated by informer-gen. DO NOT EDIT.$' \
+                -r "${out_dir}/dynamicinformers" \
+                --include '*.go' \
+                || true \
+            ) | xargs -0 rm -f
+
+            "${GOBIN}/informer-gen" \
+                -v "${v}" \
+                --go-header-file "${boilerplate}" \
+                --output-dir "${out_dir}/dynamicinformers" \
+                --output-pkg "${out_pkg}/dynamicinformers" \
+                --plural-exceptions "${plural_exceptions}" \
+                --dynamic \
+                "${input_pkgs[@]}"
+        fi
+
+        if [ "${metadata_informers}" == "true" ]; then
+            echo "Generating metadata informer code for ${#input_pkgs[@]} targets"
+
+            ( kube::codegen::internal::grep -l --null \
+                -e '^// Code generated by informer-gen. DO NOT EDIT.$' \
+                -r "${out_dir}/metadatainformers" \
+                --include '*.go' \
+                || true \
+            ) | xargs -0 rm -f
+
+            "${GOBIN}/informer-gen" \
+                -v "${v}" \
+                --go-header-file "${boilerplate}" \
+                --output-dir "${out_dir}/metadatainformers" \
+                --output-pkg "${out_pkg}/metadatainformers" \
+                --plural-exceptions "${plural_exceptions}" \
+                --metadata-only \
+                "${input_pkgs[@]}"
+        fi
     fi
 }
 
@@ -780,10 +924,15 @@ function kube::codegen::gen_client() {
 #   --boilerplate <string = path_to_kube_codegen_boilerplate>
 #     An optional override for the header file to insert into generated files.
 #
+#   --scheme-var-prefix <string = "">
+#     An optional prefix for the generated SchemeBuilder, AddToScheme and
+#     Install variables, for packages which already declare those names.
+#
 function kube::codegen::gen_register() {
     local in_dir=""
     local boilerplate="${KUBE_CODEGEN_ROOT}/hack/boilerplate.go.txt"
     local v="${KUBE_VERBOSE:-0}"
+    local scheme_var_prefix=""
 
     while [ "$#" -gt 0 ]; do
         case "$1" in
@@ -791,6 +940,10 @@ function kube::codegen::gen_register() {
                 boilerplate="$2"
                 shift 2
                 ;;
+            "--scheme-var-prefix")
+                scheme_var_prefix="$2"
+                shift 2
+                ;;
             *)
                 if [[ "$1" =~ ^-- ]]; then
                     echo "unknown argument: $1" >&2
@@ -851,6 +1004,7 @@ function kube::codegen::gen_register() {
             -v "${v}" \
             --output-file zz_generated.register.go \
             --go-header-file "${boilerplate}" \
+            --scheme-var-prefix "${scheme_var_prefix}" \
             "${input_pkgs[@]}"
     fi
 }